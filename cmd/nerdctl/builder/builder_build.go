@@ -20,7 +20,6 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -37,7 +36,7 @@ import (
 
 func BuildCommand() *cobra.Command {
 	var cmd = &cobra.Command{
-		Use:   "build [flags] PATH",
+		Use:   "build [flags] PATH | URL | -",
 		Short: "Build an image from a Dockerfile. Needs buildkitd to be running.",
 		Long: `Build an image from a Dockerfile. Needs buildkitd to be running.
 If Dockerfile is not present and -f is not specified, it will look for Containerfile and build with it. `,
@@ -107,9 +106,6 @@ func processBuildCommandFlag(cmd *cobra.Command, args []string) (types.BuilderBu
 		return types.BuilderBuildOptions{}, errors.New("context needs to be specified")
 	}
 	buildContext := args[0]
-	if buildContext == "-" || strings.Contains(buildContext, "://") {
-		return types.BuilderBuildOptions{}, fmt.Errorf("unsupported build context: %q", buildContext)
-	}
 	output, err := cmd.Flags().GetString("output")
 	if err != nil {
 		return types.BuilderBuildOptions{}, err
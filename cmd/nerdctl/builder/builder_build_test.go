@@ -17,6 +17,8 @@
 package builder
 
 import (
+	"archive/tar"
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
@@ -265,6 +267,88 @@ CMD ["echo", "nerdctl-build-test-stdin"]`, testutil.CommonImage)
 	testCase.Run(t)
 }
 
+func TestBuildFromStdinContext(t *testing.T) {
+	nerdtest.Setup()
+
+	dockerfile := fmt.Sprintf(`FROM %s
+COPY hello /hello
+CMD ["cat", "/hello"]`, testutil.CommonImage)
+
+	testCase := &test.Case{
+		Require: nerdtest.Build,
+		SubTests: []*test.Case{
+			{
+				Description: "Dockerfile as context",
+				Cleanup: func(data test.Data, helpers test.Helpers) {
+					helpers.Anyhow("rmi", "-f", data.Identifier())
+				},
+				Setup: func(data test.Data, helpers test.Helpers) {
+					cmd := helpers.Command("build", "-t", data.Identifier(), "-")
+					cmd.Feed(strings.NewReader(fmt.Sprintf(`FROM %s
+CMD ["echo", "nerdctl-build-test-stdin-context"]`, testutil.CommonImage)))
+					cmd.Run(&test.Expected{ExitCode: expect.ExitCodeSuccess})
+				},
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("run", "--rm", data.Identifier())
+				},
+				Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Equals("nerdctl-build-test-stdin-context\n")),
+			},
+			{
+				Description: "tar archive as context",
+				Cleanup: func(data test.Data, helpers test.Helpers) {
+					helpers.Anyhow("rmi", "-f", data.Identifier())
+				},
+				Setup: func(data test.Data, helpers test.Helpers) {
+					var buf bytes.Buffer
+					tw := tar.NewWriter(&buf)
+					for name, content := range map[string]string{"Dockerfile": dockerfile, "hello": "nerdctl-build-test-stdin-tar"} {
+						assert.NilError(helpers.T(), tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}))
+						_, err := tw.Write([]byte(content))
+						assert.NilError(helpers.T(), err)
+					}
+					assert.NilError(helpers.T(), tw.Close())
+
+					cmd := helpers.Command("build", "-t", data.Identifier(), "-")
+					cmd.Feed(&buf)
+					cmd.Run(&test.Expected{ExitCode: expect.ExitCodeSuccess})
+				},
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("run", "--rm", data.Identifier())
+				},
+				Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Equals("nerdctl-build-test-stdin-tar")),
+			},
+			{
+				Description: "stdin for both context and Dockerfile",
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					cmd := helpers.Command("build", "-t", data.Identifier(), "-f", "-", "-")
+					cmd.Feed(strings.NewReader(dockerfile))
+					return cmd
+				},
+				Expected: test.Expects(expect.ExitCodeGenericFail, nil, nil),
+			},
+			{
+				Description: "Dockerfile from stdin with an URL context",
+				Cleanup: func(data test.Data, helpers test.Helpers) {
+					helpers.Anyhow("rmi", "-f", data.Identifier())
+				},
+				Setup: func(data test.Data, helpers test.Helpers) {
+					cmd := helpers.Command("build", "-t", data.Identifier(), "-f", "-", "https://github.com/containerd/nerdctl.git")
+					cmd.Feed(strings.NewReader(fmt.Sprintf(`FROM %s
+COPY README.md /README.md
+CMD ["head", "-n", "1", "/README.md"]`, testutil.CommonImage)))
+					cmd.Run(&test.Expected{ExitCode: expect.ExitCodeSuccess})
+				},
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("run", "--rm", data.Identifier())
+				},
+				Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Contains("nerdctl")),
+			},
+		},
+	}
+
+	testCase.Run(t)
+}
+
 func TestBuildWithDockerfile(t *testing.T) {
 	nerdtest.Setup()
 
@@ -318,6 +402,67 @@ CMD ["echo", "nerdctl-build-test-dockerfile"]
 	testCase.Run(t)
 }
 
+func TestBuildTarget(t *testing.T) {
+	nerdtest.Setup()
+
+	dockerfile := fmt.Sprintf(`FROM %s AS base
+CMD ["echo", "nerdctl-build-test-base"]
+
+FROM base AS prod
+CMD ["echo", "nerdctl-build-test-prod"]
+	`, testutil.CommonImage)
+
+	testCase := &test.Case{
+		Require: nerdtest.Build,
+		Setup: func(data test.Data, helpers test.Helpers) {
+			data.Temp().Save(dockerfile, "Dockerfile")
+		},
+		SubTests: []*test.Case{
+			{
+				Description: "existing target",
+				Cleanup: func(data test.Data, helpers test.Helpers) {
+					helpers.Anyhow("rmi", "-f", data.Identifier())
+				},
+				Setup: func(data test.Data, helpers test.Helpers) {
+					helpers.Ensure("build", "-t", data.Identifier(), "--target", "base", data.Temp().Path())
+				},
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("run", "--rm", data.Identifier())
+				},
+				Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Equals("nerdctl-build-test-base\n")),
+			},
+			{
+				Description: "nonexistent target lists the stages",
+				Require:     require.Not(nerdtest.Docker),
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("build", "-t", data.Identifier(), "--target", "prd", data.Temp().Path())
+				},
+				Expected: test.Expects(expect.ExitCodeGenericFail, []error{
+					errors.New(`target stage "prd" could not be found`),
+					errors.New("(available stages: base, prod)"),
+				}, nil),
+			},
+			{
+				Description: "nonexistent target of a Dockerfile with a syntax directive lists the stages",
+				Require:     require.Not(nerdtest.Docker),
+				Setup: func(data test.Data, helpers test.Helpers) {
+					data.Temp().Save("# syntax=docker/dockerfile:1\n"+dockerfile, "Dockerfile.syntax")
+				},
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("build", "-t", data.Identifier(), "-f", data.Temp().Path("Dockerfile.syntax"),
+						"--target", "prd", data.Temp().Path())
+				},
+				Expected: test.Expects(expect.ExitCodeGenericFail, []error{
+					errors.New(`target stage "prd" could not be found`),
+					errors.New("(available stages: base, prod)"),
+				}, nil),
+			},
+		},
+	}
+
+	testCase.Run(t)
+}
+
 func TestBuildLocal(t *testing.T) {
 	nerdtest.Setup()
 
@@ -582,6 +727,35 @@ CMD ["echo", "nerdctl-build-test-string"]
 
 				Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Equals("nerdctl-build-test-string\n")),
 			},
+			{
+				Description: "all the tags point at the same digest",
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("image", "inspect", "--format", "{{.Id}}",
+						data.Labels().Get("i1"), data.Labels().Get("i2"), data.Labels().Get("i3"))
+				},
+				Expected: test.Expects(expect.ExitCodeSuccess, nil, func(stdout string, t tig.T) {
+					ids := strings.Split(strings.TrimSpace(stdout), "\n")
+					assert.Equal(t, len(ids), 3, stdout)
+					assert.Equal(t, ids[0], ids[1])
+					assert.Equal(t, ids[0], ids[2])
+				}),
+			},
+			{
+				Description: "invalid tag fails before building",
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("build", data.Temp().Path(),
+						"-t", data.Identifier("invalid")+":1", "-t", "INVALID:1")
+				},
+				Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+					return &test.Expected{
+						ExitCode: 1,
+						Errors:   []error{errors.New(`invalid argument "INVALID:1" for "-t, --tag" flag`)},
+						Output: func(stdout string, t tig.T) {
+							helpers.Fail("image", "inspect", data.Identifier("invalid")+":1")
+						},
+					}
+				},
+			},
 		},
 	}
 
@@ -290,3 +290,57 @@ image: hello-world
 
 	testCase.Run(t)
 }
+
+func TestComposeConfigWithDotEnv(t *testing.T) {
+	const dockerComposeYAML = `
+services:
+  hello:
+    image: ${IMAGE}
+    environment:
+      FROM_DOTENV: ${FROM_DOTENV}
+      FROM_ENV_FILE: ${FROM_ENV_FILE}
+      FROM_SHELL: ${FROM_SHELL}
+`
+
+	testCase := nerdtest.Setup()
+
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		data.Temp().Save(dockerComposeYAML, "compose.yaml")
+		data.Temp().Save("IMAGE=hello-world\nFROM_DOTENV=dotenv\nFROM_ENV_FILE=dotenv\nFROM_SHELL=dotenv\n", ".env")
+		data.Temp().Save("FROM_ENV_FILE=envfile\nFROM_SHELL=envfile\n", "env")
+	}
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: ".env in the project directory is used for interpolation",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("compose", "-f", data.Temp().Path("compose.yaml"), "config")
+			},
+			Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Contains(
+				"image: hello-world",
+				"FROM_DOTENV: dotenv",
+				"FROM_ENV_FILE: dotenv",
+			)),
+		},
+		{
+			Description: "shell env takes precedence over --env-file, which takes precedence over .env",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				cmd := helpers.Command("compose",
+					"-f", data.Temp().Path("compose.yaml"),
+					"--env-file", data.Temp().Path("env"),
+					"config",
+				)
+				cmd.Setenv("FROM_SHELL", "shell")
+				return cmd
+			},
+			Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Contains(
+				"image: hello-world",
+				"FROM_DOTENV: dotenv",
+				"FROM_ENV_FILE: envfile",
+				"FROM_SHELL: shell",
+			)),
+		},
+	}
+
+	testCase.Run(t)
+}
@@ -18,8 +18,11 @@ package compose
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
+	"gotest.tools/v3/assert"
+
 	"github.com/containerd/nerdctl/v2/pkg/testutil"
 )
 
@@ -72,3 +75,43 @@ volumes:
 	base.ComposeCmd("-f", comp.YAMLFullPath(), "ps", "db").AssertOutContainsAny("Up", "running")
 	base.ComposeCmd("-f", comp.YAMLFullPath(), "ps", "wordpress").AssertOutContainsAny("Up", "running")
 }
+
+func TestComposeRestartDependsOnRestart(t *testing.T) {
+	base := testutil.NewBase(t)
+	// Each service records its starts, so that the restarts can be counted
+	var dockerComposeYAML = fmt.Sprintf(`
+services:
+  db:
+    image: %[1]s
+    command: ["sh", "-c", "echo started >> /starts; sleep infinity"]
+  app:
+    image: %[1]s
+    command: ["sh", "-c", "echo started >> /starts; sleep infinity"]
+    depends_on:
+      db:
+        condition: service_started
+        restart: true
+  other:
+    image: %[1]s
+    command: ["sh", "-c", "echo started >> /starts; sleep infinity"]
+    depends_on:
+      - db
+`, testutil.AlpineImage)
+
+	comp := testutil.NewComposeDir(t, dockerComposeYAML)
+	defer comp.CleanUp()
+
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "up", "-d").AssertOK()
+	defer base.ComposeCmd("-f", comp.YAMLFullPath(), "down", "-v").Run()
+
+	base.ComposeCmd("-f", comp.YAMLFullPath(), "restart", "db").AssertOK()
+
+	starts := func(service string) int {
+		out := base.ComposeCmd("-f", comp.YAMLFullPath(), "exec", "-T", service, "cat", "/starts").Out()
+		return strings.Count(out, "started")
+	}
+	assert.Equal(t, starts("db"), 2)
+	// app declares `restart: true` on db
+	assert.Equal(t, starts("app"), 2)
+	assert.Equal(t, starts("other"), 1)
+}
@@ -39,6 +39,7 @@ func upCommand() *cobra.Command {
 		SilenceErrors: true,
 	}
 	cmd.Flags().Bool("abort-on-container-exit", false, "Stops all containers if any container was stopped. Incompatible with -d.")
+	cmd.Flags().String("exit-code-from", "", "Return the exit code of the selected service container. Implies --abort-on-container-exit")
 	cmd.Flags().BoolP("detach", "d", false, "Detached mode: Run containers in the background. Incompatible with --abort-on-container-exit.")
 	cmd.Flags().Bool("no-build", false, "Don't build an image, even if it's missing.")
 	cmd.Flags().Bool("no-color", false, "Produce monochrome output")
@@ -51,6 +52,7 @@ func upCommand() *cobra.Command {
 	cmd.Flags().Bool("no-recreate", false, "Don't recreate containers if they exist, conflict with --force-recreate.")
 	cmd.Flags().StringArray("scale", []string{}, "Scale SERVICE to NUM instances. Overrides the `scale` setting in the Compose file if present.")
 	cmd.Flags().String("pull", "", "Pull image before running (\"always\"|\"missing\"|\"never\")")
+	cmd.Flags().UintP("timeout", "t", 10, "Seconds to wait for stop before killing the containers when attached")
 	return cmd
 }
 
@@ -70,6 +72,16 @@ func upAction(cmd *cobra.Command, services []string) error {
 	if err != nil {
 		return err
 	}
+	exitCodeFrom, err := cmd.Flags().GetString("exit-code-from")
+	if err != nil {
+		return err
+	}
+	if exitCodeFrom != "" {
+		if detach {
+			return fmt.Errorf("--exit-code-from flag is incompatible with flag --detach")
+		}
+		abortOnContainerExit = true
+	}
 	noBuild, err := cmd.Flags().GetBool("no-build")
 	if err != nil {
 		return err
@@ -120,6 +132,14 @@ func upAction(cmd *cobra.Command, services []string) error {
 	if forceRecreate && noRecreate {
 		return errors.New("flag --force-recreate and --no-recreate cannot be specified together")
 	}
+	var timeout *uint
+	if cmd.Flags().Changed("timeout") {
+		timeValue, err := cmd.Flags().GetUint("timeout")
+		if err != nil {
+			return err
+		}
+		timeout = &timeValue
+	}
 	scale := make(map[string]int)
 	for _, s := range scaleSlice {
 		parts := strings.Split(s, "=")
@@ -162,6 +182,8 @@ func upAction(cmd *cobra.Command, services []string) error {
 		Pull:                 pull,
 		ForceRecreate:        forceRecreate,
 		NoRecreate:           noRecreate,
+		Timeout:              timeout,
+		ExitCodeFrom:         exitCodeFrom,
 	}
 	return c.Up(ctx, uo, services)
 }
@@ -19,10 +19,13 @@ package compose
 import (
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/go-connections/nat"
 	"gotest.tools/v3/assert"
@@ -554,6 +557,136 @@ services:
 	testCase.Run(t)
 }
 
+func TestComposeUpScaleFromFileAndFlag(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		var composeYAML = fmt.Sprintf(`
+services:
+  test:
+    image: %s
+    command: "sleep infinity"
+    scale: 2
+`, testutil.CommonImage)
+
+		composePath := data.Temp().Save(composeYAML, "compose.yaml")
+		data.Labels().Set("composeYAML", composePath)
+	}
+
+	expectReplicas := func(n int) func(stdout string, t tig.T) {
+		return func(stdout string, t tig.T) {
+			assert.Equal(t, len(strings.Fields(stdout)), n, "unexpected containers: %q", stdout)
+		}
+	}
+
+	upAndCount := func(upArgs ...string) test.Executor {
+		return func(data test.Data, helpers test.Helpers) test.TestableCommand {
+			helpers.Ensure(append([]string{"compose", "-f", data.Labels().Get("composeYAML"), "up", "-d"}, upArgs...)...)
+			return helpers.Command("compose", "-f", data.Labels().Get("composeYAML"), "ps", "-q")
+		}
+	}
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "scale from the compose file",
+			NoParallel:  true,
+			Command:     upAndCount(),
+			Expected:    test.Expects(0, nil, expectReplicas(2)),
+		},
+		{
+			Description: "--scale overrides the compose file when scaling up",
+			NoParallel:  true,
+			Command:     upAndCount("--scale", "test=3"),
+			Expected:    test.Expects(0, nil, expectReplicas(3)),
+		},
+		{
+			Description: "--scale overrides the compose file when scaling down",
+			NoParallel:  true,
+			Command:     upAndCount("--scale", "test=1"),
+			Expected:    test.Expects(0, nil, expectReplicas(1)),
+		},
+		{
+			Description: "compose run containers are not removed as excess replicas",
+			NoParallel:  true,
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("compose", "-f", data.Labels().Get("composeYAML"), "run", "-d", "test")
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				helpers.Ensure("compose", "-f", data.Labels().Get("composeYAML"), "up", "-d", "--scale", "test=1")
+				return helpers.Command("ps", "-a", "--format", "{{.Names}}")
+			},
+			Expected: test.Expects(0, nil, expect.Contains("-test-run-")),
+		},
+	}
+
+	testCase.Cleanup = func(data test.Data, helpers test.Helpers) {
+		if data.Labels().Get("composeYAML") != "" {
+			helpers.Anyhow("compose", "-f", data.Labels().Get("composeYAML"), "down", "-v")
+		}
+	}
+
+	testCase.Run(t)
+}
+
+func TestComposeUpScaleNetworkAlias(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		// The custom hostname makes sure that the service name resolves through its network alias
+		var composeYAML = fmt.Sprintf(`
+services:
+  web:
+    image: %s
+    hostname: custom
+    command: "sleep infinity"
+`, testutil.CommonImage)
+
+		composePath := data.Temp().Save(composeYAML, "compose.yaml")
+		projectName := filepath.Base(filepath.Dir(composePath))
+		data.Labels().Set("composeYAML", composePath)
+
+		helpers.Ensure("compose", "-f", composePath, "up", "-d", "--scale", "web=3")
+		var ips []string
+		for i := 1; i <= 3; i++ {
+			name := serviceparser.DefaultContainerName(projectName, "web", strconv.Itoa(i))
+			nerdtest.EnsureContainerStarted(helpers, name)
+			ips = append(ips, strings.TrimSpace(helpers.Capture("inspect", "--format", "{{range .NetworkSettings.Networks}}{{.IPAddress}}{{end}}", name)))
+		}
+		data.Labels().Set("web1", serviceparser.DefaultContainerName(projectName, "web", "1"))
+		data.Labels().Set("ips", strings.Join(ips, ","))
+	}
+
+	testCase.Command = func(data test.Data, helpers test.Helpers) test.TestableCommand {
+		return helpers.Command("exec", data.Labels().Get("web1"), "cat", "/etc/hosts")
+	}
+
+	testCase.Expected = func(data test.Data, helpers test.Helpers) *test.Expected {
+		return &test.Expected{
+			ExitCode: 0,
+			Output: func(stdout string, t tig.T) {
+				var resolved []string
+				for _, line := range strings.Split(stdout, "\n") {
+					if fields := strings.Fields(line); len(fields) > 1 && slices.Contains(fields[1:], "web") {
+						resolved = append(resolved, fields[0])
+					}
+				}
+				expected := strings.Split(data.Labels().Get("ips"), ",")
+				slices.Sort(expected)
+				slices.Sort(resolved)
+				assert.DeepEqual(t, resolved, expected)
+			},
+		}
+	}
+
+	testCase.Cleanup = func(data test.Data, helpers test.Helpers) {
+		if data.Labels().Get("composeYAML") != "" {
+			helpers.Anyhow("compose", "-f", data.Labels().Get("composeYAML"), "down", "-v")
+		}
+	}
+
+	testCase.Run(t)
+}
+
 func TestComposeIPAMConfig(t *testing.T) {
 	testCase := nerdtest.Setup()
 
@@ -1047,7 +1180,7 @@ services:
     image: %s
   %s:
     image: %s
-    entrypoint: /bin/sh -c "exit 1"
+    entrypoint: /bin/sh -c "echo exiting; exit 1"
 `, serviceRegular, testutil.NginxAlpineImage, serviceProfiled, testutil.BusyboxImage)
 
 		composePath := data.Temp().Save(composeYAML, "compose.yaml")
@@ -1068,6 +1201,8 @@ services:
 				helpers.Command("compose", "-f", data.Labels().Get("composeYAML"), "up", "--abort-on-container-exit").Run(
 					&test.Expected{
 						ExitCode: 1,
+						// The logs of the services are shown until the exit
+						Output: expect.Contains("exiting"),
 					},
 				)
 			},
@@ -1124,6 +1259,283 @@ services:
 	testCase.Run(t)
 }
 
+func TestComposeUpExitCodeFrom(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.NoParallel = true
+
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		composeYAML := fmt.Sprintf(`
+services:
+  server:
+    image: %s
+  tests:
+    image: %s
+    entrypoint: /bin/sh -c "echo testing; exit 3"
+`, testutil.NginxAlpineImage, testutil.BusyboxImage)
+
+		composePath := data.Temp().Save(composeYAML, "compose.yaml")
+		projectName := filepath.Base(filepath.Dir(composePath))
+		data.Labels().Set("composeYAML", composePath)
+		data.Labels().Set("serverContainer", serviceparser.DefaultContainerName(projectName, "server", "1"))
+	}
+
+	serverExited := func(data test.Data, helpers test.Helpers) test.Comparator {
+		return func(stdout string, t tig.T) {
+			status := helpers.Capture("inspect", "--format", "{{.State.Status}}", data.Labels().Get("serverContainer"))
+			assert.Equal(t, status, "exited\n", "the stack should have been stopped")
+		}
+	}
+
+	cleanup := func(data test.Data, helpers test.Helpers) {
+		helpers.Anyhow("compose", "-f", data.Labels().Get("composeYAML"), "down", "-v")
+	}
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "the exit code of the service is returned",
+			NoParallel:  true,
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("compose", "-f", data.Labels().Get("composeYAML"), "up", "--exit-code-from", "tests")
+			},
+			Cleanup: cleanup,
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: 3,
+					Output:   expect.All(expect.Contains("testing"), serverExited(data, helpers)),
+				}
+			},
+		},
+		{
+			Description: "the exit code of the service is returned, even if another container exited first",
+			NoParallel:  true,
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("compose", "-f", data.Labels().Get("composeYAML"), "up", "--exit-code-from", "server")
+			},
+			Cleanup: cleanup,
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: expect.ExitCodeSuccess,
+					Output:   serverExited(data, helpers),
+				}
+			},
+		},
+		{
+			Description: "the containers of compose run are not taken into account",
+			NoParallel:  true,
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Command("compose", "-f", data.Labels().Get("composeYAML"), "run", "--entrypoint", "/bin/sh", "tests", "-c", "exit 5").
+					Run(&test.Expected{ExitCode: 5})
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("compose", "-f", data.Labels().Get("composeYAML"), "up", "--exit-code-from", "tests")
+			},
+			Cleanup: cleanup,
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: 3,
+					Output:   serverExited(data, helpers),
+				}
+			},
+		},
+		{
+			Description: "--abort-on-container-exit returns the exit code of the first container to exit",
+			NoParallel:  true,
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("compose", "-f", data.Labels().Get("composeYAML"), "up", "--abort-on-container-exit")
+			},
+			Cleanup: cleanup,
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: 3,
+					Output:   serverExited(data, helpers),
+				}
+			},
+		},
+		{
+			Description: "an unknown service is an error",
+			NoParallel:  true,
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("compose", "-f", data.Labels().Get("composeYAML"), "up", "--exit-code-from", "unknown")
+			},
+			Cleanup:  cleanup,
+			Expected: test.Expects(expect.ExitCodeGenericFail, nil, nil),
+		},
+		{
+			Description: "flag -d incompatible with --exit-code-from",
+			NoParallel:  true,
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("compose", "-f", data.Labels().Get("composeYAML"), "up", "-d", "--exit-code-from", "tests")
+			},
+			Expected: test.Expects(expect.ExitCodeGenericFail, nil, nil),
+		},
+	}
+
+	testCase.Run(t)
+}
+
+func TestComposeUpForegroundInterrupt(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	// Docker compose exits with 130 on Ctrl-C
+	// FIXME: gomodjail signal handling is not working yet: https://github.com/AkihiroSuda/gomodjail/issues/51
+	testCase.Require = require.All(require.Not(nerdtest.Docker), require.Not(nerdtest.Gomodjail))
+
+	setup := func(trap string, upArgs ...string) func(data test.Data, helpers test.Helpers) {
+		return func(data test.Data, helpers test.Helpers) {
+			composeYAML := fmt.Sprintf(`
+services:
+  svc:
+    image: %s
+    command: sh -c 'trap %s TERM; echo started; while true; do sleep 1; done'
+    stop_grace_period: 30s
+`, testutil.CommonImage, trap)
+			composePath := data.Temp().Save(composeYAML, "compose.yaml")
+			projectName := filepath.Base(filepath.Dir(composePath))
+			data.Labels().Set("composeYAML", composePath)
+			data.Labels().Set("container", serviceparser.DefaultContainerName(projectName, "svc", "1"))
+			data.Labels().Set("upArgs", strings.Join(upArgs, " "))
+		}
+	}
+
+	command := func(data test.Data, helpers test.Helpers) test.TestableCommand {
+		args := append([]string{"compose", "-f", data.Labels().Get("composeYAML"), "up"}, strings.Fields(data.Labels().Get("upArgs"))...)
+		cmd := helpers.Command(args...)
+		cmd.Background()
+		nerdtest.EnsureContainerStarted(helpers, data.Labels().Get("container"))
+		assert.NilError(helpers.T(), cmd.Signal(os.Interrupt))
+		return cmd
+	}
+
+	cleanup := func(data test.Data, helpers test.Helpers) {
+		helpers.Anyhow("compose", "-f", data.Labels().Get("composeYAML"), "down", "-v")
+	}
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "Ctrl-C stops the containers gracefully",
+			Setup:       setup(`"echo stopping; exit 0"`),
+			Command:     command,
+			Cleanup:     cleanup,
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: expect.ExitCodeSuccess,
+					Output: expect.All(
+						expect.Contains("svc-1", "started"),
+						func(stdout string, t tig.T) {
+							state := helpers.Capture("inspect", "--format", "{{.State.Status}} {{.State.ExitCode}}", data.Labels().Get("container"))
+							assert.Equal(t, state, "exited 0\n", "the container should have been stopped with SIGTERM")
+						},
+					),
+				}
+			},
+		},
+		{
+			Description: "the containers are killed after --timeout",
+			Setup:       setup(`""`, "--timeout", "1"),
+			Command:     command,
+			Cleanup:     cleanup,
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: expect.ExitCodeSuccess,
+					Output: func(stdout string, t tig.T) {
+						state := helpers.Capture("inspect", "--format", "{{.State.Status}} {{.State.ExitCode}}", data.Labels().Get("container"))
+						assert.Equal(t, state, "exited 137\n", "the container should have been killed after the timeout")
+					},
+				}
+			},
+		},
+		{
+			Description: "compose run containers are left running",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				setup(`"echo stopping; exit 0"`)(data, helpers)
+				helpers.Ensure("compose", "-f", data.Labels().Get("composeYAML"), "run", "-d", "--name", data.Identifier("run"), "svc")
+				nerdtest.EnsureContainerStarted(helpers, data.Identifier("run"))
+			},
+			Command: command,
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier("run"))
+				cleanup(data, helpers)
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: expect.ExitCodeSuccess,
+					Output: func(stdout string, t tig.T) {
+						state := helpers.Capture("inspect", "--format", "{{.State.Status}}", data.Labels().Get("container"))
+						assert.Equal(t, state, "exited\n", "the container of compose up should have been stopped")
+						state = helpers.Capture("inspect", "--format", "{{.State.Status}}", data.Identifier("run"))
+						assert.Equal(t, state, "running\n", "the container of compose run should have been left running")
+					},
+				}
+			},
+		},
+	}
+
+	testCase.Run(t)
+}
+
+func TestComposeUpForegroundDoubleInterrupt(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	// FIXME: gomodjail signal handling is not working yet: https://github.com/AkihiroSuda/gomodjail/issues/51
+	testCase.Require = require.All(require.Not(nerdtest.Docker), require.Not(nerdtest.Gomodjail))
+
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		// front is stopped first and exits on SIGTERM, back ignores SIGTERM and is still being stopped on the second signal
+		composeYAML := fmt.Sprintf(`
+services:
+  back:
+    image: %[1]s
+    command: sh -c 'trap "" TERM; echo started; while true; do sleep 1; done'
+    stop_grace_period: 60s
+  front:
+    image: %[1]s
+    command: sh -c 'trap "exit 0" TERM; echo started; while true; do sleep 1; done'
+    depends_on:
+      - back
+`, testutil.CommonImage)
+		composePath := data.Temp().Save(composeYAML, "compose.yaml")
+		projectName := filepath.Base(filepath.Dir(composePath))
+		data.Labels().Set("composeYAML", composePath)
+		data.Labels().Set("back", serviceparser.DefaultContainerName(projectName, "back", "1"))
+		data.Labels().Set("front", serviceparser.DefaultContainerName(projectName, "front", "1"))
+	}
+
+	testCase.Command = func(data test.Data, helpers test.Helpers) test.TestableCommand {
+		cmd := helpers.Command("compose", "-f", data.Labels().Get("composeYAML"), "up")
+		cmd.Background()
+		nerdtest.EnsureContainerStarted(helpers, data.Labels().Get("back"))
+		nerdtest.EnsureContainerStarted(helpers, data.Labels().Get("front"))
+		assert.NilError(helpers.T(), cmd.Signal(os.Interrupt))
+		for i := 0; i < 20; i++ {
+			if helpers.Capture("inspect", "--format", "{{.State.Status}}", data.Labels().Get("front")) == "exited\n" {
+				break
+			}
+			time.Sleep(time.Second)
+		}
+		assert.NilError(helpers.T(), cmd.Signal(os.Interrupt))
+		return cmd
+	}
+
+	testCase.Cleanup = func(data test.Data, helpers test.Helpers) {
+		helpers.Anyhow("compose", "-f", data.Labels().Get("composeYAML"), "down", "-v")
+	}
+
+	testCase.Expected = func(data test.Data, helpers test.Helpers) *test.Expected {
+		return &test.Expected{
+			ExitCode: expect.ExitCodeSuccess,
+			Output: func(stdout string, t tig.T) {
+				state := helpers.Capture("inspect", "--format", "{{.State.Status}} {{.State.ExitCode}}", data.Labels().Get("front"))
+				assert.Equal(t, state, "exited 0\n", "the container stopped first should have exited on SIGTERM")
+				state = helpers.Capture("inspect", "--format", "{{.State.Status}} {{.State.ExitCode}}", data.Labels().Get("back"))
+				assert.Equal(t, state, "exited 137\n", "the container still running should have been killed by the second signal")
+			},
+		}
+	}
+
+	testCase.Run(t)
+}
+
 func TestComposeUpPull(t *testing.T) {
 	testCase := nerdtest.Setup()
 
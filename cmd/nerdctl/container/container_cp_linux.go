@@ -32,9 +32,12 @@ import (
 )
 
 func copyCommand() *cobra.Command {
-	shortHelp := "Copy files/folders between a running container and the local filesystem."
+	shortHelp := "Copy files/folders between a container and the local filesystem."
 
 	longHelp := shortHelp + `
+Use '-' as SRC_PATH to extract a tar archive read from stdin into the directory DEST_PATH,
+or as DEST_PATH to write a tar archive of SRC_PATH to stdout.
+
 This command requires 'tar' to be installed on the host (not in the container).
 Using GNU tar is recommended.
 The path of the 'tar' binary can be specified with an environment variable '$TAR'.
@@ -107,12 +110,6 @@ func copyOptions(cmd *cobra.Command, args []string) (types.ContainerCpOptions, e
 	if srcSpec.Container == nil && destSpec.Container == nil {
 		return types.ContainerCpOptions{}, fmt.Errorf("one of src or dest must be a container file specification")
 	}
-	if srcSpec.Path == "-" {
-		return types.ContainerCpOptions{}, fmt.Errorf("support for reading a tar archive from stdin is not implemented yet")
-	}
-	if destSpec.Path == "-" {
-		return types.ContainerCpOptions{}, fmt.Errorf("support for writing a tar archive to stdout is not implemented yet")
-	}
 
 	container2host := srcSpec.Container != nil
 	var containerReq string
@@ -128,6 +125,8 @@ func copyOptions(cmd *cobra.Command, args []string) (types.ContainerCpOptions, e
 		DestPath:       destSpec.Path,
 		SrcPath:        srcSpec.Path,
 		FollowSymLink:  flagL,
+		Stdin:          cmd.InOrStdin(),
+		Stdout:         cmd.OutOrStdout(),
 	}, nil
 }
 
@@ -0,0 +1,138 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/nerdctl/mod/tigron/expect"
+	"github.com/containerd/nerdctl/mod/tigron/require"
+	"github.com/containerd/nerdctl/mod/tigron/test"
+	"github.com/containerd/nerdctl/mod/tigron/tig"
+
+	"github.com/containerd/nerdctl/v2/pkg/testutil"
+	"github.com/containerd/nerdctl/v2/pkg/testutil/nerdtest"
+)
+
+func newTarArchive(t tig.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range files {
+		assert.NilError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		assert.NilError(t, err)
+	}
+	assert.NilError(t, tw.Close())
+	return buf.Bytes()
+}
+
+func readTarArchive(t tig.T, archive string) map[string]string {
+	files := map[string]string{}
+	tr := tar.NewReader(strings.NewReader(archive))
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		assert.NilError(t, err)
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		assert.NilError(t, err)
+		files[hdr.Name] = string(content)
+	}
+	return files
+}
+
+func TestCopyTarStream(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	// Copying into/out of stopped containers is not supported in rootless mode
+	testCase.Require = require.Not(nerdtest.Rootless)
+	// The subtests read back what the previous ones copied
+	testCase.NoParallel = true
+
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		// The container is created, but never started
+		helpers.Ensure("create", "--name", data.Identifier(), testutil.CommonImage, "sleep", "Inf")
+		data.Labels().Set("container", data.Identifier())
+	}
+
+	testCase.Cleanup = func(data test.Data, helpers test.Helpers) {
+		helpers.Anyhow("rm", "-f", data.Identifier())
+	}
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "extract a tar archive from stdin into a stopped container",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				cmd := helpers.Command("cp", "-", data.Labels().Get("container")+":/tmp")
+				cmd.Feed(bytes.NewReader(newTarArchive(helpers.T(), map[string]string{
+					"from-stdin":        "hello",
+					"from-stdin-2/file": "world",
+				})))
+				return cmd
+			},
+			Expected: test.Expects(0, nil, nil),
+		},
+		{
+			Description: "write a tar archive of a file of a stopped container to stdout",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("cp", data.Labels().Get("container")+":/tmp/from-stdin", "-")
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: func(stdout string, t tig.T) {
+						assert.DeepEqual(t, readTarArchive(t, stdout), map[string]string{"from-stdin": "hello"})
+					},
+				}
+			},
+		},
+		{
+			Description: "write a tar archive of a directory of a stopped container to stdout",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("cp", data.Labels().Get("container")+":/tmp/from-stdin-2", "-")
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: func(stdout string, t tig.T) {
+						assert.DeepEqual(t, readTarArchive(t, stdout), map[string]string{"from-stdin-2/file": "world"})
+					},
+				}
+			},
+		},
+		{
+			Description: "extracting a tar archive into a file fails",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				cmd := helpers.Command("cp", "-", data.Labels().Get("container")+":/tmp/from-stdin")
+				cmd.Feed(bytes.NewReader(newTarArchive(helpers.T(), map[string]string{"file": "content"})))
+				return cmd
+			},
+			Expected: test.Expects(expect.ExitCodeGenericFail, nil, nil),
+		},
+	}
+
+	testCase.Run(t)
+}
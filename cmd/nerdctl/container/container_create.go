@@ -112,6 +112,10 @@ func createOptions(cmd *cobra.Command) (types.ContainerCreateOptions, error) {
 	if err != nil {
 		return opt, err
 	}
+	opt.AllowMissingEmulation, err = cmd.Flags().GetBool("allow-missing-emulation")
+	if err != nil {
+		return opt, err
+	}
 	// #endregion
 
 	// #region for init process flags
@@ -196,6 +200,10 @@ func createOptions(cmd *cobra.Command) (types.ContainerCreateOptions, error) {
 	if err != nil {
 		return opt, err
 	}
+	opt.OomGroup, err = cmd.Flags().GetBool("oom-group")
+	if err != nil {
+		return opt, err
+	}
 	opt.OomScoreAdjChanged = cmd.Flags().Changed("oom-score-adj")
 	opt.OomScoreAdj, err = cmd.Flags().GetInt("oom-score-adj")
 	if err != nil {
@@ -205,6 +213,9 @@ func createOptions(cmd *cobra.Command) (types.ContainerCreateOptions, error) {
 	if err != nil {
 		return opt, err
 	}
+	if err := validatePidsLimit(opt.PidsLimit); err != nil {
+		return opt, err
+	}
 	opt.CgroupConf, err = cmd.Flags().GetStringSlice("cgroup-conf")
 	if err != nil {
 		return opt, err
@@ -279,6 +290,10 @@ func createOptions(cmd *cobra.Command) (types.ContainerCreateOptions, error) {
 	if err != nil {
 		return opt, err
 	}
+	opt.HealthStartInterval, err = cmd.Flags().GetDuration("health-start-interval")
+	if err != nil {
+		return opt, err
+	}
 	opt.NoHealthcheck, err = cmd.Flags().GetBool("no-healthcheck")
 	if err != nil {
 		return opt, err
@@ -343,6 +358,10 @@ func createOptions(cmd *cobra.Command) (types.ContainerCreateOptions, error) {
 	if err != nil {
 		return opt, err
 	}
+	opt.RuntimeOpts, err = cmd.Flags().GetStringArray("runtime-opt")
+	if err != nil {
+		return opt, err
+	}
 	opt.Sysctl, err = cmd.Flags().GetStringArray("sysctl")
 	if err != nil {
 		return opt, err
@@ -367,6 +386,10 @@ func createOptions(cmd *cobra.Command) (types.ContainerCreateOptions, error) {
 	if err != nil {
 		return opt, err
 	}
+	opt.VolumeDriver, err = cmd.Flags().GetString("volume-driver")
+	if err != nil {
+		return opt, err
+	}
 	// #endregion
 
 	// #region for rootfs flags
@@ -562,3 +585,11 @@ func createAction(cmd *cobra.Command, args []string) error {
 	fmt.Fprintln(createOpt.Stdout, c.ID())
 	return nil
 }
+
+// validatePidsLimit checks the --pids-limit flag value: -1 (the default) means unlimited.
+func validatePidsLimit(limit int64) error {
+	if limit == 0 || limit < -1 {
+		return fmt.Errorf("invalid pids-limit %d: must be a positive integer, or -1 for unlimited", limit)
+	}
+	return nil
+}
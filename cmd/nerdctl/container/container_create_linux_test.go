@@ -55,6 +55,19 @@ func TestCreateWithLabel(t *testing.T) {
 	assert.Equal(base.T, "NGINX Docker Maintainers <docker-maint@nginx.com>", inspect.Config.Labels["maintainer"])
 }
 
+func TestCreateWithLabelOverridingImageLabel(t *testing.T) {
+	t.Parallel()
+	base := testutil.NewBase(t)
+	tID := testutil.Identifier(t)
+
+	base.Cmd("create", "--name", tID, "--label", "maintainer=nerdctl", "--label", "foo=bar", testutil.NginxAlpineImage, "echo", "foo").AssertOK()
+	defer base.Cmd("rm", "-f", tID).Run()
+	inspect := base.InspectContainer(tID)
+	assert.Equal(base.T, "bar", inspect.Config.Labels["foo"])
+	// the label `maintainer` defined by image is overridden by --label
+	assert.Equal(base.T, "nerdctl", inspect.Config.Labels["maintainer"])
+}
+
 func TestCreateWithMACAddress(t *testing.T) {
 	base := testutil.NewBase(t)
 	tID := testutil.Identifier(t)
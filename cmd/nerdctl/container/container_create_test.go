@@ -52,6 +52,14 @@ func TestCreate(t *testing.T) {
 			},
 			Expected: test.Expects(0, nil, expect.Contains("Created")),
 		},
+		{
+			Description: "no task before start",
+			NoParallel:  true,
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("container", "inspect", "--format", "{{.State.Status}} {{.State.Pid}}", data.Labels().Get("cID"))
+			},
+			Expected: test.Expects(0, nil, expect.Equals("created 0\n")),
+		},
 		{
 			Description: "start",
 			NoParallel:  true,
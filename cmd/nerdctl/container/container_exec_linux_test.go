@@ -54,6 +54,35 @@ func TestExecWithUser(t *testing.T) {
 	}
 }
 
+func TestExecWithContainerUser(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		helpers.Ensure("run", "-d", "--name", data.Identifier(), "--user", "guest", testutil.CommonImage, "sleep", nerdtest.Infinity)
+		nerdtest.EnsureContainerStarted(helpers, data.Identifier())
+	}
+	testCase.Cleanup = func(data test.Data, helpers test.Helpers) {
+		helpers.Anyhow("rm", "-f", data.Identifier())
+	}
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "exec without -u runs as the user of the container",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("exec", data.Identifier(), "id")
+			},
+			Expected: test.Expects(0, nil, expect.Contains("uid=405(guest) gid=100(users)")),
+		},
+		{
+			Description: "exec -u overrides the user of the container",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("exec", "-u", "nobody", data.Identifier(), "id")
+			},
+			Expected: test.Expects(0, nil, expect.Contains("uid=65534(nobody) gid=65534(nobody)")),
+		},
+	}
+	testCase.Run(t)
+}
+
 func TestExecTTY(t *testing.T) {
 	const sttyPartialOutput = "speed 38400 baud"
 
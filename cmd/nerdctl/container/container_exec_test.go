@@ -69,6 +69,37 @@ func TestExecWithDoubleDash(t *testing.T) {
 	testCase.Run(t)
 }
 
+func TestExecWorkdir(t *testing.T) {
+	nerdtest.Setup()
+
+	testCase := &test.Case{
+		Require: require.Not(require.Windows),
+		Setup: func(data test.Data, helpers test.Helpers) {
+			helpers.Ensure("run", "-d", "--name", data.Identifier(), "-w", "/usr/bin", testutil.CommonImage, "sleep", nerdtest.Infinity)
+		},
+		Cleanup: func(data test.Data, helpers test.Helpers) {
+			helpers.Anyhow("rm", "-f", data.Identifier())
+		},
+		SubTests: []*test.Case{
+			{
+				Description: "exec without -w runs in the WORKDIR of the container",
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("exec", data.Identifier(), "pwd")
+				},
+				Expected: test.Expects(0, nil, expect.Equals("/usr/bin\n")),
+			},
+			{
+				Description: "exec -w overrides the WORKDIR of the container",
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("exec", "-w", "/etc", data.Identifier(), "pwd")
+				},
+				Expected: test.Expects(0, nil, expect.Equals("/etc\n")),
+			},
+		},
+	}
+	testCase.Run(t)
+}
+
 func TestExecStdin(t *testing.T) {
 	nerdtest.Setup()
 
@@ -26,6 +26,7 @@ import (
 
 	"github.com/containerd/nerdctl/v2/cmd/nerdctl/completion"
 	"github.com/containerd/nerdctl/v2/cmd/nerdctl/helpers"
+	"github.com/containerd/nerdctl/v2/pkg/api/types"
 	"github.com/containerd/nerdctl/v2/pkg/clientutil"
 	"github.com/containerd/nerdctl/v2/pkg/cmd/container"
 	"github.com/containerd/nerdctl/v2/pkg/idutil/containerwalker"
@@ -43,6 +44,10 @@ func HealthCheckCommand() *cobra.Command {
 		SilenceErrors:     true,
 	}
 
+	// --scheduled is used by the healthcheck timer, to skip the probes that are not due yet
+	healthCheckCommand.Flags().Bool("scheduled", false, "Only run the health check if a probe is due")
+	healthCheckCommand.Flags().MarkHidden("scheduled")
+
 	return healthCheckCommand
 }
 
@@ -52,6 +57,16 @@ func healthCheckAction(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	scheduled, err := cmd.Flags().GetBool("scheduled")
+	if err != nil {
+		return err
+	}
+	options := types.ContainerHealthCheckOptions{
+		GOptions:  globalOptions,
+		Scheduled: scheduled,
+	}
+	options.NerdctlCmd, options.NerdctlArgs = helpers.GlobalFlags(cmd)
+
 	client, ctx, cancel, err := clientutil.NewClient(cmd.Context(), globalOptions.Namespace, globalOptions.Address)
 	if err != nil {
 		return err
@@ -65,7 +80,7 @@ func healthCheckAction(cmd *cobra.Command, args []string) error {
 			if found.MatchCount > 1 {
 				return fmt.Errorf("multiple IDs found with provided prefix: %s", found.Req)
 			}
-			return container.HealthCheck(ctx, client, found.Container)
+			return container.HealthCheck(ctx, client, found.Container, options)
 		},
 	}
 
@@ -743,6 +743,45 @@ func TestContainerHealthCheckAdvance(t *testing.T) {
 				}
 			},
 		},
+		{
+			Description: "Restart resets health status to starting",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("run", "-d", "--name", data.Identifier(),
+					"--health-cmd", "test ! -f /tmp/restarted || exit 1", "--health-retries", "1",
+					"--health-start-period", "30s",
+					testutil.CommonImage, "sleep", nerdtest.Infinity)
+				nerdtest.EnsureContainerStarted(helpers, data.Identifier())
+				helpers.Ensure("container", "healthcheck", data.Identifier())
+				helpers.Ensure("exec", data.Identifier(), "touch", "/tmp/restarted")
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				inspect := nerdtest.InspectContainer(helpers, data.Identifier())
+				assert.Equal(helpers.T(), inspect.State.Health.Status, healthcheck.Healthy)
+
+				helpers.Ensure("restart", data.Identifier())
+				nerdtest.EnsureContainerStarted(helpers, data.Identifier())
+				// Failures right after the restart are within the new start period
+				helpers.Ensure("container", "healthcheck", data.Identifier())
+				return helpers.Command("inspect", data.Identifier())
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: 0,
+					Output: expect.All(func(stdout string, t tig.T) {
+						inspect := nerdtest.InspectContainer(helpers, data.Identifier())
+						h := inspect.State.Health
+						debug, _ := json.MarshalIndent(h, "", "  ")
+						t.Log(string(debug))
+						assert.Assert(t, h != nil, "expected health state")
+						assert.Equal(t, h.Status, healthcheck.Starting, "expected starting status after restart")
+						assert.Equal(t, h.FailingStreak, 0)
+					}),
+				}
+			},
+		},
 	}
 
 	testCase.Run(t)
@@ -1093,6 +1132,53 @@ func TestHealthCheck_SystemdIntegration_Advanced(t *testing.T) {
 				}
 			},
 		},
+		{
+			// Tests that the timer running at the start interval is re-created at the interval by UpdateTimer()
+			// once the container is healthy.
+			Description: "Systemd timer at the start interval is re-created at the interval",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("run", "-d", "--name", data.Identifier(),
+					"--health-cmd", "echo healthy",
+					"--health-interval", "1h",
+					"--health-start-period", "1m",
+					"--health-start-interval", "1s",
+					testutil.CommonImage, "sleep", "60")
+				nerdtest.EnsureContainerStarted(helpers, data.Identifier())
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				containerID := nerdtest.InspectContainer(helpers, data.Identifier()).ID
+				// The first probe is healthy, so the start interval no longer applies
+				for i := 0; i < 10; i++ {
+					var state string
+					helpers.Custom("systemctl", "is-active", containerID+".timer").Run(&test.Expected{
+						ExitCode: expect.ExitCodeNoCheck,
+						Output: func(stdout string, _ tig.T) {
+							state = strings.TrimSpace(stdout)
+						},
+					})
+					if state == "active" {
+						break
+					}
+					time.Sleep(1 * time.Second)
+				}
+				return helpers.Custom("systemctl", "list-timers", "--all", "--no-pager")
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: expect.ExitCodeNoCheck,
+					Output: func(stdout string, t tig.T) {
+						containerID := nerdtest.InspectContainer(helpers, data.Identifier()).ID
+						assert.Assert(t, strings.Contains(stdout, containerID+".timer"),
+							"expected the timer of container %s to run at the interval", containerID)
+						assert.Assert(t, !strings.Contains(stdout, containerID+"-start.timer"),
+							"expected the timer of container %s at the start interval to be removed", containerID)
+					},
+				}
+			},
+		},
 		{
 			Description: "Container restart recreates systemd timer",
 			Setup: func(data test.Data, helpers test.Helpers) {
@@ -23,6 +23,7 @@ import (
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/go-connections/nat"
 	"gotest.tools/v3/assert"
@@ -269,6 +270,78 @@ func TestContainerInspectState(t *testing.T) {
 
 }
 
+func TestContainerInspectStateTimestamps(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	const zeroTime = "0001-01-01T00:00:00Z"
+	parse := func(t tig.T, s string) time.Time {
+		ts, err := time.Parse(time.RFC3339Nano, s)
+		assert.NilError(t, err)
+		assert.Equal(t, ts.Location(), time.UTC, "timestamp %q is not in UTC", s)
+		return ts
+	}
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "created container",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("create", "--name", data.Identifier(), testutil.CommonImage, "sleep", nerdtest.Infinity)
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("container", "inspect", data.Identifier())
+			},
+			Expected: test.Expects(0, nil, expect.JSON([]dockercompat.Container{}, func(dc []dockercompat.Container, t tig.T) {
+				assert.Equal(t, len(dc), 1)
+				assert.Assert(t, !parse(t, dc[0].Created).IsZero())
+				assert.Equal(t, dc[0].State.StartedAt, zeroTime)
+				assert.Equal(t, dc[0].State.FinishedAt, zeroTime)
+			})),
+		},
+		{
+			Description: "running container",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("run", "-d", "--name", data.Identifier(), testutil.CommonImage, "sleep", nerdtest.Infinity)
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("container", "inspect", data.Identifier())
+			},
+			Expected: test.Expects(0, nil, expect.JSON([]dockercompat.Container{}, func(dc []dockercompat.Container, t tig.T) {
+				assert.Equal(t, len(dc), 1)
+				created := parse(t, dc[0].Created)
+				startedAt := parse(t, dc[0].State.StartedAt)
+				assert.Assert(t, !startedAt.Before(created), "StartedAt %s is before Created %s", startedAt, created)
+				assert.Equal(t, dc[0].State.FinishedAt, zeroTime)
+			})),
+		},
+		{
+			Description: "exited container",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("run", "--name", data.Identifier(), testutil.CommonImage, "sh", "-c", "sleep 1")
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("container", "inspect", data.Identifier())
+			},
+			Expected: test.Expects(0, nil, expect.JSON([]dockercompat.Container{}, func(dc []dockercompat.Container, t tig.T) {
+				assert.Equal(t, len(dc), 1)
+				startedAt := parse(t, dc[0].State.StartedAt)
+				finishedAt := parse(t, dc[0].State.FinishedAt)
+				assert.Assert(t, finishedAt.After(startedAt), "FinishedAt %s is not after StartedAt %s", finishedAt, startedAt)
+			})),
+		},
+	}
+
+	testCase.Run(t)
+}
+
 func TestContainerInspectHostConfig(t *testing.T) {
 	testContainer := testutil.Identifier(t)
 	if rootlessutil.IsRootless() && infoutil.CgroupsVersion() == "1" {
@@ -314,6 +387,32 @@ func TestContainerInspectHostConfig(t *testing.T) {
 	assert.Equal(t, int64(268435456), inspect.HostConfig.ShmSize)
 }
 
+func TestContainerInspectHostConfigBindsPortsRestart(t *testing.T) {
+	testContainer := testutil.Identifier(t)
+	if rootlessutil.IsRootless() && infoutil.CgroupsVersion() == "1" {
+		t.Skip("test skipped for rootless containers on cgroup v1")
+	}
+
+	base := testutil.NewBase(t)
+	defer base.Cmd("rm", "-f", testContainer).Run()
+
+	hostDir := t.TempDir()
+	base.Cmd("run", "-d", "--name", testContainer,
+		"-v", hostDir+":/mnt/foo:ro",
+		"-p", "127.0.0.1:8089:80",
+		"--memory", "64m",
+		"--restart", "on-failure:3",
+		testutil.AlpineImage, "sleep", "infinity").AssertOK()
+
+	inspect := base.InspectContainer(testContainer)
+	assert.DeepEqual(t, []string{hostDir + ":/mnt/foo:ro"}, inspect.HostConfig.Binds)
+	assert.DeepEqual(t, []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: "8089"}}, inspect.HostConfig.PortBindings["80/tcp"])
+	assert.Equal(t, int64(67108864), inspect.HostConfig.Memory)
+	assert.Equal(t, dockercompat.RestartPolicy{Name: "on-failure", MaximumRetryCount: 3}, inspect.HostConfig.RestartPolicy)
+	assert.Equal(t, "bridge", inspect.HostConfig.NetworkMode)
+	assert.Equal(t, false, inspect.HostConfig.AutoRemove)
+}
+
 func TestContainerInspectHostConfigDefaults(t *testing.T) {
 	testContainer := testutil.Identifier(t)
 
@@ -363,6 +462,9 @@ func TestContainerInspectHostConfigDefaults(t *testing.T) {
 	assert.Equal(t, hc.ShmSize, inspect.HostConfig.ShmSize)
 	assert.Equal(t, hc.Runtime, inspect.HostConfig.Runtime)
 	assert.Equal(t, 0, len(inspect.HostConfig.Devices))
+	assert.Equal(t, 0, len(inspect.HostConfig.Binds))
+	assert.Equal(t, "no", inspect.HostConfig.RestartPolicy.Name)
+	assert.Equal(t, false, inspect.HostConfig.AutoRemove)
 	// Sysctls can be empty or contain "net.ipv4.ip_unprivileged_port_start" depending on the environment.
 	got := len(inspect.HostConfig.Sysctls)
 	if got != 0 && got != 1 {
@@ -24,6 +24,10 @@ import (
 	"github.com/coreos/go-iptables/iptables"
 	"gotest.tools/v3/assert"
 
+	"github.com/containerd/nerdctl/mod/tigron/expect"
+	"github.com/containerd/nerdctl/mod/tigron/test"
+	"github.com/containerd/nerdctl/mod/tigron/tig"
+
 	"github.com/containerd/nerdctl/v2/pkg/rootlessutil"
 	"github.com/containerd/nerdctl/v2/pkg/testutil"
 	iptablesutil "github.com/containerd/nerdctl/v2/pkg/testutil/iptables"
@@ -78,3 +82,61 @@ func TestKillCleanupForwards(t *testing.T) {
 	base.Cmd("kill", testContainerName).AssertOK()
 	assert.Equal(t, iptablesutil.ForwardExists(t, ipt, chain, containerIP, hostPort), false)
 }
+
+// TestKillPaused checks that killing a paused container does not hang, and that the container is terminated.
+func TestKillPaused(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.Require = nerdtest.CGroup
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "SIGKILL",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("run", "-d", "--name", data.Identifier(), testutil.CommonImage, "sleep", nerdtest.Infinity)
+				nerdtest.EnsureContainerStarted(helpers, data.Identifier())
+				helpers.Ensure("pause", data.Identifier())
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("kill", data.Identifier())
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: func(stdout string, t tig.T) {
+						helpers.Command("wait", data.Identifier()).Run(&test.Expected{
+							Output: expect.Equals("137\n"),
+						})
+					},
+				}
+			},
+		},
+		{
+			Description: "SIGTERM is handled by the container once unpaused",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("run", "-d", "--name", data.Identifier(), testutil.CommonImage,
+					"sh", "-c", "trap 'exit 42' TERM; while true; do sleep 0.1; done")
+				nerdtest.EnsureContainerStarted(helpers, data.Identifier())
+				helpers.Ensure("pause", data.Identifier())
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("kill", "--signal", "SIGTERM", data.Identifier())
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: func(stdout string, t tig.T) {
+						helpers.Command("wait", data.Identifier()).Run(&test.Expected{
+							Output: expect.Equals("42\n"),
+						})
+					},
+				}
+			},
+		},
+	}
+
+	testCase.Run(t)
+}
@@ -250,6 +250,38 @@ func TestContainerListWithNames(t *testing.T) {
 	})
 }
 
+func TestContainerListWithNetworksAndState(t *testing.T) {
+	base, testContainer := preparePsTestContainer(t, "listWithNetworks", true)
+
+	// hope there are no tests running parallel
+	base.Cmd("ps", "-n", "1", "--format", "{{.Networks}} {{.State}} {{.RunningFor}}").AssertOutWithFunc(func(stdout string) error {
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		if len(lines) != 1 {
+			return fmt.Errorf("expected 1 line, got %d", len(lines))
+		}
+		fields := strings.SplitN(lines[0], " ", 3)
+		assert.Equal(t, len(fields), 3, lines[0])
+		assert.Equal(t, fields[0], testContainer.network)
+		assert.Equal(t, fields[1], "running")
+		assert.Assert(t, strings.HasSuffix(fields[2], " ago"), fields[2])
+		return nil
+	})
+}
+
+func TestContainerListWithPorts(t *testing.T) {
+	base := testutil.NewBase(t)
+	testContainerName := testutil.Identifier(t)
+	t.Cleanup(func() {
+		base.Cmd("rm", "-f", testContainerName).Run()
+	})
+	base.Cmd("run", "-d", "--name", testContainerName,
+		"-p", "127.0.0.1:18080:80", "-p", "127.0.0.1:18443:443/udp", "-p", "127.0.0.1:19000-19001:19000-19001",
+		testutil.CommonImage, "sleep", nerdtest.Infinity).AssertOK()
+
+	base.Cmd("ps", "--filter", "name="+testContainerName, "--format", "{{.Ports}}").AssertOutExactly(
+		"127.0.0.1:19000-19001->19000-19001/tcp, 127.0.0.1:18080->80/tcp, 127.0.0.1:18443->443/udp\n")
+}
+
 func TestContainerListWithFilter(t *testing.T) {
 	base, testContainerA := preparePsTestContainer(t, "listWithFilterA", true)
 	_, testContainerB := preparePsTestContainer(t, "listWithFilterB", true)
@@ -229,6 +229,95 @@ bar
 	}
 }
 
+func TestLogsOfJournaldDriverReadBack(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.Require = require.All(
+		require.Not(require.Windows),
+		require.Binary("journalctl"),
+		&test.Requirement{
+			Check: func(data test.Data, helpers test.Helpers) (bool, string) {
+				works := false
+				cmd := helpers.Custom("journalctl", "-xe")
+				cmd.Run(&test.Expected{
+					ExitCode: expect.ExitCodeNoCheck,
+					Output: func(stdout string, t tig.T) {
+						if stdout != "" {
+							works = true
+						}
+					},
+				})
+				return works, "Journactl to return data for the current user"
+			},
+		},
+	)
+
+	testCase.Cleanup = func(data test.Data, helpers test.Helpers) {
+		helpers.Anyhow("rm", "-f", data.Identifier())
+	}
+
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		// A custom tag must not prevent reading the logs back.
+		helpers.Ensure("run", "--network", "none", "--log-driver", "journald", "--log-opt", "tag={{.FullID}}",
+			"--name", data.Identifier(), testutil.CommonImage, "sh", "-ec", "echo foo; echo bar >&2; echo baz")
+		// journald may take a moment to make the entries available
+		for i := 0; i < 20; i++ {
+			if strings.Contains(helpers.Capture("logs", data.Identifier()), "baz") {
+				break
+			}
+			time.Sleep(500 * time.Millisecond)
+		}
+		data.Labels().Set("cID", data.Identifier())
+	}
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "stdout is read back in order",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("logs", data.Labels().Get("cID"))
+			},
+			Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Equals("foo\nbaz\n")),
+		},
+		{
+			Description: "stderr is read back on stderr",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("logs", data.Labels().Get("cID"))
+			},
+			Expected: test.Expects(expect.ExitCodeSuccess, []error{errors.New("bar")}, expect.DoesNotContain("bar")),
+		},
+		{
+			Description: "tail",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("logs", "--tail", "1", data.Labels().Get("cID"))
+			},
+			Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Equals("baz\n")),
+		},
+		{
+			Description: "until in the past",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("logs", "--until", "2000-01-01T00:00:00Z", data.Labels().Get("cID"))
+			},
+			Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Equals("")),
+		},
+		{
+			Description: "since in the past",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("logs", "--since", "1h", data.Labels().Get("cID"))
+			},
+			Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Equals("foo\nbaz\n")),
+		},
+		{
+			Description: "follow returns once the container has exited",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("logs", "-f", data.Labels().Get("cID"))
+			},
+			Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Equals("foo\nbaz\n")),
+		},
+	}
+
+	testCase.Run(t)
+}
+
 func TestLogsWithFailingContainer(t *testing.T) {
 	const expected = `foo
 bar
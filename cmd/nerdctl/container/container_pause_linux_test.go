@@ -0,0 +1,118 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/containerd/nerdctl/mod/tigron/expect"
+	"github.com/containerd/nerdctl/mod/tigron/test"
+
+	"github.com/containerd/nerdctl/v2/pkg/testutil"
+	"github.com/containerd/nerdctl/v2/pkg/testutil/nerdtest"
+)
+
+func TestPauseUnpause(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.Require = nerdtest.CGroup
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		helpers.Ensure("run", "-d", "--name", data.Identifier(), testutil.CommonImage, "sleep", nerdtest.Infinity)
+		nerdtest.EnsureContainerStarted(helpers, data.Identifier())
+		helpers.Ensure("create", "--name", data.Identifier("created"), testutil.CommonImage, "sleep", nerdtest.Infinity)
+	}
+	testCase.Cleanup = func(data test.Data, helpers test.Helpers) {
+		helpers.Anyhow("rm", "-f", data.Identifier(), data.Identifier("created"))
+	}
+	// The subtests transition the state of the same container, so they must run in order
+	testCase.NoParallel = true
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "unpause a running container fails",
+			NoParallel:  true,
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("unpause", data.Identifier())
+			},
+			Expected: test.Expects(expect.ExitCodeGenericFail, []error{errors.New("is not paused")}, nil),
+		},
+		{
+			Description: "pause a created container fails",
+			NoParallel:  true,
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("pause", data.Identifier("created"))
+			},
+			Expected: test.Expects(expect.ExitCodeGenericFail, []error{errors.New("is not running")}, nil),
+		},
+		{
+			Description: "pause a running container",
+			NoParallel:  true,
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				helpers.Ensure("pause", data.Identifier())
+				return helpers.Command("inspect", "--format", "{{.State.Status}} {{.State.Running}} {{.State.Paused}}", data.Identifier())
+			},
+			Expected: test.Expects(0, nil, expect.Equals("paused true true\n")),
+		},
+		{
+			Description: "ps shows the paused state",
+			NoParallel:  true,
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("ps", "--filter", "status=paused", "--format", "{{.Names}}")
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: expect.Equals(data.Identifier() + "\n"),
+				}
+			},
+		},
+		{
+			Description: "pause a paused container fails",
+			NoParallel:  true,
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("pause", data.Identifier())
+			},
+			Expected: test.Expects(expect.ExitCodeGenericFail, []error{errors.New("is already paused")}, nil),
+		},
+		{
+			Description: "exec into a paused container fails",
+			NoParallel:  true,
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("exec", data.Identifier(), "true")
+			},
+			Expected: test.Expects(expect.ExitCodeGenericFail, []error{errors.New("is paused")}, nil),
+		},
+		{
+			Description: "unpause a paused container",
+			NoParallel:  true,
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				helpers.Ensure("unpause", data.Identifier())
+				return helpers.Command("inspect", "--format", "{{.State.Status}} {{.State.Running}} {{.State.Paused}}", data.Identifier())
+			},
+			Expected: test.Expects(0, nil, expect.Equals("running true false\n")),
+		},
+		{
+			Description: "exec into an unpaused container",
+			NoParallel:  true,
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("exec", data.Identifier(), "echo", "unpaused")
+			},
+			Expected: test.Expects(0, nil, expect.Equals("unpaused\n")),
+		},
+	}
+
+	testCase.Run(t)
+}
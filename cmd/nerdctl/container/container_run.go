@@ -17,6 +17,7 @@
 package container
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"runtime"
@@ -46,10 +47,6 @@ import (
 	"github.com/containerd/nerdctl/v2/pkg/taskutil"
 )
 
-const (
-	tiniInitBinary = "tini"
-)
-
 func RunCommand() *cobra.Command {
 	shortHelp := "Run a command in a new container. Optionally specify \"ipfs://\" or \"ipns://\" scheme to pull image from IPFS."
 	longHelp := shortHelp
@@ -105,12 +102,13 @@ func setCreateFlags(cmd *cobra.Command) {
 
 	// #region for init process
 	cmd.Flags().Bool("init", false, "Run an init process inside the container, Default to use tini")
-	cmd.Flags().String("init-binary", tiniInitBinary, "The custom binary to use as the init process")
+	cmd.Flags().String("init-binary", "", "The custom binary to use as the init process (default: the default_init_binary of nerdctl.toml, or tini)")
 	// #endregion
 
 	// #region platform flags
 	cmd.Flags().String("platform", "", "Set platform (e.g. \"amd64\", \"arm64\")") // not a slice, and there is no --all-platforms
 	cmd.RegisterFlagCompletionFunc("platform", completion.Platforms)
+	cmd.Flags().Bool("allow-missing-emulation", false, "Do not check that the host can emulate --platform")
 	// #endregion
 
 	// #region network flags
@@ -133,8 +131,10 @@ func setCreateFlags(cmd *cobra.Command) {
 	cmd.Flags().StringSliceP("publish", "p", nil, "Publish a container's port(s) to the host")
 	cmd.Flags().String("ip", "", "IPv4 address to assign to the container")
 	cmd.Flags().String("ip6", "", "IPv6 address to assign to the container")
+	cmd.Flags().StringSlice("link-local-ip", nil, "Container IPv4/IPv6 link-local addresses")
 	cmd.Flags().StringP("hostname", "h", "", "Container host name")
 	cmd.Flags().String("domainname", "", "Container domain name")
+	cmd.Flags().StringSlice("network-alias", nil, "Add network-scoped alias for the container")
 	cmd.Flags().String("mac-address", "", "MAC address to assign to the container")
 	// #endregion
 
@@ -150,6 +150,7 @@ func setCreateFlags(cmd *cobra.Command) {
 	cmd.Flags().Int64("memory-swappiness", -1, "Tune container memory swappiness (0 to 100) (default -1)")
 	cmd.Flags().String("kernel-memory", "", "Kernel memory limit (deprecated)")
 	cmd.Flags().Bool("oom-kill-disable", false, "Disable OOM Killer")
+	cmd.Flags().Bool("oom-group", false, "Kill all the processes of the container together on OOM (memory.oom.group=1, cgroup v2 only)")
 	cmd.Flags().Int("oom-score-adj", 0, "Tune container’s OOM preferences (-1000 to 1000, rootless: 100 to 1000)")
 	cmd.Flags().String("pid", "", "PID namespace to use")
 	cmd.Flags().String("uts", "", "UTS namespace to use")
@@ -212,6 +213,7 @@ func setCreateFlags(cmd *cobra.Command) {
 
 	// #region runtime flags
 	cmd.Flags().String("runtime", defaults.Runtime, "Runtime to use for this container, e.g. \"crun\", or \"io.containerd.runsc.v1\"")
+	cmd.Flags().StringArray("runtime-opt", nil, "Runtime-specific options (key=value), e.g. \"platform=ptrace\" for \"io.containerd.runsc.v1\"")
 	// sysctl needs to be StringArray, not StringSlice, to prevent "foo=foo1,foo2" from being split to {"foo=foo1", "foo2"}
 	cmd.Flags().StringArray("sysctl", nil, "Sysctl options")
 	// gpus needs to be StringArray, not StringSlice, to prevent "capabilities=utility,device=DEV" from being split to {"capabilities=utility", "device=DEV"}
@@ -229,6 +231,7 @@ func setCreateFlags(cmd *cobra.Command) {
 	cmd.Flags().StringArray("mount", nil, "Attach a filesystem mount to the container")
 	// volumes-from needs to be StringArray, not StringSlice, to prevent "id1,id2" from being split to {"id1", "id2"} (compatible with Docker)
 	cmd.Flags().StringArray("volumes-from", nil, "Mount volumes from the specified container(s)")
+	cmd.Flags().String("volume-driver", "", "Optional volume driver for the container (only \"local\" is supported)")
 	// #endregion
 
 	// rootfs flags
@@ -242,6 +245,7 @@ func setCreateFlags(cmd *cobra.Command) {
 	cmd.Flags().Duration("health-timeout", 0, "Maximum time to allow one check to run (default: 30s)")
 	cmd.Flags().Int("health-retries", 0, "Consecutive failures needed to report unhealthy (default: 3)")
 	cmd.Flags().Duration("health-start-period", 0, "Start period for the container to initialize before starting health-retries countdown")
+	cmd.Flags().Duration("health-start-interval", 0, "Time between running the check during the start period (default: the health interval)")
 	cmd.Flags().Bool("no-healthcheck", false, "Disable any container-specified HEALTHCHECK")
 
 	// #region env flags
@@ -298,7 +302,7 @@ func setCreateFlags(cmd *cobra.Command) {
 	// #endregion
 
 	cmd.Flags().String("ipfs-address", "", "multiaddr of IPFS API (default uses $IPFS_PATH env variable if defined or local directory ~/.ipfs)")
-	cmd.Flags().String("isolation", "default", "Specify isolation technology for container. On Linux the only valid value is default. Windows options are host, process and hyperv with process isolation as the default")
+	cmd.Flags().String("isolation", "default", "Specify isolation technology for container. Windows options are host, process and hyperv with process isolation as the default. On Linux, these values are accepted and ignored")
 	cmd.RegisterFlagCompletionFunc("isolation", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if runtime.GOOS == "windows" {
 			return []string{"default", "host", "process", "hyperv"}, cobra.ShellCompDirectiveNoFileComp
@@ -431,6 +435,9 @@ func runAction(cmd *cobra.Command, args []string) error {
 	}
 	logURI := lab[labels.LogURI]
 	detachC := make(chan struct{})
+	if err := containerutil.MountPluginVolumes(ctx, c, lab); err != nil {
+		return err
+	}
 	task, err := taskutil.NewTask(ctx, client, c, taskutil.TaskOptions{
 		AttachStreamOpt: createOpt.Attach,
 		IsInteractive:   createOpt.Interactive,
@@ -444,6 +451,7 @@ func runAction(cmd *cobra.Command, args []string) error {
 		CheckpointDir:   "",
 	})
 	if err != nil {
+		containerutil.UnmountPluginVolumes(ctx, c)
 		return err
 	}
 
@@ -453,6 +461,7 @@ func runAction(cmd *cobra.Command, args []string) error {
 	}
 
 	if err := task.Start(ctx); err != nil {
+		containerutil.UnmountPluginVolumes(ctx, c)
 		return err
 	}
 
@@ -478,6 +487,15 @@ func runAction(cmd *cobra.Command, args []string) error {
 			defer signalutil.StopCatch(sigC)
 		}
 	}
+	if createOpt.Rm && (createOpt.TTY || !createOpt.SigProxy) {
+		// Termination signals are not forwarded to the container in this case.
+		// Stop the container (honoring its stop signal and --stop-timeout) instead of exiting right away,
+		// so that it is removed once its task has exited.
+		sigC := signalutil.StopOnTerminationSignals(ctx, task, func(ctx context.Context) error {
+			return containerutil.Stop(ctx, c, nil, "")
+		})
+		defer signalutil.StopCatch(sigC)
+	}
 
 	select {
 	// io.Wait() would return when either 1) the user detaches from the container OR 2) the container is about to exit.
@@ -19,10 +19,10 @@ package container
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strconv"
 	"strings"
 	"testing"
@@ -226,6 +226,54 @@ func TestIssue3781(t *testing.T) {
 	assert.Equal(t, spec.Linux.Resources.Pids == nil, true)
 }
 
+func TestRunPidsLimit(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.Require = nerdtest.Info(
+		func(info dockercompat.Info) error {
+			if info.CgroupDriver == "none" || info.CgroupDriver == "" {
+				return fmt.Errorf("test requires cgroup driver")
+			}
+			if !info.PidsLimit {
+				return fmt.Errorf("test requires PidsLimit")
+			}
+			return nil
+		},
+	)
+
+	pidsMax := "/sys/fs/cgroup/pids.max"
+	if cgroups.Mode() != cgroups.Unified {
+		pidsMax = "/sys/fs/cgroup/pids/pids.max"
+	}
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "limit",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("run", "--rm", "--pids-limit", "512", testutil.AlpineImage, "cat", pidsMax)
+			},
+			Expected: test.Expects(0, nil, expect.Equals("512\n")),
+		},
+		{
+			Description: "unlimited",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("run", "--rm", "--pids-limit", "-1", testutil.AlpineImage, "cat", pidsMax)
+			},
+			Expected: test.Expects(0, nil, expect.Equals("max\n")),
+		},
+		{
+			Description: "zero is rejected",
+			Require:     require.Not(nerdtest.Docker),
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("run", "--rm", "--pids-limit", "0", testutil.AlpineImage, "true")
+			},
+			Expected: test.Expects(1, []error{errors.New("invalid pids-limit")}, nil),
+		},
+	}
+
+	testCase.Run(t)
+}
+
 func TestRunDevice(t *testing.T) {
 	testCase := nerdtest.Setup()
 
@@ -370,6 +418,22 @@ func TestParseDevice(t *testing.T) {
 			s:   "/dev/sda7:/dev/sda7:rwmx",
 			err: "unexpected rune",
 		},
+		{
+			s:                     "/dev/sda8:/dev/foo8:",
+			expectedDevPath:       "/dev/sda8",
+			expectedContainerPath: "/dev/foo8",
+			expectedMode:          "rwm",
+		},
+		{
+			s:                     "/dev/sda9:/dev/foo9:mr",
+			expectedDevPath:       "/dev/sda9",
+			expectedContainerPath: "/dev/foo9",
+			expectedMode:          "mr",
+		},
+		{
+			s:   "/dev/sda10:/dev/sda10:rwr",
+			err: "duplicated rune",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -405,6 +469,50 @@ func TestRunCgroupConf(t *testing.T) {
 		"cat", "memory.high").AssertOutExactly("33554432\n")
 }
 
+func TestRunOOMGroup(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.Require = require.All(
+		nerdtest.CGroupV2,
+		require.Not(nerdtest.Docker), // Docker lacks --oom-group
+		nerdtest.Info(
+			func(info dockercompat.Info) error {
+				if info.CgroupDriver == "none" || info.CgroupDriver == "" {
+					return fmt.Errorf("test requires cgroup driver")
+				}
+				return nil
+			},
+		),
+	)
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "oom-group sets memory.oom.group to 1",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("run", "--rm", "--oom-group", testutil.AlpineImage, "cat", "/sys/fs/cgroup/memory.oom.group")
+			},
+			Expected: test.Expects(0, nil, expect.Equals("1\n")),
+		},
+		{
+			Description: "oom-kill-disable sets memory.oom.group to 0",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("run", "--rm", "--oom-kill-disable", "-m", "64m", testutil.AlpineImage,
+					"sh", "-ec", "cat /sys/fs/cgroup/memory.oom.group /sys/fs/cgroup/memory.max")
+			},
+			Expected: test.Expects(0, nil, expect.Equals("0\n67108864\n")),
+		},
+		{
+			Description: "oom-kill-disable requires a memory limit",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("run", "--rm", "--oom-kill-disable", testutil.AlpineImage, "true")
+			},
+			Expected: test.Expects(1, []error{errors.New("requires a memory limit")}, nil),
+		},
+	}
+
+	testCase.Run(t)
+}
+
 func TestRunCgroupParent(t *testing.T) {
 	t.Parallel()
 	base := testutil.NewBase(t)
@@ -752,9 +860,33 @@ func TestRunCPUSharesCgroupV2(t *testing.T) {
 			return helpers.Command("run", "--rm", "--cpu-shares", "2000",
 				testutil.AlpineImage, "cat", "/sys/fs/cgroup/cpu.weight")
 		},
-		// The value was historically 77, but with runc v1.4.0-rc.1 it became 170.
-		// https://github.com/opencontainers/runc/issues/4896#issuecomment-3301825811
-		Expected: test.Expects(0, nil, expect.Match(regexp.MustCompile("^(77|170)\n$"))),
+		Expected: test.Expects(0, nil, expect.Equals("77\n")),
+	}
+
+	testCase.Run(t)
+}
+
+func TestRunCPUsAndCPUSharesCgroupV2(t *testing.T) {
+	nerdtest.Setup()
+
+	testCase := &test.Case{
+		Require: require.All(
+			nerdtest.CGroupV2,
+			nerdtest.Info(
+				func(info dockercompat.Info) error {
+					if !info.CPUShares {
+						return fmt.Errorf("test requires CPUShares")
+					}
+					return nil
+				},
+			),
+		),
+		Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+			return helpers.Command("run", "--rm", "--cpus", "0.42", "--cpu-shares", "1024",
+				testutil.AlpineImage, "sh", "-ec", "cat /sys/fs/cgroup/cpu.max /sys/fs/cgroup/cpu.weight")
+		},
+		// --cpus sets cpu.max while --cpu-shares sets cpu.weight; neither overrides the other.
+		Expected: test.Expects(0, nil, expect.Equals("42000 100000\n39\n")),
 	}
 
 	testCase.Run(t)
@@ -28,6 +28,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -446,6 +447,134 @@ func TestRunSigProxy(t *testing.T) {
 	testCase.Run(t)
 }
 
+func TestRunRmStopTimeout(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	const stopTimeout = 3 * time.Second
+
+	// The container traps SIGTERM without exiting, so that it is only stopped by the SIGKILL sent after the stop timeout.
+	runSlowContainer := func(data test.Data, helpers test.Helpers, args ...string) test.TestableCommand {
+		args = append([]string{"--rm", fmt.Sprintf("--stop-timeout=%d", int(stopTimeout.Seconds()))}, args...)
+		cmd := nerdtest.RunSigProxyContainer(syscall.SIGTERM, false, args, data, helpers)
+		data.Labels().Set("start", strconv.FormatInt(time.Now().UnixNano(), 10))
+		return cmd
+	}
+
+	expectRemovedAfterTimeout := func(data test.Data, helpers test.Helpers, t tig.T) {
+		start, err := strconv.ParseInt(data.Labels().Get("start"), 10, 64)
+		assert.NilError(t, err)
+		assert.Assert(t, time.Since(time.Unix(0, start)) >= stopTimeout, "the container must be given the full stop timeout")
+		// The removal is done by the `run --rm` process once the task has exited
+		for i := 0; i < 20; i++ {
+			if strings.TrimSpace(helpers.Capture("ps", "-a", "-q", "--filter", "name="+data.Identifier())) == "" {
+				return
+			}
+			time.Sleep(500 * time.Millisecond)
+		}
+		t.Log("the container was not removed after the stop timeout")
+		t.FailNow()
+	}
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "nerdctl stop",
+			Require:     require.Not(nerdtest.Docker),
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Setup: func(data test.Data, helpers test.Helpers) {
+				runSlowContainer(data, helpers)
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("stop", data.Identifier())
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: func(stdout string, t tig.T) {
+						expectRemovedAfterTimeout(data, helpers, t)
+					},
+				}
+			},
+		},
+		{
+			Description: "signal without sig-proxy",
+			// FIXME: gomodjail signal handling is not working yet: https://github.com/AkihiroSuda/gomodjail/issues/51
+			Require: require.All(require.Not(nerdtest.Docker), require.Not(nerdtest.Gomodjail)),
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				cmd := runSlowContainer(data, helpers, "--sig-proxy=false")
+				err := cmd.Signal(os.Interrupt)
+				assert.NilError(helpers.T(), err)
+				return cmd
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: expect.ExitCodeGenericFail,
+					Output: func(stdout string, t tig.T) {
+						assert.Assert(t, strings.Contains(stdout, nerdtest.SignalCaught), "the stop signal should be sent before SIGKILL")
+						expectRemovedAfterTimeout(data, helpers, t)
+					},
+				}
+			},
+		},
+	}
+
+	testCase.Run(t)
+}
+
+func TestRunRmCleanup(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "setup error does not leak the container",
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("run", "--rm", "--name", data.Identifier(), testutil.CommonImage, "/nonexistent")
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: expect.ExitCodeGenericFail,
+					Output: func(stdout string, t tig.T) {
+						helpers.Fail("container", "inspect", data.Identifier())
+						// The name must have been released as well
+						helpers.Ensure("create", "--name", data.Identifier(), testutil.CommonImage)
+					},
+				}
+			},
+		},
+		{
+			Description: "signal without sig-proxy does not leak the container",
+			// FIXME: gomodjail signal handling is not working yet: https://github.com/AkihiroSuda/gomodjail/issues/51
+			Require: require.All(require.Not(nerdtest.Docker), require.Not(nerdtest.Gomodjail)),
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				cmd := nerdtest.RunSigProxyContainer(os.Interrupt, false, []string{"--rm", "--sig-proxy=false"}, data, helpers)
+				err := cmd.Signal(os.Interrupt)
+				assert.NilError(helpers.T(), err)
+				return cmd
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: expect.ExitCodeGenericFail,
+					Output: func(stdout string, t tig.T) {
+						assert.Assert(t, !strings.Contains(stdout, nerdtest.SignalCaught), "the signal should not be forwarded")
+						helpers.Fail("container", "inspect", data.Identifier())
+					},
+				}
+			},
+		},
+	}
+
+	testCase.Run(t)
+}
+
 func TestRunWithFluentdLogDriver(t *testing.T) {
 	base := testutil.NewBase(t)
 	tempDirectory := t.TempDir()
@@ -723,3 +852,56 @@ devices:
 	err = os.WriteFile(cdiSpecPath, []byte(testCDIVendor1), 0400)
 	assert.NilError(t, err)
 }
+
+func TestRunSnapshotter(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.Require = require.Not(nerdtest.Docker)
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "the container uses the specified snapshotter",
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("run", "-d", "--name", data.Identifier(), "--snapshotter", "native", testutil.CommonImage, "sleep", nerdtest.Infinity)
+				nerdtest.EnsureContainerStarted(helpers, data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("inspect", "--format", "{{.Driver}}", data.Identifier())
+			},
+			Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Equals("native\n")),
+		},
+		{
+			Description: "the rootfs of a stopped container is read from its snapshotter",
+			Require:     require.Not(nerdtest.Rootless),
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("create", "--name", data.Identifier(), "--snapshotter", "native", testutil.CommonImage)
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("cp", data.Identifier()+":/etc/os-release", data.Temp().Path("os-release"))
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: func(stdout string, t tig.T) {
+						_, err := os.Stat(data.Temp().Path("os-release"))
+						assert.NilError(t, err)
+					},
+				}
+			},
+		},
+		{
+			Description: "an unavailable snapshotter is an error",
+			Command:     test.Command("run", "--rm", "--snapshotter", "nonexistent", testutil.CommonImage),
+			Expected: test.Expects(expect.ExitCodeGenericFail, []error{
+				errors.New(`snapshotter "nonexistent" is not available`),
+			}, nil),
+		},
+	}
+
+	testCase.Run(t)
+}
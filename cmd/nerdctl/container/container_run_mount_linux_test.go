@@ -17,6 +17,7 @@
 package container
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -28,10 +29,12 @@ import (
 
 	"github.com/containerd/containerd/v2/core/mount"
 	"github.com/containerd/nerdctl/mod/tigron/expect"
+	"github.com/containerd/nerdctl/mod/tigron/require"
 	"github.com/containerd/nerdctl/mod/tigron/test"
 	"github.com/containerd/nerdctl/mod/tigron/tig"
 
 	"github.com/containerd/nerdctl/v2/cmd/nerdctl/helpers"
+	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/dockercompat"
 	"github.com/containerd/nerdctl/v2/pkg/rootlessutil"
 	"github.com/containerd/nerdctl/v2/pkg/testutil"
 	"github.com/containerd/nerdctl/v2/pkg/testutil/nerdtest"
@@ -284,6 +287,8 @@ func TestRunTmpfs(t *testing.T) {
 	base.Cmd("run", "--rm", "--tmpfs", "/tmp:size=64m,exec", testutil.AlpineImage, "grep", "/tmp", "/proc/mounts").AssertOutWithFunc(f([]string{"rw", "nosuid", "nodev", "size=65536k"}, []string{"noexec"}))
 	// for https://github.com/containerd/nerdctl/issues/594
 	base.Cmd("run", "--rm", "--tmpfs", "/dev/shm:rw,exec,size=1g", testutil.AlpineImage, "grep", "/dev/shm", "/proc/mounts").AssertOutWithFunc(f([]string{"rw", "nosuid", "nodev", "size=1048576k"}, []string{"noexec"}))
+	base.Cmd("run", "--rm", "--tmpfs", "/run:rw,size=64m,mode=1777", "--tmpfs", "/tmp", testutil.AlpineImage, "grep", "/run", "/proc/mounts").AssertOutWithFunc(f([]string{"rw", "nosuid", "nodev", "noexec", "size=65536k", "mode=1777"}, nil))
+	base.Cmd("run", "--rm", "--tmpfs", "/tmp:mode=999", testutil.AlpineImage, "true").AssertFail()
 }
 
 func TestRunBindMountTmpfs(t *testing.T) {
@@ -408,6 +413,125 @@ func TestRunBindMountBind(t *testing.T) {
 	testCase.Run(t)
 }
 
+func TestRunBindMountNonexistentTarget(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "file source is mounted onto a nonexistent path",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				data.Labels().Set("src", data.Temp().Save("file-content", "file"))
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("run", "--rm",
+					"--mount", fmt.Sprintf("type=bind,src=%s,target=/nonexistent/dir/file", data.Labels().Get("src")),
+					testutil.AlpineImage, "sh", "-ec", "test -f /nonexistent/dir/file; cat /nonexistent/dir/file")
+			},
+			Expected: test.Expects(0, nil, expect.Equals("file-content")),
+		},
+		{
+			Description: "directory source is mounted onto a nonexistent path",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				data.Temp().Save("dir-content", "dir", "file")
+				data.Labels().Set("src", data.Temp().Path("dir"))
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("run", "--rm",
+					"--mount", fmt.Sprintf("type=bind,src=%s,target=/nonexistent/dir", data.Labels().Get("src")),
+					testutil.AlpineImage, "sh", "-ec", "test -d /nonexistent/dir; cat /nonexistent/dir/file")
+			},
+			Expected: test.Expects(0, nil, expect.Equals("dir-content")),
+		},
+		{
+			Description: "file source cannot be mounted onto an existing directory",
+			Require:     require.Not(nerdtest.Docker),
+			Setup: func(data test.Data, helpers test.Helpers) {
+				data.Labels().Set("src", data.Temp().Save("file-content", "file"))
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("run", "--rm",
+					"--mount", fmt.Sprintf("type=bind,src=%s,target=/etc", data.Labels().Get("src")),
+					testutil.AlpineImage, "true")
+			},
+			Expected: test.Expects(1, []error{errors.New("cannot mount file")}, nil),
+		},
+	}
+
+	testCase.Run(t)
+}
+
+func TestRunMountVolumeLabels(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.Cleanup = func(data test.Data, helpers test.Helpers) {
+		helpers.Anyhow("volume", "rm", "-f", data.Identifier())
+	}
+
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		helpers.Ensure("run", "--rm",
+			"--mount", fmt.Sprintf("type=volume,src=%s,dst=/mnt,volume-label=foo=bar,volume-label=baz=qux", data.Identifier()),
+			testutil.AlpineImage, "touch", "/mnt/file")
+		// The volume now exists, and is reused with its original labels
+		helpers.Ensure("run", "--rm",
+			"--mount", fmt.Sprintf("type=volume,src=%s,dst=/mnt,volume-label=foo=other", data.Identifier()),
+			testutil.AlpineImage, "test", "-f", "/mnt/file")
+	}
+
+	testCase.Command = func(data test.Data, helpers test.Helpers) test.TestableCommand {
+		return helpers.Command("volume", "inspect", "--format", "{{.Labels.foo}} {{.Labels.baz}}", data.Identifier())
+	}
+
+	testCase.Expected = test.Expects(0, nil, expect.Equals("bar qux\n"))
+
+	testCase.Run(t)
+}
+
+func TestRunVolumeDriver(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "local driver is set on the named and anonymous volumes",
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", "-v", data.Identifier())
+				helpers.Anyhow("volume", "rm", "-f", data.Identifier())
+			},
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("create", "--name", data.Identifier(), "--volume-driver", "local",
+					"-v", data.Identifier()+":/mnt1", "-v", "/mnt2", testutil.AlpineImage)
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("container", "inspect", "--format", "{{range .Mounts}}{{.Driver}} {{end}}", data.Identifier())
+			},
+			Expected: test.Expects(0, nil, expect.Equals("local local \n")),
+		},
+		{
+			Description: "unknown driver",
+			Require:     require.Not(nerdtest.Docker),
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+				helpers.Anyhow("volume", "rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("create", "--name", data.Identifier(), "--volume-driver", "unknown-driver",
+					"-v", data.Identifier()+":/mnt", testutil.AlpineImage)
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: expect.ExitCodeGenericFail,
+					Errors:   []error{errors.New(`unknown volume driver "unknown-driver"`)},
+					Output: func(stdout string, t tig.T) {
+						// The named volume must not have been created
+						helpers.Fail("volume", "inspect", data.Identifier())
+					},
+				}
+			},
+		},
+	}
+
+	testCase.Run(t)
+}
+
 func TestRunMountBindMode(t *testing.T) {
 	if rootlessutil.IsRootless() {
 		t.Skip("must be superuser to use mount")
@@ -736,6 +860,79 @@ func TestRunVolumesFrom(t *testing.T) {
 	).AssertOutExactly("str1str3")
 }
 
+func TestRunVolumesFromMultipleSources(t *testing.T) {
+	t.Parallel()
+	base := testutil.NewBase(t)
+	tID := testutil.Identifier(t)
+	dataVolName := tID + "-data"
+	cacheVolName := tID + "-cache"
+	for _, v := range []string{dataVolName, cacheVolName} {
+		defer base.Cmd("volume", "rm", "-f", v).Run()
+		base.Cmd("volume", "create", v).AssertOK()
+	}
+
+	dataContainerName := tID + "-data"
+	cacheContainerName := tID + "-cache"
+	defer base.Cmd("rm", "-f", dataContainerName).AssertOK()
+	defer base.Cmd("rm", "-f", cacheContainerName).AssertOK()
+	base.Cmd("run", "-d", "--name", dataContainerName,
+		"-v", fmt.Sprintf("%s:/data", dataVolName),
+		testutil.AlpineImage, "sh", "-exc", "echo -n data > /data/file; sleep infinity").AssertOK()
+	base.Cmd("run", "-d", "--name", cacheContainerName,
+		"-v", fmt.Sprintf("%s:/cache", cacheVolName),
+		testutil.AlpineImage, "sleep", "infinity").AssertOK()
+
+	// The mounts of both containers are imported, and the mounts of the data container are read-only
+	base.Cmd("run", "--rm",
+		"--volumes-from", dataContainerName+":ro",
+		"--volumes-from", cacheContainerName,
+		testutil.AlpineImage, "sh", "-ec", "cat /data/file; echo -n cache > /cache/file; ! touch /data/file2 2>/dev/null",
+	).AssertOutExactly("data")
+	base.Cmd("run", "--rm",
+		"-v", fmt.Sprintf("%s:/cache", cacheVolName),
+		testutil.AlpineImage, "cat", "/cache/file",
+	).AssertOutExactly("cache")
+
+	containerName := tID + "-to"
+	defer base.Cmd("rm", "-f", containerName).Run()
+	base.Cmd("create", "--name", containerName,
+		"--volumes-from", dataContainerName+":ro",
+		"--volumes-from", cacheContainerName,
+		testutil.AlpineImage).AssertOK()
+	inspect := base.InspectContainer(containerName)
+	mounts := make(map[string]dockercompat.MountPoint)
+	for _, m := range inspect.Mounts {
+		mounts[m.Destination] = m
+	}
+	assert.Equal(t, mounts["/data"].Name, dataVolName)
+	assert.Equal(t, mounts["/data"].RW, false)
+	assert.Equal(t, mounts["/cache"].Name, cacheVolName)
+	assert.Equal(t, mounts["/cache"].RW, true)
+
+	base.Cmd("run", "--rm", "--volumes-from", tID+"-nonexistent", testutil.AlpineImage).AssertFail()
+}
+
+func TestRunVolumesFromAnonymousVolume(t *testing.T) {
+	t.Parallel()
+	base := testutil.NewBase(t)
+	tID := testutil.Identifier(t)
+
+	fromContainerName := tID + "-from"
+	defer base.Cmd("rm", "-f", "-v", fromContainerName).Run()
+	base.Cmd("run", "-d", "--name", fromContainerName, "-v", "/anon", testutil.AlpineImage, "sleep", "infinity").AssertOK()
+	base.Cmd("exec", fromContainerName, "sh", "-exc", "echo -n anon > /anon/file").AssertOK()
+	inspect := base.InspectContainer(fromContainerName)
+	assert.Equal(t, len(inspect.Mounts), 1)
+	anonVolName := inspect.Mounts[0].Name
+
+	// The anonymous volume of the source is not removed with the containers importing it
+	for range 2 {
+		base.Cmd("run", "--rm", "--volumes-from", fromContainerName,
+			testutil.AlpineImage, "cat", "/anon/file").AssertOutExactly("anon")
+	}
+	base.Cmd("volume", "inspect", anonVolName).AssertOK()
+}
+
 func TestBindMountWhenHostFolderDoesNotExist(t *testing.T) {
 	t.Parallel()
 	base := testutil.NewBase(t)
@@ -20,13 +20,16 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/containerd/errdefs"
 	"github.com/containerd/go-cni"
 
 	"github.com/containerd/nerdctl/v2/pkg/api/types"
 	"github.com/containerd/nerdctl/v2/pkg/dnsutil"
+	"github.com/containerd/nerdctl/v2/pkg/netutil"
 	"github.com/containerd/nerdctl/v2/pkg/portutil"
 	"github.com/containerd/nerdctl/v2/pkg/strutil"
 )
@@ -61,6 +64,11 @@ func loadNetworkFlags(cmd *cobra.Command, globalOpts types.GlobalCommandOptions)
 		}
 		netSlice = append(netSlice, network...)
 	}
+	// --network=name=<NETWORK>,mac-address=<MAC>
+	netSlice, networkMACs, err := netutil.ParseNetworkFlagValues(netSlice)
+	if err != nil {
+		return netOpts, err
+	}
 	netOpts.NetworkSlice = strutil.DedupeStrSlice(netSlice)
 
 	// --mac-address=<MAC>
@@ -73,6 +81,16 @@ func loadNetworkFlags(cmd *cobra.Command, globalOpts types.GlobalCommandOptions)
 			return netOpts, err
 		}
 	}
+	// The MAC address is passed to the CNI plugins of all the networks, so it cannot be set for one network among others
+	if len(networkMACs) > 0 && len(netOpts.NetworkSlice) > 1 {
+		return netOpts, fmt.Errorf("mac-address cannot be set in the network syntax when connecting to multiple networks, got %d networks (%w)", len(netOpts.NetworkSlice), errdefs.ErrInvalidArgument)
+	}
+	for network, mac := range networkMACs {
+		if macAddress != "" && !strings.EqualFold(macAddress, mac) {
+			return netOpts, fmt.Errorf("conflicting MAC addresses %q (--mac-address) and %q (network %q) (%w)", macAddress, mac, network, errdefs.ErrInvalidArgument)
+		}
+		macAddress = mac
+	}
 	netOpts.MACAddress = macAddress
 
 	// --ip=<container static IP>
@@ -89,6 +107,18 @@ func loadNetworkFlags(cmd *cobra.Command, globalOpts types.GlobalCommandOptions)
 	}
 	netOpts.IP6Address = ip6Address
 
+	// --link-local-ip=<container link-local IP>
+	linkLocalIPs, err := cmd.Flags().GetStringSlice("link-local-ip")
+	if err != nil {
+		return netOpts, err
+	}
+	for _, ip := range linkLocalIPs {
+		if err := netutil.ValidateLinkLocalIP(ip); err != nil {
+			return netOpts, err
+		}
+	}
+	netOpts.LinkLocalIPs = strutil.DedupeStrSlice(linkLocalIPs)
+
 	// -h/--hostname=<container hostname>
 	hostName, err := cmd.Flags().GetString("hostname")
 	if err != nil {
@@ -103,6 +133,13 @@ func loadNetworkFlags(cmd *cobra.Command, globalOpts types.GlobalCommandOptions)
 	}
 	netOpts.Domainname = domainname
 
+	// --network-alias=<alias> ...
+	networkAliases, err := cmd.Flags().GetStringSlice("network-alias")
+	if err != nil {
+		return netOpts, err
+	}
+	netOpts.NetworkAliases = strutil.DedupeStrSlice(networkAliases)
+
 	// --dns=<DNS host> ...
 	// Use command flags if set, otherwise use global config is set
 	var dnsSlice []string
@@ -122,6 +159,11 @@ func loadNetworkFlags(cmd *cobra.Command, globalOpts types.GlobalCommandOptions)
 		}
 	} else {
 		dnsSlice = globalOpts.DNS
+		for _, dns := range dnsSlice {
+			if _, err := dnsutil.ValidateIPAddress(dns); err != nil {
+				return netOpts, fmt.Errorf("%w in the \"dns\" property of nerdctl.toml", err)
+			}
+		}
 	}
 	netOpts.DNSServers = strutil.DedupeStrSlice(dnsSlice)
 
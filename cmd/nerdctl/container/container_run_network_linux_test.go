@@ -17,6 +17,7 @@
 package container
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -800,6 +801,59 @@ func TestRunContainerWithMACAddress(t *testing.T) {
 	}
 }
 
+func TestRunContainerWithMACAddressNetworkSyntax(t *testing.T) {
+	testCase := nerdtest.Setup()
+	testCase.Require = require.Not(nerdtest.Docker)
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "MAC address set with the extended network syntax",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				macAddress, err := nettestutil.GenerateMACAddress()
+				assert.NilError(helpers.T(), err)
+				data.Labels().Set("mac", macAddress)
+				helpers.Ensure("run", "-d", "--name", data.Identifier(), "--network", "name=bridge,mac-address="+macAddress,
+					testutil.CommonImage, "sleep", "inf")
+				nerdtest.EnsureContainerStarted(helpers, data.Identifier())
+			},
+			Cleanup: func(data test.Data, helpers test.Helpers) {
+				helpers.Anyhow("rm", "-f", data.Identifier())
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("exec", data.Identifier(), "cat", "/sys/class/net/eth0/address")
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: func(stdout string, t tig.T) {
+						assert.Equal(t, strings.TrimSpace(stdout), data.Labels().Get("mac"))
+						inspected := helpers.Capture("inspect", "--format", "{{.NetworkSettings.MacAddress}}", data.Identifier())
+						assert.Equal(t, strings.TrimSpace(inspected), data.Labels().Get("mac"))
+					},
+				}
+			},
+		},
+		{
+			Description: "conflicting MAC addresses",
+			Command: test.Command("run", "--rm", "--network", "name=bridge,mac-address=02:42:ac:11:00:02",
+				"--mac-address", "02:42:ac:11:00:03", testutil.CommonImage, "true"),
+			Expected: test.Expects(expect.ExitCodeGenericFail, []error{errors.New("conflicting MAC addresses")}, nil),
+		},
+		{
+			Description: "MAC address with multiple networks",
+			Command: test.Command("run", "--rm", "--network", "name=bridge,mac-address=02:42:ac:11:00:02",
+				"--network", "none", testutil.CommonImage, "true"),
+			Expected: test.Expects(expect.ExitCodeGenericFail, []error{errors.New("multiple networks")}, nil),
+		},
+		{
+			Description: "unsupported network option",
+			Command:     test.Command("run", "--rm", "--network", "name=bridge,foo=bar", testutil.CommonImage, "true"),
+			Expected:    test.Expects(expect.ExitCodeGenericFail, []error{errors.New("unsupported network option")}, nil),
+		},
+	}
+
+	testCase.Run(t)
+}
+
 func TestHostsFileMounts(t *testing.T) {
 	if rootlessutil.IsRootless() {
 		if detachedNetNS, _ := rootlessutil.DetachedNetNS(); detachedNetNS != "" {
@@ -907,6 +961,34 @@ func TestRunContainerWithStaticIP6(t *testing.T) {
 	}
 }
 
+func TestRunContainerWithLinkLocalIP(t *testing.T) {
+	testCase := nerdtest.Setup()
+	testCase.Require = require.Not(nerdtest.Docker)
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "link-local addresses are added to the container interface",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("run", "--rm", "--link-local-ip", "169.254.10.1", "--link-local-ip", "169.254.10.2",
+					testutil.CommonImage, "ip", "-o", "addr", "show", "eth0")
+			},
+			Expected: test.Expects(0, nil, expect.Contains("inet 169.254.10.1/16", "inet 169.254.10.2/16")),
+		},
+		{
+			Description: "non link-local address",
+			Command:     test.Command("run", "--rm", "--link-local-ip", "10.0.0.1", testutil.CommonImage, "true"),
+			Expected:    test.Expects(expect.ExitCodeGenericFail, []error{errors.New("is not a link-local IP address")}, nil),
+		},
+		{
+			Description: "invalid address",
+			Command:     test.Command("run", "--rm", "--link-local-ip", "foo", testutil.CommonImage, "true"),
+			Expected:    test.Expects(expect.ExitCodeGenericFail, []error{errors.New("invalid link-local IP address")}, nil),
+		},
+	}
+
+	testCase.Run(t)
+}
+
 func TestNoneNetworkHostName(t *testing.T) {
 	nerdtest.Setup()
 	testCase := &test.Case{
@@ -1044,6 +1126,19 @@ dns_search = ["example.com", "test.local"]`
 					expect.Contains("options ndots:3"),
 				)),
 			},
+			{
+				Description: "Overriding only --dns keeps the global search domains and options",
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("run", "--rm", "--dns", "9.9.9.9",
+						testutil.CommonImage, "cat", "/etc/resolv.conf")
+				},
+				Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.All(
+					expect.Contains("nameserver 9.9.9.9"),
+					expect.DoesNotContain("nameserver 10.10.10.10", "nameserver 20.20.20.20"),
+					expect.Contains("search example.com test.local"),
+					expect.Contains("options ndots:2 timeout:5"),
+				)),
+			},
 			{
 				Description: "Global DNS settings should also apply when using host network",
 				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
@@ -1081,6 +1176,20 @@ dns_search = ["example.com", "test.local"]`
 	testCase.Run(t)
 }
 
+func TestDNSWithInvalidGlobalConfig(t *testing.T) {
+	var configContent test.ConfigValue = `dns = ["not-an-ip"]`
+
+	testCase := nerdtest.Setup()
+
+	testCase.Config = test.WithConfig(nerdtest.NerdctlToml, configContent)
+	// NERDCTL_TOML not supported in Docker
+	testCase.Require = require.Not(nerdtest.Docker)
+	testCase.Command = test.Command("run", "--rm", testutil.CommonImage, "true")
+	testCase.Expected = test.Expects(expect.ExitCodeGenericFail, []error{errors.New("nerdctl.toml")}, nil)
+
+	testCase.Run(t)
+}
+
 // TestReservePorts tests that a published port appears
 // as a listening port on the host.
 // See https://github.com/containerd/nerdctl/pull/4526
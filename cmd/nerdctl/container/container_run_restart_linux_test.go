@@ -136,6 +136,32 @@ func TestRunRestartWithUnlessStopped(t *testing.T) {
 	assert.Equal(t, inspect.RestartCount, 2)
 }
 
+func TestRunRestartCountKeptOnStopAndRestart(t *testing.T) {
+	base := testutil.NewBase(t)
+	if !nerdtest.IsDocker() {
+		testutil.RequireContainerdPlugin(base, "io.containerd.internal.v1", "restart", []string{"always"})
+	}
+	tID := testutil.Identifier(t)
+	defer base.Cmd("rm", "-f", tID).Run()
+	base.Cmd("run", "-d", "--restart=always", "--name", tID, testutil.AlpineImage, "sh", "-c", "sleep 1; exit 1").AssertOK()
+
+	check := func(log poll.LogT) poll.Result {
+		if inspect := base.InspectContainer(tID); inspect.RestartCount >= 2 {
+			return poll.Success()
+		}
+		return poll.Continue("container is not yet restarted twice")
+	}
+	poll.WaitOn(t, check, poll.WithDelay(100*time.Millisecond), poll.WithTimeout(60*time.Second))
+
+	base.Cmd("stop", tID).AssertOK()
+	count := base.InspectContainer(tID).RestartCount
+	assert.Assert(t, count >= 2)
+
+	// manual restarts are not counted, and do not reset the count
+	base.Cmd("restart", tID).AssertOK()
+	assert.Assert(t, base.InspectContainer(tID).RestartCount >= count)
+}
+
 func TestUpdateRestartPolicy(t *testing.T) {
 	base := testutil.NewBase(t)
 	if !nerdtest.IsDocker() {
@@ -21,6 +21,8 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -108,6 +110,27 @@ CMD ["echo", "bar"]
 	testCase.Run(t)
 }
 
+func TestRunEntrypointJSONArray(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.Require = require.Not(require.Windows)
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "JSON array entrypoint is exec form with multiple args",
+			Command:     test.Command("run", "--rm", "--entrypoint", `["/bin/sh","-c"]`, testutil.CommonImage, "echo foo bar"),
+			Expected:    test.Expects(expect.ExitCodeSuccess, nil, expect.Equals("foo bar\n")),
+		},
+		{
+			Description: "Plain string entrypoint is a single arg",
+			Command:     test.Command("run", "--rm", "--entrypoint", "echo", testutil.CommonImage, "foo", "bar"),
+			Expected:    test.Expects(expect.ExitCodeSuccess, nil, expect.Equals("foo bar\n")),
+		},
+	}
+
+	testCase.Run(t)
+}
+
 func TestRunWorkdir(t *testing.T) {
 	testCase := nerdtest.Setup()
 
@@ -123,6 +146,44 @@ func TestRunWorkdir(t *testing.T) {
 	testCase.Run(t)
 }
 
+func TestRunRelativeWorkdir(t *testing.T) {
+	nerdtest.Setup()
+
+	dockerfile := fmt.Sprintf(`FROM %s
+WORKDIR /app
+	`, testutil.CommonImage)
+
+	testCase := &test.Case{
+		Require: require.All(require.Not(require.Windows), nerdtest.Build),
+		Setup: func(data test.Data, helpers test.Helpers) {
+			data.Temp().Save(dockerfile, "Dockerfile")
+			data.Labels().Set("image", data.Identifier())
+			helpers.Ensure("build", "-t", data.Labels().Get("image"), data.Temp().Path())
+		},
+		Cleanup: func(data test.Data, helpers test.Helpers) {
+			helpers.Anyhow("rmi", "-f", data.Labels().Get("image"))
+		},
+		SubTests: []*test.Case{
+			{
+				Description: "Relative workdir is joined onto the image WORKDIR",
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("run", "--rm", "--workdir", "sub", data.Labels().Get("image"), "pwd")
+				},
+				Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Equals("/app/sub\n")),
+			},
+			{
+				Description: "Absolute workdir replaces the image WORKDIR",
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("run", "--rm", "--workdir", "/sub", data.Labels().Get("image"), "pwd")
+				},
+				Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Equals("/sub\n")),
+			},
+		},
+	}
+
+	testCase.Run(t)
+}
+
 func TestRunWithDoubleDash(t *testing.T) {
 	testCase := nerdtest.Setup()
 
@@ -216,6 +277,56 @@ func TestRunEnvFile(t *testing.T) {
 	testCase.Run(t)
 }
 
+func TestRunEnvFileAndLabelFileURL(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	// Docker does not support remote env and label files
+	testCase.Require = require.Not(nerdtest.Docker)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/svc.env", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("# this is a comment line\nREMOTEKEY=REMOTEVAL\n"))
+	})
+	mux.HandleFunc("/svc.labels", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("remote.label=remote-value\n"))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	testCase.Cleanup = func(data test.Data, helpers test.Helpers) {
+		helpers.Anyhow("rm", "-f", data.Identifier())
+	}
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "env-file URL",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("run", "--rm", "--env-file", srv.URL+"/svc.env", testutil.CommonImage, "env")
+			},
+			Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Contains("REMOTEKEY=REMOTEVAL")),
+		},
+		{
+			Description: "label-file URL",
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("create", "--name", data.Identifier(), "--label-file", srv.URL+"/svc.labels", testutil.CommonImage)
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("inspect", "--format", "{{index .Config.Labels \"remote.label\"}}", data.Identifier())
+			},
+			Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Equals("remote-value\n")),
+		},
+		{
+			Description: "env-file URL not found",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("run", "--rm", "--env-file", srv.URL+"/missing.env", testutil.CommonImage, "env")
+			},
+			Expected: test.Expects(expect.ExitCodeGenericFail, []error{errors.New("404 Not Found")}, nil),
+		},
+	}
+
+	testCase.Run(t)
+}
+
 func TestRunEnv(t *testing.T) {
 	testCase := nerdtest.Setup()
 
@@ -741,24 +852,33 @@ func TestRunQuiet(t *testing.T) {
 		base.Cmd("rmi", "-f", testutil.CommonImage).Run()
 	}
 	defer teardown()
-	teardown()
-
-	sentinel := "test run quiet"
-	result := base.Cmd("run", "--rm", "--quiet", testutil.CommonImage, fmt.Sprintf(`echo "%s"`, sentinel)).Run()
-	assert.Assert(t, strings.Contains(result.Combined(), sentinel))
-
-	wasQuiet := func(output, sentinel string) bool {
-		return !strings.Contains(output, sentinel)
-	}
 
 	// Docker and nerdctl image pulls are not 1:1.
+	progressSentinel := "elapsed"
 	if nerdtest.IsDocker() {
-		sentinel = "Pull complete"
-	} else {
-		sentinel = "resolved"
+		progressSentinel = "Pull complete"
 	}
+	sentinel := "test run quiet"
+
+	// Without --quiet, the implicit pull shows its progress on stderr
+	teardown()
+	result := base.Cmd("run", "--rm", testutil.CommonImage, "echo", sentinel).Run()
+	assert.Equal(t, result.ExitCode, 0, result.Combined())
+	assert.Assert(t, strings.Contains(result.Stderr(), progressSentinel), "Did not find %s in container run stderr", progressSentinel)
+	assert.Equal(t, result.Stdout(), sentinel+"\n")
 
-	assert.Assert(t, wasQuiet(result.Combined(), sentinel), "Found %s in container run output", sentinel)
+	// With --quiet, the progress is suppressed, but the output of the container is still shown
+	teardown()
+	result = base.Cmd("run", "--rm", "--quiet", testutil.CommonImage, "echo", sentinel).Run()
+	assert.Equal(t, result.ExitCode, 0, result.Combined())
+	assert.Assert(t, !strings.Contains(result.Combined(), progressSentinel), "Found %s in container run output", progressSentinel)
+	assert.Equal(t, result.Stdout(), sentinel+"\n")
+
+	// Pull errors are still reported on stderr
+	result = base.Cmd("run", "--rm", "--quiet", testutil.CommonImage+"-nonexistent").Run()
+	assert.Assert(t, result.ExitCode != 0)
+	assert.Assert(t, result.Stderr() != "")
+	assert.Equal(t, result.Stdout(), "")
 }
 
 func TestRunFromOCIArchive(t *testing.T) {
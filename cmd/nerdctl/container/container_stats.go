@@ -30,7 +30,7 @@ import (
 
 func StatsCommand() *cobra.Command {
 	var cmd = &cobra.Command{
-		Use:               "stats",
+		Use:               "stats [flags] [CONTAINER...]",
 		Short:             "Display a live stream of container(s) resource usage statistics.",
 		RunE:              statsAction,
 		ValidArgsFunction: statsShellComplete,
@@ -45,7 +45,7 @@ func StatsCommand() *cobra.Command {
 
 func addStatsFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolP("all", "a", false, "Show all containers (default shows just running)")
-	cmd.Flags().String("format", "", "Pretty-print images using a Go template, e.g, '{{json .}}'")
+	cmd.Flags().String("format", "", "Pretty-print stats using a Go template, e.g, '{{json .}}'")
 	cmd.Flags().Bool("no-stream", false, "Disable streaming stats and only pull the first result")
 	cmd.Flags().Bool("no-trunc", false, "Do not truncate output")
 }
@@ -17,13 +17,19 @@
 package container
 
 import (
+	"errors"
+	"regexp"
 	"runtime"
 	"testing"
 
+	"gotest.tools/v3/assert"
+
 	"github.com/containerd/nerdctl/mod/tigron/expect"
 	"github.com/containerd/nerdctl/mod/tigron/require"
 	"github.com/containerd/nerdctl/mod/tigron/test"
+	"github.com/containerd/nerdctl/mod/tigron/tig"
 
+	"github.com/containerd/nerdctl/v2/pkg/statsutil"
 	"github.com/containerd/nerdctl/v2/pkg/testutil"
 	"github.com/containerd/nerdctl/v2/pkg/testutil/nerdtest"
 )
@@ -54,6 +60,8 @@ func TestStats(t *testing.T) {
 		helpers.Ensure("run", "-d", "--name", data.Identifier("memlimited"), "--memory", "1g", testutil.CommonImage, "sleep", nerdtest.Infinity)
 		helpers.Ensure("run", "--name", data.Identifier("exited"), testutil.CommonImage, "echo", "'exited'")
 		data.Labels().Set("id", data.Identifier("container"))
+		data.Labels().Set("memlimited", data.Identifier("memlimited"))
+		data.Labels().Set("exited", data.Identifier("exited"))
 	}
 
 	testCase.SubTests = []*test.Case{
@@ -89,6 +97,61 @@ func TestStats(t *testing.T) {
 			},
 			Expected: test.Expects(0, nil, nil),
 		},
+		{
+			Description: "stats ID only shows the requested container",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("stats", "--no-stream", "--format", "{{.Name}}", data.Labels().Get("id"))
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: expect.Equals(data.Labels().Get("id") + "\n"),
+				}
+			},
+		},
+		{
+			Description: "stats without --all does not show stopped containers",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("stats", "--no-stream", "--format", "{{.Name}}")
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: expect.All(
+						expect.Contains(data.Labels().Get("id"), data.Labels().Get("memlimited")),
+						expect.DoesNotContain(data.Labels().Get("exited")),
+					),
+				}
+			},
+		},
+		{
+			Description: "stats --all shows stopped containers with zero usage",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("stats", "--no-stream", "--all", "--format", "{{.Name}} {{.CPUPerc}} {{.PIDs}}")
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: expect.All(
+						expect.Contains(data.Labels().Get("id"), data.Labels().Get("memlimited")),
+						expect.Contains(data.Labels().Get("exited")+" 0.00% 0\n"),
+					),
+				}
+			},
+		},
+		{
+			Description: "stats json has the network and block IO",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("stats", "--no-stream", "--format", "json", data.Labels().Get("id"))
+			},
+			Expected: test.Expects(0, nil, expect.JSON(statsutil.FormattedStatsEntry{}, func(entry statsutil.FormattedStatsEntry, t tig.T) {
+				ioFormat := regexp.MustCompile(`^[0-9.]+[kMGTP]?B / [0-9.]+[kMGTP]?B$`)
+				assert.Assert(t, ioFormat.MatchString(entry.NetIO), "unexpected NetIO %q", entry.NetIO)
+				assert.Assert(t, ioFormat.MatchString(entry.BlockIO), "unexpected BlockIO %q", entry.BlockIO)
+			})),
+		},
+		{
+			Description: "stats of a nonexistent container",
+			Command:     test.Command("stats", "--no-stream", "nonexistent-container"),
+			Expected:    test.Expects(1, []error{errors.New("no such container")}, nil),
+		},
 		{
 			Description: "no mem limit set",
 			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
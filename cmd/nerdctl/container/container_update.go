@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"strconv"
 	"time"
 
 	"github.com/docker/go-units"
@@ -55,6 +56,8 @@ type updateResourceOptions struct {
 	CpusetMems         string
 	PidsLimit          int64
 	BlkioWeight        uint16
+	Devices            []string
+	DevicesToRemove    []string
 }
 
 func UpdateCommand() *cobra.Command {
@@ -85,6 +88,8 @@ func setUpdateFlags(cmd *cobra.Command) {
 	cmd.Flags().String("cpuset-mems", "", "MEMs in which to allow execution (0-3, 0,1)")
 	cmd.Flags().Int64("pids-limit", -1, "Tune container pids limit (set -1 for unlimited)")
 	cmd.Flags().Uint16("blkio-weight", 0, "Block IO (relative weight), between 10 and 1000, or 0 to disable (default 0)")
+	cmd.Flags().StringArray("device", nil, "Add a host device to the container")
+	cmd.Flags().StringArray("device-rm", nil, "Remove a device from the container, by its path in the container")
 	cmd.Flags().String("restart", "no", `Restart policy to apply when a container exits (implemented values: "no"|"always|on-failure:n|unless-stopped")`)
 	cmd.RegisterFlagCompletionFunc("restart", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return []string{"no", "always", "on-failure", "unless-stopped"}, cobra.ShellCompDirectiveNoFileComp
@@ -214,6 +219,9 @@ func getUpdateOption(cmd *cobra.Command, globalOptions types.GlobalCommandOption
 	if err != nil {
 		return options, err
 	}
+	if err := validatePidsLimit(pidsLimit); err != nil {
+		return options, err
+	}
 	blkioWeight, err := cmd.Flags().GetUint16("blkio-weight")
 	if err != nil {
 		return options, err
@@ -225,6 +233,15 @@ func getUpdateOption(cmd *cobra.Command, globalOptions types.GlobalCommandOption
 		return options, errors.New("range of blkio weight is from 10 to 1000")
 	}
 
+	devices, err := cmd.Flags().GetStringArray("device")
+	if err != nil {
+		return options, err
+	}
+	devicesToRemove, err := cmd.Flags().GetStringArray("device-rm")
+	if err != nil {
+		return options, err
+	}
+
 	if runtime.GOOS == "linux" {
 		options = updateResourceOptions{
 			CPUPeriod:          cpuPeriod,
@@ -237,6 +254,8 @@ func getUpdateOption(cmd *cobra.Command, globalOptions types.GlobalCommandOption
 			MemorySwapInBytes:  memSwap64,
 			PidsLimit:          pidsLimit,
 			BlkioWeight:        blkioWeight,
+			Devices:            devices,
+			DevicesToRemove:    devicesToRemove,
 		}
 	}
 	return options, nil
@@ -260,6 +279,7 @@ func updateContainer(ctx context.Context, client *containerd.Client, id string,
 	if err != nil {
 		return err
 	}
+	var addedDevices, removedDevices []runtimespec.LinuxDevice
 	if runtime.GOOS == "linux" {
 		if spec.Linux == nil {
 			spec.Linux = &runtimespec.Linux{}
@@ -284,6 +304,13 @@ func updateContainer(ctx context.Context, client *containerd.Client, id string,
 			if spec.Linux.Resources.CPU.Shares != &opts.CPUShares {
 				spec.Linux.Resources.CPU.Shares = &opts.CPUShares
 			}
+			// Keep cpu.weight, which is set explicitly on cgroup v2 by nerdctl run, in sync with the shares
+			if infoutil.CgroupsVersion() == "2" {
+				if spec.Linux.Resources.Unified == nil {
+					spec.Linux.Resources.Unified = make(map[string]string)
+				}
+				spec.Linux.Resources.Unified["cpu.weight"] = strconv.FormatUint(nerdctlcontainer.CPUSharesToWeight(opts.CPUShares), 10)
+			}
 		}
 		if cmd.Flags().Changed("cpu-quota") {
 			if spec.Linux.Resources.CPU.Quota != &opts.CPUQuota {
@@ -296,9 +323,9 @@ func updateContainer(ctx context.Context, client *containerd.Client, id string,
 			}
 		}
 		if cmd.Flags().Changed("cpus") {
-			if spec.Linux.Resources.CPU.Cpus != opts.CpusetCpus {
-				spec.Linux.Resources.CPU.Cpus = opts.CpusetCpus
-			}
+			// --cpus is converted to quota/period by getUpdateOption and must not touch the cpuset or the shares
+			spec.Linux.Resources.CPU.Quota = &opts.CPUQuota
+			spec.Linux.Resources.CPU.Period = &opts.CPUPeriod
 		}
 		if cmd.Flags().Changed("cpuset-mems") {
 			if spec.Linux.Resources.CPU.Mems != opts.CpusetMems {
@@ -337,6 +364,12 @@ func updateContainer(ctx context.Context, client *containerd.Client, id string,
 				spec.Linux.Resources.Pids.Limit = &opts.PidsLimit
 			}
 		}
+		if len(opts.Devices) > 0 || len(opts.DevicesToRemove) > 0 {
+			addedDevices, removedDevices, err = nerdctlcontainer.UpdateSpecDevices(ctx, spec, opts.Devices, opts.DevicesToRemove)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	if err := updateContainerSpec(ctx, container, spec); err != nil {
@@ -352,6 +385,14 @@ func updateContainer(ctx context.Context, client *containerd.Client, id string,
 			}
 		}
 	}()
+	if len(opts.Devices) > 0 || len(opts.DevicesToRemove) > 0 {
+		// The devices shown by inspect are only updated once the devices have been hot-plugged (if running).
+		defer func() {
+			if retErr == nil {
+				retErr = nerdctlcontainer.UpdateDeviceMappingLabel(ctx, container, opts.Devices, opts.DevicesToRemove)
+			}
+		}()
+	}
 
 	restart, err := cmd.Flags().GetString("restart")
 	if err != nil {
@@ -376,7 +417,14 @@ func updateContainer(ctx context.Context, client *containerd.Client, id string,
 		}
 		return fmt.Errorf("failed to get task:%w", err)
 	}
-	return task.Update(ctx, containerd.WithResources(spec.Linux.Resources))
+	if err := task.Update(ctx, containerd.WithResources(spec.Linux.Resources)); err != nil {
+		return err
+	}
+	// The OCI runtime does not update the device cgroup, nor the device nodes of a running container
+	if len(addedDevices) > 0 || len(removedDevices) > 0 {
+		return nerdctlcontainer.HotplugDevices(int(task.Pid()), spec, addedDevices, removedDevices)
+	}
+	return nil
 }
 
 func updateContainerSpec(ctx context.Context, container containerd.Container, spec *runtimespec.Spec) error {
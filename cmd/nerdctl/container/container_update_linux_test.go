@@ -17,9 +17,25 @@
 package container
 
 import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
 	"testing"
 
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"golang.org/x/sys/unix"
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/continuity/testutil/loopback"
+	"github.com/containerd/nerdctl/mod/tigron/expect"
+	"github.com/containerd/nerdctl/mod/tigron/require"
+	"github.com/containerd/nerdctl/mod/tigron/test"
+	"github.com/containerd/nerdctl/mod/tigron/tig"
+
+	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/native"
 	"github.com/containerd/nerdctl/v2/pkg/testutil"
+	"github.com/containerd/nerdctl/v2/pkg/testutil/nerdtest"
 )
 
 func TestUpdateContainer(t *testing.T) {
@@ -31,3 +47,128 @@ func TestUpdateContainer(t *testing.T) {
 	base.Cmd("update", "--memory", "999999999", "--restart", "123", testContainerName).AssertFail()
 	base.Cmd("inspect", "--mode=native", testContainerName).AssertOutNotContains(`"limit": 999999999,`)
 }
+
+func TestUpdateDevice(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.Require = require.All(
+		require.Not(nerdtest.Docker),
+		nerdtest.Rootful,
+	)
+	testCase.NoParallel = true
+
+	var lo *loopback.Loopback
+
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		var err error
+		lo, err = loopback.New(4096)
+		assert.NilError(t, err)
+		assert.NilError(t, os.WriteFile(lo.Device, []byte("hotplug-content"), 0o700))
+		helpers.Ensure("run", "-d", "--name", data.Identifier(), testutil.AlpineImage, "sleep", nerdtest.Infinity)
+		data.Labels().Set("id", data.Identifier())
+	}
+
+	testCase.Cleanup = func(data test.Data, helpers test.Helpers) {
+		helpers.Anyhow("rm", "-f", data.Identifier())
+		if lo != nil {
+			_ = lo.Close()
+		}
+	}
+
+	specDevices := func(helpers test.Helpers, id string) ([]specs.LinuxDevice, []specs.LinuxDeviceCgroup) {
+		var dc []native.Container
+		assert.NilError(helpers.T(), json.Unmarshal([]byte(helpers.Capture("container", "inspect", "--mode=native", id)), &dc))
+		assert.Equal(helpers.T(), len(dc), 1)
+		b, err := json.Marshal(dc[0].Spec)
+		assert.NilError(helpers.T(), err)
+		var spec specs.Spec
+		assert.NilError(helpers.T(), json.Unmarshal(b, &spec))
+		return spec.Linux.Devices, spec.Linux.Resources.Devices
+	}
+	isAllowed := func(rules []specs.LinuxDeviceCgroup, major, minor int64) bool {
+		for _, rule := range rules {
+			if rule.Allow && rule.Type == "b" && rule.Major != nil && *rule.Major == major && rule.Minor != nil && *rule.Minor == minor {
+				return true
+			}
+		}
+		return false
+	}
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "device is not accessible before the update",
+			NoParallel:  true,
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("exec", data.Labels().Get("id"), "cat", "/dev/hotplug")
+			},
+			Expected: test.Expects(expect.ExitCodeGenericFail, nil, nil),
+		},
+		{
+			Description: "--device hot-plugs the device",
+			NoParallel:  true,
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("update", "--device", lo.Device+":/dev/hotplug:rwm", data.Labels().Get("id"))
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("exec", data.Labels().Get("id"), "cat", "/dev/hotplug")
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: func(stdout string, t tig.T) {
+						assert.Assert(t, strings.Contains(stdout, "hotplug-content"))
+
+						devices, rules := specDevices(helpers, data.Labels().Get("id"))
+						var found bool
+						for _, dev := range devices {
+							if dev.Path == "/dev/hotplug" {
+								found = true
+								assert.Assert(t, isAllowed(rules, dev.Major, dev.Minor), "the cgroup rule of the device was not added")
+							}
+						}
+						assert.Assert(t, found, "the device was not added to the spec")
+
+						inspect := nerdtest.InspectContainer(helpers, data.Labels().Get("id"))
+						assert.Equal(t, len(inspect.HostConfig.Devices), 1)
+						assert.Equal(t, inspect.HostConfig.Devices[0].PathOnHost, lo.Device)
+						assert.Equal(t, inspect.HostConfig.Devices[0].PathInContainer, "/dev/hotplug")
+					},
+				}
+			},
+		},
+		{
+			Description: "--device-rm removes the device",
+			NoParallel:  true,
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("update", "--device-rm", "/dev/hotplug", data.Labels().Get("id"))
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("exec", data.Labels().Get("id"), "ls", "/dev/hotplug")
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: expect.ExitCodeGenericFail,
+					Output: func(stdout string, t tig.T) {
+						devices, rules := specDevices(helpers, data.Labels().Get("id"))
+						for _, dev := range devices {
+							assert.Assert(t, dev.Path != "/dev/hotplug", "the device was not removed from the spec")
+						}
+						var st unix.Stat_t
+						assert.NilError(t, unix.Stat(lo.Device, &st))
+						assert.Assert(t, !isAllowed(rules, int64(unix.Major(st.Rdev)), int64(unix.Minor(st.Rdev))), "the cgroup rule of the device was not removed")
+						assert.Equal(t, len(nerdtest.InspectContainer(helpers, data.Labels().Get("id")).HostConfig.Devices), 0)
+					},
+				}
+			},
+		},
+		{
+			Description: "--device-rm fails for a device that is not attached",
+			NoParallel:  true,
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("update", "--device-rm", "/dev/hotplug", data.Labels().Get("id"))
+			},
+			Expected: test.Expects(expect.ExitCodeGenericFail, []error{errors.New("not attached")}, nil),
+		},
+	}
+
+	testCase.Run(t)
+}
@@ -52,7 +52,8 @@ func ValidateHealthcheckFlags(options types.ContainerCreateOptions) error {
 		options.HealthInterval != 0 ||
 			options.HealthTimeout != 0 ||
 			options.HealthRetries != 0 ||
-			options.HealthStartPeriod != 0
+			options.HealthStartPeriod != 0 ||
+			options.HealthStartInterval != 0
 
 	if options.NoHealthcheck {
 		if options.HealthCmd != "" || healthFlagsSet {
@@ -73,6 +74,9 @@ func ValidateHealthcheckFlags(options types.ContainerCreateOptions) error {
 	if options.HealthStartPeriod < 0 {
 		return fmt.Errorf("--health-start-period cannot be negative")
 	}
+	if options.HealthStartInterval < 0 {
+		return fmt.Errorf("--health-start-interval cannot be negative")
+	}
 	return nil
 }
 
@@ -153,6 +157,26 @@ func ProcessRootCmdFlags(cmd *cobra.Command) (types.GlobalCommandOptions, error)
 	if err != nil {
 		return types.GlobalCommandOptions{}, err
 	}
+	defaultUlimits, err := cmd.Flags().GetStringSlice("global-default-ulimits")
+	if err != nil {
+		return types.GlobalCommandOptions{}, err
+	}
+	gpuMode, err := cmd.Flags().GetString("global-gpu-mode")
+	if err != nil {
+		return types.GlobalCommandOptions{}, err
+	}
+	defaultInitBinary, err := cmd.Flags().GetString("global-default-init-binary")
+	if err != nil {
+		return types.GlobalCommandOptions{}, err
+	}
+	remoteFileAuthHeader, err := cmd.Flags().GetString("global-remote-file-auth-header")
+	if err != nil {
+		return types.GlobalCommandOptions{}, err
+	}
+	remoteFileAuthHost, err := cmd.Flags().GetString("global-remote-file-auth-host")
+	if err != nil {
+		return types.GlobalCommandOptions{}, err
+	}
 
 	// Point to dataRoot for filesystem-helpers implementing rollback / backups.
 	err = fs.InitFS(dataRoot)
@@ -180,6 +204,12 @@ func ProcessRootCmdFlags(cmd *cobra.Command) (types.GlobalCommandOptions, error)
 		DNS:              dns,
 		DNSOpts:          dnsOpts,
 		DNSSearch:        dnsSearch,
+		DefaultUlimits:   defaultUlimits,
+		GPUMode:          gpuMode,
+
+		DefaultInitBinary:    defaultInitBinary,
+		RemoteFileAuthHeader: remoteFileAuthHeader,
+		RemoteFileAuthHost:   remoteFileAuthHost,
 	}, nil
 }
 
@@ -49,6 +49,7 @@ func Command() *cobra.Command {
 		encryptCommand(),
 		decryptCommand(),
 		pruneCommand(),
+		listReferrersCommand(),
 	)
 	return cmd
 }
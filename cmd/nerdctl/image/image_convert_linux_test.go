@@ -21,9 +21,15 @@ import (
 	"testing"
 	"time"
 
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/nerdctl/mod/tigron/expect"
 	"github.com/containerd/nerdctl/mod/tigron/require"
 	"github.com/containerd/nerdctl/mod/tigron/test"
+	"github.com/containerd/nerdctl/mod/tigron/tig"
 
+	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/native"
+	"github.com/containerd/nerdctl/v2/pkg/snapshotterutil"
 	"github.com/containerd/nerdctl/v2/pkg/testutil"
 	"github.com/containerd/nerdctl/v2/pkg/testutil/nerdtest"
 	"github.com/containerd/nerdctl/v2/pkg/testutil/nerdtest/registry"
@@ -90,6 +96,42 @@ func TestImageConvert(t *testing.T) {
 				},
 				Expected: test.Expects(0, nil, nil),
 			},
+			{
+				Description: "platform",
+				Cleanup: func(data test.Data, helpers test.Helpers) {
+					helpers.Anyhow("rmi", "-f", data.Identifier("converted-image"))
+				},
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("image", "convert", "--platform", "linux/arm64",
+						testutil.CommonImage, data.Identifier("converted-image"))
+				},
+				Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+					return &test.Expected{
+						Output: func(stdout string, t tig.T) {
+							// The converted index only refers to the linux/arm64 manifest
+							helpers.Command("image", "inspect", "--mode=native", "--platform=linux/arm64",
+								data.Identifier("converted-image")).Run(&test.Expected{
+								Output: expect.JSON([]native.Image{}, func(images []native.Image, t tig.T) {
+									assert.Equal(t, len(images), 1)
+									assert.Assert(t, images[0].Index != nil, "expected the converted image to be an index")
+									assert.Equal(t, len(images[0].Index.Manifests), 1)
+									plat := images[0].Index.Manifests[0].Platform
+									assert.Assert(t, plat != nil && plat.OS == "linux" && plat.Architecture == "arm64",
+										"unexpected platform %v", plat)
+								}),
+							})
+							// The manifests of the other platforms are not referenced anymore
+							helpers.Command("image", "inspect", "--mode=native", "--platform=linux/amd64",
+								data.Identifier("converted-image")).Run(&test.Expected{
+								Output: expect.JSON([]native.Image{}, func(images []native.Image, t tig.T) {
+									assert.Equal(t, len(images), 1)
+									assert.Assert(t, images[0].Manifest == nil, "expected no linux/amd64 manifest")
+								}),
+							})
+						},
+					}
+				},
+			},
 			{
 				Description: "soci",
 				Require: require.All(
@@ -106,7 +148,17 @@ func TestImageConvert(t *testing.T) {
 						"--soci-min-layer-size", "0",
 						testutil.CommonImage, data.Identifier("converted-image"))
 				},
-				Expected: test.Expects(0, nil, nil),
+				Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+					return &test.Expected{
+						Output: func(stdout string, t tig.T) {
+							// The converted manifest points at its SOCI index
+							helpers.Command("image", "inspect", "--mode=native", "--format={{json .Manifest.Annotations}}",
+								data.Identifier("converted-image")).Run(&test.Expected{
+								Output: expect.Contains(snapshotterutil.SociIndexDigestAnnotation),
+							})
+						},
+					}
+				},
 			},
 			{
 				Description: "soci with all-platforms",
@@ -235,9 +235,14 @@ func (x *historyPrinter) printHistory(printable historyPrintable) error {
 	}
 
 	// Format date and size for display based on --human preference
-	printable.CreatedAt = printable.creationTime.Local().Format(time.RFC3339)
+	// The created field is optional in the image config history, so it may be missing
+	if printable.creationTime != nil {
+		printable.CreatedAt = printable.creationTime.Local().Format(time.RFC3339)
+	}
 	if x.human {
-		printable.CreatedSince = formatter.TimeSinceInHuman(*printable.creationTime)
+		if printable.creationTime != nil {
+			printable.CreatedSince = formatter.TimeSinceInHuman(*printable.creationTime)
+		}
 		printable.Size = units.HumanSize(float64(printable.size))
 	} else {
 		printable.CreatedSince = printable.CreatedAt
@@ -194,3 +194,88 @@ func TestImageHistory(t *testing.T) {
 
 	testCase.Run(t)
 }
+
+func TestImageHistoryByDigest(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	// Pulling by digest while the tag is also present yields two image records with the same digest:
+	// the digest reference should resolve to its own record only.
+	testCase.Require = require.Not(nerdtest.Docker)
+
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		helpers.Ensure("pull", "--quiet", testutil.CommonImage)
+		repoDigest := nerdtest.InspectImage(helpers, testutil.CommonImage).RepoDigests[0]
+		helpers.Ensure("pull", "--quiet", repoDigest)
+		data.Labels().Set("repoDigest", repoDigest)
+	}
+
+	testCase.Cleanup = func(data test.Data, helpers test.Helpers) {
+		helpers.Anyhow("rmi", data.Labels().Get("repoDigest"))
+	}
+
+	testCase.Command = func(data test.Data, helpers test.Helpers) test.TestableCommand {
+		return helpers.Command("image", "history", "--quiet", data.Labels().Get("repoDigest"))
+	}
+
+	testCase.Expected = test.Expects(0, nil, nil)
+
+	testCase.Run(t)
+}
+
+func TestHistoryPrinterFormatting(t *testing.T) {
+	t.Parallel()
+
+	created, _ := time.Parse(time.RFC3339, createdAt1)
+	testCases := []struct {
+		description string
+		human       bool
+		printable   historyPrintable
+		expected    historyObj
+	}{
+		{
+			description: "human",
+			human:       true,
+			printable:   historyPrintable{creationTime: &created, size: 5947392},
+			expected: historyObj{
+				CreatedAt:    created.Local().Format(time.RFC3339),
+				CreatedSince: formatter.TimeSinceInHuman(created),
+				Size:         "5.947MB",
+			},
+		},
+		{
+			description: "raw",
+			human:       false,
+			printable:   historyPrintable{creationTime: &created, size: 5947392},
+			expected: historyObj{
+				CreatedAt:    created.Local().Format(time.RFC3339),
+				CreatedSince: created.Local().Format(time.RFC3339),
+				Size:         "5947392",
+			},
+		},
+		{
+			description: "missing creation time",
+			human:       true,
+			printable:   historyPrintable{size: 0},
+			expected: historyObj{
+				Size: "0B",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			var buf strings.Builder
+			tmpl, err := formatter.ParseTemplate("{{json .}}")
+			assert.NilError(t, err)
+			printer := &historyPrinter{w: &buf, human: tc.human, tmpl: tmpl}
+			assert.NilError(t, printer.printHistory(tc.printable))
+			history, err := decode(buf.String())
+			assert.NilError(t, err)
+			assert.Equal(t, len(history), 1)
+			assert.Equal(t, history[0].CreatedAt, tc.expected.CreatedAt)
+			assert.Equal(t, history[0].CreatedSince, tc.expected.CreatedSince)
+			assert.Equal(t, history[0].Size, tc.expected.Size)
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 	"gotest.tools/v3/assert"
 
+	"github.com/containerd/nerdctl/mod/tigron/expect"
 	"github.com/containerd/nerdctl/mod/tigron/require"
 	"github.com/containerd/nerdctl/mod/tigron/test"
 	"github.com/containerd/nerdctl/mod/tigron/tig"
@@ -248,3 +249,38 @@ func TestImageInspectDifferentValidReferencesForTheSameImage(t *testing.T) {
 
 	testCase.Run(t)
 }
+
+func TestImageInspectPlatform(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.Require = require.All(
+		require.Not(require.Windows),
+		require.Not(nerdtest.Docker),
+	)
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		// Isolate the data root, as pulling a single non-host platform of the common image would affect the other tests
+		helpers.Write(nerdtest.DataRoot, test.ConfigValue(data.Temp().Path()))
+		helpers.Ensure("pull", "--quiet", "--platform", "linux/arm/v7", testutil.CommonImage)
+	}
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "non-host platform",
+			Command: test.Command("image", "inspect", "--platform", "linux/arm/v7",
+				"--format", "{{.Os}}/{{.Architecture}}/{{.Variant}}", testutil.CommonImage),
+			Expected: test.Expects(0, nil, expect.Equals("linux/arm/v7\n")),
+		},
+		{
+			Description: "size of the layers of the non-host platform",
+			Command:     test.Command("image", "inspect", "--platform", "linux/arm/v7", testutil.CommonImage),
+			Expected: test.Expects(0, nil, func(stdout string, t tig.T) {
+				var dc []dockercompat.Image
+				assert.NilError(t, json.Unmarshal([]byte(stdout), &dc))
+				assert.Equal(t, 1, len(dc))
+				assert.Equal(t, dc[0].Architecture, "arm")
+				assert.Assert(t, dc[0].Size > 0, "size should be > 0")
+			}),
+		},
+	}
+
+	testCase.Run(t)
+}
@@ -0,0 +1,70 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package image
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/containerd/nerdctl/v2/cmd/nerdctl/helpers"
+	"github.com/containerd/nerdctl/v2/pkg/api/types"
+	"github.com/containerd/nerdctl/v2/pkg/cmd/image"
+)
+
+func listReferrersCommand() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:           "list-referrers [flags] IMAGE",
+		Short:         "List the artifacts (e.g. signatures, SBOMs) that refer to an image in a registry",
+		Args:          helpers.IsExactArgs(1),
+		RunE:          listReferrersAction,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	cmd.Flags().StringSlice("artifact-type", nil, "Only list the referrers with the given artifact type (can be specified multiple times)")
+	cmd.Flags().StringP("format", "f", "", "Format the output using the given Go template, e.g, '{{json .}}'")
+	cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"json", "table"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().BoolP("quiet", "q", false, "Only show the digests of the referrers")
+	return cmd
+}
+
+func listReferrersAction(cmd *cobra.Command, args []string) error {
+	globalOptions, err := helpers.ProcessRootCmdFlags(cmd)
+	if err != nil {
+		return err
+	}
+	artifactTypes, err := cmd.Flags().GetStringSlice("artifact-type")
+	if err != nil {
+		return err
+	}
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return err
+	}
+	quiet, err := cmd.Flags().GetBool("quiet")
+	if err != nil {
+		return err
+	}
+	options := types.ImageListReferrersOptions{
+		Stdout:        cmd.OutOrStdout(),
+		GOptions:      globalOptions,
+		ArtifactTypes: artifactTypes,
+		Format:        format,
+		Quiet:         quiet,
+	}
+	return image.ListReferrers(cmd.Context(), args[0], options)
+}
@@ -107,6 +107,40 @@ func TestImages(t *testing.T) {
 					}
 				},
 			},
+			{
+				Description: "With digests",
+				Command:     test.Command("images", "--digests", commonImage.String()),
+				Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+					storedDigest := strings.TrimSpace(helpers.Capture("image", "inspect", "--mode=native", "--format={{.Image.Target.Digest}}", commonImage.String()))
+					return &test.Expected{
+						Output: func(stdout string, t tig.T) {
+							lines := strings.Split(strings.TrimSpace(stdout), "\n")
+							assert.Assert(t, len(lines) >= 2, "there should be at least two lines\n")
+							tab := tabutil.NewReader("REPOSITORY\tTAG\tDIGEST\tIMAGE ID\tCREATED\tPLATFORM\tSIZE\tBLOB SIZE")
+							err := tab.ParseHeader(lines[0])
+							assert.NilError(t, err, "ParseHeader should not fail\n")
+							for _, line := range lines[1:] {
+								dgst, _ := tab.ReadRow(line, "DIGEST")
+								assert.Equal(t, dgst, storedDigest, "digest column should match the stored image digest\n")
+							}
+						},
+					}
+				},
+			},
+			{
+				Description: "With digests format",
+				Command:     test.Command("images", "--format", "{{.Digest}}", commonImage.String()),
+				Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+					storedDigest := strings.TrimSpace(helpers.Capture("image", "inspect", "--mode=native", "--format={{.Image.Target.Digest}}", commonImage.String()))
+					return &test.Expected{
+						Output: func(stdout string, t tig.T) {
+							for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+								assert.Equal(t, line, storedDigest, "digest should match the stored image digest\n")
+							}
+						},
+					}
+				},
+			},
 			{
 				Description: "CheckCreatedTime",
 				Command:     test.Command("images", "--format", "'{{json .CreatedAt}}'"),
@@ -65,6 +65,9 @@ func PullCommand() *cobra.Command {
 	cmd.Flags().String("soci-index-digest", "", "Specify a particular index digest for SOCI. If left empty, SOCI will automatically use the index determined by the selection policy.")
 	// #endregion
 
+	cmd.Flags().Bool(allowNonDistFlag, false, "Fetch non-distributable blobs from the registry, falling back to the URLs of their descriptor")
+	cmd.Flags().Bool("include-chunks", false, "Fetch only the chunks of zstd:chunked layers that are missing from the local zstd:chunked layers")
+	cmd.Flags().Bool("no-chunk-dedup", false, "Fetch zstd:chunked layers as a whole, overriding --include-chunks")
 	cmd.Flags().BoolP("quiet", "q", false, "Suppress verbose output")
 
 	cmd.Flags().String("ipfs-address", "", "multiaddr of IPFS API (default uses $IPFS_PATH env variable if defined or local directory ~/.ipfs)")
@@ -114,6 +117,20 @@ func processPullCommandFlags(cmd *cobra.Command) (types.ImagePullOptions, error)
 		return types.ImagePullOptions{}, err
 	}
 
+	allowNonDist, err := cmd.Flags().GetBool(allowNonDistFlag)
+	if err != nil {
+		return types.ImagePullOptions{}, err
+	}
+
+	includeChunks, err := cmd.Flags().GetBool("include-chunks")
+	if err != nil {
+		return types.ImagePullOptions{}, err
+	}
+	noChunkDedup, err := cmd.Flags().GetBool("no-chunk-dedup")
+	if err != nil {
+		return types.ImagePullOptions{}, err
+	}
+
 	verifyOptions, err := helpers.VerifyOptions(cmd)
 	if err != nil {
 		return types.ImagePullOptions{}, err
@@ -129,9 +146,11 @@ func processPullCommandFlags(cmd *cobra.Command) (types.ImagePullOptions, error)
 		RFlags: types.RemoteSnapshotterFlags{
 			SociIndexDigest: sociIndexDigest,
 		},
-		Stdout:                 cmd.OutOrStdout(),
-		Stderr:                 cmd.OutOrStderr(),
-		ProgressOutputToStdout: true,
+		AllowNondistributableArtifacts: allowNonDist,
+		IncludeChunks:                  includeChunks && !noChunkDedup,
+		Stdout:                         cmd.OutOrStdout(),
+		Stderr:                         cmd.OutOrStderr(),
+		ProgressOutputToStdout:         true,
 	}, nil
 }
 
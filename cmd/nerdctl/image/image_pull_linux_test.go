@@ -17,6 +17,7 @@
 package image
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -158,6 +159,64 @@ CMD ["echo", "nerdctl-build-test-string"]
 	testCase.Run(t)
 }
 
+func TestImagePullInsecureRegistry(t *testing.T) {
+	nerdtest.Setup()
+
+	var reg *registry.Server
+
+	testCase := &test.Case{
+		Require: require.All(
+			require.Linux,
+			require.Not(nerdtest.Docker),
+			nerdtest.Registry,
+		),
+
+		Setup: func(data test.Data, helpers test.Helpers) {
+			// A plain HTTP registry on a non-localhost address, so that HTTP is not implied
+			reg = nerdtest.RegistryWithNoAuth(data, helpers, 0, false)
+			reg.Setup(data, helpers)
+			testImageRef := fmt.Sprintf("%s:%d/%s", reg.IP.String(), reg.Port, data.Identifier())
+			helpers.Ensure("pull", "--quiet", testutil.CommonImage)
+			helpers.Ensure("tag", testutil.CommonImage, testImageRef)
+			helpers.Ensure("push", "--insecure-registry", testImageRef)
+			helpers.Ensure("rmi", "-f", testImageRef)
+			data.Labels().Set("image_ref", testImageRef)
+		},
+
+		Cleanup: func(data test.Data, helpers test.Helpers) {
+			if reg != nil {
+				helpers.Anyhow("rmi", "-f", data.Labels().Get("image_ref"))
+				reg.Cleanup(data, helpers)
+			}
+		},
+
+		// The subtests pull and remove the same reference
+		NoParallel: true,
+
+		SubTests: []*test.Case{
+			{
+				Description: "pull without --insecure-registry fails",
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("pull", "--quiet", data.Labels().Get("image_ref"))
+				},
+				Expected: test.Expects(expect.ExitCodeGenericFail, []error{errors.New("server gave HTTP response to HTTPS client")}, nil),
+			},
+			{
+				Description: "pull with --insecure-registry succeeds",
+				Cleanup: func(data test.Data, helpers test.Helpers) {
+					helpers.Anyhow("rmi", "-f", data.Labels().Get("image_ref"))
+				},
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("pull", "--quiet", "--insecure-registry", data.Labels().Get("image_ref"))
+				},
+				Expected: test.Expects(0, nil, nil),
+			},
+		},
+	}
+
+	testCase.Run(t)
+}
+
 func TestImagePullSoci(t *testing.T) {
 	nerdtest.Setup()
 
@@ -282,3 +341,83 @@ func TestImagePullProcessOutput(t *testing.T) {
 
 	testCase.Run(t)
 }
+
+func TestImagePullUnpack(t *testing.T) {
+	nerdtest.Setup()
+
+	// The size of an image is the size of its snapshots, so it is "0B" when the image is not unpacked
+	imageSize := func(helpers test.Helpers, args ...string) string {
+		args = append(args, "images", "--format", "{{.Size}}", testutil.CommonImage)
+		return strings.TrimSpace(helpers.Capture(args...))
+	}
+
+	testCase := &test.Case{
+		Require: require.Not(nerdtest.Docker),
+		SubTests: []*test.Case{
+			{
+				Description: "--unpack=false does not create snapshots",
+				Require:     nerdtest.Private,
+				Setup: func(data test.Data, helpers test.Helpers) {
+					helpers.Ensure("pull", "--quiet", "--unpack=false", testutil.CommonImage)
+				},
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("images", "--format", "{{.Size}}", testutil.CommonImage)
+				},
+				Expected: test.Expects(expect.ExitCodeSuccess, nil, expect.Equals("0B\n")),
+			},
+			{
+				Description: "--unpack=true creates snapshots",
+				Require:     nerdtest.Private,
+				Setup: func(data test.Data, helpers test.Helpers) {
+					helpers.Ensure("pull", "--quiet", "--unpack=true", testutil.CommonImage)
+				},
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("images", "--format", "{{.Size}}", testutil.CommonImage)
+				},
+				Expected: test.Expects(expect.ExitCodeSuccess, nil, func(stdout string, t tig.T) {
+					assert.Assert(t, strings.TrimSpace(stdout) != "0B", "the image should have been unpacked")
+				}),
+			},
+			{
+				Description: "unpacks for the host platform by default",
+				Require:     nerdtest.Private,
+				Setup: func(data test.Data, helpers test.Helpers) {
+					helpers.Ensure("pull", "--quiet", testutil.CommonImage)
+				},
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("images", "--format", "{{.Size}}", testutil.CommonImage)
+				},
+				Expected: test.Expects(expect.ExitCodeSuccess, nil, func(stdout string, t tig.T) {
+					assert.Assert(t, strings.TrimSpace(stdout) != "0B", "the image should have been unpacked")
+				}),
+			},
+			{
+				Description: "--unpack=true unpacks for the specified snapshotter only",
+				Require:     nerdtest.Private,
+				Setup: func(data test.Data, helpers test.Helpers) {
+					helpers.Ensure("--snapshotter=native", "pull", "--quiet", "--unpack=true", testutil.CommonImage)
+				},
+				Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+					return helpers.Command("--snapshotter=native", "images", "--format", "{{.Size}}", testutil.CommonImage)
+				},
+				Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+					return &test.Expected{
+						Output: func(stdout string, t tig.T) {
+							assert.Assert(t, strings.TrimSpace(stdout) != "0B", "the image should have been unpacked for native")
+							assert.Equal(t, imageSize(helpers, "--snapshotter=overlayfs"), "0B", "the image should not have been unpacked for overlayfs")
+						},
+					}
+				},
+			},
+			{
+				Description: "--unpack=true requires a single platform",
+				Command:     test.Command("pull", "--quiet", "--unpack=true", "--all-platforms", testutil.CommonImage),
+				Expected: test.Expects(expect.ExitCodeGenericFail, []error{
+					errors.New("unpacking requires a single platform"),
+				}, nil),
+			},
+		},
+	}
+
+	testCase.Run(t)
+}
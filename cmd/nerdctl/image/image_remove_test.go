@@ -353,6 +353,101 @@ func TestIssue3016(t *testing.T) {
 	testCase.Run(t)
 }
 
+func TestRemoveByDigest(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	const (
+		repoDigestKey = "repoDigest"
+	)
+
+	repoName, _ := imgutil.ParseRepoTag(testutil.CommonImage)
+	otherTag := repoName + ":rmi-by-digest"
+
+	// Docker only removes the digest reference itself
+	testCase.Require = require.All(nerdtest.Private, require.Not(nerdtest.Docker))
+	testCase.NoParallel = true
+
+	testCase.Setup = func(data test.Data, helpers test.Helpers) {
+		helpers.Ensure("pull", "--quiet", testutil.CommonImage)
+		helpers.Ensure("pull", "--quiet", testutil.NginxAlpineImage)
+		helpers.Ensure("tag", testutil.CommonImage, otherTag)
+		img := nerdtest.InspectImage(helpers, testutil.CommonImage)
+		data.Labels().Set(repoDigestKey, img.RepoDigests[0])
+	}
+
+	listImages := func(helpers test.Helpers) string {
+		return helpers.Capture("images", "--format", "{{.Repository}}:{{.Tag}}")
+	}
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "Digest referenced by multiple tags requires -f",
+			NoParallel:  true,
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("rmi", data.Labels().Get(repoDigestKey))
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					ExitCode: 1,
+					Errors:   []error{errors.New("digest is referenced by 2 tags")},
+					Output: func(stdout string, t tig.T) {
+						images := listImages(helpers)
+						assert.Assert(t, strings.Contains(images, testutil.CommonImage), images)
+						assert.Assert(t, strings.Contains(images, otherTag), images)
+					},
+				}
+			},
+		},
+		{
+			Description: "Digest referenced by a single tag removes only that image",
+			NoParallel:  true,
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("rmi", otherTag)
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("rmi", data.Labels().Get(repoDigestKey))
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: func(stdout string, t tig.T) {
+						images := listImages(helpers)
+						assert.Assert(t, !strings.Contains(images, testutil.CommonImage), images)
+						assert.Assert(t, strings.Contains(images, testutil.NginxAlpineImage), images)
+					},
+				}
+			},
+		},
+		{
+			Description: "Digest referenced by multiple tags with -f removes all of them",
+			NoParallel:  true,
+			Setup: func(data test.Data, helpers test.Helpers) {
+				helpers.Ensure("pull", "--quiet", testutil.CommonImage)
+				helpers.Ensure("tag", testutil.CommonImage, otherTag)
+			},
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("rmi", "-f", data.Labels().Get(repoDigestKey))
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: func(stdout string, t tig.T) {
+						images := listImages(helpers)
+						assert.Assert(t, !strings.Contains(images, repoName+":"), images)
+						assert.Assert(t, strings.Contains(images, testutil.NginxAlpineImage), images)
+					},
+				}
+			},
+		},
+		{
+			Description: "Unknown digest",
+			NoParallel:  true,
+			Command:     test.Command("rmi", repoName+"@sha256:0000000000000000000000000000000000000000000000000000000000000000"),
+			Expected:    test.Expects(1, []error{errors.New("no such image")}, nil),
+		},
+	}
+
+	testCase.Run(t)
+}
+
 func TestRemoveKubeWithKubeHideDupe(t *testing.T) {
 	var numTags, numNoTags int
 	testCase := nerdtest.Setup()
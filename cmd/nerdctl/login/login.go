@@ -18,7 +18,6 @@ package login
 
 import (
 	"errors"
-	"io"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -42,6 +41,10 @@ func Command() *cobra.Command {
 	cmd.Flags().StringP("username", "u", "", "Username")
 	cmd.Flags().StringP("password", "p", "", "Password")
 	cmd.Flags().Bool("password-stdin", false, "Take the password from stdin")
+	cmd.Flags().String("tls-cacert", "", "Trust the registry certificates signed by this CA, on top of hosts.toml")
+	cmd.Flags().String("tls-cert", "", "Path to the TLS client certificate presented to the registry")
+	cmd.Flags().String("tls-key", "", "Path to the TLS client key")
+	cmd.Flags().Bool("tls-verify", true, "Verify the registry certificate")
 	return cmd
 }
 
@@ -64,6 +67,26 @@ func loginOptions(cmd *cobra.Command) (types.LoginCommandOptions, error) {
 		return types.LoginCommandOptions{}, err
 	}
 
+	tlsCACert, err := cmd.Flags().GetString("tls-cacert")
+	if err != nil {
+		return types.LoginCommandOptions{}, err
+	}
+	tlsCert, err := cmd.Flags().GetString("tls-cert")
+	if err != nil {
+		return types.LoginCommandOptions{}, err
+	}
+	tlsKey, err := cmd.Flags().GetString("tls-key")
+	if err != nil {
+		return types.LoginCommandOptions{}, err
+	}
+	tlsVerify, err := cmd.Flags().GetBool("tls-verify")
+	if err != nil {
+		return types.LoginCommandOptions{}, err
+	}
+	if (tlsCert == "") != (tlsKey == "") {
+		return types.LoginCommandOptions{}, errors.New("--tls-cert and --tls-key must be specified together")
+	}
+
 	if strings.Contains(username, ":") {
 		return types.LoginCommandOptions{}, errors.New("username cannot contain colons")
 	}
@@ -80,18 +103,19 @@ func loginOptions(cmd *cobra.Command) (types.LoginCommandOptions, error) {
 			return types.LoginCommandOptions{}, errors.New("must provide --username with --password-stdin")
 		}
 
-		contents, err := io.ReadAll(cmd.InOrStdin())
+		password, err = login.ReadPasswordStdin(cmd.InOrStdin())
 		if err != nil {
 			return types.LoginCommandOptions{}, err
 		}
-
-		password = strings.TrimSuffix(string(contents), "\n")
-		password = strings.TrimSuffix(password, "\r")
 	}
 	return types.LoginCommandOptions{
-		GOptions: globalOptions,
-		Username: username,
-		Password: password,
+		GOptions:      globalOptions,
+		Username:      username,
+		Password:      password,
+		TLSCACert:     tlsCACert,
+		TLSCert:       tlsCert,
+		TLSKey:        tlsKey,
+		TLSSkipVerify: !tlsVerify,
 	}, nil
 }
 
@@ -53,6 +53,16 @@ func (ag *Client) WithHostsDir(hostDirs string) *Client {
 	return ag
 }
 
+func (ag *Client) WithTLSCACert(path string) *Client {
+	ag.args = append(ag.args, "--tls-cacert", path)
+	return ag
+}
+
+func (ag *Client) WithTLSVerify(value bool) *Client {
+	ag.args = append(ag.args, "--tls-verify="+strconv.FormatBool(value))
+	return ag
+}
+
 func (ag *Client) WithCredentials(username, password string) *Client {
 	if username != "" {
 		ag.args = append(ag.args, "--username", username)
@@ -543,6 +553,28 @@ func TestLoginAgainstVariants(t *testing.T) {
 							Run(base, regHost).
 							AssertFail()
 					})
+
+					t.Run("6. valid credentials with the server CA from --tls-cacert", func(t *testing.T) {
+						t.Parallel()
+
+						// TODO: remove specialization when we fix the localhost mess
+						rl, _ := dockerconfigresolver.Parse(regHost)
+						if !tls || rl.IsLocalhost() {
+							t.Skip("requires a TLS registry on a non-localhost address")
+						}
+
+						(&Client{}).
+							WithCredentials(username, password).
+							WithTLSCACert(ca.CertPath).
+							Run(base, regHost).
+							AssertOK()
+
+						(&Client{}).
+							WithCredentials(username, password).
+							WithTLSVerify(false).
+							Run(base, regHost).
+							AssertOK()
+					})
 				})
 			}
 		})
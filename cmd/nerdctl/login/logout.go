@@ -42,12 +42,12 @@ func logoutAction(cmd *cobra.Command, args []string) error {
 		logoutServer = args[0]
 	}
 
-	errGroup, err := logout.Logout(cmd.Context(), logoutServer)
+	errGroup, err := logout.Logout(cmd.Context(), logoutServer, cmd.OutOrStdout())
 	if err != nil {
 		log.L.WithError(err).Errorf("Failed to erase credentials for: %s", logoutServer)
 	}
 	if errGroup != nil {
-		log.L.Error("None of the following entries could be found")
+		log.L.Error("The following entries could not be erased")
 		for _, v := range errGroup {
 			log.L.Errorf("%s", v)
 		}
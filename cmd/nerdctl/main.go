@@ -193,6 +193,15 @@ func initRootCmdFlags(rootCmd *cobra.Command, tomlPath string) (*pflag.FlagSet,
 	helpers.HiddenPersistentStringArrayFlag(rootCmd, "global-dns", cfg.DNS, "Global DNS servers for containers")
 	helpers.HiddenPersistentStringArrayFlag(rootCmd, "global-dns-opts", cfg.DNSOpts, "Global DNS options for containers")
 	helpers.HiddenPersistentStringArrayFlag(rootCmd, "global-dns-search", cfg.DNSSearch, "Global DNS search domains for containers")
+	helpers.HiddenPersistentStringArrayFlag(rootCmd, "global-default-ulimits", cfg.DefaultUlimits, "Default ulimits for containers")
+	rootCmd.PersistentFlags().String("global-gpu-mode", cfg.GPUMode, "How --gpus exposes NVIDIA GPUs (auto|cdi|legacy)")
+	rootCmd.PersistentFlags().MarkHidden("global-gpu-mode")
+	rootCmd.PersistentFlags().String("global-default-init-binary", cfg.DefaultInitBinary, "Default init binary for --init")
+	rootCmd.PersistentFlags().MarkHidden("global-default-init-binary")
+	rootCmd.PersistentFlags().String("global-remote-file-auth-header", cfg.RemoteFileAuthHeader, "Authorization header for fetching remote --env-file and --label-file")
+	rootCmd.PersistentFlags().MarkHidden("global-remote-file-auth-header")
+	rootCmd.PersistentFlags().String("global-remote-file-auth-host", cfg.RemoteFileAuthHost, "Host the authorization header for fetching remote --env-file and --label-file is sent to")
+	rootCmd.PersistentFlags().MarkHidden("global-remote-file-auth-host")
 	return aliasToBeInherited, nil
 }
 
@@ -35,7 +35,7 @@ func listCommand() *cobra.Command {
 		SilenceErrors: true,
 	}
 	cmd.Flags().BoolP("quiet", "q", false, "Only display network IDs")
-	cmd.Flags().StringSliceP("filter", "f", []string{}, "Provide filter values (e.g. \"name=default\")")
+	cmd.Flags().StringSliceP("filter", "f", []string{}, "Provide filter values (e.g. \"name=default\", \"driver=bridge\", \"label=key=value\", \"scope=local\")")
 	cmd.Flags().String("format", "", "Format the output using the given Go template, e.g, '{{json .}}'")
 	cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return []string{"json", "table", "wide"}, cobra.ShellCompDirectiveNoFileComp
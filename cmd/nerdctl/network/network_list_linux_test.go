@@ -22,6 +22,7 @@ import (
 
 	"gotest.tools/v3/assert"
 
+	"github.com/containerd/nerdctl/mod/tigron/expect"
 	"github.com/containerd/nerdctl/mod/tigron/test"
 	"github.com/containerd/nerdctl/mod/tigron/tig"
 
@@ -112,6 +113,25 @@ func TestNetworkLsFilter(t *testing.T) {
 				}
 			},
 		},
+		{
+			Description: "filter driver and format",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("network", "ls", "--filter", "driver=bridge", "--filter", "name="+data.Labels().Get("net1"),
+					"--format", "{{.Name}} {{.Driver}} {{.Scope}} {{.Labels}}")
+			},
+			Expected: func(data test.Data, helpers test.Helpers) *test.Expected {
+				return &test.Expected{
+					Output: expect.Equals(data.Labels().Get("net1") + " bridge local " + data.Labels().Get("label") + "\n"),
+				}
+			},
+		},
+		{
+			Description: "filter driver without match",
+			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
+				return helpers.Command("network", "ls", "--quiet", "--filter", "driver=macvlan", "--filter", "name="+data.Labels().Get("identifier"))
+			},
+			Expected: test.Expects(0, nil, expect.Equals("")),
+		},
 	}
 
 	testCase.Run(t)
@@ -42,6 +42,8 @@ func EventsCommand() *cobra.Command {
 		return []string{"json"}, cobra.ShellCompDirectiveNoFileComp
 	})
 	cmd.Flags().StringSliceP("filter", "f", []string{}, "Filter matches containers based on given conditions")
+	cmd.Flags().String("since", "", "Show all events created since timestamp (e.g. 2013-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes)")
+	cmd.Flags().String("until", "", "Stream events until this timestamp (e.g. 2013-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes)")
 	return cmd
 }
 
@@ -58,11 +60,21 @@ func eventsOptions(cmd *cobra.Command) (types.SystemEventsOptions, error) {
 	if err != nil {
 		return types.SystemEventsOptions{}, err
 	}
+	since, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return types.SystemEventsOptions{}, err
+	}
+	until, err := cmd.Flags().GetString("until")
+	if err != nil {
+		return types.SystemEventsOptions{}, err
+	}
 	return types.SystemEventsOptions{
 		Stdout:   cmd.OutOrStdout(),
 		GOptions: globalOptions,
 		Format:   format,
 		Filters:  filters,
+		Since:    since,
+		Until:    until,
 	}, nil
 }
 
@@ -119,3 +119,31 @@ func TestEventFilters(t *testing.T) {
 
 	testCase.Run(t)
 }
+
+func TestEventActorAttributes(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.Require = require.Not(nerdtest.Docker)
+
+	testCase.Command = func(data test.Data, helpers test.Helpers) test.TestableCommand {
+		helpers.Ensure("pull", testutil.CommonImage)
+		cmd := helpers.Command("events", "--filter", "event=start", "--format", "{{.Actor.Attributes.name}} {{.Actor.Attributes.image}} {{index .Actor.Attributes \"com.example\"}}")
+		cmd.WithTimeout(10 * time.Second)
+		cmd.Background()
+		helpers.Ensure("run", "--name", data.Identifier(), "--label", "com.example=foo", testutil.CommonImage)
+		return cmd
+	}
+
+	testCase.Cleanup = func(data test.Data, helpers test.Helpers) {
+		helpers.Anyhow("rm", "-f", data.Identifier())
+	}
+
+	testCase.Expected = func(data test.Data, helpers test.Helpers) *test.Expected {
+		return &test.Expected{
+			ExitCode: expect.ExitCodeTimeout,
+			Output:   expect.Contains(data.Identifier() + " " + testutil.CommonImage + " foo"),
+		}
+	}
+
+	testCase.Run(t)
+}
@@ -31,6 +31,7 @@ import (
 
 	"github.com/containerd/nerdctl/v2/pkg/infoutil"
 	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/dockercompat"
+	"github.com/containerd/nerdctl/v2/pkg/rootlessutil"
 	"github.com/containerd/nerdctl/v2/pkg/testutil"
 	"github.com/containerd/nerdctl/v2/pkg/testutil/nerdtest"
 )
@@ -41,6 +42,26 @@ func testInfoComparator(stdout string, t tig.T) {
 	assert.NilError(t, err, "failed to unmarshal stdout")
 	unameM := infoutil.UnameM()
 	assert.Assert(t, dinf.Architecture == unameM, fmt.Sprintf("expected info.Architecture to be %q, got %q", unameM, dinf.Architecture))
+	assert.Assert(t, dinf.ServerVersion != "", "expected info.ServerVersion to be set")
+	assert.Assert(t, dinf.Driver != "", "expected info.Driver to be set")
+	assert.Assert(t, dinf.KernelVersion != "", "expected info.KernelVersion to be set")
+	assert.Assert(t, dinf.OperatingSystem != "", "expected info.OperatingSystem to be set")
+	assert.Assert(t, dinf.DefaultRuntime != "", "expected info.DefaultRuntime to be set")
+}
+
+func testInfoNerdctlComparator(stdout string, t tig.T) {
+	testInfoComparator(stdout, t)
+	var dinf dockercompat.Info
+	err := json.Unmarshal([]byte(stdout), &dinf)
+	assert.NilError(t, err, "failed to unmarshal stdout")
+	assert.Assert(t, len(dinf.Plugins.Storage) > 0, "expected info.Plugins.Storage to be set")
+	assert.Assert(t, dinf.CgroupDriver != "", "expected info.CgroupDriver to be set")
+	_, ok := dinf.Runtimes[dinf.DefaultRuntime]
+	assert.Assert(t, ok, fmt.Sprintf("expected info.Runtimes (%v) to contain %q", dinf.Runtimes, dinf.DefaultRuntime))
+	assert.Assert(t, dinf.CNI != nil && dinf.CNI.Path != "", "expected info.CNI.Path to be set")
+	assert.Assert(t, len(dinf.CNI.Plugins) > 0, "expected info.CNI.Plugins to be set")
+	assert.Assert(t, dinf.NerdctlRegistryConfig != nil, "expected info.NerdctlRegistryConfig to be set")
+	assert.Equal(t, dinf.Rootless, rootlessutil.IsRootless())
 }
 
 func TestInfo(t *testing.T) {
@@ -62,6 +83,12 @@ func TestInfo(t *testing.T) {
 			Command:     test.Command("info", "--format", "json"),
 			Expected:    test.Expects(0, nil, testInfoComparator),
 		},
+		{
+			Description: "info nerdctl extensions",
+			Require:     require.All(require.Linux, require.Not(nerdtest.Docker)),
+			Command:     test.Command("info", "--format", "json"),
+			Expected:    test.Expects(0, nil, testInfoNerdctlComparator),
+		},
 		{
 			Description: "info with namespace",
 			Require:     require.Not(nerdtest.Docker),
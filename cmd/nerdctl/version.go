@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"text/template"
 
 	"github.com/spf13/cobra"
@@ -95,27 +96,32 @@ func versionAction(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(w, " OS/Arch:\t%s/%s\n", v.Client.Os, v.Client.Arch)
 		fmt.Fprintf(w, " Git commit:\t%s\n", v.Client.GitCommit)
 		for _, compo := range v.Client.Components {
-			fmt.Fprintf(w, " %s:\n", compo.Name)
-			fmt.Fprintf(w, "  Version:\t%s\n", compo.Version)
-			for detailK, detailV := range compo.Details {
-				fmt.Fprintf(w, "  %s:\t%s\n", detailK, detailV)
-			}
+			printComponentVersion(w, compo)
 		}
 		if v.Server != nil {
 			fmt.Fprintln(w)
 			fmt.Fprintln(w, "Server:")
 			for _, compo := range v.Server.Components {
-				fmt.Fprintf(w, " %s:\n", compo.Name)
-				fmt.Fprintf(w, "  Version:\t%s\n", compo.Version)
-				for detailK, detailV := range compo.Details {
-					fmt.Fprintf(w, "  %s:\t%s\n", detailK, detailV)
-				}
+				printComponentVersion(w, compo)
 			}
 		}
 	}
 	return vErr
 }
 
+func printComponentVersion(w io.Writer, compo dockercompat.ComponentVersion) {
+	fmt.Fprintf(w, " %s:\n", compo.Name)
+	fmt.Fprintf(w, "  Version:\t%s\n", compo.Version)
+	detailKeys := make([]string, 0, len(compo.Details))
+	for k := range compo.Details {
+		detailKeys = append(detailKeys, k)
+	}
+	sort.Strings(detailKeys)
+	for _, k := range detailKeys {
+		fmt.Fprintf(w, "  %s:\t%s\n", k, compo.Details[k])
+	}
+}
+
 // versionInfo may return partial VersionInfo on error.
 // Address can be empty to skip inspecting the server.
 func versionInfo(cmd *cobra.Command, ns, address string) (dockercompat.VersionInfo, error) {
@@ -0,0 +1,73 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package main
+
+import (
+	"runtime"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/nerdctl/mod/tigron/expect"
+	"github.com/containerd/nerdctl/mod/tigron/require"
+	"github.com/containerd/nerdctl/mod/tigron/test"
+	"github.com/containerd/nerdctl/mod/tigron/tig"
+
+	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/dockercompat"
+	"github.com/containerd/nerdctl/v2/pkg/testutil/nerdtest"
+)
+
+func componentNames(t tig.T, components []dockercompat.ComponentVersion) []string {
+	var names []string
+	for _, c := range components {
+		assert.Assert(t, c.Version != "", "expected the version of %q to be set", c.Name)
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func TestVersion(t *testing.T) {
+	testCase := nerdtest.Setup()
+
+	testCase.SubTests = []*test.Case{
+		{
+			Description: "version",
+			Command:     test.Command("version"),
+			Expected:    test.Expects(0, nil, expect.Contains("Client:", "Server:", "Version:")),
+		},
+		{
+			Description: "version json",
+			Require:     require.Not(nerdtest.Docker),
+			Command:     test.Command("version", "--format", "json"),
+			Expected: test.Expects(0, nil, expect.JSON(dockercompat.VersionInfo{}, func(v dockercompat.VersionInfo, t tig.T) {
+				assert.Assert(t, v.Client.Version != "")
+				assert.Equal(t, v.Client.Os, runtime.GOOS)
+				assert.Equal(t, v.Client.Arch, runtime.GOARCH)
+				assert.DeepEqual(t, componentNames(t, v.Client.Components), []string{"buildctl"})
+				assert.Assert(t, v.Server != nil, "expected the server version to be set")
+				assert.DeepEqual(t, componentNames(t, v.Server.Components), []string{"containerd", "runc"})
+			})),
+		},
+		{
+			Description: "version template",
+			Command:     test.Command("version", "--format", "{{.Client.Os}}/{{.Client.Arch}}"),
+			Expected:    test.Expects(0, nil, expect.Equals(runtime.GOOS+"/"+runtime.GOARCH+"\n")),
+		},
+	}
+
+	testCase.Run(t)
+}
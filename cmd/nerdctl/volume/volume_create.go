@@ -18,6 +18,7 @@ package volume
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/containerd/nerdctl/v2/cmd/nerdctl/helpers"
 	"github.com/containerd/nerdctl/v2/pkg/api/types"
 	"github.com/containerd/nerdctl/v2/pkg/cmd/volume"
+	"github.com/containerd/nerdctl/v2/pkg/mountutil/volumestore"
 )
 
 func createCommand() *cobra.Command {
@@ -38,6 +40,8 @@ func createCommand() *cobra.Command {
 		SilenceErrors: true,
 	}
 	cmd.Flags().StringArray("label", nil, "Set a label on the volume")
+	cmd.Flags().StringP("driver", "d", volumestore.LocalDriver, "Specify volume driver name (\"local\" or the name of a volume plugin)")
+	cmd.Flags().StringArrayP("opt", "o", nil, "Set driver specific options")
 	return cmd
 }
 
@@ -56,10 +60,29 @@ func createOptions(cmd *cobra.Command) (types.VolumeCreateOptions, error) {
 		}
 	}
 
+	driver, err := cmd.Flags().GetString("driver")
+	if err != nil {
+		return types.VolumeCreateOptions{}, err
+	}
+	opts, err := cmd.Flags().GetStringArray("opt")
+	if err != nil {
+		return types.VolumeCreateOptions{}, err
+	}
+	driverOpts := make(map[string]string, len(opts))
+	for _, opt := range opts {
+		k, v, ok := strings.Cut(opt, "=")
+		if !ok || k == "" {
+			return types.VolumeCreateOptions{}, fmt.Errorf("invalid volume option %q, must be key=value (%w)", opt, errdefs.ErrInvalidArgument)
+		}
+		driverOpts[k] = v
+	}
+
 	return types.VolumeCreateOptions{
-		GOptions: globalOptions,
-		Labels:   labels,
-		Stdout:   cmd.OutOrStdout(),
+		GOptions:   globalOptions,
+		Labels:     labels,
+		Driver:     driver,
+		DriverOpts: driverOpts,
+		Stdout:     cmd.OutOrStdout(),
 	}, nil
 }
 
@@ -72,7 +95,7 @@ func createAction(cmd *cobra.Command, args []string) error {
 	if len(args) > 0 {
 		volumeName = args[0]
 	}
-	_, err = volume.Create(volumeName, options)
+	_, err = volume.Create(cmd.Context(), volumeName, options)
 
 	return err
 }
@@ -23,6 +23,7 @@ import (
 
 	"github.com/containerd/errdefs"
 	"github.com/containerd/nerdctl/mod/tigron/expect"
+	"github.com/containerd/nerdctl/mod/tigron/require"
 	"github.com/containerd/nerdctl/mod/tigron/test"
 
 	"github.com/containerd/nerdctl/v2/pkg/testutil/nerdtest"
@@ -47,6 +48,17 @@ func TestVolumeCreate(t *testing.T) {
 			Command:     test.Command("volume", "create", "too", "many"),
 			Expected:    test.Expects(1, []error{errors.New("at most 1 arg")}, nil),
 		},
+		{
+			Description: "unknown driver should fail",
+			Command:     test.Command("volume", "create", "--driver", "unknown-driver"),
+			Expected:    test.Expects(1, []error{errdefs.ErrNotFound}, nil),
+		},
+		{
+			Description: "options with the local driver should fail",
+			Require:     require.Not(nerdtest.Docker),
+			Command:     test.Command("volume", "create", "--driver", "local", "-o", "foo=bar"),
+			Expected:    test.Expects(1, []error{errdefs.ErrInvalidArgument}, nil),
+		},
 		{
 			Description: "success",
 			Command: func(data test.Data, helpers test.Helpers) test.TestableCommand {
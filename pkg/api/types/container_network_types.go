@@ -30,10 +30,14 @@ type NetworkOptions struct {
 	IPAddress string
 	// IP6Address set specific static IP6 address(es) to use
 	IP6Address string
+	// LinkLocalIPs set IPv4/IPv6 link-local addresses to add to the container interface
+	LinkLocalIPs []string
 	// Hostname set container host name
 	Hostname string
 	// Domainname specifies the container's domain name
 	Domainname string
+	// NetworkAliases set network-scoped aliases for the container
+	NetworkAliases []string
 	// DNSServers set custom DNS servers
 	DNSServers []string
 	// DNSResolvConfOptions set DNS options
@@ -104,12 +104,15 @@ type ContainerCreateOptions struct {
 	// #region for platform flags
 	// Platform set target platform for build (e.g., "amd64", "arm64", "windows", "freebsd")
 	Platform string
+	// AllowMissingEmulation skips the check that the host can emulate Platform
+	AllowMissingEmulation bool
 	// #endregion
 
 	// #region for init process flags
 	// InitProcessFlag specifies to run an init inside the container that forwards signals and reaps processes
 	InitProcessFlag bool
-	// InitBinary specifies the custom init binary to use, default is tini
+	// InitBinary specifies the custom init binary to use.
+	// When empty, the default_init_binary of nerdctl.toml is used, or tini is searched.
 	InitBinary *string
 	// #endregion
 
@@ -153,6 +156,8 @@ type ContainerCreateOptions struct {
 	KernelMemory string
 	// OomKillDisable specifies whether to disable OOM Killer
 	OomKillDisable bool
+	// OomGroup specifies whether to treat the container cgroup as a single unit for the OOM killer (memory.oom.group, cgroup v2 only)
+	OomGroup bool
 	// OomScoreAdjChanged specifies whether the OOM preferences has been changed
 	OomScoreAdjChanged bool
 	// OomScoreAdj specifies the tune container's OOM preferences (-1000 to 1000, rootless: 100 to 1000)
@@ -216,6 +221,9 @@ type ContainerCreateOptions struct {
 	// #region for runtime flags
 	// Runtime to use for this container, e.g. "crun", or "io.containerd.runsc.v1".
 	Runtime string
+	// RuntimeOpts are runtime-specific options (key=value), e.g. "binary_name=/usr/local/bin/crun" for runc,
+	// or "platform=ptrace" for gVisor
+	RuntimeOpts []string
 	// Sysctl set sysctl options, e.g "net.ipv4.ip_forward=1"
 	Sysctl []string
 	// #endregion
@@ -229,6 +237,8 @@ type ContainerCreateOptions struct {
 	Mount []string
 	// VolumesFrom specifies a list of specified containers to mount from
 	VolumesFrom []string
+	// VolumeDriver is the driver of the volumes created for the container
+	VolumeDriver string
 	// #endregion
 
 	// #region for rootfs flags
@@ -300,12 +310,13 @@ type ContainerCreateOptions struct {
 	ImagePullOpt ImagePullOptions
 
 	// Healthcheck related fields
-	HealthCmd         string
-	HealthInterval    time.Duration
-	HealthTimeout     time.Duration
-	HealthRetries     int
-	HealthStartPeriod time.Duration
-	NoHealthcheck     bool
+	HealthCmd           string
+	HealthInterval      time.Duration
+	HealthTimeout       time.Duration
+	HealthRetries       int
+	HealthStartPeriod   time.Duration
+	HealthStartInterval time.Duration
+	NoHealthcheck       bool
 
 	// UserNS name for user namespace mapping of container
 	UserNS string
@@ -541,6 +552,10 @@ type ContainerCpOptions struct {
 	SrcPath string
 	// Follow symbolic links in SRC_PATH
 	FollowSymLink bool
+	// Stdin is the tar archive extracted into DestPath when SrcPath is "-".
+	Stdin io.Reader
+	// Stdout receives the tar archive of SrcPath when DestPath is "-".
+	Stdout io.Writer
 }
 
 // ContainerStatsOptions specifies options for `nerdctl stats`.
@@ -558,3 +573,15 @@ type ContainerStatsOptions struct {
 	// Do not truncate output.
 	NoTrunc bool
 }
+
+// ContainerHealthCheckOptions specifies options for `nerdctl container healthcheck`.
+type ContainerHealthCheckOptions struct {
+	// GOptions is the global options.
+	GOptions GlobalCommandOptions
+	// Scheduled specifies whether the health check is run by the healthcheck timer.
+	Scheduled bool
+	// NerdctlCmd is the command name of nerdctl
+	NerdctlCmd string
+	// NerdctlArgs is the arguments of nerdctl
+	NerdctlArgs []string
+}
@@ -232,6 +232,22 @@ type ImagePullOptions struct {
 	IPFSAddress string
 	// Flags to pass into remote snapshotters
 	RFlags RemoteSnapshotterFlags
+	// AllowNondistributableArtifacts fetches non-distributable blobs from the registry, falling back to their foreign URLs
+	AllowNondistributableArtifacts bool
+	// IncludeChunks fetches only the chunks of zstd:chunked layers that are missing from the local zstd:chunked layers
+	IncludeChunks bool
+}
+
+// ImageListReferrersOptions specifies options for `nerdctl image list-referrers`.
+type ImageListReferrersOptions struct {
+	Stdout   io.Writer
+	GOptions GlobalCommandOptions
+	// ArtifactTypes only lists the referrers with one of these artifact types
+	ArtifactTypes []string
+	// Format the output using the given Go template, e.g, 'json'
+	Format string
+	// Quiet only shows the digests of the referrers
+	Quiet bool
 }
 
 // ImageTagOptions specifies options for `nerdctl (image) tag`.
@@ -30,4 +30,12 @@ type LoginCommandOptions struct {
 	//
 	// If it's empty, the user will be prompted to provide it.
 	Password string
+	// TLSCACert is the path to a CA certificate used to verify the registry, on top of hosts.toml.
+	TLSCACert string
+	// TLSCert is the path to the TLS client certificate presented to the registry.
+	TLSCert string
+	// TLSKey is the path to the key of TLSCert.
+	TLSKey string
+	// TLSSkipVerify skips verifying the registry certificate.
+	TLSSkipVerify bool
 }
@@ -39,6 +39,10 @@ type SystemEventsOptions struct {
 	Format string
 	// Filter events based on given conditions
 	Filters []string
+	// Show events created since the given timestamp or relative time (e.g. 42m)
+	Since string
+	// Stream events until the given timestamp or relative time (e.g. 42m)
+	Until string
 }
 
 // SystemPruneOptions specifies options for `nerdctl system prune`.
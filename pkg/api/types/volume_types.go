@@ -24,6 +24,10 @@ type VolumeCreateOptions struct {
 	GOptions GlobalCommandOptions
 	// Labels are the volume labels
 	Labels []string
+	// Driver is the name of the volume driver, either "local" or a volume plugin
+	Driver string
+	// DriverOpts are the options passed to the volume plugin
+	DriverOpts map[string]string
 }
 
 // VolumeInspectOptions specifies options for `nerdctl volume inspect`.
@@ -24,7 +24,10 @@
 package buildkitutil
 
 import (
+	"archive/tar"
+	"bufio"
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -33,10 +36,13 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"slices"
 	"strings"
 
+	securejoin "github.com/cyphar/filepath-securejoin"
+
 	"github.com/containerd/log"
 
 	"github.com/containerd/nerdctl/v2/pkg/internal/filesystem"
@@ -183,6 +189,199 @@ func WriteTempDockerfile(rc io.Reader) (dockerfileDir string, err error) {
 	return dockerfileDir, nil
 }
 
+// WriteTempContext reads a build context passed as `-` from rc.
+// Like `docker build -`, the input may either be a (optionally gzip-compressed) tar archive,
+// which is extracted as the build context, or a single Dockerfile, in which case the
+// build context is empty.
+func WriteTempContext(rc io.Reader) (contextDir string, err error) {
+	br := bufio.NewReader(rc)
+	magic, err := br.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	var r io.Reader = br
+	compressed := bytes.Equal(magic, []byte{0x1f, 0x8b})
+	if compressed {
+		gzr, err := gzip.NewReader(br)
+		if err != nil {
+			return "", err
+		}
+		defer gzr.Close()
+		br = bufio.NewReader(gzr)
+		r = br
+	}
+	// A tar header is always 512 bytes long
+	header, _ := br.Peek(512)
+	if !compressed && !isTarHeader(header) {
+		return WriteTempDockerfile(r)
+	}
+
+	contextDir, err = os.MkdirTemp("", TempDockerfileName)
+	if err != nil {
+		return "", fmt.Errorf("unable to create temporary context directory: %v", err)
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(contextDir)
+		}
+	}()
+	if err := untar(r, contextDir); err != nil {
+		return "", fmt.Errorf("failed to extract build context from stdin: %w", err)
+	}
+	return contextDir, nil
+}
+
+func isTarHeader(header []byte) bool {
+	if len(header) < 512 {
+		return false
+	}
+	_, err := tar.NewReader(bytes.NewReader(header)).Next()
+	return err == nil
+}
+
+// untar extracts the regular files, directories and links of the tar stream r into dir.
+// Entries are resolved with securejoin so that they cannot escape dir.
+func untar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		target, err := securejoin.SecureJoin(dir, hdr.Name)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, hdr.FileInfo().Mode().Perm()|0o700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, hdr.FileInfo().Mode().Perm())
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.Symlink(hdr.Linkname, target); err != nil {
+				return err
+			}
+		case tar.TypeLink:
+			source, err := securejoin.SecureJoin(dir, hdr.Linkname)
+			if err != nil {
+				return err
+			}
+			if err := os.Link(source, target); err != nil {
+				return err
+			}
+		default:
+			log.L.Debugf("ignoring unsupported tar entry %q (type %q) in build context", hdr.Name, hdr.Typeflag)
+		}
+	}
+}
+
+// DockerfileStages returns the names of the named build stages (`FROM <image> AS <name>`) of a Dockerfile, in order.
+// Like BuildKit, the names are lowercased.
+func DockerfileStages(dockerfile []byte) []string {
+	var (
+		stages []string
+		line   string
+	)
+	for _, l := range strings.Split(string(dockerfile), "\n") {
+		l = strings.TrimSpace(l)
+		if strings.HasPrefix(l, "#") {
+			continue
+		}
+		// Join the lines continued with a backslash
+		if cont, ok := strings.CutSuffix(l, "\\"); ok {
+			line += cont + " "
+			continue
+		}
+		line += l
+		fields := strings.Fields(line)
+		line = ""
+		if len(fields) == 0 || !strings.EqualFold(fields[0], "FROM") {
+			continue
+		}
+		// Skip the flags, such as --platform
+		args := slices.DeleteFunc(fields[1:], func(f string) bool { return strings.HasPrefix(f, "--") })
+		if len(args) == 3 && strings.EqualFold(args[1], "AS") {
+			stages = append(stages, strings.ToLower(args[2]))
+		}
+	}
+	return stages
+}
+
+var parserDirectiveRegexp = regexp.MustCompile(`^#\s*([a-zA-Z][a-zA-Z0-9]*)\s*=\s*(.+?)\s*$`)
+
+// DockerfileParserDirectives returns the parser directives (e.g. `# syntax=<frontend>`) at the top of a Dockerfile.
+// Like BuildKit, the directive names are lowercased.
+func DockerfileParserDirectives(dockerfile []byte) map[string]string {
+	directives := make(map[string]string)
+	for _, l := range strings.Split(string(dockerfile), "\n") {
+		m := parserDirectiveRegexp.FindStringSubmatch(strings.TrimSpace(l))
+		if m == nil {
+			break
+		}
+		directives[strings.ToLower(m[1])] = m[2]
+	}
+	return directives
+}
+
+// ValidateTarget returns an error when target is not the name of a build stage of the Dockerfile at path.
+// It returns false when the Dockerfile has a `syntax` or `escape` parser directive, as its syntax may differ from
+// the one parsed here: the target is then left to BuildKit, whose error can be completed with TargetNotFoundError.
+func ValidateTarget(path, target string) (bool, error) {
+	dockerfile, err := filesystem.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	directives := DockerfileParserDirectives(dockerfile)
+	if _, ok := directives["syntax"]; ok {
+		return false, nil
+	}
+	if _, ok := directives["escape"]; ok {
+		return false, nil
+	}
+	stages := DockerfileStages(dockerfile)
+	if slices.Contains(stages, strings.ToLower(target)) {
+		return true, nil
+	}
+	return true, targetNotFoundError(path, target, stages)
+}
+
+// TargetNotFoundError returns the error for target not being a build stage of the Dockerfile at path,
+// with the list of the stages of the Dockerfile, or nil if target is one of them.
+func TargetNotFoundError(path, target string) error {
+	dockerfile, err := filesystem.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	stages := DockerfileStages(dockerfile)
+	if slices.Contains(stages, strings.ToLower(target)) {
+		return nil
+	}
+	return targetNotFoundError(path, target, stages)
+}
+
+func targetNotFoundError(path, target string, stages []string) error {
+	if len(stages) == 0 {
+		return fmt.Errorf("target stage %q could not be found in %s, which has no named stages", target, path)
+	}
+	return fmt.Errorf("target stage %q could not be found in %s (available stages: %s)", target, path, strings.Join(stages, ", "))
+}
+
 // BuildKitFile returns the values for the following buildctl args
 // --localfilename=dockerfile={absDir}
 // --opt=filename={file}
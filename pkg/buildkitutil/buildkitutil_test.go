@@ -24,8 +24,13 @@
 package buildkitutil
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"gotest.tools/v3/assert"
@@ -167,3 +172,139 @@ func TestBuildKitFile(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteTempContext(t *testing.T) {
+	const dockerfile = "FROM scratch\nCOPY hello /\n"
+
+	makeTar := func(t *testing.T, compress bool, entries map[string]string) []byte {
+		var buf bytes.Buffer
+		var w io.WriteCloser = nopWriteCloser{&buf}
+		if compress {
+			w = gzip.NewWriter(&buf)
+		}
+		tw := tar.NewWriter(w)
+		for _, name := range []string{"Dockerfile", "hello", "../escape"} {
+			content, ok := entries[name]
+			if !ok {
+				continue
+			}
+			assert.NilError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+			_, err := tw.Write([]byte(content))
+			assert.NilError(t, err)
+		}
+		assert.NilError(t, tw.Close())
+		assert.NilError(t, w.Close())
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name  string
+		input []byte
+		want  map[string]string
+	}{
+		{
+			name:  "plain Dockerfile",
+			input: []byte(dockerfile),
+			want:  map[string]string{DefaultDockerfileName: dockerfile},
+		},
+		{
+			name:  "tar archive",
+			input: makeTar(t, false, map[string]string{"Dockerfile": dockerfile, "hello": "world"}),
+			want:  map[string]string{DefaultDockerfileName: dockerfile, "hello": "world"},
+		},
+		{
+			name:  "gzip-compressed tar archive",
+			input: makeTar(t, true, map[string]string{"Dockerfile": dockerfile, "hello": "world"}),
+			want:  map[string]string{DefaultDockerfileName: dockerfile, "hello": "world"},
+		},
+		{
+			name:  "tar archive with an entry escaping the context",
+			input: makeTar(t, false, map[string]string{"Dockerfile": dockerfile, "../escape": "oops"}),
+			want:  map[string]string{DefaultDockerfileName: dockerfile, "escape": "oops"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := WriteTempContext(bytes.NewReader(tt.input))
+			assert.NilError(t, err)
+			defer os.RemoveAll(dir)
+
+			entries, err := os.ReadDir(dir)
+			assert.NilError(t, err)
+			assert.Equal(t, len(entries), len(tt.want))
+			for name, content := range tt.want {
+				got, err := filesystem.ReadFile(filepath.Join(dir, name))
+				assert.NilError(t, err)
+				assert.Equal(t, string(got), content)
+			}
+		})
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+func TestDockerfileStages(t *testing.T) {
+	const dockerfile = `# syntax=docker/dockerfile:1
+FROM busybox AS Builder
+RUN echo build
+# FROM busybox AS commented
+FROM --platform=$BUILDPLATFORM busybox as test
+from busybox
+FROM \
+  busybox \
+  AS prod
+COPY --from=builder / /
+`
+	assert.DeepEqual(t, DockerfileStages([]byte(dockerfile)), []string{"builder", "test", "prod"})
+	assert.Assert(t, DockerfileStages([]byte("FROM scratch\n")) == nil)
+
+	path := filepath.Join(t.TempDir(), DefaultDockerfileName)
+	withoutDirectives := strings.TrimPrefix(dockerfile, "# syntax=docker/dockerfile:1\n")
+	assert.NilError(t, os.WriteFile(path, []byte(withoutDirectives), 0o644))
+	validateTarget := func(target string) error {
+		validated, err := ValidateTarget(path, target)
+		assert.Assert(t, validated)
+		return err
+	}
+	assert.NilError(t, validateTarget("prod"))
+	assert.NilError(t, validateTarget("BUILDER"))
+	assert.ErrorContains(t, validateTarget("prd"), `target stage "prd" could not be found`)
+	assert.ErrorContains(t, validateTarget("prd"), "(available stages: builder, test, prod)")
+
+	assert.NilError(t, os.WriteFile(path, []byte("FROM scratch\n"), 0o644))
+	assert.ErrorContains(t, validateTarget("prod"), "has no named stages")
+
+	// The target of the Dockerfiles with syntax or escape parser directives is left to BuildKit,
+	// and its error is completed with the stages
+	assert.NilError(t, os.WriteFile(path, []byte(dockerfile), 0o644))
+	validated, err := ValidateTarget(path, "prd")
+	assert.NilError(t, err)
+	assert.Assert(t, !validated)
+	assert.ErrorContains(t, TargetNotFoundError(path, "prd"), "(available stages: builder, test, prod)")
+	assert.NilError(t, TargetNotFoundError(path, "prod"))
+
+	assert.NilError(t, os.WriteFile(path, []byte("# escape=`\nFROM scratch\n"), 0o644))
+	validated, err = ValidateTarget(path, "prod")
+	assert.NilError(t, err)
+	assert.Assert(t, !validated)
+}
+
+func TestDockerfileParserDirectives(t *testing.T) {
+	const dockerfile = `#syntax=docker/dockerfile:1
+# Escape = ` + "`" + `
+# check=skip=all
+
+# syntax=ignored
+FROM scratch
+`
+	assert.DeepEqual(t, DockerfileParserDirectives([]byte(dockerfile)), map[string]string{
+		"syntax": "docker/dockerfile:1",
+		"escape": "`",
+		"check":  "skip=all",
+	})
+	assert.DeepEqual(t, DockerfileParserDirectives([]byte("# a comment\n# syntax=ignored\nFROM scratch\n")), map[string]string{})
+}
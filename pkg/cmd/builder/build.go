@@ -25,6 +25,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -63,7 +64,7 @@ func (p platformParser) DefaultSpec() platforms.Platform {
 }
 
 func Build(ctx context.Context, client *containerd.Client, options types.BuilderBuildOptions) error {
-	buildctlBinary, buildctlArgs, needsLoading, metaFile, tags, cleanup, err := generateBuildctlArgs(ctx, client, options)
+	buildctlBinary, buildctlArgs, needsLoading, metaFile, tags, cleanup, targetDockerfile, err := generateBuildctlArgs(ctx, client, options)
 	if err != nil {
 		return err
 	}
@@ -103,6 +104,13 @@ func Build(ctx context.Context, client *containerd.Client, options types.Builder
 	}
 
 	if err = buildctlCmd.Wait(); err != nil {
+		// The target of a Dockerfile with parser directives is validated by BuildKit:
+		// the bare error of buildctl is completed with the stages of the Dockerfile.
+		if targetDockerfile != "" {
+			if targetErr := buildkitutil.TargetNotFoundError(targetDockerfile, options.Target); targetErr != nil {
+				return fmt.Errorf("%w: %w", targetErr, err)
+			}
+		}
 		return err
 	}
 
@@ -128,6 +136,10 @@ func Build(ctx context.Context, client *containerd.Client, options types.Builder
 			if _, err := imageService.Create(ctx, image); err != nil {
 				// if already exists; skip.
 				if errors.Is(err, errdefs.ErrAlreadyExists) {
+					if existing, err := imageService.Get(ctx, targetRef); err == nil && existing.Target.Digest == image.Target.Digest {
+						// already created by BuildKit
+						continue
+					}
 					if err = imageService.Delete(ctx, targetRef, images.SynchronousDelete()); err != nil {
 						return err
 					}
@@ -194,23 +206,38 @@ func loadImage(ctx context.Context, in io.Reader, namespace, address, snapshotte
 	return nil
 }
 
+// parseTags normalizes the --tag values, so that invalid references fail before building.
+func parseTags(rawTags []string) ([]string, error) {
+	tags := strutil.DedupeStrSlice(rawTags)
+	for idx, tag := range tags {
+		parsedReference, err := referenceutil.Parse(tag)
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument %q for \"-t, --tag\" flag: %w", tag, err)
+		}
+		tags[idx] = parsedReference.String()
+	}
+	return tags, nil
+}
+
+// generateBuildctlArgs returns the buildctl command to run.
+// targetDockerfile is the path of the local Dockerfile when its target could not be validated beforehand.
 func generateBuildctlArgs(ctx context.Context, client *containerd.Client, options types.BuilderBuildOptions) (buildCtlBinary string,
-	buildctlArgs []string, needsLoading bool, metaFile string, tags []string, cleanup func(), err error) {
+	buildctlArgs []string, needsLoading bool, metaFile string, tags []string, cleanup func(), targetDockerfile string, err error) {
 
 	buildctlBinary, err := buildkitutil.BuildctlBinary()
 	if err != nil {
-		return "", nil, false, "", nil, nil, err
+		return "", nil, false, "", nil, nil, "", err
 	}
 
 	output := options.Output
 	if output == "" {
 		info, err := client.Server(ctx)
 		if err != nil {
-			return "", nil, false, "", nil, nil, err
+			return "", nil, false, "", nil, nil, "", err
 		}
 		sharable, err := isImageSharable(options.BuildKitHost, options.GOptions.Namespace, info.UUID, options.GOptions.Snapshotter, options.Platform)
 		if err != nil {
-			return "", nil, false, "", nil, nil, err
+			return "", nil, false, "", nil, nil, "", err
 		}
 		if sharable {
 			output = "type=image,unpack=true" // ensure the target stage is unlazied (needed for any snapshotters)
@@ -235,25 +262,28 @@ func generateBuildctlArgs(ctx context.Context, client *containerd.Client, option
 			}
 		}
 	}
-	if tags = strutil.DedupeStrSlice(options.Tag); len(tags) > 0 {
-		ref := tags[0]
-		parsedReference, err := referenceutil.Parse(ref)
-		if err != nil {
-			return "", nil, false, "", nil, nil, err
-		}
-		output += ",name=" + parsedReference.String()
+	if tags, err = parseTags(options.Tag); err != nil {
+		return "", nil, false, "", nil, nil, "", err
+	} else if len(tags) > 0 {
+		// BuildKit creates (or pushes) all the names of the image in one build.
+		// The names are comma-separated, so the field is quoted as a CSV value.
+		output += `,"name=` + strings.Join(tags, ",") + `"`
+	} else {
+		output = output + ",dangling-name-prefix=<none>"
+	}
 
-		// pick the first tag and add it to output
-		for idx, tag := range tags {
-			parsedReference, err = referenceutil.Parse(tag)
-			if err != nil {
-				return "", nil, false, "", nil, nil, err
-			}
-			tags[idx] = parsedReference.String()
+	var tempDirs []string
+	removeTempDirs := func() {
+		for _, d := range tempDirs {
+			os.RemoveAll(d)
 		}
-	} else if len(tags) == 0 {
-		output = output + ",dangling-name-prefix=<none>"
 	}
+	defer func() {
+		if err != nil {
+			removeTempDirs()
+		}
+	}()
+	cleanup = removeTempDirs
 
 	buildctlArgs = buildkitutil.BuildctlBaseArgs(options.BuildKitHost)
 
@@ -261,39 +291,69 @@ func generateBuildctlArgs(ctx context.Context, client *containerd.Client, option
 		"build",
 		"--progress=" + options.Progress,
 		"--frontend=dockerfile.v0",
-		"--local=context=" + options.BuildContext,
-		"--output=" + output,
 	}...)
 
-	dir := options.BuildContext
+	buildContext := options.BuildContext
+	contextKind := detectBuildContextKind(buildContext)
+	switch {
+	case buildContext == "-":
+		if options.File == "-" {
+			return "", nil, false, "", nil, nil, "", errors.New("invalid argument: can't use stdin for both build context and dockerfile")
+		}
+		// `nerdctl build -` reads either a tar archive or a single Dockerfile from stdin
+		buildContext, err = buildkitutil.WriteTempContext(options.Stdin)
+		if err != nil {
+			return "", nil, false, "", nil, nil, "", err
+		}
+		tempDirs = append(tempDirs, buildContext)
+		buildctlArgs = append(buildctlArgs, "--local=context="+buildContext)
+	case contextKind != localBuildContext:
+		// Git repositories and HTTP(S) tarballs or Dockerfiles are fetched by BuildKit itself
+		buildctlArgs = append(buildctlArgs, "--opt=context="+buildContext)
+	default:
+		buildctlArgs = append(buildctlArgs, "--local=context="+buildContext)
+	}
+	buildctlArgs = append(buildctlArgs, "--output="+output)
+
+	// For remote contexts, the Dockerfile is read from the context by BuildKit, unless passed via stdin
+	localDockerfile := contextKind == localBuildContext || options.File == "-"
+	dir := buildContext
 	file := buildkitutil.DefaultDockerfileName
-	if options.File != "" {
+	if !localDockerfile {
+		if options.File != "" {
+			file = options.File
+		}
+	} else if options.File != "" {
 		if options.File == "-" {
 			// Super Warning: this is a special trick to update the dir variable, Don't move this line!!!!!!
-			var err error
 			dir, err = buildkitutil.WriteTempDockerfile(options.Stdin)
 			if err != nil {
-				return "", nil, false, "", nil, nil, err
-			}
-			cleanup = func() {
-				os.RemoveAll(dir)
+				return "", nil, false, "", nil, nil, "", err
 			}
+			tempDirs = append(tempDirs, dir)
 		} else {
-			dir, file = filepath.Split(options.File)
+			if options.BuildContext == "-" && !filepath.IsAbs(options.File) {
+				// Like Docker, the Dockerfile path is relative to the context read from stdin
+				dir, file = filepath.Split(filepath.Join(buildContext, options.File))
+			} else {
+				dir, file = filepath.Split(options.File)
+			}
 		}
 
 		if dir == "" {
 			dir = "."
 		}
 	}
-	dir, file, err = buildkitutil.BuildKitFile(dir, file)
-	if err != nil {
-		return "", nil, false, "", nil, nil, err
+	if localDockerfile {
+		dir, file, err = buildkitutil.BuildKitFile(dir, file)
+		if err != nil {
+			return "", nil, false, "", nil, nil, "", err
+		}
 	}
 
 	buildCtx, err := parseContextNames(options.ExtendedBuildContext)
 	if err != nil {
-		return "", nil, false, "", nil, nil, err
+		return "", nil, false, "", nil, nil, "", err
 	}
 
 	for k, v := range buildCtx {
@@ -308,7 +368,7 @@ func generateBuildctlArgs(ctx context.Context, client *containerd.Client, option
 		if isOCILayout := strings.HasPrefix(v, "oci-layout://"); isOCILayout {
 			args, err := parseBuildContextFromOCILayout(k, v)
 			if err != nil {
-				return "", nil, false, "", nil, nil, err
+				return "", nil, false, "", nil, nil, "", err
 			}
 
 			buildctlArgs = append(buildctlArgs, args...)
@@ -317,16 +377,30 @@ func generateBuildctlArgs(ctx context.Context, client *containerd.Client, option
 
 		path, err := filepath.Abs(v)
 		if err != nil {
-			return "", nil, false, "", nil, nil, err
+			return "", nil, false, "", nil, nil, "", err
 		}
 		buildctlArgs = append(buildctlArgs, fmt.Sprintf("--local=%s=%s", k, path))
 		buildctlArgs = append(buildctlArgs, fmt.Sprintf("--opt=context:%s=local:%s", k, k))
 	}
 
-	buildctlArgs = append(buildctlArgs, "--local=dockerfile="+dir)
+	if localDockerfile {
+		buildctlArgs = append(buildctlArgs, "--local=dockerfile="+dir)
+	}
 	buildctlArgs = append(buildctlArgs, "--opt=filename="+file)
 
 	if options.Target != "" {
+		// Fail early with the list of the stages, rather than with the bare error of BuildKit.
+		// Remote Dockerfiles are validated by BuildKit alone.
+		if localDockerfile {
+			path := filepath.Join(dir, file)
+			validated, err := buildkitutil.ValidateTarget(path, options.Target)
+			if err != nil {
+				return "", nil, false, "", nil, nil, "", err
+			}
+			if !validated {
+				targetDockerfile = path
+			}
+		}
 		buildctlArgs = append(buildctlArgs, "--opt=target="+options.Target)
 	}
 
@@ -364,7 +438,7 @@ func generateBuildctlArgs(ctx context.Context, client *containerd.Client, option
 				}
 			}
 		} else {
-			return "", nil, false, "", nil, nil, fmt.Errorf("invalid build arg %q", ba)
+			return "", nil, false, "", nil, nil, "", fmt.Errorf("invalid build arg %q", ba)
 		}
 	}
 
@@ -407,7 +481,7 @@ func generateBuildctlArgs(ctx context.Context, client *containerd.Client, option
 			if strings.HasPrefix(optAttestAttrs, "disabled=") {
 				disabled, err := strconv.ParseBool(strings.TrimPrefix(optAttestAttrs, "disabled="))
 				if err != nil {
-					return "", nil, false, "", nil, nil, fmt.Errorf("invalid value for attribute \"disabled\"")
+					return "", nil, false, "", nil, nil, "", fmt.Errorf("invalid value for attribute \"disabled\"")
 				}
 				if disabled {
 					continue
@@ -416,11 +490,16 @@ func generateBuildctlArgs(ctx context.Context, client *containerd.Client, option
 			optAttestType := strings.TrimPrefix(optAttestType, "type=")
 			buildctlArgs = append(buildctlArgs, fmt.Sprintf("--opt=attest:%s=%s", optAttestType, optAttestAttrs))
 		} else {
-			return "", nil, false, "", nil, nil, fmt.Errorf("attestation type not specified")
+			return "", nil, false, "", nil, nil, "", fmt.Errorf("attestation type not specified")
 		}
 	}
 
-	for _, s := range strutil.DedupeStrSlice(options.SSH) {
+	ssh := strutil.DedupeStrSlice(options.SSH)
+	if len(ssh) == 0 && contextKind == gitSSHBuildContext && os.Getenv("SSH_AUTH_SOCK") != "" {
+		// Forward the SSH agent for cloning private repositories, as `docker buildx build` does
+		ssh = []string{"default"}
+	}
+	for _, s := range ssh {
 		buildctlArgs = append(buildctlArgs, "--ssh="+s)
 	}
 
@@ -445,7 +524,7 @@ func generateBuildctlArgs(ctx context.Context, client *containerd.Client, option
 	if options.IidFile != "" {
 		file, err := os.CreateTemp("", "buildkit-meta-*")
 		if err != nil {
-			return "", nil, false, "", nil, cleanup, err
+			return "", nil, false, "", nil, cleanup, "", err
 		}
 		defer file.Close()
 		metaFile = file.Name()
@@ -467,12 +546,12 @@ func generateBuildctlArgs(ctx context.Context, client *containerd.Client, option
 	if len(options.ExtraHosts) > 0 {
 		extraHosts, err := containerutil.ParseExtraHosts(options.ExtraHosts, options.GOptions.HostGatewayIP, "=")
 		if err != nil {
-			return "", nil, false, "", nil, nil, err
+			return "", nil, false, "", nil, nil, "", err
 		}
 		buildctlArgs = append(buildctlArgs, "--opt=add-hosts="+strings.Join(extraHosts, ","))
 	}
 
-	return buildctlBinary, buildctlArgs, needsLoading, metaFile, tags, cleanup, nil
+	return buildctlBinary, buildctlArgs, needsLoading, metaFile, tags, cleanup, targetDockerfile, nil
 }
 
 func getDigestFromMetaFile(path string) (string, error) {
@@ -552,6 +631,38 @@ func isImageSharable(buildkitHost, namespace, uuid, snapshotter string, platform
 	return executor == "containerd" && containerdUUID == uuid && containerdNamespace == namespace && workerSnapshotter == snapshotter && isBuildPlatformDefault(platform, parser), nil
 }
 
+type buildContextKind int
+
+const (
+	localBuildContext buildContextKind = iota
+	// gitBuildContext is a Git repository, optionally suffixed with `#ref:subdir`
+	gitBuildContext
+	// gitSSHBuildContext is a Git repository cloned over SSH
+	gitSSHBuildContext
+	// urlBuildContext is a tarball or a plain Dockerfile fetched over HTTP(S)
+	urlBuildContext
+)
+
+var gitSuffixRegexp = regexp.MustCompile(`\.git(?:#.+)?$`)
+
+// detectBuildContextKind tells remote build contexts apart from local directories,
+// following the rules of Docker's `urlutil.IsGitURL` and `urlutil.IsURL`.
+func detectBuildContextKind(buildContext string) buildContextKind {
+	switch {
+	case strings.HasPrefix(buildContext, "git@"), strings.HasPrefix(buildContext, "ssh://"):
+		return gitSSHBuildContext
+	case strings.HasPrefix(buildContext, "git://"), strings.HasPrefix(buildContext, "github.com/"):
+		return gitBuildContext
+	case strings.HasPrefix(buildContext, "http://"), strings.HasPrefix(buildContext, "https://"):
+		if gitSuffixRegexp.MatchString(buildContext) {
+			return gitBuildContext
+		}
+		return urlBuildContext
+	default:
+		return localBuildContext
+	}
+}
+
 func parseContextNames(values []string) (map[string]string, error) {
 	if len(values) == 0 {
 		return nil, nil
@@ -238,3 +238,42 @@ func TestParseBuildctlArgsForOCILayout(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectBuildContextKind(t *testing.T) {
+	tests := []struct {
+		buildContext string
+		want         buildContextKind
+	}{
+		{".", localBuildContext},
+		{"/path/to/context", localBuildContext},
+		{"github.com.local", localBuildContext},
+		{"https://github.com/containerd/nerdctl.git", gitBuildContext},
+		{"https://github.com/containerd/nerdctl.git#main:examples", gitBuildContext},
+		{"http://example.com/repo.git#v1.0.0", gitBuildContext},
+		{"git://github.com/containerd/nerdctl#main", gitBuildContext},
+		{"github.com/containerd/nerdctl#main:examples", gitBuildContext},
+		{"git@github.com:containerd/nerdctl.git#main", gitSSHBuildContext},
+		{"ssh://git@github.com/containerd/nerdctl.git", gitSSHBuildContext},
+		{"https://example.com/context.tar.gz", urlBuildContext},
+		{"https://example.com/Dockerfile", urlBuildContext},
+		{"http://example.com/git/Dockerfile", urlBuildContext},
+	}
+	for _, tt := range tests {
+		t.Run(tt.buildContext, func(t *testing.T) {
+			assert.Equal(t, detectBuildContextKind(tt.buildContext), tt.want)
+		})
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	tags, err := parseTags([]string{"a:1", "a:latest", "reg.example.com/a:1", "a:1"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, tags, []string{"docker.io/library/a:1", "docker.io/library/a:latest", "reg.example.com/a:1"})
+
+	tags, err = parseTags([]string{"a"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, tags, []string{"docker.io/library/a:latest"})
+
+	_, err = parseTags([]string{"a:1", "A:1"})
+	assert.ErrorContains(t, err, `invalid argument "A:1" for "-t, --tag" flag`)
+}
@@ -87,7 +87,9 @@ func New(client *containerd.Client, globalOptions types.GlobalCommandOptions, op
 		return nil, err
 	}
 	// FIXME: this is racy. See note in up_volume.go
-	options.VolumeExists = volStore.Exists
+	options.VolumeExists = func(ctx context.Context, volName string) (bool, error) {
+		return volume.Exists(ctx, volStore, volName)
+	}
 
 	options.ImageExists = func(ctx context.Context, rawRef string) (bool, error) {
 		parsedReference, err := referenceutil.Parse(rawRef)
@@ -0,0 +1,42 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+const (
+	// linuxMinCPUShares and linuxMaxCPUShares are the bounds of cpu.shares on cgroup v1.
+	linuxMinCPUShares = 2
+	linuxMaxCPUShares = 262144
+
+	// linuxMinCPUWeight and linuxMaxCPUWeight are the bounds of cpu.weight on cgroup v2.
+	linuxMinCPUWeight = 1
+	linuxMaxCPUWeight = 10000
+)
+
+// CPUSharesToWeight converts the cgroup v1 cpu.shares value to the cgroup v2 cpu.weight value,
+// with the same linear conversion as Docker: weight = 1 + ((shares - 2) * 9999) / 262142.
+//
+// OCI runtimes do not agree on this conversion (runc >= 1.4 and recent crun use a quadratic one),
+// so nerdctl sets cpu.weight itself on cgroup v2 instead of letting the runtime convert the shares.
+func CPUSharesToWeight(shares uint64) uint64 {
+	if shares <= linuxMinCPUShares {
+		return linuxMinCPUWeight
+	}
+	if shares >= linuxMaxCPUShares {
+		return linuxMaxCPUWeight
+	}
+	return linuxMinCPUWeight + ((shares-linuxMinCPUShares)*(linuxMaxCPUWeight-linuxMinCPUWeight))/(linuxMaxCPUShares-linuxMinCPUShares)
+}
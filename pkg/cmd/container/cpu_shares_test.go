@@ -0,0 +1,46 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestCPUSharesToWeight(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		shares   uint64
+		expected uint64
+	}{
+		{shares: 0, expected: 1},
+		{shares: 1, expected: 1},
+		{shares: 2, expected: 1},
+		{shares: 3, expected: 1},
+		{shares: 512, expected: 20},
+		{shares: 1024, expected: 39},
+		{shares: 2048, expected: 79},
+		{shares: 131073, expected: 5000},
+		{shares: 262143, expected: 9999},
+		{shares: 262144, expected: 10000},
+		{shares: 1000000, expected: 10000},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, CPUSharesToWeight(tc.shares), tc.expected, "shares=%d", tc.shares)
+	}
+}
@@ -21,9 +21,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net/url"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"reflect"
 	"runtime"
@@ -52,6 +54,7 @@ import (
 	"github.com/containerd/nerdctl/v2/pkg/idgen"
 	"github.com/containerd/nerdctl/v2/pkg/imgutil"
 	"github.com/containerd/nerdctl/v2/pkg/imgutil/load"
+	"github.com/containerd/nerdctl/v2/pkg/infoutil"
 	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/dockercompat"
 	"github.com/containerd/nerdctl/v2/pkg/internal/filesystem"
 	"github.com/containerd/nerdctl/v2/pkg/ipcutil"
@@ -59,6 +62,8 @@ import (
 	"github.com/containerd/nerdctl/v2/pkg/logging"
 	"github.com/containerd/nerdctl/v2/pkg/maputil"
 	"github.com/containerd/nerdctl/v2/pkg/mountutil"
+	"github.com/containerd/nerdctl/v2/pkg/mountutil/volumeplugin"
+	"github.com/containerd/nerdctl/v2/pkg/mountutil/volumestore"
 	"github.com/containerd/nerdctl/v2/pkg/namestore"
 	"github.com/containerd/nerdctl/v2/pkg/netutil/networkstore"
 	"github.com/containerd/nerdctl/v2/pkg/platformutil"
@@ -71,6 +76,19 @@ import (
 
 // Create will create a container.
 func Create(ctx context.Context, client *containerd.Client, args []string, netManager containerutil.NetworkOptionsManager, options types.ContainerCreateOptions) (containerd.Container, func(), error) {
+	if options.Platform != "" && !options.AllowMissingEmulation {
+		if err := platformutil.CheckEmulation(options.Platform); err != nil {
+			return nil, nil, err
+		}
+	}
+	// The rootfs of the container is created with the snapshotter of the global `--snapshotter` flag,
+	// which can be specified per container (`nerdctl run --snapshotter`)
+	if !options.Rootfs {
+		if err := checkSnapshotter(ctx, client, options.GOptions.Snapshotter); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// Acquire an exclusive lock on the volume store until we are done to avoid being raced by any other
 	// volume operations (or any other operation involving volume manipulation)
 	volStore, err := volume.Store(options.GOptions.Namespace, options.GOptions.DataRoot, options.GOptions.Address)
@@ -232,10 +250,15 @@ func Create(ctx context.Context, client *containerd.Client, args []string, netMa
 	}
 
 	if options.Workdir != "" {
-		opts = append(opts, oci.WithProcessCwd(options.Workdir))
+		opts = append(opts, oci.WithProcessCwd(resolveWorkdir(options.Workdir, ensuredImage)))
 	}
 
-	envs, err := flagutil.MergeEnvFileAndOSEnv(options.EnvFile, options.Env)
+	envFiles, cleanupEnvFiles, err := flagutil.FetchRemoteFiles(ctx, options.EnvFile, options.GOptions.RemoteFileAuthHeader, options.GOptions.RemoteFileAuthHost)
+	defer cleanupEnvFiles()
+	if err != nil {
+		return nil, generateRemoveStateDirFunc(ctx, id, internalLabels), err
+	}
+	envs, err := flagutil.MergeEnvFileAndOSEnv(envFiles, options.Env)
 	if err != nil {
 		return nil, generateRemoveStateDirFunc(ctx, id, internalLabels), err
 	}
@@ -250,8 +273,16 @@ func Create(ctx context.Context, client *containerd.Client, args []string, netMa
 		opts = append(opts, oci.WithTTY)
 	}
 
+	// The volumes that do not exist yet are created with the volume driver (--volume-driver)
+	containerVolStore, err := volumeplugin.NewVolumeStore(ctx, volStore, options.VolumeDriver, id)
+	if err != nil {
+		return nil, generateRemoveStateDirFunc(ctx, id, internalLabels), err
+	}
+	// The plugin volumes are only mounted to know their mountpoint, they are mounted again when the container starts
+	defer volumeplugin.Unmount(ctx, containerVolStore)
+
 	var mountOpts []oci.SpecOpts
-	mountOpts, internalLabels.anonVolumes, internalLabels.mountPoints, err = generateMountOpts(ctx, client, ensuredImage, volStore, options)
+	mountOpts, internalLabels.anonVolumes, internalLabels.mountPoints, err = generateMountOpts(ctx, client, ensuredImage, containerVolStore, options)
 	if err != nil {
 		return nil, generateRemoveStateDirFunc(ctx, id, internalLabels), err
 	}
@@ -295,8 +326,16 @@ func Create(ctx context.Context, client *containerd.Client, args []string, netMa
 		return nil, generateRemoveOrphanedDirsFunc(ctx, id, dataStore, internalLabels), fmt.Errorf("failed to generate internal networking labels: %w", err)
 	}
 
-	envs = append(envs, "HOSTNAME="+netLabelOpts.Hostname)
-	opts = append(opts, oci.WithEnv(envs))
+	// HOSTNAME comes first, so that it can be overridden by the env specified by the user
+	envs = append([]string{"HOSTNAME=" + netLabelOpts.Hostname}, envs...)
+	opts = append(opts, withEnv(envs))
+	if len(options.GPUs) > 0 {
+		gpuEnvOpt, err := withGPUEnvDefaults(options.GPUs)
+		if err != nil {
+			return nil, generateRemoveOrphanedDirsFunc(ctx, id, dataStore, internalLabels), err
+		}
+		opts = append(opts, gpuEnvOpt)
+	}
 
 	internalLabels.loadNetOpts(netLabelOpts)
 
@@ -335,11 +374,14 @@ func Create(ctx context.Context, client *containerd.Client, args []string, netMa
 		opts = append(opts, withDefaultUnprivilegedPortSysctl())
 	}
 
-	rtCOpts, err := generateRuntimeCOpts(options.GOptions.CgroupManager, options.Runtime)
+	rtCOpts, rtAnnotations, err := generateRuntimeCOpts(options.GOptions.CgroupManager, options.Runtime, options.RuntimeOpts)
 	if err != nil {
 		return nil, generateRemoveOrphanedDirsFunc(ctx, id, dataStore, internalLabels), err
 	}
 	cOpts = append(cOpts, rtCOpts...)
+	if len(rtAnnotations) > 0 {
+		opts = append(opts, oci.WithAnnotations(rtAnnotations))
+	}
 
 	// Generate health check config based on CLI flags and image.
 	healthcheckConfig, err := withHealthcheck(options, ensuredImage)
@@ -350,7 +392,12 @@ func Create(ctx context.Context, client *containerd.Client, args []string, netMa
 		internalLabels.healthcheck = healthcheckConfig
 	}
 
-	lCOpts, err := withContainerLabels(options.Label, options.LabelFile, ensuredImage)
+	labelFiles, cleanupLabelFiles, err := flagutil.FetchRemoteFiles(ctx, options.LabelFile, options.GOptions.RemoteFileAuthHeader, options.GOptions.RemoteFileAuthHost)
+	defer cleanupLabelFiles()
+	if err != nil {
+		return nil, generateRemoveOrphanedDirsFunc(ctx, id, dataStore, internalLabels), err
+	}
+	lCOpts, err := withContainerLabels(options.Label, labelFiles, ensuredImage)
 	if err != nil {
 		return nil, generateRemoveOrphanedDirsFunc(ctx, id, dataStore, internalLabels), err
 	}
@@ -476,7 +523,7 @@ func generateRootfsOpts(args []string, id string, ensured *imgutil.EnsuredImage,
 		}
 		var processArgs []string
 		if len(options.Entrypoint) != 0 {
-			processArgs = append(processArgs, options.Entrypoint...)
+			processArgs = append(processArgs, parseEntrypoint(options.Entrypoint)...)
 		}
 		if len(args) > 1 {
 			processArgs = append(processArgs, args[1:]...)
@@ -530,14 +577,15 @@ func generateRootfsOpts(args []string, id string, ensured *imgutil.EnsuredImage,
 		options.InitProcessFlag = true
 	}
 	if options.InitProcessFlag {
-		binaryPath, err := exec.LookPath(*options.InitBinary)
+		var initBinary string
+		if options.InitBinary != nil {
+			initBinary = *options.InitBinary
+		}
+		binaryPath, err := resolveInitBinary(initBinary, options.GOptions.DefaultInitBinary)
 		if err != nil {
-			if errors.Is(err, exec.ErrNotFound) {
-				return nil, nil, fmt.Errorf(`init binary %q not found`, *options.InitBinary)
-			}
 			return nil, nil, err
 		}
-		inContainerPath := filepath.Join("/sbin", filepath.Base(*options.InitBinary))
+		inContainerPath := filepath.Join("/sbin", filepath.Base(binaryPath))
 		opts = append(opts, func(_ context.Context, _ oci.Client, _ *containers.Container, spec *oci.Spec) error {
 			spec.Process.Args = append([]string{inContainerPath, "--"}, spec.Process.Args...)
 			spec.Mounts = append([]specs.Mount{{
@@ -642,15 +690,51 @@ func withNerdctlOCIHook(cmd string, args []string) (oci.SpecOpts, error) {
 	}, nil
 }
 
-func withContainerLabels(label, labelFile []string, ensuredImage *imgutil.EnsuredImage) ([]containerd.NewContainerOpts, error) {
-	var opts []containerd.NewContainerOpts
+// withEnv merges envs into the process env of the spec, which already contains the image env.
+// Unlike oci.WithEnv, a key repeated in envs does not result in duplicated entries:
+// the last value wins, at the position of the first occurrence.
+func withEnv(envs []string) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		if len(envs) == 0 {
+			return nil
+		}
+		if s.Process == nil {
+			s.Process = &specs.Process{}
+		}
+		s.Process.Env = flagutil.ReplaceOrAppendEnvValues(s.Process.Env, envs)
+		return nil
+	}
+}
 
-	// add labels defined by image
-	if ensuredImage != nil {
-		imageLabelOpts := containerd.WithAdditionalContainerLabels(ensuredImage.ImageConfig.Labels)
-		opts = append(opts, imageLabelOpts)
+// parseEntrypoint returns the process args for --entrypoint.
+// A single value in the JSON array form, e.g. `["/bin/sh","-c"]`, is parsed into multiple args.
+// Any other value is used as-is, and malformed JSON falls back to the literal value.
+func parseEntrypoint(entrypoint []string) []string {
+	if len(entrypoint) != 1 || !strings.HasPrefix(strings.TrimSpace(entrypoint[0]), "[") {
+		return entrypoint
 	}
+	var args []string
+	if err := json.Unmarshal([]byte(entrypoint[0]), &args); err != nil {
+		log.L.WithError(err).Warnf("failed to parse entrypoint %q as a JSON array, using it as a literal string", entrypoint[0])
+		return entrypoint
+	}
+	return args
+}
 
+// resolveWorkdir returns the working directory of the container for --workdir.
+// Like Docker, a relative workdir is joined onto the WORKDIR of the image, while an absolute one replaces it.
+func resolveWorkdir(workdir string, ensuredImage *imgutil.EnsuredImage) string {
+	if path.IsAbs(workdir) || filepath.IsAbs(workdir) {
+		return workdir
+	}
+	base := "/"
+	if ensuredImage != nil && ensuredImage.ImageConfig.WorkingDir != "" {
+		base = ensuredImage.ImageConfig.WorkingDir
+	}
+	return path.Join(base, workdir)
+}
+
+func withContainerLabels(label, labelFile []string, ensuredImage *imgutil.EnsuredImage) ([]containerd.NewContainerOpts, error) {
 	labelMap, err := readKVStringsMapfFromLabel(label, labelFile)
 	if err != nil {
 		return nil, err
@@ -662,10 +746,20 @@ func withContainerLabels(label, labelFile []string, ensuredImage *imgutil.Ensure
 			return nil, fmt.Errorf("internal label %q must not be specified manually", k)
 		}
 	}
-	o := containerd.WithAdditionalContainerLabels(labelMap)
-	opts = append(opts, o)
+	var imageLabels map[string]string
+	if ensuredImage != nil {
+		imageLabels = ensuredImage.ImageConfig.Labels
+	}
+	return []containerd.NewContainerOpts{containerd.WithAdditionalContainerLabels(mergeContainerLabels(imageLabels, labelMap))}, nil
+}
 
-	return opts, nil
+// mergeContainerLabels returns the labels defined by the image, overridden by the labels
+// specified with `--label` and `--label-file`.
+func mergeContainerLabels(imageLabels, labelMap map[string]string) map[string]string {
+	merged := make(map[string]string, len(imageLabels)+len(labelMap))
+	maps.Copy(merged, imageLabels)
+	maps.Copy(merged, labelMap)
+	return merged
 }
 
 func readKVStringsMapfFromLabel(label, labelFile []string) (map[string]string, error) {
@@ -720,15 +814,17 @@ type internalLabels struct {
 	extraHosts []string
 	pidFile    string
 	// labels from cmd options or automatically set
-	name       string
-	hostname   string
-	domainname string
+	name           string
+	hostname       string
+	domainname     string
+	networkAliases []string
 	// automatically generated
 	stateDir string
 	// network
 	networks             []string
 	ipAddress            string
 	ip6Address           string
+	linkLocalIPs         []string
 	macAddress           string
 	dnsServers           []string
 	dnsSearchDomains     []string
@@ -769,6 +865,13 @@ func withInternalLabels(internalLabels internalLabels) (containerd.NewContainerO
 	m[labels.Name] = internalLabels.name
 	m[labels.Hostname] = internalLabels.hostname
 	m[labels.Domainname] = internalLabels.domainname
+	if len(internalLabels.networkAliases) > 0 {
+		networkAliasesJSON, err := json.Marshal(internalLabels.networkAliases)
+		if err != nil {
+			return nil, err
+		}
+		m[labels.NetworkAliases] = string(networkAliasesJSON)
+	}
 	extraHostsJSON, err := json.Marshal(internalLabels.extraHosts)
 	if err != nil {
 		return nil, err
@@ -808,6 +911,14 @@ func withInternalLabels(internalLabels internalLabels) (containerd.NewContainerO
 		m[labels.IP6Address] = internalLabels.ip6Address
 	}
 
+	if len(internalLabels.linkLocalIPs) > 0 {
+		linkLocalIPsJSON, err := json.Marshal(internalLabels.linkLocalIPs)
+		if err != nil {
+			return nil, err
+		}
+		m[labels.LinkLocalIPs] = string(linkLocalIPsJSON)
+	}
+
 	m[labels.Platform], err = platformutil.NormalizeString(internalLabels.platform)
 	if err != nil {
 		return nil, err
@@ -922,10 +1033,16 @@ func withHealthcheck(options types.ContainerCreateOptions, ensuredImage *imgutil
 	if options.HealthStartPeriod != 0 {
 		hc.StartPeriod = options.HealthStartPeriod
 	}
+	if options.HealthStartInterval != 0 {
+		hc.StartInterval = options.HealthStartInterval
+	}
 
 	// Apply defaults for any unset values, but only if we have a healthcheck configured
 	if len(hc.Test) > 0 && hc.Test[0] != "NONE" {
 		hc.ApplyDefaults()
+		if hc.StartInterval > hc.Interval {
+			return "", fmt.Errorf("health start interval (%s) must not be longer than the health interval (%s)", hc.StartInterval, hc.Interval)
+		}
 	}
 
 	// If no healthcheck config is set (via CLI or image), return empty string so we skip adding to container config.
@@ -943,8 +1060,10 @@ func withHealthcheck(options types.ContainerCreateOptions, ensuredImage *imgutil
 func (il *internalLabels) loadNetOpts(opts types.NetworkOptions) {
 	il.hostname = opts.Hostname
 	il.domainname = opts.Domainname
+	il.networkAliases = opts.NetworkAliases
 	il.ipAddress = opts.IPAddress
 	il.ip6Address = opts.IP6Address
+	il.linkLocalIPs = opts.LinkLocalIPs
 	il.networks = opts.NetworkSlice
 	il.macAddress = opts.MACAddress
 	il.dnsServers = opts.DNSServers
@@ -961,7 +1080,7 @@ func dockercompatMounts(mountPoints []*mountutil.Processed) []dockercompat.Mount
 			Name:        mp.Name,
 			Source:      mp.Mount.Source,
 			Destination: mp.Mount.Destination,
-			Driver:      "",
+			Driver:      mp.Driver,
 			Mode:        mp.Mode,
 		}
 		result[i].RW, result[i].Propagation = dockercompat.ParseMountProperties(strings.Split(mp.Mode, ","))
@@ -971,9 +1090,9 @@ func dockercompatMounts(mountPoints []*mountutil.Processed) []dockercompat.Mount
 			result[i].Name = mp.AnonymousVolume
 		}
 
-		// volume only support local driver
-		if mp.Type == "volume" {
-			result[i].Driver = "local"
+		// volumes that are not provided by a volume plugin use the local driver
+		if mp.Type == "volume" && mp.Driver == "" {
+			result[i].Driver = volumestore.LocalDriver
 		}
 	}
 	return result
@@ -1074,6 +1193,18 @@ func generateLogConfig(dataStore string, id string, logDriver string, logOpt []s
 	return logConfig, nil
 }
 
+// checkSnapshotter returns an error when the snapshotter is not available in containerd.
+func checkSnapshotter(ctx context.Context, client *containerd.Client, snapshotter string) error {
+	names, err := infoutil.GetSnapshotterNames(ctx, client.IntrospectionService())
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(names, snapshotter) {
+		return fmt.Errorf("snapshotter %q is not available (available snapshotters: %s)", snapshotter, strings.Join(names, ", "))
+	}
+	return nil
+}
+
 func generateRemoveStateDirFunc(ctx context.Context, id string, internalLabels internalLabels) func() {
 	return func() {
 		if rmErr := os.RemoveAll(internalLabels.stateDir); rmErr != nil {
@@ -0,0 +1,195 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/nerdctl/v2/pkg/api/types"
+	"github.com/containerd/nerdctl/v2/pkg/healthcheck"
+	"github.com/containerd/nerdctl/v2/pkg/imgutil"
+	"github.com/containerd/nerdctl/v2/pkg/labels"
+)
+
+func TestWithHealthcheck(t *testing.T) {
+	t.Parallel()
+	imageWithHealthcheck := func(hc string) *imgutil.EnsuredImage {
+		return &imgutil.EnsuredImage{
+			ImageConfig: ocispec.ImageConfig{Labels: map[string]string{labels.HealthCheck: hc}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		options  types.ContainerCreateOptions
+		image    *imgutil.EnsuredImage
+		expected *healthcheck.Healthcheck
+	}{
+		{
+			name:     "no healthcheck",
+			image:    &imgutil.EnsuredImage{},
+			expected: nil,
+		},
+		{
+			name:    "custom health-cmd gets the defaults",
+			options: types.ContainerCreateOptions{HealthCmd: "echo healthy", HealthRetries: 5},
+			expected: &healthcheck.Healthcheck{
+				Test:        []string{"CMD-SHELL", "echo healthy"},
+				Interval:    healthcheck.DefaultProbeInterval,
+				Timeout:     healthcheck.DefaultProbeTimeout,
+				Retries:     5,
+				StartPeriod: healthcheck.DefaultStartPeriod,
+			},
+		},
+		{
+			name:    "image healthcheck merged with run-time overrides",
+			options: types.ContainerCreateOptions{HealthInterval: 45 * time.Second, HealthStartPeriod: 5 * time.Second},
+			image:   imageWithHealthcheck(`{"Test":["CMD","true"],"Interval":30000000000,"Timeout":10000000000,"Retries":2}`),
+			expected: &healthcheck.Healthcheck{
+				Test:        []string{"CMD", "true"},
+				Interval:    45 * time.Second,
+				Timeout:     10 * time.Second,
+				Retries:     2,
+				StartPeriod: 5 * time.Second,
+			},
+		},
+		{
+			name:    "health-start-interval",
+			options: types.ContainerCreateOptions{HealthCmd: "true", HealthStartPeriod: time.Minute, HealthStartInterval: 5 * time.Second},
+			expected: &healthcheck.Healthcheck{
+				Test:          []string{"CMD-SHELL", "true"},
+				Interval:      healthcheck.DefaultProbeInterval,
+				Timeout:       healthcheck.DefaultProbeTimeout,
+				Retries:       healthcheck.DefaultProbeRetries,
+				StartPeriod:   time.Minute,
+				StartInterval: 5 * time.Second,
+			},
+		},
+		{
+			name:     "image healthcheck disabled",
+			image:    imageWithHealthcheck(`{"Test":["NONE"]}`),
+			expected: &healthcheck.Healthcheck{Test: []string{"NONE"}},
+		},
+		{
+			name:     "no-healthcheck disables the image healthcheck",
+			options:  types.ContainerCreateOptions{NoHealthcheck: true},
+			image:    imageWithHealthcheck(`{"Test":["CMD","true"]}`),
+			expected: &healthcheck.Healthcheck{Test: []string{"NONE"}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			hcJSON, err := withHealthcheck(tc.options, tc.image)
+			assert.NilError(t, err)
+			if tc.expected == nil {
+				assert.Equal(t, hcJSON, "")
+				return
+			}
+			hc, err := healthcheck.HealthCheckFromJSON(hcJSON)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, hc, tc.expected)
+		})
+	}
+}
+
+func TestWithHealthcheckStartIntervalLongerThanInterval(t *testing.T) {
+	t.Parallel()
+	options := types.ContainerCreateOptions{HealthCmd: "true", HealthInterval: 10 * time.Second, HealthStartInterval: 20 * time.Second}
+	_, err := withHealthcheck(options, &imgutil.EnsuredImage{})
+	assert.ErrorContains(t, err, "must not be longer than the health interval")
+}
+
+func TestMergeContainerLabels(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		imageLabels map[string]string
+		labelMap    map[string]string
+		expected    map[string]string
+	}{
+		{
+			name:     "no labels",
+			expected: map[string]string{},
+		},
+		{
+			name:        "image labels are kept",
+			imageLabels: map[string]string{"foo": "image", "version": "0.1"},
+			labelMap:    map[string]string{"bar": "flag"},
+			expected:    map[string]string{"foo": "image", "version": "0.1", "bar": "flag"},
+		},
+		{
+			name:        "flags override image labels of the same key",
+			imageLabels: map[string]string{"foo": "image", "version": "0.1"},
+			labelMap:    map[string]string{"foo": "flag"},
+			expected:    map[string]string{"foo": "flag", "version": "0.1"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.DeepEqual(t, tc.expected, mergeContainerLabels(tc.imageLabels, tc.labelMap))
+		})
+	}
+}
+
+func TestResolveWorkdir(t *testing.T) {
+	app := &imgutil.EnsuredImage{ImageConfig: ocispec.ImageConfig{WorkingDir: "/app"}}
+	noWorkdir := &imgutil.EnsuredImage{}
+
+	assert.Equal(t, resolveWorkdir("sub", app), "/app/sub")
+	assert.Equal(t, resolveWorkdir("./sub/../other", app), "/app/other")
+	assert.Equal(t, resolveWorkdir("/sub", app), "/sub")
+	assert.Equal(t, resolveWorkdir("sub", noWorkdir), "/sub")
+	// --rootfs has no image
+	assert.Equal(t, resolveWorkdir("sub", nil), "/sub")
+}
+
+func TestParseEntrypoint(t *testing.T) {
+	assert.DeepEqual(t, parseEntrypoint([]string{`["/bin/sh","-c"]`}), []string{"/bin/sh", "-c"})
+	assert.DeepEqual(t, parseEntrypoint([]string{` ["echo", "a b"] `}), []string{"echo", "a b"})
+	assert.DeepEqual(t, parseEntrypoint([]string{"/bin/sh"}), []string{"/bin/sh"})
+	assert.DeepEqual(t, parseEntrypoint([]string{"/bin/sh", "-c"}), []string{"/bin/sh", "-c"})
+	// Malformed JSON is used as-is
+	assert.DeepEqual(t, parseEntrypoint([]string{`["/bin/sh",`}), []string{`["/bin/sh",`})
+	assert.DeepEqual(t, parseEntrypoint([]string{`[1, 2]`}), []string{`[1, 2]`})
+}
+
+func TestWithEnv(t *testing.T) {
+	t.Parallel()
+	s := &specs.Spec{
+		Process: &specs.Process{
+			Env: []string{"PATH=/usr/local/bin:/usr/bin", "LANG=C.UTF-8", "APP_MODE=image"},
+		},
+	}
+	envs := []string{"HOSTNAME=foo", "APP_MODE=run", "DEBUG=1", "EXTRA=1", "DEBUG=0"}
+	assert.NilError(t, withEnv(envs)(context.Background(), nil, nil, s))
+	assert.DeepEqual(t, []string{
+		"PATH=/usr/local/bin:/usr/bin",
+		"LANG=C.UTF-8",
+		"APP_MODE=run",
+		"HOSTNAME=foo",
+		"DEBUG=0",
+		"EXTRA=1",
+	}, s.Process.Env)
+}
@@ -70,6 +70,17 @@ func execActionWithContainer(ctx context.Context, client *containerd.Client, con
 	if err != nil {
 		return err
 	}
+	taskStatus, err := task.Status(ctx)
+	if err != nil {
+		return err
+	}
+	switch taskStatus.Status {
+	case containerd.Paused, containerd.Pausing:
+		return fmt.Errorf("container %s is paused, unpause the container before exec", container.ID())
+	case containerd.Created, containerd.Stopped:
+		return fmt.Errorf("container %s is not running", container.ID())
+	default:
+	}
 	var (
 		ioCreator cio.Creator
 		in        io.Reader
@@ -153,6 +164,9 @@ func generateExecProcessSpec(ctx context.Context, client *containerd.Client, con
 	if err != nil {
 		return nil, err
 	}
+	// Like Docker, the process runs as the user of the container (Config.User) unless -u is specified.
+	// The uid and gid of that user were resolved against the passwd of the container on creation, and
+	// are inherited from the spec as-is; -u is resolved against the passwd of the container again.
 	userOpts, err := generateUserOpts(options.User)
 	if err != nil {
 		return nil, err
@@ -182,10 +196,19 @@ func generateExecProcessSpec(ctx context.Context, client *containerd.Client, con
 	}
 	pspec.Args = args[1:]
 
+	// Like Docker, the process runs in the working directory of the container (Config.WorkingDir)
+	// unless -w is specified.
 	if options.Workdir != "" {
 		pspec.Cwd = options.Workdir
+	} else if pspec.Cwd == "" {
+		pspec.Cwd = "/"
+	}
+	envFiles, cleanupEnvFiles, err := flagutil.FetchRemoteFiles(ctx, options.EnvFile, options.GOptions.RemoteFileAuthHeader, options.GOptions.RemoteFileAuthHost)
+	defer cleanupEnvFiles()
+	if err != nil {
+		return nil, err
 	}
-	envs, err := flagutil.MergeEnvFileAndOSEnv(options.EnvFile, options.Env)
+	envs, err := flagutil.MergeEnvFileAndOSEnv(envFiles, options.Env)
 	if err != nil {
 		return nil, err
 	}
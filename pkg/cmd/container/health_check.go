@@ -23,12 +23,16 @@ import (
 
 	containerd "github.com/containerd/containerd/v2/client"
 
+	"github.com/containerd/nerdctl/v2/pkg/api/types"
+	"github.com/containerd/nerdctl/v2/pkg/config"
 	"github.com/containerd/nerdctl/v2/pkg/healthcheck"
 	"github.com/containerd/nerdctl/v2/pkg/labels"
 )
 
-// HealthCheck executes the health check command for a container
-func HealthCheck(ctx context.Context, client *containerd.Client, container containerd.Container) error {
+// HealthCheck executes the health check command for a container.
+// When options.Scheduled is true (i.e., when run by the healthcheck timer), the probe is skipped if it is not due yet,
+// and the timer is re-created at the interval once the start interval no longer applies.
+func HealthCheck(ctx context.Context, client *containerd.Client, container containerd.Container, options types.ContainerHealthCheckOptions) error {
 	// verify container status and get task
 	task, err := isContainerRunning(ctx, container)
 	if err != nil {
@@ -64,6 +68,12 @@ func HealthCheck(ctx context.Context, client *containerd.Client, container conta
 	}
 
 	// Execute the health check
+	if options.Scheduled {
+		if err := healthcheck.ExecuteScheduledHealthCheck(ctx, task, container, hcConfig); err != nil {
+			return err
+		}
+		return healthcheck.UpdateTimer(ctx, container, (*config.Config)(&options.GOptions), options.NerdctlCmd, options.NerdctlArgs)
+	}
 	return healthcheck.ExecuteHealthCheck(ctx, task, container, hcConfig)
 }
 
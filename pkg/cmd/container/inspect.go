@@ -42,11 +42,12 @@ func Inspect(ctx context.Context, client *containerd.Client, containers []string
 	}
 
 	f := &containerInspector{
-		mode:        options.Mode,
-		size:        options.Size,
-		snapshotter: containerdutil.SnapshotService(client, options.GOptions.Snapshotter),
-		dataStore:   dataStore,
-		namespace:   options.GOptions.Namespace,
+		mode:         options.Mode,
+		size:         options.Size,
+		client:       client,
+		snapshotters: map[string]snapshots.Snapshotter{},
+		dataStore:    dataStore,
+		namespace:    options.GOptions.Namespace,
 	}
 
 	walker := &containerwalker.ContainerWalker{
@@ -63,12 +64,21 @@ func Inspect(ctx context.Context, client *containerd.Client, containers []string
 }
 
 type containerInspector struct {
-	mode        string
-	size        bool
-	snapshotter snapshots.Snapshotter
-	entries     []interface{}
-	dataStore   string
-	namespace   string
+	mode         string
+	size         bool
+	client       *containerd.Client
+	snapshotters map[string]snapshots.Snapshotter // keyed by the snapshotter name of the containers
+	entries      []interface{}
+	dataStore    string
+	namespace    string
+}
+
+// snapshotter returns the snapshotter of a container, which is not necessarily the default snapshotter.
+func (x *containerInspector) snapshotter(name string) snapshots.Snapshotter {
+	if _, ok := x.snapshotters[name]; !ok {
+		x.snapshotters[name] = containerdutil.SnapshotService(x.client, name)
+	}
+	return x.snapshotters[name]
 }
 
 func (x *containerInspector) Handler(ctx context.Context, found containerwalker.Found) error {
@@ -101,7 +111,7 @@ func (x *containerInspector) Handler(ctx context.Context, found containerwalker.
 			return err
 		}
 		if x.size {
-			resourceUsage, allResourceUsage, err := imgutil.ResourceUsage(ctx, x.snapshotter, d.ID)
+			resourceUsage, allResourceUsage, err := imgutil.ResourceUsage(ctx, x.snapshotter(n.Snapshotter), d.ID)
 			if err == nil {
 				d.SizeRw = &resourceUsage.Size
 				d.SizeRootFs = &allResourceUsage.Size
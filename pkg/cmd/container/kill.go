@@ -98,13 +98,15 @@ func killContainer(ctx context.Context, container containerd.Container, signal s
 		return err
 	}
 
-	paused := false
-
 	switch status.Status {
 	case containerd.Created, containerd.Stopped:
 		return fmt.Errorf("cannot kill container %s: container is not running", container.ID())
 	case containerd.Paused, containerd.Pausing:
-		paused = true
+		// The processes of a frozen container do not handle signals (and, with the cgroup v1 freezer,
+		// are not even killed by SIGKILL) until the container is thawed, so unpause it first.
+		if err := task.Resume(ctx); err != nil {
+			return fmt.Errorf("cannot unpause container %s before killing it: %w", container.ID(), err)
+		}
 	default:
 	}
 
@@ -116,13 +118,6 @@ func killContainer(ctx context.Context, container containerd.Container, signal s
 	if err := healthcheck.RemoveTransientHealthCheckFiles(ctx, container); err != nil {
 		log.G(ctx).Warnf("failed to clean up healthcheck units for container %s: %s", container.ID(), err)
 	}
-
-	// signal will be sent once resume is finished
-	if paused {
-		if err := task.Resume(ctx); err != nil {
-			log.G(ctx).Warnf("cannot unpause container %s: %s", container.ID(), err)
-		}
-	}
 	return nil
 }
 
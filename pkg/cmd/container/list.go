@@ -25,6 +25,8 @@ import (
 	"sync"
 	"time"
 
+	"golang.org/x/sync/errgroup"
+
 	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/containerd/v2/core/snapshots"
 	"github.com/containerd/containerd/v2/pkg/progress"
@@ -114,20 +116,23 @@ func filterContainers(ctx context.Context, client *containerd.Client, filters []
 }
 
 type ListItem struct {
-	Command   string
-	CreatedAt time.Time
-	ID        string
-	Image     string
-	Platform  string // nerdctl extension
-	Names     string
-	Ports     string
-	Status    string
-	Runtime   string // nerdctl extension
-	Size      string
-	Labels    string
-	LabelsMap map[string]string `json:"-"`
-
-	// TODO: "LocalVolumes", "Mounts", "Networks", "RunningFor", "State"
+	Command    string
+	CreatedAt  time.Time
+	ID         string
+	Image      string
+	Platform   string // nerdctl extension
+	Names      string
+	Networks   string
+	Ports      string
+	RunningFor string
+	State      string
+	Status     string
+	Runtime    string // nerdctl extension
+	Size       string
+	Labels     string
+	LabelsMap  map[string]string `json:"-"`
+
+	// TODO: "LocalVolumes", "Mounts"
 }
 
 func (x *ListItem) Label(s string) string {
@@ -137,6 +142,7 @@ func (x *ListItem) Label(s string) string {
 func prepareContainers(ctx context.Context, client *containerd.Client, containers []containerd.Container, statusPerContainer map[string]string, options types.ContainerListOptions) ([]ListItem, error) {
 	listItems := make([]ListItem, len(containers))
 	snapshottersCache := map[string]snapshots.Snapshotter{}
+	sizeRequests := map[int]containerSizeRequest{}
 	for i, c := range containers {
 		info, err := c.Info(ctx, containerd.WithoutRefreshedMetadata)
 		if err != nil {
@@ -177,35 +183,141 @@ func prepareContainers(ctx context.Context, client *containerd.Client, container
 			return nil, err
 		}
 		li := ListItem{
-			Command:   formatter.InspectContainerCommand(spec, options.Truncate, true),
-			CreatedAt: info.CreatedAt,
-			ID:        id,
-			Image:     info.Image,
-			Platform:  info.Labels[labels.Platform],
-			Names:     containerutil.GetContainerName(info.Labels),
-			Ports:     formatter.FormatPorts(ports),
-			Status:    status,
-			Runtime:   info.Runtime.Name,
-			Labels:    formatter.FormatLabels(info.Labels),
-			LabelsMap: info.Labels,
+			Command:    formatter.InspectContainerCommand(spec, options.Truncate, true),
+			CreatedAt:  info.CreatedAt,
+			ID:         id,
+			Image:      info.Image,
+			Platform:   info.Labels[labels.Platform],
+			Names:      containerutil.GetContainerName(info.Labels),
+			Networks:   strings.Join(getContainerNetworks(info.Labels), ","),
+			Ports:      formatter.FormatPorts(ports),
+			RunningFor: formatter.TimeSinceInHuman(info.CreatedAt),
+			State:      containerState(status),
+			Status:     status,
+			Runtime:    info.Runtime.Name,
+			Labels:     formatter.FormatLabels(info.Labels),
+			LabelsMap:  info.Labels,
 		}
 		if options.Size {
 			snapshotter, ok := snapshottersCache[info.Snapshotter]
 			if !ok {
-				snapshottersCache[info.Snapshotter] = containerdutil.SnapshotService(client, info.Snapshotter)
+				// Image layers are usually shared across containers, so cache their usage for this invocation.
+				snapshottersCache[info.Snapshotter] = newUsageCachingSnapshotter(containerdutil.SnapshotService(client, info.Snapshotter))
 				snapshotter = snapshottersCache[info.Snapshotter]
 			}
-			containerSize, err := getContainerSize(ctx, snapshotter, info.SnapshotKey)
-			if err != nil {
-				return nil, err
-			}
-			li.Size = containerSize
+			sizeRequests[i] = containerSizeRequest{snapshotter: snapshotter, snapshotKey: info.SnapshotKey}
 		}
 		listItems[i] = li
 	}
+	if options.Size {
+		sizes, err := getContainerSizes(ctx, sizeRequests, containerSizeConcurrency)
+		if err != nil {
+			return nil, err
+		}
+		for i, size := range sizes {
+			listItems[i].Size = size
+		}
+	}
 	return listItems, nil
 }
 
+// containerSizeConcurrency is the maximum number of container sizes computed concurrently.
+const containerSizeConcurrency = 8
+
+type containerSizeRequest struct {
+	snapshotter snapshots.Snapshotter
+	snapshotKey string
+}
+
+// getContainerSizes computes the sizes of the requested containers, using at most `concurrency` workers.
+func getContainerSizes(ctx context.Context, requests map[int]containerSizeRequest, concurrency int) (map[int]string, error) {
+	var (
+		mu    sync.Mutex
+		sizes = make(map[int]string, len(requests))
+	)
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(concurrency)
+	for i, req := range requests {
+		eg.Go(func() error {
+			size, err := getContainerSize(ctx, req.snapshotter, req.snapshotKey)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			sizes[i] = size
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return sizes, nil
+}
+
+// usageCachingSnapshotter memoizes the Usage and Stat results of the underlying snapshotter.
+type usageCachingSnapshotter struct {
+	snapshots.Snapshotter
+	mu    sync.Mutex
+	usage map[string]snapshots.Usage
+	info  map[string]snapshots.Info
+}
+
+func newUsageCachingSnapshotter(snapshotter snapshots.Snapshotter) *usageCachingSnapshotter {
+	return &usageCachingSnapshotter{
+		Snapshotter: snapshotter,
+		usage:       map[string]snapshots.Usage{},
+		info:        map[string]snapshots.Info{},
+	}
+}
+
+func (s *usageCachingSnapshotter) Usage(ctx context.Context, key string) (snapshots.Usage, error) {
+	s.mu.Lock()
+	usage, ok := s.usage[key]
+	s.mu.Unlock()
+	if ok {
+		return usage, nil
+	}
+	usage, err := s.Snapshotter.Usage(ctx, key)
+	if err != nil {
+		return usage, err
+	}
+	s.mu.Lock()
+	s.usage[key] = usage
+	s.mu.Unlock()
+	return usage, nil
+}
+
+func (s *usageCachingSnapshotter) Stat(ctx context.Context, key string) (snapshots.Info, error) {
+	s.mu.Lock()
+	info, ok := s.info[key]
+	s.mu.Unlock()
+	if ok {
+		return info, nil
+	}
+	info, err := s.Snapshotter.Stat(ctx, key)
+	if err != nil {
+		return info, err
+	}
+	s.mu.Lock()
+	s.info[key] = info
+	s.mu.Unlock()
+	return info, nil
+}
+
+// containerState returns the Docker state of a container ("created", "running", "paused", "restarting", "exited"...)
+// from its status, as returned by formatter.ContainerStatus.
+func containerState(status string) string {
+	switch state, _, _ := strings.Cut(status, " "); state {
+	case "Up":
+		return "running"
+	case "Pausing":
+		return "paused"
+	default:
+		return strings.ToLower(state)
+	}
+}
+
 func getContainerNetworks(containerLables map[string]string) []string {
 	var networks []string
 	if names, ok := containerLables[labels.Networks]; ok {
@@ -0,0 +1,159 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/containerd/v2/core/snapshots"
+	"github.com/containerd/errdefs"
+)
+
+// fakeSnapshotter implements Stat and Usage over a static snapshot tree, and records the concurrency of Usage calls.
+type fakeSnapshotter struct {
+	snapshots.Snapshotter
+	parents map[string]string
+	sizes   map[string]int64
+
+	inflight    atomic.Int32
+	maxInflight atomic.Int32
+	mu          sync.Mutex
+	usageCalls  map[string]int
+}
+
+func (s *fakeSnapshotter) Stat(_ context.Context, key string) (snapshots.Info, error) {
+	if _, ok := s.sizes[key]; !ok {
+		return snapshots.Info{}, errdefs.ErrNotFound
+	}
+	return snapshots.Info{Name: key, Parent: s.parents[key]}, nil
+}
+
+func (s *fakeSnapshotter) Usage(_ context.Context, key string) (snapshots.Usage, error) {
+	n := s.inflight.Add(1)
+	defer s.inflight.Add(-1)
+	for {
+		m := s.maxInflight.Load()
+		if n <= m || s.maxInflight.CompareAndSwap(m, n) {
+			break
+		}
+	}
+	s.mu.Lock()
+	s.usageCalls[key]++
+	s.mu.Unlock()
+	time.Sleep(time.Millisecond)
+	size, ok := s.sizes[key]
+	if !ok {
+		return snapshots.Usage{}, errdefs.ErrNotFound
+	}
+	return snapshots.Usage{Size: size}, nil
+}
+
+func newFakeSnapshotter(containers int) *fakeSnapshotter {
+	s := &fakeSnapshotter{
+		parents:    map[string]string{"layer1": "layer0"},
+		sizes:      map[string]int64{"layer0": 4096, "layer1": 2048},
+		usageCalls: map[string]int{},
+	}
+	for i := range containers {
+		key := fmt.Sprintf("container%d", i)
+		s.parents[key] = "layer1"
+		s.sizes[key] = int64(i * 1024)
+	}
+	return s
+}
+
+func TestGetContainerSizes(t *testing.T) {
+	t.Parallel()
+	const (
+		containers  = 32
+		concurrency = 4
+	)
+	ctx := context.Background()
+
+	// Serial results, from the uncached snapshotter
+	serial := newFakeSnapshotter(containers)
+	expected := map[int]string{}
+	for i := range containers {
+		size, err := getContainerSize(ctx, serial, fmt.Sprintf("container%d", i))
+		assert.NilError(t, err)
+		expected[i] = size
+	}
+
+	fake := newFakeSnapshotter(containers)
+	cached := newUsageCachingSnapshotter(fake)
+	requests := map[int]containerSizeRequest{}
+	for i := range containers {
+		requests[i] = containerSizeRequest{snapshotter: cached, snapshotKey: fmt.Sprintf("container%d", i)}
+	}
+	sizes, err := getContainerSizes(ctx, requests, concurrency)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, sizes, expected)
+	assert.Assert(t, fake.maxInflight.Load() <= concurrency, "max inflight %d exceeds %d", fake.maxInflight.Load(), concurrency)
+
+	// Each container snapshot is only computed once; shared layers may race, but are bounded by the concurrency
+	for i := range containers {
+		assert.Equal(t, fake.usageCalls[fmt.Sprintf("container%d", i)], 1)
+	}
+	assert.Assert(t, fake.usageCalls["layer0"] <= concurrency)
+	assert.Assert(t, fake.usageCalls["layer1"] <= concurrency)
+}
+
+func TestGetContainerSizesError(t *testing.T) {
+	t.Parallel()
+	fake := newFakeSnapshotter(1)
+	requests := map[int]containerSizeRequest{
+		0: {snapshotter: fake, snapshotKey: "container0"},
+		1: {snapshotter: fake, snapshotKey: "missing"},
+	}
+	_, err := getContainerSizes(context.Background(), requests, 2)
+	assert.Assert(t, errdefs.IsNotFound(err))
+}
+
+func BenchmarkGetContainerSizes(b *testing.B) {
+	ctx := context.Background()
+	requests := map[int]containerSizeRequest{}
+	for b.Loop() {
+		cached := newUsageCachingSnapshotter(newFakeSnapshotter(64))
+		for i := range 64 {
+			requests[i] = containerSizeRequest{snapshotter: cached, snapshotKey: fmt.Sprintf("container%d", i)}
+		}
+		if _, err := getContainerSizes(ctx, requests, containerSizeConcurrency); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestContainerState(t *testing.T) {
+	for status, state := range map[string]string{
+		"Up":                           "running",
+		"Created":                      "created",
+		"Paused":                       "paused",
+		"Pausing":                      "paused",
+		"Exited (0) 2 minutes ago":     "exited",
+		"Restarting (1) 3 seconds ago": "restarting",
+		"Unknown":                      "unknown",
+	} {
+		assert.Equal(t, containerState(status), state, status)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"syscall"
 
 	containerd "github.com/containerd/containerd/v2/client"
@@ -36,8 +37,10 @@ import (
 	"github.com/containerd/nerdctl/v2/pkg/dnsutil/hostsstore"
 	"github.com/containerd/nerdctl/v2/pkg/healthcheck"
 	"github.com/containerd/nerdctl/v2/pkg/idutil/containerwalker"
+	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/dockercompat"
 	"github.com/containerd/nerdctl/v2/pkg/ipcutil"
 	"github.com/containerd/nerdctl/v2/pkg/labels"
+	"github.com/containerd/nerdctl/v2/pkg/mountutil/volumeplugin"
 	"github.com/containerd/nerdctl/v2/pkg/mountutil/volumestore"
 	"github.com/containerd/nerdctl/v2/pkg/namestore"
 	"github.com/containerd/nerdctl/v2/pkg/portutil"
@@ -261,11 +264,15 @@ func RemoveContainer(ctx context.Context, c containerd.Container, globalOptions
 		// Technically, a concurrent operation MAY have deleted these anonymous volumes already at this point, which
 		// would make this operation here "soft fail".
 		// This is not a problem per-se, though we will output a warning in that case.
+		pluginVolumes := releasePluginVolumes(ctx, containerLabels, id, removeAnonVolumes)
 		if anonVolumesJSON, ok := containerLabels[labels.AnonymousVolumes]; ok && removeAnonVolumes {
 			var anonVolumes []string
 			if err = json.Unmarshal([]byte(anonVolumesJSON), &anonVolumes); err != nil {
 				log.G(ctx).WithError(err).Warnf("failed to unmarshall anonvolume information for container %q", id)
-			} else {
+			} else if anonVolumes = slices.DeleteFunc(anonVolumes, func(name string) bool {
+				_, ok := pluginVolumes[name]
+				return ok
+			}); len(anonVolumes) > 0 {
 				var errs []error
 				_, errs, err = volStore.Remove(func() ([]string, []error, error) {
 					return anonVolumes, nil, nil
@@ -332,3 +339,40 @@ func RemoveContainer(ctx context.Context, c containerd.Container, globalOptions
 	_, err = task.Delete(ctx, containerd.WithProcessKill)
 	return err
 }
+
+// releasePluginVolumes unmounts the volumes that volume plugins provide to the container id if they are still mounted,
+// and removes the anonymous ones when removeAnonVolumes is set.
+// It returns the names of the plugin volumes, which are not known to the local volume store.
+func releasePluginVolumes(ctx context.Context, containerLabels map[string]string, id string, removeAnonVolumes bool) map[string]struct{} {
+	mountsJSON, ok := containerLabels[labels.Mounts]
+	if !ok {
+		return nil
+	}
+	var mounts []dockercompat.MountPoint
+	if err := json.Unmarshal([]byte(mountsJSON), &mounts); err != nil {
+		log.G(ctx).WithError(err).Warnf("failed to unmarshall mount information for container %q", id)
+		return nil
+	}
+	var anonVolumes []string
+	if anonVolumesJSON, ok := containerLabels[labels.AnonymousVolumes]; ok {
+		_ = json.Unmarshal([]byte(anonVolumesJSON), &anonVolumes)
+	}
+	pluginVolumes := make(map[string]struct{})
+	for _, m := range mounts {
+		if m.Type != "volume" || m.Driver == "" || m.Driver == volumestore.LocalDriver {
+			continue
+		}
+		pluginVolumes[m.Name] = struct{}{}
+		p, err := volumeplugin.Get(ctx, m.Driver)
+		if err == nil && containerLabels[labels.PluginVolumesMounted] == "true" {
+			err = p.Unmount(ctx, m.Name, id)
+		}
+		if err == nil && removeAnonVolumes && slices.Contains(anonVolumes, m.Name) {
+			err = p.Remove(ctx, m.Name)
+		}
+		if err != nil {
+			log.G(ctx).WithError(err).Warnf("failed to release volume %q of volume plugin %q", m.Name, m.Driver)
+		}
+	}
+	return pluginVolumes
+}
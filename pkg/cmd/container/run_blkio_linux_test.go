@@ -0,0 +1,60 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/containerd/v2/pkg/oci"
+)
+
+func TestThrottleIOpsDevices(t *testing.T) {
+	toSpec := func(t *testing.T, vals []string, withDevices func([]specs.LinuxThrottleDevice) oci.SpecOpts) []specs.LinuxThrottleDevice {
+		t.Helper()
+		devs, err := validateThrottleIOpsDevices(vals)
+		assert.NilError(t, err)
+		throttleDevices, err := toOCIThrottleDevices(devs)
+		assert.NilError(t, err)
+		s := &oci.Spec{Linux: &specs.Linux{Resources: &specs.LinuxResources{}}}
+		assert.NilError(t, withDevices(throttleDevices)(context.Background(), nil, nil, s))
+		assert.Assert(t, s.Linux.Resources.BlockIO != nil)
+		return append(s.Linux.Resources.BlockIO.ThrottleReadIOPSDevice, s.Linux.Resources.BlockIO.ThrottleWriteIOPSDevice...)
+	}
+
+	// /dev/null and /dev/zero are 1:3 and 1:5 on Linux
+	expected := []specs.LinuxThrottleDevice{
+		{LinuxBlockIODevice: specs.LinuxBlockIODevice{Major: 1, Minor: 3}, Rate: 1000},
+		{LinuxBlockIODevice: specs.LinuxBlockIODevice{Major: 1, Minor: 5}, Rate: 2000},
+	}
+	vals := []string{"/dev/null:1000", "/dev/zero:2000"}
+	assert.DeepEqual(t, toSpec(t, vals, withBlkioReadIOPSDevice), expected)
+	assert.DeepEqual(t, toSpec(t, vals, withBlkioWriteIOPSDevice), expected)
+
+	for _, val := range []string{"/dev/null", ":1000", "null:1000", "/dev/null:1kb", "/dev/null:-1"} {
+		_, err := validateThrottleIOpsDevices([]string{"/dev/zero:1", val})
+		assert.ErrorContains(t, err, val)
+	}
+
+	devs, err := validateThrottleIOpsDevices([]string{"/dev/nerdctl-does-not-exist:1000"})
+	assert.NilError(t, err)
+	_, err = toOCIThrottleDevices(devs)
+	assert.ErrorContains(t, err, "failed to stat /dev/nerdctl-does-not-exist")
+}
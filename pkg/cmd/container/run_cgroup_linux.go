@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/docker/go-units"
@@ -47,10 +48,6 @@ func generateCgroupOpts(id string, options types.ContainerCreateOptions, interna
 		log.L.Warnf("The --kernel-memory flag is no longer supported. This flag is a noop.")
 	}
 
-	if options.Memory == "" && options.OomKillDisable {
-		log.L.Warn("Disabling the OOM killer on containers without setting a '-m/--memory' limit may be dangerous.")
-	}
-
 	if options.GOptions.CgroupManager == "none" {
 		if !rootlessutil.IsRootless() {
 			return nil, errors.New(`cgroup-manager "none" is only supported for rootless`)
@@ -76,6 +73,8 @@ func generateCgroupOpts(id string, options types.ContainerCreateOptions, interna
 		opts = append(opts, oci.WithCgroup(path))
 	}
 
+	// --cpus sets the CFS quota/period (cpu.max on cgroup v2), while --cpu-shares sets
+	// the relative weight (cpu.weight on cgroup v2): they are applied independently.
 	// cpus: from https://github.com/containerd/containerd/blob/v1.4.3/cmd/ctr/commands/run/run_unix.go#L187-L193
 	if options.CPUs > 0.0 {
 		var (
@@ -86,7 +85,7 @@ func generateCgroupOpts(id string, options types.ContainerCreateOptions, interna
 	}
 
 	if options.CPUShares != 0 {
-		opts = append(opts, oci.WithCPUShares(options.CPUShares))
+		opts = append(opts, oci.WithCPUShares(clampCPUShares(options.CPUShares)))
 	}
 
 	if options.CPUSetCPUs != "" {
@@ -131,28 +130,16 @@ func generateCgroupOpts(id string, options types.ContainerCreateOptions, interna
 			return nil, fmt.Errorf("failed to parse memory bytes %q: %w", options.MemoryReservation, err)
 		}
 	}
-	var memSwap64 int64
-	if options.MemorySwap != "" {
-		if options.MemorySwap == "-1" {
-			memSwap64 = -1
-		} else {
-			memSwap64, err = units.RAMInBytes(options.MemorySwap)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse memory-swap bytes %q: %w", options.MemorySwap, err)
-			}
-			if mem64 > 0 && memSwap64 > 0 && memSwap64 < mem64 {
-				return nil, fmt.Errorf("minimum memoryswap limit should be larger than memory limit, see usage")
-			}
-		}
-	} else {
-		// if `--memory-swap` is unset, the container can use as much swap as the `--memory` setting.
-		memSwap64 = mem64 * 2
+	memSwap64, err := parseMemorySwap(mem64, options.MemorySwap)
+	if err != nil {
+		return nil, err
 	}
-	if memSwap64 == 0 {
-		// if --memory-swap is set to 0, the setting is ignored, and the value is treated as unset.
-		memSwap64 = mem64 * 2
+	swapWarnings, applySwap, applySwappiness := checkSwapSupport(options,
+		infoutil.SwapLimit(options.GOptions.CgroupManager), infoutil.MemorySwappiness(options.GOptions.CgroupManager))
+	for _, w := range swapWarnings {
+		log.L.Warn(w)
 	}
-	if memSwap64 != 0 {
+	if memSwap64 != 0 && applySwap {
 		opts = append(opts, oci.WithMemorySwap(memSwap64))
 	}
 	if mem64 > 0 && memReserve64 > 0 && mem64 < memReserve64 {
@@ -166,10 +153,14 @@ func generateCgroupOpts(id string, options types.ContainerCreateOptions, interna
 	if memReserve64 >= 0 && options.MemoryReservationChanged {
 		customMemRes.MemoryReservation = &memReserve64
 	}
-	if options.MemorySwappiness64 >= 0 && options.MemorySwappiness64Changed {
+	if options.MemorySwappiness64 >= 0 && options.MemorySwappiness64Changed && applySwappiness {
 		memSwapinessUint64 := uint64(options.MemorySwappiness64)
 		customMemRes.MemorySwappiness = &memSwapinessUint64
 	}
+	oomGroup, err := parseOOMOptions(options, infoutil.CgroupsVersion())
+	if err != nil {
+		return nil, err
+	}
 	if options.OomKillDisable {
 		customMemRes.disableOOMKiller = &options.OomKillDisable
 	}
@@ -191,6 +182,14 @@ func generateCgroupOpts(id string, options types.ContainerCreateOptions, interna
 		}
 		unifieds[splitUnified[0]] = splitUnified[1]
 	}
+	if _, ok := unifieds[memoryOOMGroup]; !ok && oomGroup != "" {
+		unifieds[memoryOOMGroup] = oomGroup
+	}
+	// On cgroup v2, cpu.weight is set explicitly so that the weight does not depend on the
+	// shares conversion of the OCI runtime. An explicit --cgroup-conf cpu.weight takes precedence.
+	if _, ok := unifieds[cpuWeight]; !ok && options.CPUShares != 0 && infoutil.CgroupsVersion() == "2" {
+		unifieds[cpuWeight] = strconv.FormatUint(CPUSharesToWeight(clampCPUShares(options.CPUShares)), 10)
+	}
 	opts = append(opts, withUnified(unifieds))
 
 	blkioOpts, err := BlkioOCIOpts(options)
@@ -227,6 +226,93 @@ func generateCgroupOpts(id string, options types.ContainerCreateOptions, interna
 	return opts, nil
 }
 
+// clampCPUShares adjusts shares to the range accepted by the kernel, like Docker does.
+func clampCPUShares(shares uint64) uint64 {
+	if shares < linuxMinCPUShares {
+		log.L.Warnf("Changing requested CPUShares of %d to minimum allowed of %d", shares, linuxMinCPUShares)
+		return linuxMinCPUShares
+	}
+	if shares > linuxMaxCPUShares {
+		log.L.Warnf("Changing requested CPUShares of %d to maximum allowed of %d", shares, linuxMaxCPUShares)
+		return linuxMaxCPUShares
+	}
+	return shares
+}
+
+const (
+	memoryOOMGroup = "memory.oom.group"
+	cpuWeight      = "cpu.weight"
+)
+
+// parseOOMOptions validates --oom-kill-disable and --oom-group, and returns the value
+// to write to memory.oom.group, or "" to leave it untouched.
+//
+// cgroup v2 has no equivalent of the cgroup v1 memory.oom_control knob, so the OOM killer
+// cannot be disabled there: --oom-kill-disable only ensures that the container is not
+// killed as a whole (memory.oom.group=0).
+func parseOOMOptions(options types.ContainerCreateOptions, cgroupVersion string) (string, error) {
+	if options.OomKillDisable && options.Memory == "" {
+		return "", errors.New("--oom-kill-disable requires a memory limit to be set with -m/--memory")
+	}
+	if options.OomGroup {
+		if cgroupVersion != "2" {
+			return "", errors.New("--oom-group requires cgroup v2")
+		}
+		if options.OomKillDisable {
+			return "", errors.New("--oom-group and --oom-kill-disable cannot be used together")
+		}
+		return "1", nil
+	}
+	if options.OomKillDisable && cgroupVersion == "2" {
+		log.L.Warn("The OOM killer cannot be disabled on cgroup v2, only the killing of the whole container as a group is disabled")
+		return "0", nil
+	}
+	return "", nil
+}
+
+// checkSwapSupport returns the warnings for --memory-swap and --memory-swappiness that cannot be
+// enforced because the memory cgroup lacks swap accounting (swapLimit) or swappiness (swappiness),
+// e.g. when the kernel is booted without `swapaccount=1`, and whether each option should be applied.
+// Like Docker, the unsupported options are discarded rather than failing the container creation.
+func checkSwapSupport(options types.ContainerCreateOptions, swapLimit, swappiness bool) (warnings []string, applySwap, applySwappiness bool) {
+	if options.MemorySwap != "" && !swapLimit {
+		warnings = append(warnings, "Your kernel does not support swap limit capabilities or the cgroup is not mounted. --memory-swap discarded.")
+	}
+	if options.MemorySwappiness64Changed && options.MemorySwappiness64 >= 0 && !swappiness {
+		warnings = append(warnings, "Your kernel does not support memory swappiness capabilities or the cgroup is not mounted. --memory-swappiness discarded.")
+	}
+	return warnings, swapLimit, swappiness
+}
+
+// parseMemorySwap translates the --memory-swap flag into the OCI memory.swap value,
+// which is the combined memory+swap limit (runc converts it to memory.swap.max on cgroup v2).
+// "-1" means unlimited swap, a value equal to the memory limit disables swap, and
+// an unset (or zero) value allows as much swap as the memory limit.
+func parseMemorySwap(mem64 int64, memorySwap string) (int64, error) {
+	if memorySwap == "" {
+		// if `--memory-swap` is unset, the container can use as much swap as the `--memory` setting.
+		return mem64 * 2, nil
+	}
+	if memorySwap == "-1" {
+		return -1, nil
+	}
+	memSwap64, err := units.RAMInBytes(memorySwap)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse memory-swap bytes %q: %w", memorySwap, err)
+	}
+	if memSwap64 == 0 {
+		// if --memory-swap is set to 0, the setting is ignored, and the value is treated as unset.
+		return mem64 * 2, nil
+	}
+	if mem64 <= 0 {
+		return 0, errors.New("you should always set the memory limit when using memoryswap limit, see usage")
+	}
+	if memSwap64 < mem64 {
+		return 0, errors.New("minimum memoryswap limit should be larger than memory limit, see usage")
+	}
+	return memSwap64, nil
+}
+
 func generateCgroupPath(id, cgroupManager, cgroupParent string) (string, error) {
 	var (
 		path         string
@@ -294,19 +380,29 @@ func ParseDevice(s string) (hostDevPath string, containerPath string, mode strin
 		return "", "", "", fmt.Errorf("%q is not an absolute path", hostDevPath)
 	}
 
+	// An omitted permission string (e.g. "/dev/fuse:/dev/fuse:") grants full access, like Docker.
+	if mode == "" {
+		mode = "rwm"
+	}
 	if err := validateDeviceMode(mode); err != nil {
 		return "", "", "", err
 	}
 	return hostDevPath, containerDevPath, mode, nil
 }
 
+// validateDeviceMode checks that mode is a subset of "rwm", with each permission given at most once.
 func validateDeviceMode(mode string) error {
+	seen := make(map[rune]bool, len(mode))
 	for _, r := range mode {
 		switch r {
 		case 'r', 'w', 'm':
 		default:
 			return fmt.Errorf("invalid mode %q: unexpected rune %v", mode, r)
 		}
+		if seen[r] {
+			return fmt.Errorf("invalid mode %q: duplicated rune %q", mode, r)
+		}
+		seen[r] = true
 	}
 	return nil
 }
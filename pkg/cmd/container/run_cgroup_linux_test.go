@@ -0,0 +1,448 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/containerd/containerd/v2/core/containers"
+	"github.com/containerd/containerd/v2/pkg/oci"
+
+	"github.com/containerd/nerdctl/v2/pkg/api/types"
+	"github.com/containerd/nerdctl/v2/pkg/infoutil"
+)
+
+func TestParseMemorySwap(t *testing.T) {
+	t.Parallel()
+	const mem = 64 * 1024 * 1024
+	tests := []struct {
+		name        string
+		mem64       int64
+		memorySwap  string
+		expected    int64
+		expectError string
+	}{
+		{
+			name:       "unset swap defaults to twice the memory",
+			mem64:      mem,
+			memorySwap: "",
+			expected:   2 * mem,
+		},
+		{
+			name:       "unset swap and memory",
+			memorySwap: "",
+			expected:   0,
+		},
+		{
+			name:       "zero swap is treated as unset",
+			mem64:      mem,
+			memorySwap: "0",
+			expected:   2 * mem,
+		},
+		{
+			name:       "unlimited swap",
+			mem64:      mem,
+			memorySwap: "-1",
+			expected:   -1,
+		},
+		{
+			name:       "unlimited swap without memory",
+			memorySwap: "-1",
+			expected:   -1,
+		},
+		{
+			name:       "swap equal to memory disables swap",
+			mem64:      mem,
+			memorySwap: "64m",
+			expected:   mem,
+		},
+		{
+			name:       "combined limit",
+			mem64:      mem,
+			memorySwap: "128m",
+			expected:   2 * mem,
+		},
+		{
+			name:        "swap lower than memory",
+			mem64:       mem,
+			memorySwap:  "32m",
+			expectError: "minimum memoryswap limit should be larger than memory limit",
+		},
+		{
+			name:        "swap without memory",
+			memorySwap:  "128m",
+			expectError: "you should always set the memory limit when using memoryswap limit",
+		},
+		{
+			name:        "invalid swap",
+			mem64:       mem,
+			memorySwap:  "foo",
+			expectError: "failed to parse memory-swap bytes",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			memSwap64, err := parseMemorySwap(tc.mem64, tc.memorySwap)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, memSwap64, tc.expected)
+		})
+	}
+}
+
+func TestCheckSwapSupport(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name             string
+		options          types.ContainerCreateOptions
+		swapLimit        bool
+		swappiness       bool
+		expectedWarnings []string
+	}{
+		{
+			name:       "swap accounting available",
+			options:    types.ContainerCreateOptions{Memory: "64m", MemorySwap: "128m", MemorySwappiness64: 10, MemorySwappiness64Changed: true},
+			swapLimit:  true,
+			swappiness: true,
+		},
+		{
+			name:             "memory-swap without swap accounting",
+			options:          types.ContainerCreateOptions{Memory: "64m", MemorySwap: "128m", MemorySwappiness64: -1},
+			expectedWarnings: []string{"Your kernel does not support swap limit capabilities or the cgroup is not mounted. --memory-swap discarded."},
+		},
+		{
+			name:             "memory-swappiness without swappiness support",
+			options:          types.ContainerCreateOptions{MemorySwappiness64: 10, MemorySwappiness64Changed: true},
+			swapLimit:        true,
+			expectedWarnings: []string{"Your kernel does not support memory swappiness capabilities or the cgroup is not mounted. --memory-swappiness discarded."},
+		},
+		{
+			name:    "swap options unset",
+			options: types.ContainerCreateOptions{Memory: "64m", MemorySwappiness64: -1},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			warnings, applySwap, applySwappiness := checkSwapSupport(tc.options, tc.swapLimit, tc.swappiness)
+			assert.DeepEqual(t, warnings, tc.expectedWarnings)
+			assert.Equal(t, applySwap, tc.swapLimit)
+			assert.Equal(t, applySwappiness, tc.swappiness)
+		})
+	}
+}
+
+func TestGenerateCgroupOptsCPU(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		cpus           float64
+		cpuShares      uint64
+		expectedQuota  int64
+		expectedPeriod uint64
+		expectedShares uint64
+	}{
+		{
+			name:           "cpus only",
+			cpus:           0.5,
+			expectedQuota:  50000,
+			expectedPeriod: 100000,
+		},
+		{
+			name:           "cpu-shares only",
+			cpuShares:      1024,
+			expectedShares: 1024,
+		},
+		{
+			name:           "cpus and cpu-shares are applied independently",
+			cpus:           1.5,
+			cpuShares:      512,
+			expectedQuota:  150000,
+			expectedPeriod: 100000,
+			expectedShares: 512,
+		},
+		{
+			name:           "cpu-shares below the minimum are clamped",
+			cpuShares:      1,
+			expectedShares: linuxMinCPUShares,
+		},
+		{
+			name:           "cpu-shares above the maximum are clamped",
+			cpus:           2,
+			cpuShares:      linuxMaxCPUShares + 1,
+			expectedQuota:  200000,
+			expectedPeriod: 100000,
+			expectedShares: linuxMaxCPUShares,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			options := types.ContainerCreateOptions{
+				GOptions:  types.GlobalCommandOptions{CgroupManager: "cgroupfs"},
+				CPUs:      tc.cpus,
+				CPUShares: tc.cpuShares,
+				CPUQuota:  -1,
+				Cgroupns:  "private",
+			}
+			opts, err := generateCgroupOpts("test", options, &internalLabels{})
+			assert.NilError(t, err)
+
+			spec := &oci.Spec{Linux: &specs.Linux{}}
+			for _, opt := range opts {
+				assert.NilError(t, opt(context.Background(), nil, &containers.Container{}, spec))
+			}
+			cpu := spec.Linux.Resources.CPU
+			if tc.expectedQuota != 0 {
+				assert.Equal(t, *cpu.Quota, tc.expectedQuota)
+				assert.Equal(t, *cpu.Period, tc.expectedPeriod)
+			} else {
+				assert.Assert(t, cpu.Quota == nil || *cpu.Quota == 0)
+			}
+			if tc.expectedShares != 0 {
+				assert.Equal(t, *cpu.Shares, tc.expectedShares)
+			} else {
+				assert.Assert(t, cpu.Shares == nil)
+			}
+		})
+	}
+}
+
+func TestGenerateCgroupOptsCPUWeight(t *testing.T) {
+	if infoutil.CgroupsVersion() != "2" {
+		t.Skip("cpu.weight is only set on cgroup v2")
+	}
+	t.Parallel()
+	tests := []struct {
+		name           string
+		cpuShares      uint64
+		cgroupConf     []string
+		expectedWeight string
+	}{
+		{
+			name: "no cpu-shares",
+		},
+		{
+			name:           "default cpu-shares",
+			cpuShares:      1024,
+			expectedWeight: "39",
+		},
+		{
+			name:           "cpu-shares below the minimum are clamped",
+			cpuShares:      1,
+			expectedWeight: "1",
+		},
+		{
+			name:           "cpu-shares above the maximum are clamped",
+			cpuShares:      linuxMaxCPUShares + 1,
+			expectedWeight: "10000",
+		},
+		{
+			name:           "cgroup-conf takes precedence",
+			cpuShares:      1024,
+			cgroupConf:     []string{"cpu.weight=100"},
+			expectedWeight: "100",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			options := types.ContainerCreateOptions{
+				GOptions:   types.GlobalCommandOptions{CgroupManager: "cgroupfs"},
+				CPUShares:  tc.cpuShares,
+				CPUQuota:   -1,
+				CgroupConf: tc.cgroupConf,
+				Cgroupns:   "private",
+			}
+			opts, err := generateCgroupOpts("test", options, &internalLabels{})
+			assert.NilError(t, err)
+
+			spec := &oci.Spec{Linux: &specs.Linux{}}
+			for _, opt := range opts {
+				assert.NilError(t, opt(context.Background(), nil, &containers.Container{}, spec))
+			}
+			weight, ok := spec.Linux.Resources.Unified[cpuWeight]
+			assert.Equal(t, ok, tc.expectedWeight != "")
+			assert.Equal(t, weight, tc.expectedWeight)
+		})
+	}
+}
+
+func TestGenerateCgroupOptsCPUsWithQuota(t *testing.T) {
+	t.Parallel()
+	options := types.ContainerCreateOptions{
+		GOptions:  types.GlobalCommandOptions{CgroupManager: "cgroupfs"},
+		CPUs:      1,
+		CPUQuota:  50000,
+		CPUShares: 1024,
+		Cgroupns:  "private",
+	}
+	_, err := generateCgroupOpts("test", options, &internalLabels{})
+	assert.ErrorContains(t, err, "cpus and quota/period should be used separately")
+}
+
+func TestParseOOMOptions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		options       types.ContainerCreateOptions
+		cgroupVersion string
+		expected      string
+		err           string
+	}{
+		{
+			name:          "unset",
+			cgroupVersion: "2",
+			expected:      "",
+		},
+		{
+			name:          "oom-group",
+			options:       types.ContainerCreateOptions{OomGroup: true},
+			cgroupVersion: "2",
+			expected:      "1",
+		},
+		{
+			name:          "oom-group on cgroup v1",
+			options:       types.ContainerCreateOptions{OomGroup: true},
+			cgroupVersion: "1",
+			err:           "--oom-group requires cgroup v2",
+		},
+		{
+			name:          "oom-kill-disable on cgroup v2",
+			options:       types.ContainerCreateOptions{OomKillDisable: true, Memory: "64m"},
+			cgroupVersion: "2",
+			expected:      "0",
+		},
+		{
+			name:          "oom-kill-disable on cgroup v1",
+			options:       types.ContainerCreateOptions{OomKillDisable: true, Memory: "64m"},
+			cgroupVersion: "1",
+			expected:      "",
+		},
+		{
+			name:          "oom-kill-disable without memory limit",
+			options:       types.ContainerCreateOptions{OomKillDisable: true},
+			cgroupVersion: "2",
+			err:           "--oom-kill-disable requires a memory limit",
+		},
+		{
+			name:          "oom-group with oom-kill-disable",
+			options:       types.ContainerCreateOptions{OomGroup: true, OomKillDisable: true, Memory: "64m"},
+			cgroupVersion: "2",
+			err:           "cannot be used together",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			oomGroup, err := parseOOMOptions(tc.options, tc.cgroupVersion)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, oomGroup, tc.expected)
+		})
+	}
+}
+
+func TestGenerateCgroupOptsDevicePermissions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		device         string
+		expectedPath   string
+		expectedAccess string
+	}{
+		{device: "/dev/null", expectedPath: "/dev/null", expectedAccess: "rwm"},
+		{device: "/dev/null:r", expectedPath: "/dev/null", expectedAccess: "r"},
+		{device: "/dev/null:/dev/foo:rw", expectedPath: "/dev/foo", expectedAccess: "rw"},
+		{device: "/dev/null:/dev/foo:", expectedPath: "/dev/foo", expectedAccess: "rwm"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.device, func(t *testing.T) {
+			t.Parallel()
+			options := types.ContainerCreateOptions{
+				GOptions: types.GlobalCommandOptions{CgroupManager: "cgroupfs"},
+				CPUQuota: -1,
+				Cgroupns: "private",
+				Device:   []string{tc.device},
+			}
+			labels := &internalLabels{}
+			opts, err := generateCgroupOpts("test", options, labels)
+			assert.NilError(t, err)
+
+			spec := &oci.Spec{Linux: &specs.Linux{Resources: &specs.LinuxResources{}}}
+			for _, opt := range opts {
+				assert.NilError(t, opt(context.Background(), nil, &containers.Container{}, spec))
+			}
+			assert.Equal(t, len(spec.Linux.Devices), 1)
+			assert.Equal(t, spec.Linux.Devices[0].Path, tc.expectedPath)
+			assert.Equal(t, len(spec.Linux.Resources.Devices), 1)
+			rule := spec.Linux.Resources.Devices[0]
+			assert.Assert(t, rule.Allow)
+			assert.Equal(t, rule.Type, spec.Linux.Devices[0].Type)
+			assert.Equal(t, *rule.Major, spec.Linux.Devices[0].Major)
+			assert.Equal(t, *rule.Minor, spec.Linux.Devices[0].Minor)
+			assert.Equal(t, rule.Access, tc.expectedAccess)
+			assert.Equal(t, len(labels.deviceMapping), 1)
+			assert.Equal(t, labels.deviceMapping[0].CgroupPermissions, tc.expectedAccess)
+		})
+	}
+}
+
+func TestGenerateCgroupOptsPidsLimit(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		limit    int64
+		expected int64 // 0 if pids.max is left untouched
+	}{
+		{limit: -1, expected: 0},
+		{limit: 0, expected: 0},
+		{limit: 1, expected: 1},
+		{limit: 512, expected: 512},
+	}
+	for _, tc := range tests {
+		options := types.ContainerCreateOptions{
+			GOptions:  types.GlobalCommandOptions{CgroupManager: "cgroupfs"},
+			CPUQuota:  -1,
+			Cgroupns:  "private",
+			PidsLimit: tc.limit,
+		}
+		opts, err := generateCgroupOpts("test", options, &internalLabels{})
+		assert.NilError(t, err)
+
+		spec := &oci.Spec{Linux: &specs.Linux{}}
+		for _, opt := range opts {
+			assert.NilError(t, opt(context.Background(), nil, &containers.Container{}, spec))
+		}
+		if tc.expected == 0 {
+			assert.Assert(t, spec.Linux.Resources.Pids == nil, "limit=%d", tc.limit)
+		} else {
+			assert.Equal(t, *spec.Linux.Resources.Pids.Limit, tc.expected)
+		}
+	}
+}
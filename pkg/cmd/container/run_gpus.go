@@ -17,13 +17,31 @@
 package container
 
 import (
+	"context"
 	"encoding/csv"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/containerd/containerd/v2/core/containers"
+	"github.com/containerd/containerd/v2/pkg/oci"
+)
+
+const (
+	// defaultNvidiaDriverCapabilities is the default of NVIDIA_DRIVER_CAPABILITIES,
+	// as with the NVIDIA container runtime.
+	defaultNvidiaDriverCapabilities = "compute,utility"
 )
 
+// genericGPUCapabilities are the capabilities of Docker device requests that select the GPUs,
+// but are not NVIDIA driver capabilities.
+// https://github.com/moby/moby/blob/v20.10.7/daemon/nvidia_linux.go#L37
+var genericGPUCapabilities = []string{"gpu", "nvidia"}
+
 // GPUReq is a request for GPUs.
 type GPUReq struct {
 	Count        int
@@ -93,6 +111,79 @@ func ParseGPUOptCSV(value string) (*GPUReq, error) {
 	return &req, nil
 }
 
+func (req *GPUReq) normalizeDeviceIDs() []string {
+	if len(req.DeviceIDs) > 0 {
+		return req.DeviceIDs
+	}
+	if req.Count < 0 {
+		return []string{"all"}
+	}
+	var ids []string
+	for i := 0; i < req.Count; i++ {
+		ids = append(ids, strconv.Itoa(i))
+	}
+
+	return ids
+}
+
+// gpuEnvDefaults returns the NVIDIA_VISIBLE_DEVICES and NVIDIA_DRIVER_CAPABILITIES env vars
+// expected by CUDA images for the GPUs requested with --gpus.
+// The driver capabilities default to "compute,utility" unless requested with capabilities=.
+func gpuEnvDefaults(gpus []string) ([]string, error) {
+	var devices, caps []string
+	for _, gpu := range gpus {
+		req, err := ParseGPUOptCSV(gpu)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range req.normalizeDeviceIDs() {
+			if !slices.Contains(devices, id) {
+				devices = append(devices, id)
+			}
+		}
+		for _, c := range req.Capabilities {
+			if !slices.Contains(genericGPUCapabilities, c) && !slices.Contains(caps, c) {
+				caps = append(caps, c)
+			}
+		}
+	}
+	if len(devices) == 0 {
+		return nil, nil
+	}
+	if slices.Contains(devices, "all") {
+		devices = []string{"all"}
+	}
+	driverCaps := defaultNvidiaDriverCapabilities
+	if len(caps) > 0 {
+		driverCaps = strings.Join(caps, ",")
+	}
+	return []string{
+		"NVIDIA_VISIBLE_DEVICES=" + strings.Join(devices, ","),
+		"NVIDIA_DRIVER_CAPABILITIES=" + driverCaps,
+	}, nil
+}
+
+// withGPUEnvDefaults sets the env vars of gpuEnvDefaults that are not set by the image or the user yet.
+// It must be applied after the env of the image and of the user.
+func withGPUEnvDefaults(gpus []string) (oci.SpecOpts, error) {
+	envs, err := gpuEnvDefaults(gpus)
+	if err != nil {
+		return nil, err
+	}
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *oci.Spec) error {
+		if s.Process == nil {
+			s.Process = &specs.Process{}
+		}
+		for _, env := range envs {
+			key := strings.SplitN(env, "=", 2)[0]
+			if !slices.ContainsFunc(s.Process.Env, func(e string) bool { return strings.HasPrefix(e, key+"=") }) {
+				s.Process.Env = append(s.Process.Env, env)
+			}
+		}
+		return nil
+	}, nil
+}
+
 func parseCount(s string) (int, error) {
 	if s == "all" {
 		return -1, nil
@@ -0,0 +1,137 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"fmt"
+	"strings"
+
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+
+	"github.com/containerd/containerd/v2/contrib/nvidia"
+	"github.com/containerd/containerd/v2/pkg/oci"
+	"github.com/containerd/log"
+
+	"github.com/containerd/nerdctl/v2/pkg/rootlessutil"
+)
+
+const (
+	// GPUModeAuto uses CDI when NVIDIA CDI devices are registered, and the legacy hook otherwise.
+	GPUModeAuto = "auto"
+	// GPUModeCDI always injects the GPUs as CDI devices (nvidia.com/gpu=<ID>).
+	GPUModeCDI = "cdi"
+	// GPUModeLegacy always uses the nvidia-container-cli prestart hook.
+	GPUModeLegacy = "legacy"
+)
+
+// nvidiaCDIKind is the CDI kind of the devices generated by `nvidia-ctk cdi generate`.
+const nvidiaCDIKind = "nvidia.com/gpu"
+
+func parseGPUOpts(cdiSpecDirs []string, mode string, value []string) (res []oci.SpecOpts, _ error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+	mode, err := resolveGPUMode(mode, cdiSpecDirs)
+	if err != nil {
+		return nil, err
+	}
+	for _, gpu := range value {
+		req, err := ParseGPUOptCSV(gpu)
+		if err != nil {
+			return nil, err
+		}
+		if mode == GPUModeLegacy {
+			res = append(res, req.toLegacyOpt())
+		} else {
+			res = append(res, withCDIDevices(cdiSpecDirs, req.toCDIDeviceIDS()...))
+		}
+	}
+	return res, nil
+}
+
+// resolveGPUMode resolves GPUModeAuto (or an empty mode) into GPUModeCDI or GPUModeLegacy,
+// depending on whether the CDI specs in cdiSpecDirs define NVIDIA GPUs.
+func resolveGPUMode(mode string, cdiSpecDirs []string) (string, error) {
+	switch mode {
+	case GPUModeCDI, GPUModeLegacy:
+		return mode, nil
+	case "", GPUModeAuto:
+		if hasNvidiaCDIDevices(cdiSpecDirs) {
+			return GPUModeCDI, nil
+		}
+		log.L.Debugf("no %s CDI devices found in %v, falling back to the legacy NVIDIA hook", nvidiaCDIKind, cdiSpecDirs)
+		return GPUModeLegacy, nil
+	}
+	return "", fmt.Errorf("invalid gpu mode %q: must be one of %q, %q, or %q", mode, GPUModeAuto, GPUModeCDI, GPUModeLegacy)
+}
+
+func hasNvidiaCDIDevices(cdiSpecDirs []string) bool {
+	// Errors of individual spec dirs and specs do not prevent listing the valid devices.
+	cache, _ := cdi.NewCache(cdi.WithSpecDirs(cdiSpecDirs...), cdi.WithAutoRefresh(false))
+	if cache == nil {
+		return false
+	}
+	for _, dev := range cache.ListDevices() {
+		if strings.HasPrefix(dev, nvidiaCDIKind+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+func (req *GPUReq) toCDIDeviceIDS() []string {
+	var cdiDeviceIDs []string
+	for _, id := range req.normalizeDeviceIDs() {
+		cdiDeviceIDs = append(cdiDeviceIDs, nvidiaCDIKind+"="+id)
+	}
+	return cdiDeviceIDs
+}
+
+// toLegacyOpt returns the spec option that sets up the nvidia-container-cli prestart hook.
+func (req *GPUReq) toLegacyOpt() oci.SpecOpts {
+	var gpuOpts []nvidia.Opts
+	switch {
+	case len(req.DeviceIDs) > 0:
+		gpuOpts = append(gpuOpts, nvidia.WithDeviceUUIDs(req.DeviceIDs...))
+	case req.Count < 0:
+		gpuOpts = append(gpuOpts, nvidia.WithAllDevices)
+	case req.Count > 0:
+		var devices []int
+		for i := 0; i < req.Count; i++ {
+			devices = append(devices, i)
+		}
+		gpuOpts = append(gpuOpts, nvidia.WithDevices(devices...))
+	}
+
+	var caps []nvidia.Capability
+	for _, c := range nvidia.AllCaps() {
+		for _, requested := range req.Capabilities {
+			if requested == string(c) {
+				caps = append(caps, c)
+			}
+		}
+	}
+	if len(caps) > 0 {
+		gpuOpts = append(gpuOpts, nvidia.WithCapabilities(caps...))
+	} else {
+		gpuOpts = append(gpuOpts, nvidia.WithAllCapabilities)
+	}
+	if rootlessutil.IsRootless() {
+		gpuOpts = append(gpuOpts, nvidia.WithNoCgroups)
+	}
+	return nvidia.WithGPUs(gpuOpts...)
+}
@@ -0,0 +1,133 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/containerd/v2/pkg/oci"
+)
+
+func writeCDISpec(t *testing.T, kind string) string {
+	t.Helper()
+	dir := t.TempDir()
+	spec := `cdiVersion: "0.5.0"
+kind: ` + kind + `
+devices:
+  - name: "0"
+    containerEdits:
+      env:
+        - GPU=0
+  - name: all
+    containerEdits:
+      env:
+        - GPU=all
+`
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "spec.yaml"), []byte(spec), 0o644))
+	return dir
+}
+
+func TestResolveGPUMode(t *testing.T) {
+	nvidiaDir := writeCDISpec(t, "nvidia.com/gpu")
+	otherDir := writeCDISpec(t, "example.com/device")
+	emptyDir := t.TempDir()
+
+	testCases := []struct {
+		name     string
+		mode     string
+		dirs     []string
+		expected string
+		err      string
+	}{
+		{name: "auto with nvidia cdi devices", mode: GPUModeAuto, dirs: []string{nvidiaDir}, expected: GPUModeCDI},
+		{name: "empty mode is auto", mode: "", dirs: []string{emptyDir, nvidiaDir}, expected: GPUModeCDI},
+		{name: "auto without cdi specs", mode: GPUModeAuto, dirs: []string{emptyDir}, expected: GPUModeLegacy},
+		{name: "auto with other cdi devices", mode: GPUModeAuto, dirs: []string{otherDir}, expected: GPUModeLegacy},
+		{name: "auto with missing spec dir", mode: GPUModeAuto, dirs: []string{filepath.Join(emptyDir, "missing")}, expected: GPUModeLegacy},
+		{name: "forced cdi", mode: GPUModeCDI, dirs: []string{emptyDir}, expected: GPUModeCDI},
+		{name: "forced legacy", mode: GPUModeLegacy, dirs: []string{nvidiaDir}, expected: GPUModeLegacy},
+		{name: "invalid", mode: "foo", err: "invalid gpu mode"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mode, err := resolveGPUMode(tc.mode, tc.dirs)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, tc.expected, mode)
+		})
+	}
+}
+
+func TestParseGPUOpts(t *testing.T) {
+	// Fake binaries for the legacy hook
+	binDir := t.TempDir()
+	for _, bin := range []string{"containerd", "nvidia-container-cli"} {
+		assert.NilError(t, os.WriteFile(filepath.Join(binDir, bin), []byte("#!/bin/sh\n"), 0o755))
+	}
+	t.Setenv("PATH", binDir)
+
+	nvidiaDir := writeCDISpec(t, "nvidia.com/gpu")
+	emptyDir := t.TempDir()
+
+	applyGPUOpts := func(t *testing.T, dirs []string, mode string, gpus ...string) *oci.Spec {
+		t.Helper()
+		opts, err := parseGPUOpts(dirs, mode, gpus)
+		assert.NilError(t, err)
+		s := &oci.Spec{Process: &specs.Process{}}
+		for _, o := range opts {
+			assert.NilError(t, o(context.Background(), nil, nil, s))
+		}
+		return s
+	}
+
+	t.Run("cdi", func(t *testing.T) {
+		s := applyGPUOpts(t, []string{nvidiaDir}, GPUModeAuto, "all")
+		assert.DeepEqual(t, s.Process.Env, []string{"GPU=all"})
+		assert.Assert(t, s.Hooks == nil)
+	})
+
+	t.Run("legacy", func(t *testing.T) {
+		s := applyGPUOpts(t, []string{emptyDir}, GPUModeAuto, "device=0,capabilities=compute")
+		assert.Equal(t, len(s.Hooks.CreateRuntime), 1)
+		hook := s.Hooks.CreateRuntime[0]
+		assert.Equal(t, hook.Path, filepath.Join(binDir, "containerd"))
+		assert.DeepEqual(t, hook.Args[:5], []string{
+			"containerd", "oci-hook", "--", filepath.Join(binDir, "nvidia-container-cli"), "--load-kmods",
+		})
+		// The args also contain --no-cgroups in rootless mode
+		assert.Assert(t, slices.Contains(hook.Args, "--device=0"), "%v", hook.Args)
+		assert.Assert(t, slices.Contains(hook.Args, "--compute"), "%v", hook.Args)
+		assert.Assert(t, !slices.Contains(hook.Args, "--utility"), "%v", hook.Args)
+		assert.Assert(t, len(s.Process.Env) == 0)
+	})
+
+	t.Run("no gpus", func(t *testing.T) {
+		opts, err := parseGPUOpts(nil, "foo", nil)
+		assert.NilError(t, err)
+		assert.Equal(t, len(opts), 0)
+	})
+}
@@ -0,0 +1,96 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/containerd/v2/pkg/oci"
+)
+
+func TestGPUEnvDefaults(t *testing.T) {
+	testCases := []struct {
+		name     string
+		gpus     []string
+		expected []string
+	}{
+		{
+			name:     "all",
+			gpus:     []string{"all"},
+			expected: []string{"NVIDIA_VISIBLE_DEVICES=all", "NVIDIA_DRIVER_CAPABILITIES=compute,utility"},
+		},
+		{
+			name:     "count",
+			gpus:     []string{"2"},
+			expected: []string{"NVIDIA_VISIBLE_DEVICES=0,1", "NVIDIA_DRIVER_CAPABILITIES=compute,utility"},
+		},
+		{
+			name:     "devices",
+			gpus:     []string{`"device=GPU-abc,GPU-def"`},
+			expected: []string{"NVIDIA_VISIBLE_DEVICES=GPU-abc,GPU-def", "NVIDIA_DRIVER_CAPABILITIES=compute,utility"},
+		},
+		{
+			name:     "capabilities",
+			gpus:     []string{`count=all,"capabilities=gpu,nvidia,compute,video"`},
+			expected: []string{"NVIDIA_VISIBLE_DEVICES=all", "NVIDIA_DRIVER_CAPABILITIES=compute,video"},
+		},
+		{
+			name:     "multiple requests",
+			gpus:     []string{"device=0,capabilities=compute", "device=1,capabilities=utility"},
+			expected: []string{"NVIDIA_VISIBLE_DEVICES=0,1", "NVIDIA_DRIVER_CAPABILITIES=compute,utility"},
+		},
+		{
+			name: "no devices",
+			gpus: []string{"0"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			envs, err := gpuEnvDefaults(tc.gpus)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, envs, tc.expected)
+		})
+	}
+
+	_, err := gpuEnvDefaults([]string{"driver=amd"})
+	assert.ErrorContains(t, err, "invalid driver")
+}
+
+func TestWithGPUEnvDefaults(t *testing.T) {
+	opt, err := withGPUEnvDefaults([]string{"all"})
+	assert.NilError(t, err)
+
+	s := &oci.Spec{Process: &specs.Process{Env: []string{"PATH=/bin"}}}
+	assert.NilError(t, opt(context.Background(), nil, nil, s))
+	assert.DeepEqual(t, s.Process.Env, []string{
+		"PATH=/bin",
+		"NVIDIA_VISIBLE_DEVICES=all",
+		"NVIDIA_DRIVER_CAPABILITIES=compute,utility",
+	})
+
+	// The env of the image or of the user is kept
+	s = &oci.Spec{Process: &specs.Process{Env: []string{"NVIDIA_DRIVER_CAPABILITIES=all"}}}
+	assert.NilError(t, opt(context.Background(), nil, nil, s))
+	assert.DeepEqual(t, s.Process.Env, []string{
+		"NVIDIA_DRIVER_CAPABILITIES=all",
+		"NVIDIA_VISIBLE_DEVICES=all",
+	})
+}
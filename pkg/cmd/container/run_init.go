@@ -0,0 +1,59 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// defaultInitBinaries are searched in order when neither `--init-binary` nor the
+// `default_init_binary` of nerdctl.toml is specified.
+// "docker-init" is the tini binary vendored in the Docker packages.
+var defaultInitBinaries = []string{
+	"tini",
+	"docker-init",
+	"/usr/bin/tini",
+	"/usr/libexec/docker/docker-init",
+}
+
+// resolveInitBinary returns the path of the init binary for `--init`.
+// initBinary (`--init-binary`) takes precedence over defaultInitBinary (`default_init_binary`).
+func resolveInitBinary(initBinary, defaultInitBinary string) (string, error) {
+	for _, b := range []string{initBinary, defaultInitBinary} {
+		if b == "" {
+			continue
+		}
+		binaryPath, err := exec.LookPath(b)
+		if err != nil {
+			if errors.Is(err, exec.ErrNotFound) {
+				return "", fmt.Errorf("init binary %q not found", b)
+			}
+			return "", err
+		}
+		return binaryPath, nil
+	}
+	for _, b := range defaultInitBinaries {
+		if binaryPath, err := exec.LookPath(b); err == nil {
+			return binaryPath, nil
+		}
+	}
+	return "", fmt.Errorf("no init binary found (searched %s): install tini, or specify --init-binary or the default_init_binary of nerdctl.toml",
+		strings.Join(defaultInitBinaries, ", "))
+}
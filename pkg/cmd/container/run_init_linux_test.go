@@ -0,0 +1,95 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestResolveInitBinary(t *testing.T) {
+	binDir := t.TempDir()
+	for _, b := range []string{"tini-custom", "tini-default", "docker-init"} {
+		assert.NilError(t, os.WriteFile(filepath.Join(binDir, b), []byte("#!/bin/sh\n"), 0o755))
+	}
+	t.Setenv("PATH", binDir)
+	origDefaultInitBinaries := defaultInitBinaries
+	defaultInitBinaries = []string{"tini", "docker-init", filepath.Join(t.TempDir(), "tini")}
+	t.Cleanup(func() {
+		defaultInitBinaries = origDefaultInitBinaries
+	})
+
+	testCases := []struct {
+		name              string
+		initBinary        string
+		defaultInitBinary string
+		expected          string
+		expectedErr       string
+	}{
+		{
+			name:     "search",
+			expected: filepath.Join(binDir, "docker-init"),
+		},
+		{
+			name:              "default_init_binary",
+			defaultInitBinary: "tini-default",
+			expected:          filepath.Join(binDir, "tini-default"),
+		},
+		{
+			name:              "init-binary overrides default_init_binary",
+			initBinary:        "tini-custom",
+			defaultInitBinary: "tini-default",
+			expected:          filepath.Join(binDir, "tini-custom"),
+		},
+		{
+			name:        "init-binary not found",
+			initBinary:  "tini-missing",
+			expectedErr: `init binary "tini-missing" not found`,
+		},
+		{
+			name:              "default_init_binary not found",
+			defaultInitBinary: "tini-missing",
+			expectedErr:       `init binary "tini-missing" not found`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := resolveInitBinary(tc.initBinary, tc.defaultInitBinary)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, tc.expected)
+		})
+	}
+}
+
+func TestResolveInitBinaryNotFound(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	origDefaultInitBinaries := defaultInitBinaries
+	defaultInitBinaries = []string{"tini", "docker-init"}
+	t.Cleanup(func() {
+		defaultInitBinaries = origDefaultInitBinaries
+	})
+
+	_, err := resolveInitBinary("", "")
+	assert.ErrorContains(t, err, "no init binary found (searched tini, docker-init)")
+}
@@ -50,6 +50,10 @@ func setPlatformOptions(ctx context.Context, client *containerd.Client, id, uts
 		WithoutRunMount(), // unmount default tmpfs on "/run": https://github.com/containerd/nerdctl/issues/157)
 	)
 
+	if err := checkIsolation(options.Isolation); err != nil {
+		return nil, err
+	}
+
 	opts = append(opts,
 		oci.WithMounts([]specs.Mount{
 			{Type: "cgroup", Source: "cgroup", Destination: "/sys/fs/cgroup", Options: []string{"ro", "nosuid", "noexec", "nodev"}},
@@ -83,7 +87,7 @@ func setPlatformOptions(ctx context.Context, client *containerd.Client, id, uts
 	}
 	opts = append(opts, b4nnOpts...)
 
-	ulimitOpts, err := generateUlimitsOpts(options.Ulimit)
+	ulimitOpts, err := generateUlimitsOpts(options.GOptions.DefaultUlimits, options.Ulimit)
 	if err != nil {
 		return nil, err
 	}
@@ -98,7 +102,7 @@ func setPlatformOptions(ctx context.Context, client *containerd.Client, id, uts
 	if options.Sysctl != nil {
 		opts = append(opts, WithSysctls(strutil.ConvertKVStringsToMap(options.Sysctl)))
 	}
-	gpuOpt, err := parseGPUOpts(options.GOptions.CDISpecDirs, options.GPUs)
+	gpuOpt, err := parseGPUOpts(options.GOptions.CDISpecDirs, options.GOptions.GPUMode, options.GPUs)
 	if err != nil {
 		return nil, err
 	}
@@ -261,36 +265,15 @@ func withOOMScoreAdj(score int) oci.SpecOpts {
 	}
 }
 
-func parseGPUOpts(cdiSpecDirs []string, value []string) (res []oci.SpecOpts, _ error) {
-	for _, gpu := range value {
-		req, err := ParseGPUOptCSV(gpu)
-		if err != nil {
-			return nil, err
-		}
-		res = append(res, withCDIDevices(cdiSpecDirs, req.toCDIDeviceIDS()...))
-	}
-	return res, nil
-}
-
-func (req *GPUReq) toCDIDeviceIDS() []string {
-	var cdiDeviceIDs []string
-	for _, id := range req.normalizeDeviceIDs() {
-		cdiDeviceIDs = append(cdiDeviceIDs, "nvidia.com/gpu="+id)
-	}
-	return cdiDeviceIDs
-}
-
-func (req *GPUReq) normalizeDeviceIDs() []string {
-	if len(req.DeviceIDs) > 0 {
-		return req.DeviceIDs
-	}
-	if req.Count < 0 {
-		return []string{"all"}
-	}
-	var ids []string
-	for i := 0; i < req.Count; i++ {
-		ids = append(ids, fmt.Sprintf("%d", i))
+// checkIsolation accepts the Windows isolation values so that cross-platform specs can be used on Linux,
+// where they have no effect.
+func checkIsolation(isolation string) error {
+	switch isolation {
+	case "", "default":
+		return nil
+	case "process", "hyperv", "host":
+		log.L.Debugf("ignoring isolation %q: only supported on Windows", isolation)
+		return nil
 	}
-
-	return ids
+	return fmt.Errorf("unknown isolation value %q. valid values are 'default', 'host', 'process' or 'hyperv'", isolation)
 }
@@ -24,8 +24,10 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"sort"
 	"strings"
+	"syscall"
 	"time"
 
 	securejoin "github.com/cyphar/filepath-securejoin"
@@ -44,6 +46,7 @@ import (
 
 	"github.com/containerd/nerdctl/v2/pkg/api/types"
 	"github.com/containerd/nerdctl/v2/pkg/idgen"
+	"github.com/containerd/nerdctl/v2/pkg/idutil/containerwalker"
 	"github.com/containerd/nerdctl/v2/pkg/imgutil"
 	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/dockercompat"
 	"github.com/containerd/nerdctl/v2/pkg/labels"
@@ -103,11 +106,16 @@ func parseMountFlags(volStore volumestore.VolumeStore, options types.ContainerCr
 		parsed = append(parsed, x)
 	}
 
+	tmpfsDests := make(map[string]struct{})
 	for _, v := range strutil.DedupeStrSlice(options.Tmpfs) {
 		x, err := mountutil.ProcessFlagTmpfs(v)
 		if err != nil {
 			return nil, err
 		}
+		if _, ok := tmpfsDests[x.Mount.Destination]; ok {
+			return nil, fmt.Errorf("duplicate tmpfs mount point %q", x.Mount.Destination)
+		}
+		tmpfsDests[x.Mount.Destination] = struct{}{}
 		parsed = append(parsed, x)
 	}
 
@@ -237,6 +245,12 @@ func generateMountOpts(ctx context.Context, client *containerd.Client, ensuredIm
 				return nil, nil, nil, err
 			}
 
+			if x.Type == mountutil.Bind && tempDir != "" {
+				if err := validateBindTarget(tempDir, target, x.Mount.Source, x.Mount.Destination); err != nil {
+					return nil, nil, nil, err
+				}
+			}
+
 			// Copying content in AnonymousVolume and namedVolume
 			if x.Type == "volume" {
 				if err := copyExistingContents(target, x.Mount.Source); err != nil {
@@ -254,6 +268,18 @@ func generateMountOpts(ctx context.Context, client *containerd.Client, ensuredIm
 		mountPoints = append(mountPoints, parsed...)
 	}
 
+	// --volumes-from takes precedence over the image volumes, but not over --volume and --mount
+	if len(options.VolumesFrom) > 0 {
+		sources, err := getVolumesFromSources(ctx, client, options.VolumesFrom)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		// Like Docker, the imported volumes are not anonymous volumes of the new container: `rm -v` does not remove them
+		vfMounts, vfMountPoints := importVolumesFrom(sources, mounted)
+		userMounts = append(userMounts, vfMounts...)
+		mountPoints = append(mountPoints, vfMountPoints...)
+	}
+
 	// imageVolumes are defined in Dockerfile "VOLUME" instruction
 	for imgVolRaw := range imageVolumes {
 		imgVol := filepath.Clean(imgVolRaw)
@@ -295,6 +321,7 @@ func generateMountOpts(ctx context.Context, client *containerd.Client, ensuredIm
 		mountPoint := &mountutil.Processed{
 			Type:            "volume",
 			AnonymousVolume: anonVolName,
+			Driver:          anonVol.Driver,
 			Mount:           m,
 		}
 		mountPoints = append(mountPoints, mountPoint)
@@ -302,58 +329,188 @@ func generateMountOpts(ctx context.Context, client *containerd.Client, ensuredIm
 
 	opts = append(opts, withMounts(userMounts))
 
-	containers, err := client.Containers(ctx)
-	if err != nil {
-		return nil, nil, nil, err
-	}
+	return opts, anonVolumes, mountPoints, nil
+}
+
+// volumesFromSource is a container specified by `--volumes-from CONTAINER[:ro|:rw]`.
+type volumesFromSource struct {
+	// mode is "ro", "rw", or empty to keep the mode of each mount
+	mode        string
+	mountPoints []dockercompat.MountPoint
+	specMounts  []specs.Mount
+}
 
-	vfSet := strutil.SliceToSet(options.VolumesFrom)
-	var vfMountPoints []dockercompat.MountPoint
-	var vfAnonVolumes []string
+// parseVolumesFrom parses `CONTAINER[:ro|:rw]`.
+func parseVolumesFrom(s string) (container, mode string, err error) {
+	container, mode, _ = strings.Cut(s, ":")
+	if container == "" {
+		return "", "", fmt.Errorf("invalid --volumes-from %q: container must be specified", s)
+	}
+	switch mode {
+	case "", "ro", "rw":
+	default:
+		return "", "", fmt.Errorf("invalid --volumes-from %q: mode must be \"ro\" or \"rw\"", s)
+	}
+	return container, mode, nil
+}
 
-	for _, c := range containers {
-		ls, err := c.Labels(ctx)
+// getVolumesFromSources resolves the containers of `--volumes-from`, in the order of the flags.
+func getVolumesFromSources(ctx context.Context, client *containerd.Client, volumesFrom []string) ([]volumesFromSource, error) {
+	sources := make([]volumesFromSource, 0, len(volumesFrom))
+	for _, vf := range volumesFrom {
+		req, mode, err := parseVolumesFrom(vf)
 		if err != nil {
-			// Containerd note: there is no guarantee that the containers we got from the list still exist at this point
-			// If that is the case, just ignore and move on
-			if errors.Is(err, errdefs.ErrNotFound) {
-				log.G(ctx).Debugf("container %q is gone - ignoring", c.ID())
-				continue
-			}
-			return nil, nil, nil, err
+			return nil, err
+		}
+		src := volumesFromSource{mode: mode}
+		walker := &containerwalker.ContainerWalker{
+			Client: client,
+			OnFound: func(ctx context.Context, found containerwalker.Found) error {
+				if found.MatchCount > 1 {
+					return fmt.Errorf("multiple IDs found with provided prefix: %s", found.Req)
+				}
+				ls, err := found.Container.Labels(ctx)
+				if err != nil {
+					return err
+				}
+				if m, ok := ls[labels.Mounts]; ok {
+					if err := json.Unmarshal([]byte(m), &src.mountPoints); err != nil {
+						return err
+					}
+				}
+				spec, err := found.Container.Spec(ctx)
+				if err != nil {
+					return err
+				}
+				src.specMounts = spec.Mounts
+				return nil
+			},
 		}
-		_, idMatch := vfSet[c.ID()]
-		nameMatch := false
-		if name, found := ls[labels.Name]; found {
-			_, nameMatch = vfSet[name]
+		n, err := walker.Walk(ctx, req)
+		if err != nil {
+			return nil, err
 		}
+		if n == 0 {
+			return nil, fmt.Errorf("no such container: %s", req)
+		}
+		sources = append(sources, src)
+	}
+	return sources, nil
+}
 
-		if idMatch || nameMatch {
-			if av, found := ls[labels.AnonymousVolumes]; found {
-				err = json.Unmarshal([]byte(av), &vfAnonVolumes)
-				if err != nil {
-					return nil, nil, nil, err
+// importVolumesFrom returns the volumes and the bind mounts of the sources.
+// A destination that is already in mounted (i.e., specified by --volume or --mount) is skipped,
+// and when several sources have the same destination, the later source wins.
+// mounted is updated with the imported destinations.
+func importVolumesFrom(sources []volumesFromSource, mounted map[string]struct{}) ([]specs.Mount, []*mountutil.Processed) {
+	var (
+		ociMounts   []specs.Mount
+		mountPoints []*mountutil.Processed
+	)
+	index := make(map[string]int)
+	for _, src := range sources {
+		for _, mp := range src.mountPoints {
+			if mp.Type != mountutil.Bind && mp.Type != mountutil.Volume {
+				continue
+			}
+			dst := filepath.Clean(mp.Destination)
+			i, imported := index[dst]
+			if _, ok := mounted[dst]; ok && !imported {
+				continue
+			}
+
+			m := specs.Mount{
+				Type:        "none",
+				Source:      mp.Source,
+				Destination: mp.Destination,
+				Options:     []string{"rbind"},
+			}
+			for _, sm := range src.specMounts {
+				if filepath.Clean(sm.Destination) == dst {
+					m = sm
+					m.Options = append([]string(nil), sm.Options...)
+					break
 				}
 			}
-			if m, found := ls[labels.Mounts]; found {
-				err = json.Unmarshal([]byte(m), &vfMountPoints)
-				if err != nil {
-					return nil, nil, nil, err
+			p := &mountutil.Processed{
+				Type:  mp.Type,
+				Name:  mp.Name,
+				Mode:  mp.Mode,
+				Mount: m,
+			}
+			if src.mode != "" {
+				p.Mount.Options = setMountReadonly(p.Mount.Options, src.mode == "ro")
+				var modeOpts []string
+				if p.Mode != "" {
+					modeOpts = strings.Split(p.Mode, ",")
 				}
+				p.Mode = strings.Join(setMountReadonly(modeOpts, src.mode == "ro"), ",")
 			}
 
-			ps := processeds(vfMountPoints)
-			s, err := c.Spec(ctx)
-			if err != nil {
-				return nil, nil, nil, err
+			if imported {
+				ociMounts[i] = p.Mount
+				mountPoints[i] = p
+				continue
 			}
-			opts = append(opts, withMounts(s.Mounts))
-			anonVolumes = append(anonVolumes, vfAnonVolumes...)
-			mountPoints = append(mountPoints, ps...)
+			index[dst] = len(ociMounts)
+			mounted[dst] = struct{}{}
+			ociMounts = append(ociMounts, p.Mount)
+			mountPoints = append(mountPoints, p)
 		}
 	}
+	return ociMounts, mountPoints
+}
 
-	return opts, anonVolumes, mountPoints, nil
+// setMountReadonly replaces the "ro", "rro" and "rw" options with "ro" if readonly is true, "rw" otherwise.
+// "rro" is kept if readonly is true.
+func setMountReadonly(options []string, readonly bool) []string {
+	mode := "rw"
+	if readonly {
+		mode = "ro"
+		if slices.Contains(options, "rro") {
+			mode = "rro"
+		}
+	}
+	res := []string{mode}
+	for _, o := range options {
+		if o == "ro" || o == "rw" || o == "rro" {
+			continue
+		}
+		res = append(res, o)
+	}
+	return res
+}
+
+// validateBindTarget checks that the bind mount source can be mounted onto target, the destination
+// resolved inside the image rootfs. A missing target is created by the OCI runtime along with its
+// parents (an empty file for file sources, a directory otherwise), but a target of the other kind,
+// or a parent that is not a directory, makes the mount fail with an opaque error at start time.
+func validateBindTarget(rootfs, target, source, destination string) error {
+	srcInfo, err := os.Stat(source)
+	if err != nil {
+		// A missing source has already been reported when parsing the flag.
+		return nil
+	}
+	for p := target; ; p = filepath.Dir(p) {
+		fi, err := os.Lstat(p)
+		if err == nil {
+			switch {
+			case p != target && !fi.IsDir():
+				return fmt.Errorf("cannot create mount point %q: %q is not a directory in the image", destination, strings.TrimPrefix(p, rootfs))
+			case p == target && srcInfo.IsDir() && !fi.IsDir():
+				return fmt.Errorf("cannot mount directory %q onto file %q", source, destination)
+			case p == target && !srcInfo.IsDir() && fi.IsDir():
+				return fmt.Errorf("cannot mount file %q onto directory %q", source, destination)
+			}
+			return nil
+		}
+		if !os.IsNotExist(err) && !errors.Is(err, syscall.ENOTDIR) {
+			return fmt.Errorf("failed to stat mount point %q: %w", destination, err)
+		}
+		if p == rootfs || p == filepath.Dir(p) {
+			return nil
+		}
+	}
 }
 
 // copyExistingContents copies from the source to the destination and
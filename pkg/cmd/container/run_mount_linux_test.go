@@ -0,0 +1,158 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/nerdctl/v2/pkg/api/types"
+	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/dockercompat"
+)
+
+func TestParseMountFlagsTmpfs(t *testing.T) {
+	t.Parallel()
+
+	parsed, err := parseMountFlags(nil, types.ContainerCreateOptions{
+		Tmpfs: []string{"/run", "/tmp:size=64m,mode=1777", "/run"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(parsed), 2)
+	assert.Equal(t, parsed[0].Mount.Destination, "/run")
+	assert.DeepEqual(t, parsed[0].Mount.Options, []string{"rw", "noexec", "nosuid", "nodev"})
+	assert.Equal(t, parsed[1].Mount.Destination, "/tmp")
+	assert.DeepEqual(t, parsed[1].Mount.Options, []string{"rw", "noexec", "nosuid", "nodev", "size=64m", "mode=1777"})
+
+	_, err = parseMountFlags(nil, types.ContainerCreateOptions{
+		Tmpfs: []string{"/run", "/run:size=64m"},
+	})
+	assert.ErrorContains(t, err, "duplicate tmpfs mount point")
+}
+
+func TestValidateBindTarget(t *testing.T) {
+	t.Parallel()
+
+	rootfs := t.TempDir()
+	assert.NilError(t, os.MkdirAll(filepath.Join(rootfs, "etc", "conf.d"), 0o755))
+	assert.NilError(t, os.WriteFile(filepath.Join(rootfs, "etc", "hostname"), nil, 0o644))
+
+	hostDir := t.TempDir()
+	hostFile := filepath.Join(hostDir, "file")
+	assert.NilError(t, os.WriteFile(hostFile, []byte("content"), 0o644))
+
+	tests := []struct {
+		name        string
+		source      string
+		destination string
+		err         string
+	}{
+		{name: "file onto nonexistent path", source: hostFile, destination: "/nonexistent/dir/file"},
+		{name: "directory onto nonexistent path", source: hostDir, destination: "/nonexistent/dir"},
+		{name: "file onto existing file", source: hostFile, destination: "/etc/hostname"},
+		{name: "directory onto existing directory", source: hostDir, destination: "/etc/conf.d"},
+		{name: "nonexistent source", source: filepath.Join(hostDir, "missing"), destination: "/etc/hostname"},
+		{name: "file onto directory", source: hostFile, destination: "/etc/conf.d", err: "cannot mount file"},
+		{name: "directory onto file", source: hostDir, destination: "/etc/hostname", err: "cannot mount directory"},
+		{name: "parent is a file", source: hostFile, destination: "/etc/hostname/file", err: `"/etc/hostname" is not a directory`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateBindTarget(rootfs, filepath.Join(rootfs, tc.destination), tc.source, tc.destination)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestParseVolumesFrom(t *testing.T) {
+	t.Parallel()
+
+	for s, expected := range map[string][2]string{
+		"data":    {"data", ""},
+		"data:ro": {"data", "ro"},
+		"data:rw": {"data", "rw"},
+	} {
+		container, mode, err := parseVolumesFrom(s)
+		assert.NilError(t, err)
+		assert.Equal(t, container, expected[0])
+		assert.Equal(t, mode, expected[1])
+	}
+
+	_, _, err := parseVolumesFrom("data:z")
+	assert.ErrorContains(t, err, "mode must be")
+	_, _, err = parseVolumesFrom(":ro")
+	assert.ErrorContains(t, err, "container must be specified")
+}
+
+func TestImportVolumesFrom(t *testing.T) {
+	t.Parallel()
+
+	data := volumesFromSource{
+		mode: "ro",
+		mountPoints: []dockercompat.MountPoint{
+			{Type: "volume", Name: "datavol", Source: "/vols/datavol", Destination: "/data", RW: true},
+			{Type: "bind", Source: "/host/shared", Destination: "/shared", Mode: "rw", RW: true},
+			{Type: "bind", Source: "/host/user", Destination: "/user", RW: true},
+			{Type: "tmpfs", Destination: "/tmp", RW: true},
+		},
+		specMounts: []specs.Mount{
+			{Type: "none", Source: "/vols/datavol", Destination: "/data", Options: []string{"rbind"}},
+			{Type: "bind", Source: "/host/shared", Destination: "/shared", Options: []string{"rbind", "rw"}},
+			{Type: "bind", Source: "/host/user", Destination: "/user", Options: []string{"rbind"}},
+			{Type: "tmpfs", Source: "tmpfs", Destination: "/tmp"},
+		},
+	}
+	cache := volumesFromSource{
+		mountPoints: []dockercompat.MountPoint{
+			{Type: "volume", Name: "0123abcd", Source: "/vols/0123abcd", Destination: "/cache", RW: true},
+			{Type: "bind", Source: "/host/shared2", Destination: "/shared/", RW: true},
+		},
+		specMounts: []specs.Mount{
+			{Type: "none", Source: "/vols/0123abcd", Destination: "/cache", Options: []string{"rbind"}},
+			{Type: "bind", Source: "/host/shared2", Destination: "/shared/", Options: []string{"rbind", "ro"}},
+		},
+	}
+	// "/user" is specified by --volume
+	mounted := map[string]struct{}{"/user": {}}
+
+	ociMounts, mountPoints := importVolumesFrom([]volumesFromSource{data, cache}, mounted)
+	assert.DeepEqual(t, ociMounts, []specs.Mount{
+		{Type: "none", Source: "/vols/datavol", Destination: "/data", Options: []string{"ro", "rbind"}},
+		// overridden by the later source, with its own mode
+		{Type: "bind", Source: "/host/shared2", Destination: "/shared/", Options: []string{"rbind", "ro"}},
+		{Type: "none", Source: "/vols/0123abcd", Destination: "/cache", Options: []string{"rbind"}},
+	})
+	assert.Equal(t, len(mountPoints), 3)
+	assert.Equal(t, mountPoints[0].Mode, "ro")
+	assert.Equal(t, mountPoints[0].Name, "datavol")
+	assert.Equal(t, mountPoints[1].Mount.Source, "/host/shared2")
+	assert.Equal(t, mountPoints[2].Name, "0123abcd")
+	// the anonymous volumes of the sources are not anonymous volumes of the new container
+	assert.Equal(t, mountPoints[2].AnonymousVolume, "")
+	for _, dst := range []string{"/user", "/data", "/shared", "/cache"} {
+		_, ok := mounted[dst]
+		assert.Assert(t, ok, "expected %q to be mounted", dst)
+	}
+}
@@ -18,7 +18,9 @@ package container
 
 import (
 	"context"
+	"fmt"
 	"os/exec"
+	"strconv"
 	"strings"
 
 	"github.com/opencontainers/runtime-spec/specs-go"
@@ -31,7 +33,13 @@ import (
 	"github.com/containerd/log"
 )
 
-func generateRuntimeCOpts(cgroupManager, runtimeStr string) ([]containerd.NewContainerOpts, error) {
+// gVisor reads runsc flags from annotations with this prefix (requires `allow-flag-override` in runsc.toml)
+const gvisorFlagAnnotationPrefix = "dev.gvisor.flag."
+
+// generateRuntimeCOpts returns the opts for selecting the runtime.
+// runtimeOptions (key=value) are set onto the runc options message when they match one of its fields,
+// and are passed through to the shim as OCI annotations otherwise.
+func generateRuntimeCOpts(cgroupManager, runtimeStr string, runtimeOptions []string) ([]containerd.NewContainerOpts, map[string]string, error) {
 	runtime := plugins.RuntimeRuncV2
 	var (
 		runcOpts    runcoptions.Options
@@ -60,8 +68,70 @@ func generateRuntimeCOpts(cgroupManager, runtimeStr string) ([]containerd.NewCon
 			}
 		}
 	}
+
+	annotations := make(map[string]string)
+	for _, kv := range runtimeOptions {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || key == "" {
+			return nil, nil, fmt.Errorf("invalid runtime option %q, must be key=value", kv)
+		}
+		if runtimeOpts != nil {
+			found, err := setRuntimeOption(&runcOpts, key, value)
+			if err != nil {
+				return nil, nil, err
+			}
+			if found {
+				continue
+			}
+		}
+		if strings.HasPrefix(runtime, "io.containerd.runsc.") && !strings.Contains(key, ".") {
+			key = gvisorFlagAnnotationPrefix + key
+		}
+		log.L.Debugf("passing runtime option %q to runtime %q as an annotation", key, runtime)
+		annotations[key] = value
+	}
+
 	o := containerd.WithRuntime(runtime, runtimeOpts)
-	return []containerd.NewContainerOpts{o}, nil
+	return []containerd.NewContainerOpts{o}, annotations, nil
+}
+
+// setRuntimeOption sets the runc option named key (e.g. `binary_name` or `BinaryName`).
+// It returns false if the runc options have no such field.
+func setRuntimeOption(opts *runcoptions.Options, key, value string) (bool, error) {
+	var err error
+	switch strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key)) {
+	case "nopivotroot":
+		opts.NoPivotRoot, err = strconv.ParseBool(value)
+	case "nonewkeyring":
+		opts.NoNewKeyring, err = strconv.ParseBool(value)
+	case "systemdcgroup":
+		opts.SystemdCgroup, err = strconv.ParseBool(value)
+	case "shimcgroup":
+		opts.ShimCgroup = value
+	case "binaryname":
+		opts.BinaryName = value
+	case "root":
+		opts.Root = value
+	case "criuimagepath":
+		opts.CriuImagePath = value
+	case "criuworkpath":
+		opts.CriuWorkPath = value
+	case "iouid":
+		opts.IoUid, err = parseUint32(value)
+	case "iogid":
+		opts.IoGid, err = parseUint32(value)
+	default:
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("invalid value for runtime option %q: %w", key, err)
+	}
+	return true, nil
+}
+
+func parseUint32(s string) (uint32, error) {
+	v, err := strconv.ParseUint(s, 10, 32)
+	return uint32(v), err
 }
 
 // WithSysctls sets the provided sysctls onto the spec
@@ -0,0 +1,86 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containerd/typeurl/v2"
+	"gotest.tools/v3/assert"
+
+	runcoptions "github.com/containerd/containerd/api/types/runc/options"
+	"github.com/containerd/containerd/v2/core/containers"
+)
+
+func TestGenerateRuntimeCOpts(t *testing.T) {
+	t.Parallel()
+
+	apply := func(t *testing.T, runtime string, runtimeOpts []string) (*containers.Container, map[string]string) {
+		t.Helper()
+		cOpts, annotations, err := generateRuntimeCOpts("cgroupfs", runtime, runtimeOpts)
+		assert.NilError(t, err)
+		c := &containers.Container{}
+		for _, o := range cOpts {
+			assert.NilError(t, o(context.Background(), nil, c))
+		}
+		return c, annotations
+	}
+
+	t.Run("runc options are set on the shim options", func(t *testing.T) {
+		t.Parallel()
+		c, annotations := apply(t, "io.containerd.runc.v2", []string{
+			"binary_name=/usr/local/bin/crun",
+			"NoPivotRoot=true",
+			"io-uid=1000",
+			"com.example.foo=bar",
+		})
+		assert.Equal(t, c.Runtime.Name, "io.containerd.runc.v2")
+		v, err := typeurl.UnmarshalAny(c.Runtime.Options)
+		assert.NilError(t, err)
+		opts, ok := v.(*runcoptions.Options)
+		assert.Assert(t, ok)
+		assert.Equal(t, opts.BinaryName, "/usr/local/bin/crun")
+		assert.Equal(t, opts.NoPivotRoot, true)
+		assert.Equal(t, opts.IoUid, uint32(1000))
+		assert.DeepEqual(t, annotations, map[string]string{"com.example.foo": "bar"})
+	})
+
+	t.Run("options for other runtimes are passed as annotations", func(t *testing.T) {
+		t.Parallel()
+		c, annotations := apply(t, "io.containerd.runsc.v1", []string{
+			"platform=ptrace",
+			"binary_name=foo",
+			"com.example.foo=bar",
+		})
+		assert.Equal(t, c.Runtime.Name, "io.containerd.runsc.v1")
+		assert.Assert(t, c.Runtime.Options == nil)
+		assert.DeepEqual(t, annotations, map[string]string{
+			"dev.gvisor.flag.platform":    "ptrace",
+			"dev.gvisor.flag.binary_name": "foo",
+			"com.example.foo":             "bar",
+		})
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		t.Parallel()
+		for _, opt := range []string{"nokey", "=value", "no_pivot_root=maybe", "io_gid=-1"} {
+			_, _, err := generateRuntimeCOpts("cgroupfs", "io.containerd.runc.v2", []string{opt})
+			assert.ErrorContains(t, err, "runtime option", opt)
+		}
+	})
+}
@@ -17,14 +17,22 @@
 package container
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/opencontainers/runtime-spec/specs-go"
+
 	"github.com/containerd/containerd/v2/contrib/apparmor"
 	"github.com/containerd/containerd/v2/contrib/seccomp"
+	"github.com/containerd/containerd/v2/core/containers"
 	"github.com/containerd/containerd/v2/pkg/cap"
 	"github.com/containerd/containerd/v2/pkg/oci"
 	"github.com/containerd/log"
@@ -32,7 +40,6 @@ import (
 	"github.com/containerd/nerdctl/v2/pkg/apparmorutil"
 	"github.com/containerd/nerdctl/v2/pkg/defaults"
 	"github.com/containerd/nerdctl/v2/pkg/maputil"
-	"github.com/containerd/nerdctl/v2/pkg/strutil"
 )
 
 var privilegedOpts = []oci.SpecOpts{
@@ -52,56 +59,33 @@ const (
 )
 
 func generateSecurityOpts(privileged bool, securityOptsMap map[string]string) ([]oci.SpecOpts, error) {
+	securityOptsMap = normalizeSecurityOpts(securityOptsMap)
 	for k := range securityOptsMap {
 		switch k {
-		case "seccomp", "apparmor", "no-new-privileges", "systempaths", "privileged-without-host-devices", "writable-cgroups":
+		case "seccomp", "apparmor", "no-new-privileges", "systempaths", "mask", "unmask", "privileged-without-host-devices", "writable-cgroups":
 		default:
 			log.L.Warnf("unknown security-opt: %q", k)
 		}
 	}
-	var opts []oci.SpecOpts
-	if seccompProfile, ok := securityOptsMap["seccomp"]; ok && seccompProfile != defaults.SeccompProfileName {
-		if seccompProfile == "" {
-			return nil, errors.New("invalid security-opt \"seccomp\"")
-		}
-
-		if seccompProfile != "unconfined" {
-			opts = append(opts, seccomp.WithProfile(seccompProfile))
-		}
-	} else {
-		opts = append(opts, seccomp.WithDefaultProfile())
+	opts, err := generateSeccompOpts(securityOptsMap)
+	if err != nil {
+		return nil, err
 	}
 
-	canLoadNewAppArmor := apparmorutil.CanLoadNewProfile()
-	canApplyExistingProfile := apparmorutil.CanApplyExistingProfile()
-	if aaProfile, ok := securityOptsMap["apparmor"]; ok {
-		if aaProfile == "" {
-			return nil, errors.New("invalid security-opt \"apparmor\"")
-		}
-		if aaProfile != "unconfined" {
-			if !canApplyExistingProfile {
-				log.L.Warnf("the host does not support AppArmor. Ignoring profile %q", aaProfile)
-			} else {
-				opts = append(opts, apparmor.WithProfile(aaProfile))
-			}
-		}
-	} else {
-		if canLoadNewAppArmor {
-			if err := apparmor.LoadDefaultProfile(defaults.AppArmorProfileName); err != nil {
-				return nil, err
-			}
-		}
-		if apparmorutil.CanApplySpecificExistingProfile(defaults.AppArmorProfileName) {
-			opts = append(opts, apparmor.WithProfile(defaults.AppArmorProfileName))
-		}
+	aaOpts, err := generateAppArmorOpts(securityOptsMap)
+	if err != nil {
+		return nil, err
 	}
+	opts = append(opts, aaOpts...)
 
 	nnp, err := maputil.MapBoolValueAsOpt(securityOptsMap, "no-new-privileges")
 	if err != nil {
 		return nil, err
 	}
 
-	if !nnp {
+	if nnp {
+		opts = append(opts, oci.WithNoNewPrivileges)
+	} else {
 		opts = append(opts, oci.WithNewPrivileges)
 	}
 
@@ -112,6 +96,12 @@ func generateSecurityOpts(privileged bool, securityOptsMap map[string]string) ([
 		return nil, errors.New(`invalid security-opt "systempaths=unconfined"`)
 	}
 
+	maskOpts, err := generateMaskOpts(securityOptsMap)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, maskOpts...)
+
 	privilegedWithoutHostDevices, err := maputil.MapBoolValueAsOpt(securityOptsMap, "privileged-without-host-devices")
 	if err != nil {
 		return nil, err
@@ -141,6 +131,153 @@ func generateSecurityOpts(privileged bool, securityOptsMap map[string]string) ([
 	return opts, nil
 }
 
+// generateMaskOpts generates the spec opts for the "mask" and "unmask" security-opts (compatible with Podman).
+// Both take a colon-separated list of paths. "mask" adds the paths to the masked paths,
+// and "unmask" removes the paths from the default masked and read-only paths ("ALL" removes all of them).
+func generateMaskOpts(securityOptsMap map[string]string) ([]oci.SpecOpts, error) {
+	var opts []oci.SpecOpts
+	if value, ok := securityOptsMap["unmask"]; ok {
+		if value == "" {
+			return nil, errors.New(`invalid security-opt "unmask"`)
+		}
+		paths := strings.Split(value, ":")
+		if slices.Contains(paths, "ALL") {
+			opts = append(opts, oci.WithMaskedPaths(nil), oci.WithReadonlyPaths(nil))
+		} else {
+			opts = append(opts, withoutMaskedPaths(paths))
+		}
+	}
+	if value, ok := securityOptsMap["mask"]; ok {
+		if value == "" {
+			return nil, errors.New(`invalid security-opt "mask"`)
+		}
+		paths := strings.Split(value, ":")
+		for _, p := range paths {
+			if !filepath.IsAbs(p) {
+				return nil, fmt.Errorf("invalid security-opt \"mask\": %q is not an absolute path", p)
+			}
+		}
+		opts = append(opts, withAddedMaskedPaths(paths))
+	}
+	return opts, nil
+}
+
+func withAddedMaskedPaths(paths []string) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *oci.Spec) error {
+		if s.Linux == nil {
+			s.Linux = &specs.Linux{}
+		}
+		for _, p := range paths {
+			if !slices.Contains(s.Linux.MaskedPaths, p) {
+				s.Linux.MaskedPaths = append(s.Linux.MaskedPaths, p)
+			}
+		}
+		return nil
+	}
+}
+
+func withoutMaskedPaths(paths []string) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *oci.Spec) error {
+		if s.Linux == nil {
+			return nil
+		}
+		s.Linux.MaskedPaths = slices.DeleteFunc(s.Linux.MaskedPaths, func(p string) bool {
+			return slices.Contains(paths, p)
+		})
+		s.Linux.ReadonlyPaths = slices.DeleteFunc(s.Linux.ReadonlyPaths, func(p string) bool {
+			return slices.Contains(paths, p)
+		})
+		return nil
+	}
+}
+
+// normalizeSecurityOpts converts the Docker-compatible "no-new-privileges:<bool>" form
+// into the "no-new-privileges=<bool>" form.
+func normalizeSecurityOpts(securityOptsMap map[string]string) map[string]string {
+	res := make(map[string]string, len(securityOptsMap))
+	for k, v := range securityOptsMap {
+		if name, value, ok := strings.Cut(k, ":"); ok && name == "no-new-privileges" && v == "" {
+			k, v = name, value
+		}
+		res[k] = v
+	}
+	return res
+}
+
+// AppArmor host checks, replaceable for testing.
+var (
+	appArmorCanLoadNewProfile               = apparmorutil.CanLoadNewProfile
+	appArmorCanApplyExistingProfile         = apparmorutil.CanApplyExistingProfile
+	appArmorCanApplySpecificExistingProfile = apparmorutil.CanApplySpecificExistingProfile
+	appArmorLoadDefaultProfile              = apparmor.LoadDefaultProfile
+)
+
+// generateAppArmorOpts generates the AppArmor spec opts from the "apparmor" security-opt.
+// A named profile must already be loaded (except the nerdctl default profile, which is loaded on demand),
+// "unconfined" disables AppArmor, and an unset value applies the nerdctl default profile when possible.
+// On hosts without AppArmor, the option is ignored with a warning.
+func generateAppArmorOpts(securityOptsMap map[string]string) ([]oci.SpecOpts, error) {
+	aaProfile, ok := securityOptsMap["apparmor"]
+	if !ok {
+		if appArmorCanLoadNewProfile() {
+			if err := appArmorLoadDefaultProfile(defaults.AppArmorProfileName); err != nil {
+				return nil, err
+			}
+		}
+		if appArmorCanApplySpecificExistingProfile(defaults.AppArmorProfileName) {
+			return []oci.SpecOpts{apparmor.WithProfile(defaults.AppArmorProfileName)}, nil
+		}
+		return nil, nil
+	}
+
+	switch aaProfile {
+	case "":
+		return nil, errors.New("invalid security-opt \"apparmor\"")
+	case "unconfined":
+		return nil, nil
+	}
+	if !appArmorCanApplyExistingProfile() {
+		log.L.Warnf("the host does not support AppArmor. Ignoring profile %q", aaProfile)
+		return nil, nil
+	}
+	if aaProfile == defaults.AppArmorProfileName && appArmorCanLoadNewProfile() {
+		if err := appArmorLoadDefaultProfile(defaults.AppArmorProfileName); err != nil {
+			return nil, err
+		}
+	}
+	if !appArmorCanApplySpecificExistingProfile(aaProfile) {
+		return nil, fmt.Errorf("AppArmor profile %q cannot be applied (Hint: check that the profile is loaded with `nerdctl apparmor ls`)", aaProfile)
+	}
+	return []oci.SpecOpts{apparmor.WithProfile(aaProfile)}, nil
+}
+
+// generateSeccompOpts generates the seccomp spec opts from the "seccomp" security-opt.
+// The profile may be a path to a JSON profile, "unconfined" to disable seccomp,
+// or unset (or the name of the default profile) to use the built-in default profile.
+func generateSeccompOpts(securityOptsMap map[string]string) ([]oci.SpecOpts, error) {
+	seccompProfile, ok := securityOptsMap["seccomp"]
+	if !ok || seccompProfile == defaults.SeccompProfileName {
+		return []oci.SpecOpts{seccomp.WithDefaultProfile()}, nil
+	}
+	switch seccompProfile {
+	case "":
+		return nil, errors.New("invalid security-opt \"seccomp\"")
+	case "unconfined":
+		return nil, nil
+	}
+
+	// Validate the profile early, so that the error is reported before the container is created
+	b, err := os.ReadFile(seccompProfile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load seccomp profile %q: %w", seccompProfile, err)
+	}
+	var profile specs.LinuxSeccomp
+	if err := json.Unmarshal(b, &profile); err != nil {
+		return nil, fmt.Errorf("failed to decode seccomp profile %q: %w", seccompProfile, err)
+	}
+	return []oci.SpecOpts{seccomp.WithProfile(seccompProfile)}, nil
+}
+
 func canonicalizeCapName(s string) string {
 	if s == "" {
 		return ""
@@ -173,32 +310,42 @@ func isKnownCapName(s string) bool {
 	return ok
 }
 
+// generateCapOpts resolves the capabilities in the same order as Docker:
+// the default set is applied first, then the dropped capabilities, and finally the added ones.
+// "ALL" in capAdd grants all the capabilities except the dropped ones,
+// and "ALL" in capDrop replaces the default set with the added capabilities only.
 func generateCapOpts(capAdd, capDrop []string) ([]oci.SpecOpts, error) {
 	if len(capAdd) == 0 && len(capDrop) == 0 {
 		return nil, nil
 	}
 
-	var opts []oci.SpecOpts
-	if strutil.InStringSlice(capDrop, "ALL") {
-		opts = append(opts, oci.WithCapabilities(nil))
-	}
+	addAll, capsAdd := canonicalizeCapNames(capAdd)
+	dropAll, capsDrop := canonicalizeCapNames(capDrop)
 
-	if strutil.InStringSlice(capAdd, "ALL") {
-		opts = append(opts, oci.WithAllCurrentCapabilities)
-	} else {
-		var capsAdd []string
-		for _, c := range capAdd {
-			capsAdd = append(capsAdd, canonicalizeCapName(c))
-		}
-		opts = append(opts, oci.WithAddedCapabilities(capsAdd))
+	var opts []oci.SpecOpts
+	switch {
+	case addAll:
+		opts = append(opts, oci.WithAllCurrentCapabilities, oci.WithDroppedCapabilities(capsDrop))
+	case dropAll:
+		opts = append(opts, oci.WithCapabilities(nil), oci.WithAddedCapabilities(capsAdd))
+	default:
+		opts = append(opts, oci.WithDroppedCapabilities(capsDrop), oci.WithAddedCapabilities(capsAdd))
 	}
+	return opts, nil
+}
 
-	if !strutil.InStringSlice(capDrop, "ALL") {
-		var capsDrop []string
-		for _, c := range capDrop {
-			capsDrop = append(capsDrop, canonicalizeCapName(c))
+// canonicalizeCapNames canonicalizes the given capability names, and reports whether "ALL" was specified.
+func canonicalizeCapNames(caps []string) (bool, []string) {
+	var (
+		all      bool
+		capNames []string
+	)
+	for _, c := range caps {
+		if strings.EqualFold(c, "ALL") {
+			all = true
+			continue
 		}
-		opts = append(opts, oci.WithDroppedCapabilities(capsDrop))
+		capNames = append(capNames, canonicalizeCapName(c))
 	}
-	return opts, nil
+	return all, capNames
 }
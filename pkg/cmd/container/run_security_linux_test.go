@@ -0,0 +1,428 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/containerd/v2/pkg/oci"
+
+	"github.com/containerd/nerdctl/v2/pkg/defaults"
+	"github.com/containerd/nerdctl/v2/pkg/strutil"
+)
+
+func newCapSpec(defaultCaps []string) *oci.Spec {
+	return &oci.Spec{
+		Process: &specs.Process{
+			Capabilities: &specs.LinuxCapabilities{
+				Bounding:  slices.Clone(defaultCaps),
+				Effective: slices.Clone(defaultCaps),
+				Permitted: slices.Clone(defaultCaps),
+			},
+		},
+	}
+}
+
+func TestGenerateCapOpts(t *testing.T) {
+	t.Parallel()
+	defaultCaps := []string{"CAP_CHOWN", "CAP_NET_RAW", "CAP_SETUID"}
+	tests := []struct {
+		name     string
+		capAdd   []string
+		capDrop  []string
+		expected []string
+	}{
+		{
+			name:     "no changes",
+			expected: defaultCaps,
+		},
+		{
+			name:     "drop ALL then add",
+			capAdd:   []string{"NET_BIND_SERVICE"},
+			capDrop:  []string{"ALL"},
+			expected: []string{"CAP_NET_BIND_SERVICE"},
+		},
+		{
+			name:     "drop all lowercase then add",
+			capAdd:   []string{"cap_net_bind_service", "chown"},
+			capDrop:  []string{"all"},
+			expected: []string{"CAP_NET_BIND_SERVICE", "CAP_CHOWN"},
+		},
+		{
+			name:     "drop ALL only",
+			capDrop:  []string{"ALL"},
+			expected: []string{},
+		},
+		{
+			name:     "mixed add and drop",
+			capAdd:   []string{"NET_ADMIN"},
+			capDrop:  []string{"NET_RAW"},
+			expected: []string{"CAP_CHOWN", "CAP_SETUID", "CAP_NET_ADMIN"},
+		},
+		{
+			name:     "add takes precedence over drop",
+			capAdd:   []string{"NET_RAW"},
+			capDrop:  []string{"NET_RAW", "SETUID"},
+			expected: []string{"CAP_CHOWN", "CAP_NET_RAW"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			s := newCapSpec(defaultCaps)
+			opts, err := generateCapOpts(tc.capAdd, tc.capDrop)
+			assert.NilError(t, err)
+			for _, opt := range opts {
+				assert.NilError(t, opt(ctx, nil, nil, s))
+			}
+			for _, set := range [][]string{s.Process.Capabilities.Bounding, s.Process.Capabilities.Effective, s.Process.Capabilities.Permitted} {
+				assert.DeepEqual(t, sortedCaps(set), sortedCaps(tc.expected))
+			}
+		})
+	}
+}
+
+func TestGenerateCapOptsAddAll(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	s := newCapSpec([]string{"CAP_CHOWN"})
+	opts, err := generateCapOpts([]string{"ALL"}, []string{"CHOWN"})
+	assert.NilError(t, err)
+	for _, opt := range opts {
+		assert.NilError(t, opt(ctx, nil, nil, s))
+	}
+	assert.Assert(t, !slices.Contains(s.Process.Capabilities.Bounding, "CAP_CHOWN"))
+	assert.Assert(t, !slices.Contains(s.Process.Capabilities.Effective, "CAP_CHOWN"))
+	assert.Assert(t, !slices.Contains(s.Process.Capabilities.Permitted, "CAP_CHOWN"))
+}
+
+func sortedCaps(caps []string) []string {
+	res := append([]string{}, caps...)
+	slices.Sort(res)
+	return res
+}
+
+func TestGenerateSeccompOpts(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	customProfile := filepath.Join(dir, "custom.json")
+	assert.NilError(t, os.WriteFile(customProfile, []byte(`{"defaultAction":"SCMP_ACT_ERRNO","syscalls":[{"names":["read"],"action":"SCMP_ACT_ALLOW"}]}`), 0o644))
+	invalidProfile := filepath.Join(dir, "invalid.json")
+	assert.NilError(t, os.WriteFile(invalidProfile, []byte(`{"defaultAction":`), 0o644))
+
+	tests := []struct {
+		name          string
+		securityOpts  map[string]string
+		expectError   string
+		expectSeccomp func(t *testing.T, sc *specs.LinuxSeccomp)
+	}{
+		{
+			name:         "default",
+			securityOpts: map[string]string{},
+			expectSeccomp: func(t *testing.T, sc *specs.LinuxSeccomp) {
+				assert.Assert(t, sc != nil)
+				assert.Equal(t, sc.DefaultAction, specs.ActErrno)
+				assert.Assert(t, len(sc.Syscalls) > 1)
+			},
+		},
+		{
+			name:         "unconfined",
+			securityOpts: map[string]string{"seccomp": "unconfined"},
+			expectSeccomp: func(t *testing.T, sc *specs.LinuxSeccomp) {
+				assert.Assert(t, sc == nil)
+			},
+		},
+		{
+			name:         "custom profile",
+			securityOpts: map[string]string{"seccomp": customProfile},
+			expectSeccomp: func(t *testing.T, sc *specs.LinuxSeccomp) {
+				assert.Assert(t, sc != nil)
+				assert.Equal(t, sc.DefaultAction, specs.ActErrno)
+				assert.Equal(t, len(sc.Syscalls), 1)
+				assert.DeepEqual(t, sc.Syscalls[0].Names, []string{"read"})
+			},
+		},
+		{
+			name:         "invalid profile",
+			securityOpts: map[string]string{"seccomp": invalidProfile},
+			expectError:  "failed to decode seccomp profile",
+		},
+		{
+			name:         "missing profile",
+			securityOpts: map[string]string{"seccomp": filepath.Join(dir, "missing.json")},
+			expectError:  "failed to load seccomp profile",
+		},
+		{
+			name:         "empty",
+			securityOpts: map[string]string{"seccomp": ""},
+			expectError:  "invalid security-opt",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			opts, err := generateSeccompOpts(tc.securityOpts)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NilError(t, err)
+			s := newCapSpec([]string{"CAP_CHOWN"})
+			s.Linux = &specs.Linux{}
+			for _, opt := range opts {
+				assert.NilError(t, opt(ctx, nil, nil, s))
+			}
+			tc.expectSeccomp(t, s.Linux.Seccomp)
+		})
+	}
+}
+
+func TestGenerateAppArmorOpts(t *testing.T) {
+	// Not parallel, as the AppArmor host checks are replaced
+	loadedProfiles := map[string]bool{"my-profile": true}
+	tests := []struct {
+		name            string
+		supported       bool
+		securityOpts    map[string]string
+		expectError     string
+		expectedProfile string
+	}{
+		{
+			name:            "default",
+			supported:       true,
+			securityOpts:    map[string]string{},
+			expectedProfile: defaults.AppArmorProfileName,
+		},
+		{
+			name:            "unconfined",
+			supported:       true,
+			securityOpts:    map[string]string{"apparmor": "unconfined"},
+			expectedProfile: "",
+		},
+		{
+			name:            "custom profile",
+			supported:       true,
+			securityOpts:    map[string]string{"apparmor": "my-profile"},
+			expectedProfile: "my-profile",
+		},
+		{
+			name:         "missing profile",
+			supported:    true,
+			securityOpts: map[string]string{"apparmor": "missing-profile"},
+			expectError:  `AppArmor profile "missing-profile" cannot be applied`,
+		},
+		{
+			name:         "empty",
+			supported:    true,
+			securityOpts: map[string]string{"apparmor": ""},
+			expectError:  "invalid security-opt",
+		},
+		{
+			name:            "default without AppArmor",
+			supported:       false,
+			securityOpts:    map[string]string{},
+			expectedProfile: "",
+		},
+		{
+			name:            "custom profile without AppArmor",
+			supported:       false,
+			securityOpts:    map[string]string{"apparmor": "my-profile"},
+			expectedProfile: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			setAppArmorHost(t, tc.supported, loadedProfiles)
+			ctx := context.Background()
+			opts, err := generateAppArmorOpts(tc.securityOpts)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NilError(t, err)
+			s := &oci.Spec{Process: &specs.Process{}}
+			for _, opt := range opts {
+				assert.NilError(t, opt(ctx, nil, nil, s))
+			}
+			assert.Equal(t, s.Process.ApparmorProfile, tc.expectedProfile)
+		})
+	}
+}
+
+func setAppArmorHost(t *testing.T, supported bool, loadedProfiles map[string]bool) {
+	origCanLoad, origCanApply, origCanApplySpecific, origLoad := appArmorCanLoadNewProfile, appArmorCanApplyExistingProfile, appArmorCanApplySpecificExistingProfile, appArmorLoadDefaultProfile
+	t.Cleanup(func() {
+		appArmorCanLoadNewProfile, appArmorCanApplyExistingProfile, appArmorCanApplySpecificExistingProfile, appArmorLoadDefaultProfile = origCanLoad, origCanApply, origCanApplySpecific, origLoad
+	})
+	appArmorCanLoadNewProfile = func() bool { return supported }
+	appArmorCanApplyExistingProfile = func() bool { return supported }
+	appArmorCanApplySpecificExistingProfile = func(name string) bool { return supported && loadedProfiles[name] }
+	appArmorLoadDefaultProfile = func(name string) error {
+		loadedProfiles[name] = true
+		return nil
+	}
+}
+
+func TestGenerateSecurityOptsNoNewPrivileges(t *testing.T) {
+	// Not parallel, as the AppArmor host checks are replaced
+	setAppArmorHost(t, false, nil)
+
+	tests := []struct {
+		name         string
+		securityOpts []string
+		expected     bool
+		expectError  string
+	}{
+		{
+			name:     "default",
+			expected: false,
+		},
+		{
+			name:         "flag only",
+			securityOpts: []string{"no-new-privileges"},
+			expected:     true,
+		},
+		{
+			name:         "equal form",
+			securityOpts: []string{"no-new-privileges=true"},
+			expected:     true,
+		},
+		{
+			name:         "colon form",
+			securityOpts: []string{"no-new-privileges:true"},
+			expected:     true,
+		},
+		{
+			name:         "colon form false",
+			securityOpts: []string{"no-new-privileges:false"},
+			expected:     false,
+		},
+		{
+			name:         "invalid value",
+			securityOpts: []string{"no-new-privileges=foo"},
+			expectError:  "invalid \"no-new-privileges\" value",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			opts, err := generateSecurityOpts(false, strutil.ConvertKVStringsToMap(tc.securityOpts))
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NilError(t, err)
+			s := newCapSpec([]string{"CAP_CHOWN"})
+			s.Linux = &specs.Linux{}
+			for _, opt := range opts {
+				assert.NilError(t, opt(ctx, nil, nil, s))
+			}
+			assert.Equal(t, s.Process.NoNewPrivileges, tc.expected)
+		})
+	}
+}
+
+func TestGenerateMaskOpts(t *testing.T) {
+	t.Parallel()
+	defaultMasked := []string{"/proc/kcore", "/proc/keys", "/sys/firmware"}
+	defaultReadonly := []string{"/proc/bus", "/proc/sys"}
+	tests := []struct {
+		name             string
+		securityOpts     map[string]string
+		expectError      string
+		expectedMasked   []string
+		expectedReadonly []string
+	}{
+		{
+			name:             "default",
+			securityOpts:     map[string]string{},
+			expectedMasked:   defaultMasked,
+			expectedReadonly: defaultReadonly,
+		},
+		{
+			name:             "mask",
+			securityOpts:     map[string]string{"mask": "/proc/acpi:/proc/kcore:/sys/devices/virtual"},
+			expectedMasked:   []string{"/proc/kcore", "/proc/keys", "/sys/firmware", "/proc/acpi", "/sys/devices/virtual"},
+			expectedReadonly: defaultReadonly,
+		},
+		{
+			name:             "unmask",
+			securityOpts:     map[string]string{"unmask": "/proc/kcore:/proc/sys"},
+			expectedMasked:   []string{"/proc/keys", "/sys/firmware"},
+			expectedReadonly: []string{"/proc/bus"},
+		},
+		{
+			name:             "unmask ALL",
+			securityOpts:     map[string]string{"unmask": "ALL"},
+			expectedMasked:   nil,
+			expectedReadonly: nil,
+		},
+		{
+			name:             "unmask and mask",
+			securityOpts:     map[string]string{"unmask": "ALL", "mask": "/proc/acpi"},
+			expectedMasked:   []string{"/proc/acpi"},
+			expectedReadonly: nil,
+		},
+		{
+			name:         "relative mask",
+			securityOpts: map[string]string{"mask": "proc/acpi"},
+			expectError:  "is not an absolute path",
+		},
+		{
+			name:         "empty unmask",
+			securityOpts: map[string]string{"unmask": ""},
+			expectError:  `invalid security-opt "unmask"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			opts, err := generateMaskOpts(tc.securityOpts)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NilError(t, err)
+			s := &oci.Spec{
+				Linux: &specs.Linux{
+					MaskedPaths:   slices.Clone(defaultMasked),
+					ReadonlyPaths: slices.Clone(defaultReadonly),
+				},
+			}
+			for _, opt := range opts {
+				assert.NilError(t, opt(ctx, nil, nil, s))
+			}
+			assert.DeepEqual(t, append([]string{}, s.Linux.MaskedPaths...), append([]string{}, tc.expectedMasked...))
+			assert.DeepEqual(t, append([]string{}, s.Linux.ReadonlyPaths...), append([]string{}, tc.expectedReadonly...))
+		})
+	}
+}
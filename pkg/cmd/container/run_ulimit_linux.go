@@ -29,27 +29,43 @@ import (
 	"github.com/containerd/nerdctl/v2/pkg/strutil"
 )
 
-func generateUlimitsOpts(ulimits []string) ([]oci.SpecOpts, error) {
+// generateUlimitsOpts applies defaultUlimits (from the `default_ulimits` config) and ulimits (from `--ulimit`).
+// A ulimit in ulimits overrides the default ulimit with the same name.
+func generateUlimitsOpts(defaultUlimits, ulimits []string) ([]oci.SpecOpts, error) {
 	var opts []oci.SpecOpts
-	ulimits = strutil.DedupeStrSlice(ulimits)
-	if len(ulimits) > 0 {
-		var rlimits []specs.POSIXRlimit
-		for _, ulimit := range ulimits {
-			l, err := units.ParseUlimit(ulimit)
-			if err != nil {
-				return nil, err
-			}
-			rlimits = append(rlimits, specs.POSIXRlimit{
-				Type: "RLIMIT_" + strings.ToUpper(l.Name),
-				Hard: uint64(l.Hard),
-				Soft: uint64(l.Soft),
-			})
-		}
+	rlimits, err := mergeUlimits(defaultUlimits, ulimits)
+	if err != nil {
+		return nil, err
+	}
+	if len(rlimits) > 0 {
 		opts = append(opts, withRlimits(rlimits))
 	}
 	return opts, nil
 }
 
+func mergeUlimits(defaultUlimits, ulimits []string) ([]specs.POSIXRlimit, error) {
+	var rlimits []specs.POSIXRlimit
+	index := map[string]int{}
+	for _, ulimit := range strutil.DedupeStrSlice(append(append([]string{}, defaultUlimits...), ulimits...)) {
+		l, err := units.ParseUlimit(ulimit)
+		if err != nil {
+			return nil, err
+		}
+		rlimit := specs.POSIXRlimit{
+			Type: "RLIMIT_" + strings.ToUpper(l.Name),
+			Hard: uint64(l.Hard),
+			Soft: uint64(l.Soft),
+		}
+		if i, ok := index[rlimit.Type]; ok {
+			rlimits[i] = rlimit
+			continue
+		}
+		index[rlimit.Type] = len(rlimits)
+		rlimits = append(rlimits, rlimit)
+	}
+	return rlimits, nil
+}
+
 func withRlimits(rlimits []specs.POSIXRlimit) oci.SpecOpts {
 	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *oci.Spec) error {
 		s.Process.Rlimits = rlimits
@@ -0,0 +1,86 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/containerd/v2/pkg/oci"
+)
+
+func TestGenerateUlimitsOpts(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		defaultUlimits []string
+		ulimits        []string
+		expected       []specs.POSIXRlimit
+		err            bool
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:     "flags only",
+			ulimits:  []string{"nofile=1024:2048"},
+			expected: []specs.POSIXRlimit{{Type: "RLIMIT_NOFILE", Soft: 1024, Hard: 2048}},
+		},
+		{
+			name:           "config defaults apply",
+			defaultUlimits: []string{"nofile=1024:2048", "nproc=512"},
+			expected: []specs.POSIXRlimit{
+				{Type: "RLIMIT_NOFILE", Soft: 1024, Hard: 2048},
+				{Type: "RLIMIT_NPROC", Soft: 512, Hard: 512},
+			},
+		},
+		{
+			name:           "flags override config defaults by name",
+			defaultUlimits: []string{"nofile=1024:2048", "nproc=512"},
+			ulimits:        []string{"nofile=4096", "core=0"},
+			expected: []specs.POSIXRlimit{
+				{Type: "RLIMIT_NOFILE", Soft: 4096, Hard: 4096},
+				{Type: "RLIMIT_NPROC", Soft: 512, Hard: 512},
+				{Type: "RLIMIT_CORE", Soft: 0, Hard: 0},
+			},
+		},
+		{
+			name:           "invalid config default",
+			defaultUlimits: []string{"nofile"},
+			err:            true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			opts, err := generateUlimitsOpts(tc.defaultUlimits, tc.ulimits)
+			if tc.err {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			spec := &oci.Spec{Process: &specs.Process{}}
+			for _, o := range opts {
+				assert.NilError(t, o(context.Background(), nil, nil, spec))
+			}
+			assert.DeepEqual(t, tc.expected, spec.Process.Rlimits)
+		})
+	}
+}
@@ -19,6 +19,7 @@ package container
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 
 	"github.com/containerd/containerd/v2/core/containers"
@@ -46,9 +47,23 @@ func generateUmaskOpts(umask string) ([]oci.SpecOpts, error) {
 	return opts, nil
 }
 
+// keepGroups is the special --group-add value that preserves the supplementary groups of the caller.
+// It is implemented via the "run.oci.keep_original_groups" annotation, which is supported by crun.
+const keepGroups = "keep-groups"
+
 func generateGroupsOpts(groups []string) ([]oci.SpecOpts, error) {
 	var opts []oci.SpecOpts
 
+	if slices.Contains(groups, keepGroups) {
+		if len(groups) > 1 {
+			return nil, fmt.Errorf("the %q option of --group-add cannot be combined with other groups", keepGroups)
+		}
+		opts = append(opts, oci.WithAnnotations(map[string]string{
+			"run.oci.keep_original_groups": "1",
+		}))
+		return opts, nil
+	}
+
 	if len(groups) != 0 {
 		opts = append(opts, oci.WithAppendAdditionalGroups(groups...))
 	}
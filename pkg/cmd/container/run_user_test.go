@@ -0,0 +1,106 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/containerd/v2/core/containers"
+	"github.com/containerd/containerd/v2/pkg/oci"
+)
+
+func TestGenerateGroupsOpts(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		t.Skip("additional GIDs are not supported on this platform")
+	}
+	t.Parallel()
+
+	rootfs := t.TempDir()
+	assert.NilError(t, os.MkdirAll(filepath.Join(rootfs, "etc"), 0o755))
+	assert.NilError(t, os.WriteFile(filepath.Join(rootfs, "etc", "group"), []byte("root:x:0:\naudio:x:29:\n"), 0o644))
+
+	tests := []struct {
+		name                string
+		groups              []string
+		expectedGIDs        []uint32
+		expectedAnnotations map[string]string
+		expectError         string
+	}{
+		{
+			name:   "no groups",
+			groups: []string{},
+		},
+		{
+			name:         "name and gid",
+			groups:       []string{"audio", "1001"},
+			expectedGIDs: []uint32{29, 1001},
+		},
+		{
+			name:        "unknown name",
+			groups:      []string{"video"},
+			expectError: "unable to find group video",
+		},
+		{
+			name:                "keep-groups",
+			groups:              []string{"keep-groups"},
+			expectedAnnotations: map[string]string{"run.oci.keep_original_groups": "1"},
+		},
+		{
+			name:        "keep-groups combined with other groups",
+			groups:      []string{"keep-groups", "audio"},
+			expectError: "cannot be combined with other groups",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			s := &oci.Spec{
+				Root:    &specs.Root{Path: rootfs},
+				Process: &specs.Process{},
+			}
+			opts, err := generateGroupsOpts(tc.groups)
+			if err == nil {
+				for _, opt := range opts {
+					if err = opt(ctx, nil, &containers.Container{}, s); err != nil {
+						break
+					}
+				}
+			}
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.NilError(t, err)
+			for _, gid := range tc.expectedGIDs {
+				assert.Assert(t, slices.Contains(s.Process.User.AdditionalGids, gid), "gid %d should be in %v", gid, s.Process.User.AdditionalGids)
+			}
+			for k, v := range tc.expectedAnnotations {
+				assert.Equal(t, s.Annotations[k], v)
+			}
+		})
+	}
+}
@@ -36,7 +36,6 @@ import (
 
 	"github.com/containerd/nerdctl/v2/pkg/api/types"
 	"github.com/containerd/nerdctl/v2/pkg/clientutil"
-	"github.com/containerd/nerdctl/v2/pkg/containerinspector"
 	"github.com/containerd/nerdctl/v2/pkg/containerutil"
 	"github.com/containerd/nerdctl/v2/pkg/eventutil"
 	"github.com/containerd/nerdctl/v2/pkg/formatter"
@@ -141,11 +140,8 @@ func Stats(ctx context.Context, client *containerd.Client, containerIDs []string
 		}
 
 		for _, c := range containers {
-			cStatus := formatter.ContainerStatus(ctx, c)
-			if !options.All {
-				if !strings.HasPrefix(cStatus, "Up") {
-					continue
-				}
+			if !showInStats(formatter.ContainerStatus(ctx, c), options.All) {
+				continue
 			}
 			// if an error occurs when getting labels, the ID alone is sufficient for the stats screen.
 			clabels, _ := c.Labels(ctx)
@@ -232,6 +228,9 @@ func Stats(ctx context.Context, client *containerd.Client, containerIDs []string
 		walker := &containerwalker.ContainerWalker{
 			Client: client,
 			OnFound: func(ctx context.Context, found containerwalker.Found) error {
+				if found.MatchCount > 1 {
+					return fmt.Errorf("multiple IDs found with provided prefix: %s", found.Req)
+				}
 				// if an error occurs when getting labels, the ID alone is sufficient for the stats screen.
 				clabels, _ := found.Container.Labels(ctx)
 				s := statsutil.NewStats(found.Container.ID(), containerutil.GetContainerName(clabels))
@@ -293,7 +292,7 @@ func Stats(ctx context.Context, client *containerd.Client, containerIDs []string
 				if tmpl != nil {
 					var b bytes.Buffer
 					if err := tmpl.Execute(&b, rc); err != nil {
-						break
+						return err
 					}
 					if _, err = fmt.Fprintln(options.Stdout, b.String()); err != nil {
 						break
@@ -340,6 +339,12 @@ func Stats(ctx context.Context, client *containerd.Client, containerIDs []string
 	return err
 }
 
+// showInStats returns whether a container with the given status is listed by `nerdctl stats`
+// when no container is specified: only running (or paused) containers, unless all is set.
+func showInStats(status string, all bool) bool {
+	return all || strings.HasPrefix(status, "Up") || status == "Paused"
+}
+
 func collect(ctx context.Context, globalOptions types.GlobalCommandOptions, s *statsutil.Stats, waitFirst *sync.WaitGroup, id string, noStream bool) {
 	log.G(ctx).Debugf("collecting stats for %s", s.ID)
 	var (
@@ -371,7 +376,12 @@ func collect(ctx context.Context, globalOptions types.GlobalCommandOptions, s *s
 	go func() {
 		previousStats := new(statsutil.ContainerStats)
 		firstSet := true
-		for {
+		for i := 0; ; i++ {
+			if i > 0 {
+				// sleep to create distant CPU readings, and to avoid busy looping on
+				// containers without a running task (e.g., `nerdctl stats --all`).
+				time.Sleep(500 * time.Millisecond)
+			}
 			// task is in the for loop to avoid nil task just after Container creation
 			task, err := container.Task(ctx, nil)
 			if err != nil {
@@ -401,14 +411,8 @@ func collect(ctx context.Context, globalOptions types.GlobalCommandOptions, s *s
 				continue
 			}
 
-			netNS, err := containerinspector.InspectNetNS(ctx, int(task.Pid()))
-			if err != nil {
-				u <- err
-				continue
-			}
-
 			// when (firstSet == true), we only set container stats without rendering stat entry
-			statsEntry, err := setContainerStatsAndRenderStatsEntry(previousStats, firstSet, anydata, int(task.Pid()), netNS.Interfaces, systemInfo)
+			statsEntry, err := setContainerStatsAndRenderStatsEntry(previousStats, firstSet, anydata, int(task.Pid()), systemInfo)
 			if err != nil {
 				u <- err
 				continue
@@ -420,8 +424,6 @@ func collect(ctx context.Context, globalOptions types.GlobalCommandOptions, s *s
 				s.SetStatistics(statsEntry)
 			}
 			u <- nil
-			// sleep to create distant CPU readings
-			time.Sleep(500 * time.Millisecond)
 		}
 	}()
 	for {
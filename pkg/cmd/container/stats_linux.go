@@ -21,19 +21,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/vishvananda/netlink"
-	"github.com/vishvananda/netns"
-
 	v1 "github.com/containerd/cgroups/v3/cgroup1/stats"
 	v2 "github.com/containerd/cgroups/v3/cgroup2/stats"
 
-	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/native"
 	"github.com/containerd/nerdctl/v2/pkg/statsutil"
 )
 
@@ -47,7 +42,7 @@ const (
 )
 
 //nolint:nakedret
-func setContainerStatsAndRenderStatsEntry(previousStats *statsutil.ContainerStats, firstSet bool, anydata interface{}, pid int, interfaces []native.NetInterface, systemInfo statsutil.SystemInfo) (statsEntry statsutil.StatsEntry, err error) {
+func setContainerStatsAndRenderStatsEntry(previousStats *statsutil.ContainerStats, firstSet bool, anydata interface{}, pid int, systemInfo statsutil.SystemInfo) (statsEntry statsutil.StatsEntry, err error) {
 
 	var (
 		data  *v1.Metrics
@@ -64,52 +59,18 @@ func setContainerStatsAndRenderStatsEntry(previousStats *statsutil.ContainerStat
 		return
 	}
 
-	var nlinks []netlink.Link
-
+	var netStats statsutil.NetworkStats
 	if !firstSet {
-		var (
-			nlink    netlink.Link
-			nlHandle *netlink.Handle
-			ns       netns.NsHandle
-		)
-
-		ns, err = netns.GetFromPid(pid)
-		if err != nil {
-			err = fmt.Errorf("failed to retrieve the statistics in netns %s: %v", ns, err)
-			return
-		}
-		defer func() {
-			err = ns.Close()
-		}()
-
-		nlHandle, err = netlink.NewHandleAt(ns)
+		netStats, err = statsutil.ReadNetworkStats(pid)
 		if err != nil {
-			err = fmt.Errorf("failed to retrieve the statistics in netns %s: %v", ns, err)
+			err = fmt.Errorf("failed to retrieve the network statistics of pid %d: %w", pid, err)
 			return
 		}
-		defer nlHandle.Close()
-
-		for _, v := range interfaces {
-			nlink, err = nlHandle.LinkByIndex(v.Index)
-			if err != nil {
-				err = fmt.Errorf("failed to retrieve the statistics for %s in netns %s: %v", v.Name, ns, err)
-				return
-			}
-			//exclude inactive interface
-			if nlink.Attrs().Flags&net.FlagUp != 0 {
-
-				//exclude loopback interface
-				if nlink.Attrs().Flags&net.FlagLoopback != 0 || strings.HasPrefix(nlink.Attrs().Name, "lo") {
-					continue
-				}
-				nlinks = append(nlinks, nlink)
-			}
-		}
 	}
 
 	if data != nil {
 		if !firstSet {
-			statsEntry, err = statsutil.SetCgroupStatsFields(previousStats, data, nlinks, systemInfo)
+			statsEntry, err = statsutil.SetCgroupStatsFields(previousStats, data, netStats, systemInfo)
 		}
 		previousStats.CgroupCPU = data.CPU.Usage.Total
 		previousStats.CgroupSystem = systemInfo.SystemUsage
@@ -118,7 +79,7 @@ func setContainerStatsAndRenderStatsEntry(previousStats *statsutil.ContainerStat
 		}
 	} else if data2 != nil {
 		if !firstSet {
-			statsEntry, err = statsutil.SetCgroup2StatsFields(previousStats, data2, nlinks)
+			statsEntry, err = statsutil.SetCgroup2StatsFields(previousStats, data2, netStats)
 		}
 		previousStats.Cgroup2CPU = data2.CPU.UsageUsec * 1000
 		previousStats.Cgroup2System = data2.CPU.SystemUsec * 1000
@@ -0,0 +1,44 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestShowInStats(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		status   string
+		all      bool
+		expected bool
+	}{
+		{status: "Up", expected: true},
+		{status: "Paused", expected: true},
+		{status: "Created", expected: false},
+		{status: "Exited (0) 2 minutes ago", expected: false},
+		{status: "Restarting (1) 1 second ago", expected: false},
+		{status: "Created", all: true, expected: true},
+		{status: "Exited (0) 2 minutes ago", all: true, expected: true},
+		{status: "Up", all: true, expected: true},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, showInStats(tc.status, tc.all), tc.expected, "status=%q all=%v", tc.status, tc.all)
+	}
+}
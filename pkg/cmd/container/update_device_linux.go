@@ -0,0 +1,312 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+/*
+   Portions from https://github.com/opencontainers/runc/blob/v1.2.4/libcontainer/specconv/spec_linux.go
+   Copyright The runc Authors.
+   Licensed under the Apache License, Version 2.0
+   NOTICE: https://github.com/opencontainers/runc/blob/v1.2.4/NOTICE
+*/
+
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/link"
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"golang.org/x/sys/unix"
+
+	"github.com/containerd/cgroups/v3"
+	"github.com/containerd/cgroups/v3/cgroup1"
+	"github.com/containerd/cgroups/v3/cgroup2"
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/pkg/oci"
+
+	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/dockercompat"
+	"github.com/containerd/nerdctl/v2/pkg/labels"
+	"github.com/containerd/nerdctl/v2/pkg/rootlessutil"
+)
+
+// UpdateSpecDevices adds the devices of `add` (in the `--device` format) to spec, and removes the devices
+// whose path in the container is listed in `remove`, along with their cgroup rules.
+// The added and the removed devices are returned.
+func UpdateSpecDevices(ctx context.Context, spec *specs.Spec, add, remove []string) (added, removed []specs.LinuxDevice, _ error) {
+	if spec.Linux == nil {
+		spec.Linux = &specs.Linux{}
+	}
+	if spec.Linux.Resources == nil {
+		spec.Linux.Resources = &specs.LinuxResources{}
+	}
+
+	for _, containerPath := range remove {
+		i := findDevice(spec.Linux.Devices, containerPath)
+		if i < 0 {
+			return nil, nil, fmt.Errorf("device %q is not attached to the container", containerPath)
+		}
+		dev := spec.Linux.Devices[i]
+		spec.Linux.Devices = append(spec.Linux.Devices[:i], spec.Linux.Devices[i+1:]...)
+		var rules []specs.LinuxDeviceCgroup
+		for _, rule := range spec.Linux.Resources.Devices {
+			if !rule.Allow || rule.Type != dev.Type || !equalDeviceNumber(rule.Major, dev.Major) || !equalDeviceNumber(rule.Minor, dev.Minor) {
+				rules = append(rules, rule)
+			}
+		}
+		spec.Linux.Resources.Devices = rules
+		removed = append(removed, dev)
+	}
+
+	for _, f := range add {
+		devPath, conPath, mode, err := ParseDevice(f)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse device %q: %w", f, err)
+		}
+		if findDevice(spec.Linux.Devices, conPath) >= 0 {
+			return nil, nil, fmt.Errorf("device %q is already attached to the container", conPath)
+		}
+		n := len(spec.Linux.Devices)
+		if err := oci.WithDevices(devPath, conPath, mode)(ctx, nil, nil, spec); err != nil {
+			return nil, nil, fmt.Errorf("failed to add device %q: %w", f, err)
+		}
+		added = append(added, spec.Linux.Devices[n:]...)
+	}
+	return added, removed, nil
+}
+
+func findDevice(devices []specs.LinuxDevice, containerPath string) int {
+	for i, dev := range devices {
+		if dev.Path == filepath.Clean(containerPath) {
+			return i
+		}
+	}
+	return -1
+}
+
+func equalDeviceNumber(n *int64, m int64) bool {
+	return n != nil && *n == m
+}
+
+// UpdateDeviceMappingLabel updates the devices shown by `nerdctl inspect` after UpdateSpecDevices.
+func UpdateDeviceMappingLabel(ctx context.Context, container containerd.Container, add, remove []string) error {
+	lbls, err := container.Labels(ctx)
+	if err != nil {
+		return err
+	}
+	var hostConfig dockercompat.HostConfigLabel
+	if hostConfigJSON, ok := lbls[labels.HostConfigLabel]; ok {
+		if err := json.Unmarshal([]byte(hostConfigJSON), &hostConfig); err != nil {
+			return err
+		}
+	}
+	for _, containerPath := range remove {
+		var devices []dockercompat.DeviceMapping
+		for _, d := range hostConfig.Devices {
+			if filepath.Clean(d.PathInContainer) != filepath.Clean(containerPath) {
+				devices = append(devices, d)
+			}
+		}
+		hostConfig.Devices = devices
+	}
+	for _, f := range add {
+		devPath, conPath, mode, err := ParseDevice(f)
+		if err != nil {
+			return err
+		}
+		hostConfig.Devices = append(hostConfig.Devices, dockercompat.DeviceMapping{
+			PathOnHost:        devPath,
+			PathInContainer:   conPath,
+			CgroupPermissions: mode,
+		})
+	}
+	hostConfigJSON, err := json.Marshal(hostConfig)
+	if err != nil {
+		return err
+	}
+	_, err = container.SetLabels(ctx, map[string]string{labels.HostConfigLabel: string(hostConfigJSON)})
+	return err
+}
+
+// HotplugDevices makes the changes of UpdateSpecDevices effective in the running task:
+// the device cgroup is updated, and the added (removed) device nodes are created in (removed from) the container.
+func HotplugDevices(pid int, spec *specs.Spec, added, removed []specs.LinuxDevice) error {
+	if rootlessutil.IsRootless() {
+		return errors.New("updating the devices of a running container is not supported in rootless mode")
+	}
+	root := "/proc/" + strconv.Itoa(pid) + "/root"
+	for _, dev := range removed {
+		p, err := securejoin.SecureJoin(root, dev.Path)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove device %q from the container: %w", dev.Path, err)
+		}
+	}
+
+	if cgroups.Mode() == cgroups.Unified {
+		if err := updateDeviceFilterV2(pid, spec.Linux.Resources.Devices); err != nil {
+			return err
+		}
+	} else if err := updateDeviceRulesV1(pid, spec.Linux.Resources.Devices, added, removed); err != nil {
+		return err
+	}
+
+	for _, dev := range added {
+		if err := mknodDevice(root, dev); err != nil {
+			return fmt.Errorf("failed to create device %q in the container: %w", dev.Path, err)
+		}
+	}
+	return nil
+}
+
+// updateDeviceRulesV1 only writes the rules of the added and the removed devices,
+// so that the access to the other devices is never interrupted.
+func updateDeviceRulesV1(pid int, rules []specs.LinuxDeviceCgroup, added, removed []specs.LinuxDevice) error {
+	cg, err := cgroup1.Load(cgroup1.PidPath(pid))
+	if err != nil {
+		return fmt.Errorf("failed to load the cgroup of pid %d: %w", pid, err)
+	}
+	var update []specs.LinuxDeviceCgroup
+	for _, dev := range removed {
+		update = append(update, specs.LinuxDeviceCgroup{Allow: false, Type: dev.Type, Major: &dev.Major, Minor: &dev.Minor, Access: "rwm"})
+	}
+	for _, dev := range added {
+		for _, rule := range rules {
+			if rule.Allow && rule.Type == dev.Type && equalDeviceNumber(rule.Major, dev.Major) && equalDeviceNumber(rule.Minor, dev.Minor) {
+				update = append(update, rule)
+			}
+		}
+	}
+	return cg.Update(&specs.LinuxResources{Devices: update})
+}
+
+// updateDeviceFilterV2 replaces the eBPF device filter of the cgroup of pid,
+// the same way the OCI runtime does when creating the container.
+func updateDeviceFilterV2(pid int, rules []specs.LinuxDeviceCgroup) error {
+	group, err := cgroup2.PidGroupPath(pid)
+	if err != nil {
+		return fmt.Errorf("failed to find the cgroup of pid %d: %w", pid, err)
+	}
+	path := filepath.Join("/sys/fs/cgroup", group)
+	dirFD, err := unix.Open(path, unix.O_DIRECTORY|unix.O_RDONLY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return fmt.Errorf("cannot get dir FD for %s: %w", path, err)
+	}
+	defer unix.Close(dirFD)
+	// The new filter is attached before the old ones are detached, so that there is no time window without a filter.
+	attached, err := link.QueryPrograms(link.QueryOptions{Target: dirFD, Attach: ebpf.AttachCGroupDevice})
+	if err != nil {
+		return err
+	}
+
+	insts, license, err := cgroup2.DeviceFilter(append(rules, runtimeAllowedDevices()...))
+	if err != nil {
+		return err
+	}
+	if _, err := cgroup2.LoadAttachCgroupDeviceFilter(insts, license, dirFD); err != nil {
+		return err
+	}
+	for _, p := range attached.Programs {
+		prog, err := ebpf.NewProgramFromID(p.ID)
+		if err != nil {
+			return err
+		}
+		err = link.RawDetachProgram(link.RawDetachProgramOptions{Target: dirFD, Program: prog, Attach: ebpf.AttachCGroupDevice})
+		prog.Close()
+		if err != nil {
+			return fmt.Errorf("failed to detach the previous device filter: %w", err)
+		}
+	}
+	return nil
+}
+
+// runtimeAllowedDevices returns the devices that the OCI runtime always allows in addition to the rules of the spec.
+// From AllowedDevices in https://github.com/opencontainers/runc/blob/v1.2.4/libcontainer/specconv/spec_linux.go
+func runtimeAllowedDevices() []specs.LinuxDeviceCgroup {
+	wildcard := int64(-1)
+	rule := func(typ string, major, minor int64, access string) specs.LinuxDeviceCgroup {
+		r := specs.LinuxDeviceCgroup{Allow: true, Type: typ, Access: access}
+		if major != wildcard {
+			r.Major = &major
+		}
+		if minor != wildcard {
+			r.Minor = &minor
+		}
+		return r
+	}
+	return []specs.LinuxDeviceCgroup{
+		// allow mknod for any device
+		rule("c", wildcard, wildcard, "m"),
+		rule("b", wildcard, wildcard, "m"),
+		rule("c", 1, 3, "rwm"),          // /dev/null
+		rule("c", 1, 8, "rwm"),          // /dev/random
+		rule("c", 1, 7, "rwm"),          // /dev/full
+		rule("c", 5, 0, "rwm"),          // /dev/tty
+		rule("c", 1, 5, "rwm"),          // /dev/zero
+		rule("c", 1, 9, "rwm"),          // /dev/urandom
+		rule("c", 136, wildcard, "rwm"), // /dev/pts/*
+		rule("c", 5, 2, "rwm"),          // /dev/ptmx
+		rule("c", 10, 200, "rwm"),       // /dev/net/tun
+	}
+}
+
+func mknodDevice(root string, dev specs.LinuxDevice) error {
+	p, err := securejoin.SecureJoin(root, dev.Path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	var mode uint32
+	switch dev.Type {
+	case "c", "u":
+		mode = unix.S_IFCHR
+	case "b":
+		mode = unix.S_IFBLK
+	case "p":
+		mode = unix.S_IFIFO
+	default:
+		return fmt.Errorf("unsupported device type %q", dev.Type)
+	}
+	perm := os.FileMode(0o666)
+	if dev.FileMode != nil {
+		perm = dev.FileMode.Perm()
+	}
+	if err := unix.Mknod(p, mode|uint32(perm), int(unix.Mkdev(uint32(dev.Major), uint32(dev.Minor)))); err != nil {
+		return err
+	}
+	// mknod(2) is subject to the umask
+	if err := os.Chmod(p, perm); err != nil {
+		return err
+	}
+	var uid, gid int
+	if dev.UID != nil {
+		uid = int(*dev.UID)
+	}
+	if dev.GID != nil {
+		gid = int(*dev.GID)
+	}
+	return unix.Chown(p, uid, gid)
+}
@@ -0,0 +1,77 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"gotest.tools/v3/assert"
+)
+
+func TestUpdateSpecDevices(t *testing.T) {
+	ctx := context.Background()
+	denyAll := specs.LinuxDeviceCgroup{Allow: false, Access: "rwm"}
+	spec := &specs.Spec{Linux: &specs.Linux{Resources: &specs.LinuxResources{Devices: []specs.LinuxDeviceCgroup{denyAll}}}}
+
+	added, removed, err := UpdateSpecDevices(ctx, spec, []string{"/dev/null:/dev/hotplug:rw"}, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(removed), 0)
+	assert.Equal(t, len(added), 1)
+	assert.Equal(t, added[0].Path, "/dev/hotplug")
+	assert.Equal(t, added[0].Type, "c")
+	assert.Equal(t, added[0].Major, int64(1))
+	assert.Equal(t, added[0].Minor, int64(3))
+	assert.DeepEqual(t, spec.Linux.Devices, added)
+	assert.Equal(t, len(spec.Linux.Resources.Devices), 2)
+	rule := spec.Linux.Resources.Devices[1]
+	assert.Assert(t, rule.Allow)
+	assert.Equal(t, rule.Access, "rw")
+	assert.Equal(t, *rule.Major, int64(1))
+	assert.Equal(t, *rule.Minor, int64(3))
+
+	_, _, err = UpdateSpecDevices(ctx, spec, []string{"/dev/zero:/dev/hotplug"}, nil)
+	assert.ErrorContains(t, err, "already attached")
+
+	_, _, err = UpdateSpecDevices(ctx, spec, nil, []string{"/dev/nonexistent"})
+	assert.ErrorContains(t, err, "not attached")
+
+	added, removed, err = UpdateSpecDevices(ctx, spec, nil, []string{"/dev/hotplug/"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(added), 0)
+	assert.Equal(t, len(removed), 1)
+	assert.Equal(t, removed[0].Path, "/dev/hotplug")
+	assert.Equal(t, len(spec.Linux.Devices), 0)
+	assert.DeepEqual(t, spec.Linux.Resources.Devices, []specs.LinuxDeviceCgroup{denyAll})
+}
+
+func TestUpdateSpecDevicesReplace(t *testing.T) {
+	spec := &specs.Spec{}
+	_, _, err := UpdateSpecDevices(context.Background(), spec, []string{"/dev/null:/dev/hotplug"}, nil)
+	assert.NilError(t, err)
+
+	// Removals are applied first, so a device can be replaced in a single update
+	added, removed, err := UpdateSpecDevices(context.Background(), spec, []string{"/dev/zero:/dev/hotplug:r"}, []string{"/dev/hotplug"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(removed), 1)
+	assert.Equal(t, removed[0].Minor, int64(3))
+	assert.Equal(t, len(added), 1)
+	assert.Equal(t, added[0].Minor, int64(5))
+	assert.Equal(t, len(spec.Linux.Resources.Devices), 1)
+	assert.Equal(t, spec.Linux.Resources.Devices[0].Access, "r")
+}
@@ -0,0 +1,42 @@
+//go:build !linux
+
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"errors"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	containerd "github.com/containerd/containerd/v2/client"
+)
+
+var errDeviceUpdateNotSupported = errors.New("updating the devices of a container is only supported on Linux")
+
+func UpdateSpecDevices(ctx context.Context, spec *specs.Spec, add, remove []string) (added, removed []specs.LinuxDevice, _ error) {
+	return nil, nil, errDeviceUpdateNotSupported
+}
+
+func UpdateDeviceMappingLabel(ctx context.Context, container containerd.Container, add, remove []string) error {
+	return errDeviceUpdateNotSupported
+}
+
+func HotplugDevices(pid int, spec *specs.Spec, added, removed []specs.LinuxDevice) error {
+	return errDeviceUpdateNotSupported
+}
@@ -27,6 +27,7 @@ import (
 	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/containerd/v2/core/images"
 	"github.com/containerd/log"
+	"github.com/containerd/platforms"
 
 	"github.com/containerd/nerdctl/v2/pkg/api/types"
 	"github.com/containerd/nerdctl/v2/pkg/containerdutil"
@@ -95,6 +96,15 @@ func Inspect(ctx context.Context, client *containerd.Client, identifiers []strin
 	var errs []error
 	var entries []interface{}
 
+	platformMC := platforms.Default()
+	if options.Platform != "" {
+		p, err := platforms.Parse(options.Platform)
+		if err != nil {
+			return []any{}, err
+		}
+		platformMC = platforms.Only(p)
+	}
+
 	snapshotter := containerdutil.SnapshotService(client, options.GOptions.Snapshotter)
 	// We have to query per provided identifier, as we need to post-process results for the case name + digest
 	for _, identifier := range identifiers {
@@ -111,7 +121,7 @@ func Inspect(ctx context.Context, client *containerd.Client, identifiers []strin
 		// Go through the candidates
 		for _, candidateImage := range candidateImageList {
 			// Inspect the image
-			candidateNativeImage, err := imageinspector.Inspect(ctx, client, candidateImage, snapshotter)
+			candidateNativeImage, err := imageinspector.Inspect(ctx, client, candidateImage, platformMC, snapshotter)
 			if err != nil {
 				log.G(ctx).WithError(err).WithField("name", candidateImage.Name).Error("failure inspecting image")
 				continue
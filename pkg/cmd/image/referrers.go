@@ -0,0 +1,166 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package image
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"text/tabwriter"
+	"text/template"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/containerd/containerd/v2/core/remotes"
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
+
+	"github.com/containerd/nerdctl/v2/pkg/api/types"
+	"github.com/containerd/nerdctl/v2/pkg/errutil"
+	"github.com/containerd/nerdctl/v2/pkg/formatter"
+	"github.com/containerd/nerdctl/v2/pkg/imgutil/dockerconfigresolver"
+	"github.com/containerd/nerdctl/v2/pkg/referenceutil"
+)
+
+// Referrer is an artifact (e.g. a signature or an SBOM) that refers to an image.
+type Referrer struct {
+	ArtifactType string
+	MediaType    string
+	Digest       string
+	Size         int64
+	Annotations  map[string]string
+}
+
+// ListReferrers lists the artifacts that refer to `rawRef` in the registry.
+// The OCI referrers API is used when the registry supports it, and the referrers tag schema otherwise.
+func ListReferrers(ctx context.Context, rawRef string, options types.ImageListReferrersOptions) error {
+	parsedReference, err := referenceutil.Parse(rawRef)
+	if err != nil {
+		return err
+	}
+	ref := parsedReference.String()
+
+	var dOpts []dockerconfigresolver.Opt
+	if options.GOptions.InsecureRegistry {
+		log.G(ctx).Warnf("skipping verifying HTTPS certs for %q", parsedReference.Domain)
+		dOpts = append(dOpts, dockerconfigresolver.WithSkipVerifyCerts(true))
+	}
+	dOpts = append(dOpts, dockerconfigresolver.WithHostsDirs(options.GOptions.HostsDir))
+	resolver, err := dockerconfigresolver.New(ctx, parsedReference.Domain, dOpts...)
+	if err != nil {
+		return err
+	}
+	descs, err := fetchReferrers(ctx, resolver, ref, options.ArtifactTypes)
+	if err != nil && options.GOptions.InsecureRegistry && (errors.Is(err, http.ErrSchemeMismatch) || errutil.IsErrConnectionRefused(err)) {
+		log.G(ctx).WithError(err).Warnf("server %q does not seem to support HTTPS, falling back to plain HTTP", parsedReference.Domain)
+		dOpts = append(dOpts, dockerconfigresolver.WithPlainHTTP(true))
+		resolver, err = dockerconfigresolver.New(ctx, parsedReference.Domain, dOpts...)
+		if err != nil {
+			return err
+		}
+		descs, err = fetchReferrers(ctx, resolver, ref, options.ArtifactTypes)
+	}
+	if err != nil {
+		return err
+	}
+	return printReferrers(descs, options)
+}
+
+func fetchReferrers(ctx context.Context, resolver remotes.Resolver, ref string, artifactTypes []string) ([]ocispec.Descriptor, error) {
+	_, desc, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	fetcher, err := resolver.Fetcher(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	referrersFetcher, ok := fetcher.(remotes.ReferrersFetcher)
+	if !ok {
+		return nil, fmt.Errorf("fetching referrers is not supported for %s", ref)
+	}
+	descs, err := referrersFetcher.FetchReferrers(ctx, desc.Digest, remotes.WithReferrerArtifactTypes(artifactTypes...))
+	if errdefs.IsNotFound(err) {
+		// Neither the referrers API nor the referrers tag exists: the image has no referrers
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the referrers of %s: %w", ref, err)
+	}
+	return descs, nil
+}
+
+func printReferrers(descs []ocispec.Descriptor, options types.ImageListReferrersOptions) error {
+	w := options.Stdout
+	var tmpl *template.Template
+	switch options.Format {
+	case "", "table":
+		w = tabwriter.NewWriter(w, 4, 8, 4, ' ', 0)
+		if !options.Quiet {
+			fmt.Fprintln(w, "ARTIFACT TYPE\tDIGEST\tSIZE")
+		}
+	case "raw":
+		return errors.New("unsupported format: \"raw\"")
+	default:
+		if options.Quiet {
+			return errors.New("format and quiet must not be specified together")
+		}
+		var err error
+		tmpl, err = formatter.ParseTemplate(options.Format)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, desc := range descs {
+		r := Referrer{
+			ArtifactType: desc.ArtifactType,
+			MediaType:    desc.MediaType,
+			Digest:       desc.Digest.String(),
+			Size:         desc.Size,
+			Annotations:  desc.Annotations,
+		}
+		switch {
+		case tmpl != nil:
+			var b bytes.Buffer
+			if err := tmpl.Execute(&b, r); err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintln(w, b.String()); err != nil {
+				return err
+			}
+		case options.Quiet:
+			if _, err := fmt.Fprintln(w, r.Digest); err != nil {
+				return err
+			}
+		default:
+			artifactType := r.ArtifactType
+			if artifactType == "" {
+				artifactType = "<none>"
+			}
+			if _, err := fmt.Fprintf(w, "%s\t%s\t%d\n", artifactType, r.Digest, r.Size); err != nil {
+				return err
+			}
+		}
+	}
+	if f, ok := w.(formatter.Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package image
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/containerd/v2/core/remotes/docker"
+
+	"github.com/containerd/nerdctl/v2/pkg/api/types"
+)
+
+type referrersMode int
+
+const (
+	referrersAPI referrersMode = iota
+	referrersTagSchema
+	referrersNone
+)
+
+// newMockRegistry serves a single manifest as test/image:latest, and its referrers
+// through the referrers API or the referrers tag schema, depending on mode.
+func newMockRegistry(t *testing.T, mode referrersMode, referrers []ocispec.Descriptor) string {
+	manifest, err := json.Marshal(ocispec.Manifest{MediaType: ocispec.MediaTypeImageManifest})
+	assert.NilError(t, err)
+	dgst := digest.FromBytes(manifest)
+
+	serve := func(w http.ResponseWriter, r *http.Request, mediaType string, v any) {
+		data, err := json.Marshal(v)
+		assert.Check(t, err)
+		w.Header().Set("Content-Type", mediaType)
+		w.Header().Set("Docker-Content-Digest", digest.FromBytes(data).String())
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		if r.Method != http.MethodHead {
+			w.Write(data)
+		}
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/":
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/v2/test/image/manifests/latest":
+			serve(w, r, ocispec.MediaTypeImageManifest, ocispec.Manifest{MediaType: ocispec.MediaTypeImageManifest})
+		case r.URL.Path == "/v2/test/image/referrers/"+dgst.String() && mode == referrersAPI:
+			filtered := referrers
+			if artifactType := r.URL.Query().Get("artifactType"); artifactType != "" {
+				filtered = nil
+				for _, desc := range referrers {
+					if desc.ArtifactType == artifactType {
+						filtered = append(filtered, desc)
+					}
+				}
+			}
+			serve(w, r, ocispec.MediaTypeImageIndex, ocispec.Index{MediaType: ocispec.MediaTypeImageIndex, Manifests: filtered})
+		case r.URL.Path == "/v2/test/image/manifests/"+strings.Replace(dgst.String(), ":", "-", 1) && mode == referrersTagSchema:
+			serve(w, r, ocispec.MediaTypeImageIndex, ocispec.Index{MediaType: ocispec.MediaTypeImageIndex, Manifests: referrers})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return strings.TrimPrefix(srv.URL, "http://") + "/test/image:latest"
+}
+
+func TestFetchReferrers(t *testing.T) {
+	signature := ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: "application/vnd.dev.cosign.artifact.sig.v1+json",
+		Digest:       digest.FromString("signature"),
+		Size:         512,
+	}
+	sbom := ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: "application/spdx+json",
+		Digest:       digest.FromString("sbom"),
+		Size:         1024,
+	}
+	resolver := docker.NewResolver(docker.ResolverOptions{
+		Hosts: docker.ConfigureDefaultRegistries(docker.WithPlainHTTP(docker.MatchAllHosts)),
+	})
+
+	testCases := []struct {
+		name string
+		mode referrersMode
+	}{
+		{name: "referrers API", mode: referrersAPI},
+		{name: "referrers tag schema", mode: referrersTagSchema},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ref := newMockRegistry(t, tc.mode, []ocispec.Descriptor{signature, sbom})
+
+			descs, err := fetchReferrers(context.Background(), resolver, ref, nil)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, descs, []ocispec.Descriptor{signature, sbom})
+
+			descs, err = fetchReferrers(context.Background(), resolver, ref, []string{sbom.ArtifactType})
+			assert.NilError(t, err)
+			assert.DeepEqual(t, descs, []ocispec.Descriptor{sbom})
+		})
+	}
+
+	t.Run("no referrers", func(t *testing.T) {
+		ref := newMockRegistry(t, referrersNone, nil)
+		descs, err := fetchReferrers(context.Background(), resolver, ref, nil)
+		assert.NilError(t, err)
+		assert.Equal(t, len(descs), 0)
+	})
+}
+
+func TestPrintReferrers(t *testing.T) {
+	descs := []ocispec.Descriptor{
+		{
+			MediaType:    ocispec.MediaTypeImageManifest,
+			ArtifactType: "application/spdx+json",
+			Digest:       digest.FromString("sbom"),
+			Size:         1024,
+		},
+		{
+			MediaType: ocispec.MediaTypeImageManifest,
+			Digest:    digest.FromString("untyped"),
+			Size:      42,
+		},
+	}
+
+	var b bytes.Buffer
+	assert.NilError(t, printReferrers(descs, types.ImageListReferrersOptions{Stdout: &b}))
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	assert.Equal(t, len(lines), 3)
+	assert.Assert(t, strings.HasPrefix(lines[0], "ARTIFACT TYPE"))
+	assert.Equal(t, strings.Join(strings.Fields(lines[1]), " "), "application/spdx+json "+descs[0].Digest.String()+" 1024")
+	assert.Equal(t, strings.Join(strings.Fields(lines[2]), " "), "<none> "+descs[1].Digest.String()+" 42")
+
+	b.Reset()
+	assert.NilError(t, printReferrers(descs, types.ImageListReferrersOptions{Stdout: &b, Quiet: true}))
+	assert.Equal(t, b.String(), descs[0].Digest.String()+"\n"+descs[1].Digest.String()+"\n")
+
+	b.Reset()
+	assert.NilError(t, printReferrers(descs, types.ImageListReferrersOptions{Stdout: &b, Format: "{{.ArtifactType}}"}))
+	assert.Equal(t, b.String(), "application/spdx+json\n\n")
+
+	assert.ErrorContains(t, printReferrers(descs, types.ImageListReferrersOptions{Stdout: &b, Format: "json", Quiet: true}), "format and quiet")
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/containerd/nerdctl/v2/pkg/api/types"
 	"github.com/containerd/nerdctl/v2/pkg/containerutil"
 	"github.com/containerd/nerdctl/v2/pkg/idutil/imagewalker"
+	"github.com/containerd/nerdctl/v2/pkg/referenceutil"
 )
 
 // Remove removes a list of `images`.
@@ -63,12 +64,16 @@ func Remove(ctx context.Context, client *containerd.Client, args []string, optio
 	}
 
 	walker := &imagewalker.ImageWalker{
-		Client: client,
+		Client:               client,
+		MatchDigestReference: true,
 		OnFound: func(ctx context.Context, found imagewalker.Found) error {
 			if found.NameMatchIndex == -1 {
 				// if found multiple images, return error unless in force-mode and
 				// there is only 1 unique image.
 				if found.MatchCount > 1 && !(options.Force && found.UniqueImages == 1) {
+					if isDigestReference(found.Req) {
+						return fmt.Errorf("conflict: unable to delete %s (must be forced) - digest is referenced by %d tags", found.Req, found.MatchCount)
+					}
 					return fmt.Errorf("multiple IDs found with provided prefix: %s", found.Req)
 				}
 			} else if found.NameMatchIndex != found.MatchIndex {
@@ -201,3 +206,9 @@ func Remove(ctx context.Context, client *containerd.Client, args []string, optio
 	}
 	return nil
 }
+
+// isDigestReference returns true for a `repo@digest` reference.
+func isDigestReference(req string) bool {
+	parsed, err := referenceutil.Parse(req)
+	return err == nil && parsed.Path != "" && parsed.Digest != ""
+}
@@ -58,7 +58,7 @@ func Login(ctx context.Context, options types.LoginCommandOptions, stdout io.Wri
 	credentials.IdentityToken = ""
 
 	if err == nil && credentials.Username != "" && credentials.Password != "" {
-		responseIdentityToken, err = loginClientSide(ctx, options.GOptions, registryURL, credentials)
+		responseIdentityToken, err = loginClientSide(ctx, options, registryURL, credentials)
 	}
 
 	if err != nil || credentials.Username == "" || credentials.Password == "" {
@@ -67,7 +67,7 @@ func Login(ctx context.Context, options types.LoginCommandOptions, stdout io.Wri
 			return err
 		}
 
-		responseIdentityToken, err = loginClientSide(ctx, options.GOptions, registryURL, credentials)
+		responseIdentityToken, err = loginClientSide(ctx, options, registryURL, credentials)
 		if err != nil {
 			return err
 		}
@@ -108,7 +108,8 @@ func Login(ctx context.Context, options types.LoginCommandOptions, stdout io.Wri
 	return err
 }
 
-func loginClientSide(ctx context.Context, globalOptions types.GlobalCommandOptions, registryURL *dockerconfigresolver.RegistryURL, credentials *dockerconfigresolver.Credentials) (string, error) {
+func loginClientSide(ctx context.Context, options types.LoginCommandOptions, registryURL *dockerconfigresolver.RegistryURL, credentials *dockerconfigresolver.Credentials) (string, error) {
+	globalOptions := options.GOptions
 	host := registryURL.Host
 	var dOpts []dockerconfigresolver.Opt
 	if globalOptions.InsecureRegistry {
@@ -117,6 +118,17 @@ func loginClientSide(ctx context.Context, globalOptions types.GlobalCommandOptio
 	}
 	dOpts = append(dOpts, dockerconfigresolver.WithHostsDirs(globalOptions.HostsDir))
 
+	tlsConfig, err := dockerconfigresolver.NewTLSConfig(options.TLSCACert, options.TLSCert, options.TLSKey, options.TLSSkipVerify)
+	if err != nil {
+		return "", err
+	}
+	if tlsConfig != nil {
+		if options.TLSSkipVerify {
+			log.G(ctx).Warnf("skipping verifying HTTPS certs for %q", host)
+		}
+		dOpts = append(dOpts, dockerconfigresolver.WithTLSConfig(tlsConfig))
+	}
+
 	authCreds := func(acArg string) (string, string, error) {
 		if acArg == host {
 			if credentials.RegistryToken != "" {
@@ -107,3 +107,22 @@ func readUsername() (string, error) {
 
 	return strings.TrimSpace(username), nil
 }
+
+// ReadPasswordStdin reads the password passed with `--password-stdin`, i.e. the first line of r.
+// It might error with:
+// - ErrReadingPassword
+// - ErrPasswordIsRequired
+func ReadPasswordStdin(r io.Reader) (string, error) {
+	password, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", errors.Join(ErrReadingPassword, err)
+	}
+
+	password = strings.TrimSuffix(password, "\n")
+	password = strings.TrimSuffix(password, "\r")
+	if password == "" {
+		return "", ErrPasswordIsRequired
+	}
+
+	return password, nil
+}
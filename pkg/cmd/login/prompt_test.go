@@ -0,0 +1,92 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package login
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"golang.org/x/term"
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/nerdctl/v2/pkg/imgutil/dockerconfigresolver"
+)
+
+func TestReadPasswordStdin(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		err      error
+	}{
+		{name: "trailing newline", input: "s3cr3t\n", expected: "s3cr3t"},
+		{name: "no trailing newline", input: "s3cr3t", expected: "s3cr3t"},
+		{name: "CRLF", input: "s3cr3t\r\n", expected: "s3cr3t"},
+		{name: "only the first line is read", input: "s3cr3t\nsomething else\n", expected: "s3cr3t"},
+		{name: "spaces are kept", input: " s3 cr3t \n", expected: " s3 cr3t "},
+		{name: "empty", input: "", err: ErrPasswordIsRequired},
+		{name: "empty line", input: "\n", err: ErrPasswordIsRequired},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			password, err := ReadPasswordStdin(strings.NewReader(tc.input))
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, password, tc.expected)
+		})
+	}
+}
+
+func TestPromptUserForAuthentication(t *testing.T) {
+	t.Run("username and password provided", func(t *testing.T) {
+		var stdout bytes.Buffer
+		credentials := &dockerconfigresolver.Credentials{Username: "stored"}
+		err := promptUserForAuthentication(credentials, " user ", "s3cr3t", &stdout)
+		assert.NilError(t, err)
+		assert.Equal(t, credentials.Username, "user")
+		assert.Equal(t, credentials.Password, "s3cr3t")
+		assert.Equal(t, stdout.String(), "")
+	})
+
+	t.Run("username from the credentials store", func(t *testing.T) {
+		var stdout bytes.Buffer
+		credentials := &dockerconfigresolver.Credentials{Username: "stored"}
+		err := promptUserForAuthentication(credentials, "", "s3cr3t", &stdout)
+		assert.NilError(t, err)
+		assert.Equal(t, credentials.Username, "stored")
+		assert.Equal(t, credentials.Password, "s3cr3t")
+		assert.Equal(t, stdout.String(), "")
+	})
+
+	t.Run("missing username without a terminal", func(t *testing.T) {
+		if term.IsTerminal(int(os.Stdin.Fd())) {
+			t.Skip("stdin is a terminal")
+		}
+		var stdout bytes.Buffer
+		credentials := &dockerconfigresolver.Credentials{}
+		err := promptUserForAuthentication(credentials, "", "s3cr3t", &stdout)
+		assert.ErrorIs(t, err, ErrNotATerminal)
+		assert.Equal(t, stdout.String(), "Enter Username: ")
+	})
+}
@@ -18,11 +18,14 @@ package logout
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 
 	"github.com/containerd/nerdctl/v2/pkg/imgutil/dockerconfigresolver"
 )
 
-func Logout(ctx context.Context, logoutServer string) (map[string]error, error) {
+func Logout(ctx context.Context, logoutServer string, stdout io.Writer) (map[string]error, error) {
 	reg, err := dockerconfigresolver.Parse(logoutServer)
 	if err != nil {
 		return nil, err
@@ -33,7 +36,22 @@ func Logout(ctx context.Context, logoutServer string) (map[string]error, error)
 		return nil, err
 	}
 
-	return credentialsStore.Erase(reg)
+	if logoutServer == "" {
+		logoutServer = reg.CanonicalIdentifier()
+	}
+
+	errGroup, err := credentialsStore.Erase(reg)
+	if errors.Is(err, dockerconfigresolver.ErrNotLoggedIn) {
+		// Like docker, logging out of a registry we have no credentials for is not an error
+		_, err = fmt.Fprintf(stdout, "Not logged in to %s\n", logoutServer)
+		return nil, err
+	}
+	if err != nil {
+		return errGroup, err
+	}
+
+	_, err = fmt.Fprintf(stdout, "Removing login credentials for %s\n", logoutServer)
+	return nil, err
 }
 
 func ShellCompletion() ([]string, error) {
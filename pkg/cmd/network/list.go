@@ -21,7 +21,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"regexp"
+	"slices"
 	"strings"
 	"text/tabwriter"
 	"text/template"
@@ -34,24 +36,57 @@ import (
 type networkPrintable struct {
 	ID     string // empty for non-nerdctl networks
 	Name   string
+	Driver string
+	Scope  string
 	Labels string
-	// TODO: "CreatedAt", "Driver", "IPv6", "Internal", "Scope"
-	file string
+	// TODO: "CreatedAt", "IPv6", "Internal"
+	file   string
+	labels map[string]string
 }
 
 func List(ctx context.Context, options types.NetworkListOptions) error {
 	globalOptions := options.GOptions
-	quiet := options.Quiet
-	format := options.Format
-	w := options.Stdout
-	filters := options.Filters
-	var tmpl *template.Template
+	filterFuncs, err := getNetworkFilterFuncs(options.Filters)
+	if err != nil {
+		return err
+	}
 
+	e, err := netutil.NewCNIEnv(globalOptions.CNIPath, globalOptions.CNINetConfPath, netutil.WithNamespace(options.GOptions.Namespace))
+	if err != nil {
+		return err
+	}
+	netConfigs, err := e.NetworkList()
+	if err != nil {
+		return err
+	}
+
+	pp := make([]networkPrintable, 0, len(netConfigs)+2)
+	for _, n := range netConfigs {
+		pp = append(pp, networkPrintableFromConfig(n))
+	}
+	// append pseudo networks
+	pp = append(pp, []networkPrintable{
+		{
+			Name:   "host",
+			Driver: "host",
+			Scope:  "local",
+		},
+		{
+			Name:   "none",
+			Driver: "null",
+			Scope:  "local",
+		},
+	}...)
+	return printNetworks(options.Stdout, filterNetworks(pp, filterFuncs), options.Quiet, options.Format)
+}
+
+func printNetworks(w io.Writer, pp []networkPrintable, quiet bool, format string) error {
+	var tmpl *template.Template
 	switch format {
 	case "", "table", "wide":
 		w = tabwriter.NewWriter(w, 4, 8, 4, ' ', 0)
 		if !quiet {
-			fmt.Fprintln(w, "NETWORK ID\tNAME\tFILE")
+			fmt.Fprintln(w, "NETWORK ID\tNAME\tDRIVER\tSCOPE\tFILE")
 		}
 	case "raw":
 		return errors.New("unsupported format: \"raw\"")
@@ -66,66 +101,13 @@ func List(ctx context.Context, options types.NetworkListOptions) error {
 		}
 	}
 
-	e, err := netutil.NewCNIEnv(globalOptions.CNIPath, globalOptions.CNINetConfPath, netutil.WithNamespace(options.GOptions.Namespace))
-	if err != nil {
-		return err
-	}
-	netConfigs, err := e.NetworkList()
-	if err != nil {
-		return err
-	}
-
-	labelFilterFuncs, nameFilterFuncs, err := getNetworkFilterFuncs(filters)
-	if err != nil {
-		return err
-	}
-	if len(filters) > 0 {
-		filtered := make([]*netutil.NetworkConfig, 0)
-		for _, net := range netConfigs {
-			if networkMatchesFilter(net, labelFilterFuncs, nameFilterFuncs) {
-				filtered = append(filtered, net)
-			}
-		}
-		netConfigs = filtered
-	}
-
-	pp := make([]networkPrintable, len(netConfigs))
-	for i, n := range netConfigs {
-		p := networkPrintable{
-			Name: n.Name,
-			file: n.File,
-		}
-		if n.NerdctlID != nil {
-			p.ID = *n.NerdctlID
-			if len(p.ID) > 12 {
-				p.ID = p.ID[:12]
-			}
-		}
-		if n.NerdctlLabels != nil {
-			p.Labels = formatter.FormatLabels(*n.NerdctlLabels)
-		}
-		pp[i] = p
-	}
-
-	// append pseudo networks
-	if len(filters) == 0 { // filter a pseudo networks is meanless
-		pp = append(pp, []networkPrintable{
-			{
-				Name: "host",
-			},
-			{
-				Name: "none",
-			},
-		}...)
-	}
-
 	for _, p := range pp {
 		if tmpl != nil {
 			var b bytes.Buffer
 			if err := tmpl.Execute(&b, p); err != nil {
 				return err
 			}
-			if _, err = fmt.Fprintln(w, b.String()); err != nil {
+			if _, err := fmt.Fprintln(w, b.String()); err != nil {
 				return err
 			}
 		} else if quiet {
@@ -133,7 +115,7 @@ func List(ctx context.Context, options types.NetworkListOptions) error {
 				fmt.Fprintln(w, p.ID)
 			}
 		} else {
-			fmt.Fprintf(w, "%s\t%s\t%s\n", p.ID, p.Name, p.file)
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", p.ID, p.Name, p.Driver, p.Scope, p.file)
 		}
 	}
 	if f, ok := w.(formatter.Flusher); ok {
@@ -142,55 +124,95 @@ func List(ctx context.Context, options types.NetworkListOptions) error {
 	return nil
 }
 
-func getNetworkFilterFuncs(filters []string) ([]func(*map[string]string) bool, []func(string) bool, error) {
-	labelFilterFuncs := make([]func(*map[string]string) bool, 0)
-	nameFilterFuncs := make([]func(string) bool, 0)
+func networkPrintableFromConfig(n *netutil.NetworkConfig) networkPrintable {
+	p := networkPrintable{
+		Name:  n.Name,
+		Scope: "local",
+		file:  n.File,
+	}
+	// The driver of a network is the type of its main (first) CNI plugin, e.g., "bridge" or "macvlan".
+	if len(n.Plugins) > 0 && n.Plugins[0].Network != nil {
+		p.Driver = n.Plugins[0].Network.Type
+	}
+	if n.NerdctlID != nil {
+		p.ID = *n.NerdctlID
+		if len(p.ID) > 12 {
+			p.ID = p.ID[:12]
+		}
+	}
+	if n.NerdctlLabels != nil {
+		p.labels = *n.NerdctlLabels
+		p.Labels = formatter.FormatLabels(p.labels)
+	}
+	return p
+}
+
+// getNetworkFilterFuncs parses the `--filter` values of `nerdctl network ls`.
+// Filters with different keys must all match. The driver and scope filters match when any of their values matches,
+// while the name and label filters must all match.
+func getNetworkFilterFuncs(filters []string) ([]func(*networkPrintable) bool, error) {
+	var filterFuncs []func(*networkPrintable) bool
+	var drivers, scopes []string
 
 	for _, filter := range filters {
-		if strings.HasPrefix(filter, "name") || strings.HasPrefix(filter, "label") {
-			filter, value, ok := strings.Cut(filter, "=")
-			if !ok {
-				continue
+		key, value, ok := strings.Cut(filter, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid filter %q, must be formatted KEY=VALUE", filter)
+		}
+		switch key {
+		case "name":
+			re, err := regexp.Compile(value)
+			if err != nil {
+				return nil, err
 			}
-			switch filter {
-			case "name":
-				re, err := regexp.Compile(value)
-				if err != nil {
-					return nil, nil, err
-				}
-				nameFilterFuncs = append(nameFilterFuncs, func(name string) bool {
-					return re.MatchString(name)
-				})
-			case "label":
-				k, v, hasValue := strings.Cut(value, "=")
-				labelFilterFuncs = append(labelFilterFuncs, func(labels *map[string]string) bool {
-					if labels == nil {
-						return false
-					}
-					val, ok := (*labels)[k]
-					if !ok || (hasValue && val != v) {
-						return false
-					}
-					return true
-				})
+			filterFuncs = append(filterFuncs, func(p *networkPrintable) bool {
+				return re.MatchString(p.Name)
+			})
+		case "label":
+			k, v, hasValue := strings.Cut(value, "=")
+			filterFuncs = append(filterFuncs, func(p *networkPrintable) bool {
+				val, ok := p.labels[k]
+				return ok && (!hasValue || val == v)
+			})
+		case "driver":
+			drivers = append(drivers, value)
+		case "scope":
+			switch value {
+			case "local", "swarm", "global":
+			default:
+				return nil, fmt.Errorf("invalid filter 'scope=%s'", value)
 			}
-			continue
+			scopes = append(scopes, value)
+		default:
+			return nil, fmt.Errorf("invalid filter '%s'", key)
 		}
 	}
-	return labelFilterFuncs, nameFilterFuncs, nil
+	if len(drivers) > 0 {
+		filterFuncs = append(filterFuncs, func(p *networkPrintable) bool {
+			return slices.Contains(drivers, p.Driver)
+		})
+	}
+	if len(scopes) > 0 {
+		filterFuncs = append(filterFuncs, func(p *networkPrintable) bool {
+			return slices.Contains(scopes, p.Scope)
+		})
+	}
+	return filterFuncs, nil
 }
 
-func networkMatchesFilter(net *netutil.NetworkConfig, labelFilterFuncs []func(*map[string]string) bool, nameFilterFuncs []func(string) bool) bool {
-	for _, labelFilterFunc := range labelFilterFuncs {
-		if !labelFilterFunc(net.NerdctlLabels) {
-			return false
+func filterNetworks(pp []networkPrintable, filterFuncs []func(*networkPrintable) bool) []networkPrintable {
+	filtered := make([]networkPrintable, 0, len(pp))
+	for _, p := range pp {
+		matches := true
+		for _, filterFunc := range filterFuncs {
+			if !filterFunc(&p) {
+				matches = false
+				break
+			}
 		}
-	}
-	for _, nameFilterFunc := range nameFilterFuncs {
-		if !nameFilterFunc(net.Name) {
-			return false
+		if matches {
+			filtered = append(filtered, p)
 		}
 	}
-
-	return true
+	return filtered
 }
@@ -0,0 +1,151 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package network
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/containernetworking/cni/libcni"
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/nerdctl/v2/pkg/netutil"
+)
+
+func fixtureNetworks(t *testing.T) []networkPrintable {
+	t.Helper()
+	fixtures := []struct {
+		name   string
+		plugin string
+		id     string
+		labels map[string]string
+	}{
+		{name: "bridge", plugin: "bridge", id: "17f29b073143d8cd97b5bbe492bdeffec1c5fee55cc1fe2112c8b9335f8b6121"},
+		{name: "frontend", plugin: "bridge", id: "aa2bc90b11f1ab5cd9bb1e4b5e4c0a4d8e2c3c0d7f6f1a8a13ffc04f8c1f2b3e", labels: map[string]string{"tier": "front"}},
+		{name: "lan", plugin: "macvlan", id: "f4b1c5c1e5b3d2a5d0a8e2c3b4a5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7", labels: map[string]string{"tier": ""}},
+	}
+	var pp []networkPrintable
+	for _, f := range fixtures {
+		l, err := libcni.ConfListFromBytes([]byte(fmt.Sprintf(`{"cniVersion":"1.0.0","name":%q,"plugins":[{"type":%q},{"type":"firewall"}]}`, f.name, f.plugin)))
+		assert.NilError(t, err)
+		n := &netutil.NetworkConfig{
+			NetworkConfigList: l,
+			NerdctlID:         &f.id,
+			File:              "/etc/cni/net.d/nerdctl-" + f.name + ".conflist",
+		}
+		if f.labels != nil {
+			n.NerdctlLabels = &f.labels
+		}
+		pp = append(pp, networkPrintableFromConfig(n))
+	}
+	return append(pp, networkPrintable{Name: "host", Driver: "host", Scope: "local"}, networkPrintable{Name: "none", Driver: "null", Scope: "local"})
+}
+
+func TestFilterNetworks(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		filters  []string
+		expected []string
+	}{
+		{filters: nil, expected: []string{"bridge", "frontend", "lan", "host", "none"}},
+		{filters: []string{"driver=bridge"}, expected: []string{"bridge", "frontend"}},
+		{filters: []string{"driver=macvlan"}, expected: []string{"lan"}},
+		{filters: []string{"driver=bridge", "driver=macvlan"}, expected: []string{"bridge", "frontend", "lan"}},
+		{filters: []string{"driver=ipvlan"}, expected: []string{}},
+		{filters: []string{"driver=null"}, expected: []string{"none"}},
+		{filters: []string{"label=tier"}, expected: []string{"frontend", "lan"}},
+		{filters: []string{"label=tier=front"}, expected: []string{"frontend"}},
+		{filters: []string{"label=tier="}, expected: []string{"lan"}},
+		{filters: []string{"label=tier", "label=tier=front"}, expected: []string{"frontend"}},
+		{filters: []string{"name=front"}, expected: []string{"frontend"}},
+		{filters: []string{"name=^.o"}, expected: []string{"host", "none"}},
+		{filters: []string{"scope=local"}, expected: []string{"bridge", "frontend", "lan", "host", "none"}},
+		{filters: []string{"scope=swarm"}, expected: []string{}},
+		{filters: []string{"driver=bridge", "label=tier=front", "scope=local"}, expected: []string{"frontend"}},
+	}
+	pp := fixtureNetworks(t)
+	for _, tc := range testCases {
+		filterFuncs, err := getNetworkFilterFuncs(tc.filters)
+		assert.NilError(t, err)
+		names := []string{}
+		for _, p := range filterNetworks(pp, filterFuncs) {
+			names = append(names, p.Name)
+		}
+		assert.DeepEqual(t, names, tc.expected)
+	}
+}
+
+func TestGetNetworkFilterFuncsInvalid(t *testing.T) {
+	t.Parallel()
+	for _, filters := range [][]string{{"driver"}, {"scope=cluster"}, {"dangling=true"}, {"name=("}} {
+		_, err := getNetworkFilterFuncs(filters)
+		assert.Assert(t, err != nil, "expected %v to be rejected", filters)
+	}
+}
+
+func TestPrintNetworks(t *testing.T) {
+	t.Parallel()
+	pp := fixtureNetworks(t)
+	testCases := []struct {
+		quiet    bool
+		format   string
+		expected string
+	}{
+		{
+			expected: `NETWORK ID      NAME        DRIVER     SCOPE    FILE
+17f29b073143    bridge      bridge     local    /etc/cni/net.d/nerdctl-bridge.conflist
+aa2bc90b11f1    frontend    bridge     local    /etc/cni/net.d/nerdctl-frontend.conflist
+f4b1c5c1e5b3    lan         macvlan    local    /etc/cni/net.d/nerdctl-lan.conflist
+                host        host       local    
+                none        null       local    
+`,
+		},
+		{
+			quiet: true,
+			expected: `17f29b073143
+aa2bc90b11f1
+f4b1c5c1e5b3
+`,
+		},
+		{
+			format: "{{.Name}} {{.Driver}} {{.Scope}} {{.Labels}}",
+			expected: `bridge bridge local 
+frontend bridge local tier=front
+lan macvlan local tier=
+host host local 
+none null local 
+`,
+		},
+		{
+			format: "json",
+			expected: `{"ID":"17f29b073143","Name":"bridge","Driver":"bridge","Scope":"local","Labels":""}
+{"ID":"aa2bc90b11f1","Name":"frontend","Driver":"bridge","Scope":"local","Labels":"tier=front"}
+{"ID":"f4b1c5c1e5b3","Name":"lan","Driver":"macvlan","Scope":"local","Labels":"tier="}
+{"ID":"","Name":"host","Driver":"host","Scope":"local","Labels":""}
+{"ID":"","Name":"none","Driver":"null","Scope":"local","Labels":""}
+`,
+		},
+	}
+	for _, tc := range testCases {
+		var b bytes.Buffer
+		assert.NilError(t, printNetworks(&b, pp, tc.quiet, tc.format))
+		assert.Equal(t, b.String(), tc.expected)
+	}
+
+	assert.ErrorContains(t, printNetworks(&bytes.Buffer{}, pp, true, "json"), "format and quiet must not be specified together")
+}
@@ -22,18 +22,29 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
 
-	_ "github.com/containerd/containerd/api/events" // Register grpc event types
+	timetypes "github.com/docker/docker/api/types/time"
+
+	apievents "github.com/containerd/containerd/api/events" // Register grpc event types
 	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/core/containers"
 	"github.com/containerd/containerd/v2/core/events"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
+	"github.com/containerd/containerd/v2/pkg/protobuf"
+	"github.com/containerd/errdefs"
 	"github.com/containerd/log"
 	"github.com/containerd/typeurl/v2"
 
 	"github.com/containerd/nerdctl/v2/pkg/api/types"
 	"github.com/containerd/nerdctl/v2/pkg/formatter"
+	"github.com/containerd/nerdctl/v2/pkg/labels"
+	"github.com/containerd/nerdctl/v2/pkg/ocihook/state"
 )
 
 // EventOut contains information about an event.
@@ -44,6 +55,13 @@ type EventOut struct {
 	Topic     string
 	Status    Status
 	Event     string
+	Actor     Actor
+}
+
+// Actor describes the object an event refers to.
+type Actor struct {
+	ID         string
+	Attributes map[string]string
 }
 
 type Status string
@@ -144,6 +162,217 @@ func generateEventFilters(filters []string) (map[string][]EventFilter, error) {
 	return filterMap, nil
 }
 
+// parseEventTime parses a --since/--until value relative to now.
+// An empty value returns the zero time.
+func parseEventTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	ts, err := timetypes.GetTimestamp(value, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	sec, nsec, err := timetypes.ParseTimestamps(ts, 0)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(sec, nsec), nil
+}
+
+// inWindow reports whether ts falls within [since, until].
+// A zero since or until leaves that side of the window open.
+func inWindow(ts, since, until time.Time) bool {
+	if !since.IsZero() && ts.Before(since) {
+		return false
+	}
+	if !until.IsZero() && ts.After(until) {
+		return false
+	}
+	return true
+}
+
+// eventsInWindow returns the events falling within [since, until], oldest first.
+func eventsInWindow(evs []EventOut, since, until time.Time) []EventOut {
+	var res []EventOut
+	for _, e := range evs {
+		if inWindow(e.Timestamp, since, until) {
+			res = append(res, e)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Timestamp.Before(res[j].Timestamp)
+	})
+	return res
+}
+
+func newEventOut(ts time.Time, id, namespace, topic string, v interface{}) (EventOut, error) {
+	out, err := json.Marshal(v)
+	if err != nil {
+		return EventOut{}, err
+	}
+	return EventOut{ts, id, namespace, topic, TopicToStatus(topic), string(out), newActor(v)}, nil
+}
+
+// newActor returns the actor of the containerd event v, with the attributes carried by the event itself.
+func newActor(v interface{}) Actor {
+	actor := Actor{Attributes: map[string]string{}}
+	switch e := v.(type) {
+	case *apievents.ContainerCreate:
+		actor.ID = e.ID
+		actor.Attributes["image"] = e.Image
+	case *apievents.ContainerUpdate:
+		actor.ID = e.ID
+		actor.Attributes["image"] = e.Image
+	case *apievents.ContainerDelete:
+		actor.ID = e.ID
+	case *apievents.TaskCreate:
+		actor.ID = e.ContainerID
+		actor.Attributes["pid"] = strconv.FormatUint(uint64(e.Pid), 10)
+	case *apievents.TaskStart:
+		actor.ID = e.ContainerID
+		actor.Attributes["pid"] = strconv.FormatUint(uint64(e.Pid), 10)
+	case *apievents.TaskExit:
+		actor.ID = e.ContainerID
+		actor.Attributes["pid"] = strconv.FormatUint(uint64(e.Pid), 10)
+		actor.Attributes["exitCode"] = strconv.FormatUint(uint64(e.ExitStatus), 10)
+	case *apievents.TaskDelete:
+		actor.ID = e.ContainerID
+		actor.Attributes["exitCode"] = strconv.FormatUint(uint64(e.ExitStatus), 10)
+	case *apievents.TaskOOM:
+		actor.ID = e.ContainerID
+	case *apievents.TaskPaused:
+		actor.ID = e.ContainerID
+	case *apievents.TaskResumed:
+		actor.ID = e.ContainerID
+	case *apievents.TaskExecAdded:
+		actor.ID = e.ContainerID
+		actor.Attributes["execID"] = e.ExecID
+	case *apievents.TaskExecStarted:
+		actor.ID = e.ContainerID
+		actor.Attributes["execID"] = e.ExecID
+		actor.Attributes["pid"] = strconv.FormatUint(uint64(e.Pid), 10)
+	}
+	return actor
+}
+
+// addContainerAttributes adds the user labels, the image and the name of the container
+// to the attributes of actor. Attributes carried by the event itself take precedence.
+func addContainerAttributes(actor *Actor, info containers.Container) {
+	for k, v := range info.Labels {
+		if strings.HasPrefix(k, labels.Prefix) {
+			continue
+		}
+		if _, ok := actor.Attributes[k]; !ok {
+			actor.Attributes[k] = v
+		}
+	}
+	if _, ok := actor.Attributes["image"]; !ok && info.Image != "" {
+		actor.Attributes["image"] = info.Image
+	}
+	if name := info.Labels[labels.Name]; name != "" {
+		actor.Attributes["name"] = name
+	}
+}
+
+// enrichActor looks up the container referenced by actor and adds its attributes.
+// Containers that no longer exist, e.g., after `run --rm`, are left as is.
+func enrichActor(ctx context.Context, client *containerd.Client, actor *Actor) {
+	if actor.ID == "" {
+		return
+	}
+	c, err := client.LoadContainer(ctx, actor.ID)
+	if err != nil {
+		return
+	}
+	info, err := c.Info(ctx, containerd.WithoutRefreshedMetadata)
+	if err != nil {
+		return
+	}
+	addContainerAttributes(actor, info)
+}
+
+// historicalEvents reconstructs past events from the state of the existing containers.
+// containerd does not keep an event log, so only the creation, start and exit of
+// containers that still exist can be recovered.
+func historicalEvents(ctx context.Context, client *containerd.Client) ([]EventOut, error) {
+	ns, err := namespaces.NamespaceRequired(ctx)
+	if err != nil {
+		return nil, err
+	}
+	containers, err := client.Containers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var res []EventOut
+	for _, c := range containers {
+		info, err := c.Info(ctx, containerd.WithoutRefreshedMetadata)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		create := &apievents.ContainerCreate{
+			ID:      info.ID,
+			Image:   info.Image,
+			Runtime: &apievents.ContainerCreate_Runtime{Name: info.Runtime.Name},
+		}
+		if e, err := newEventOut(info.CreatedAt, info.ID, ns, "/containers/create", create); err == nil {
+			addContainerAttributes(&e.Actor, info)
+			res = append(res, e)
+		}
+
+		task, err := c.Task(ctx, nil)
+		if err != nil {
+			continue
+		}
+		if stateDir := info.Labels[labels.StateDir]; stateDir != "" {
+			if lf, err := state.New(stateDir); err == nil && lf.Load() == nil && !lf.StartedAt.IsZero() {
+				start := &apievents.TaskStart{ContainerID: info.ID, Pid: task.Pid()}
+				if e, err := newEventOut(lf.StartedAt, info.ID, ns, "/tasks/start", start); err == nil {
+					addContainerAttributes(&e.Actor, info)
+					res = append(res, e)
+				}
+			}
+		}
+		st, err := task.Status(ctx)
+		if err != nil || st.Status != containerd.Stopped || st.ExitTime.IsZero() {
+			continue
+		}
+		exit := &apievents.TaskExit{
+			ContainerID: info.ID,
+			ID:          info.ID,
+			Pid:         task.Pid(),
+			ExitStatus:  st.ExitStatus,
+			ExitedAt:    protobuf.ToTimestamp(st.ExitTime),
+		}
+		if e, err := newEventOut(st.ExitTime, info.ID, ns, "/tasks/exit", exit); err == nil {
+			addContainerAttributes(&e.Actor, info)
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+func printEvent(w io.Writer, tmpl *template.Template, eOut EventOut) error {
+	if tmpl != nil {
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, eOut); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintln(w, b.String()+"\n")
+		return err
+	}
+	_, err := fmt.Fprintln(
+		w,
+		eOut.Timestamp,
+		eOut.Namespace,
+		eOut.Topic,
+		eOut.Event,
+	)
+	return err
+}
+
 // Events is from https://github.com/containerd/containerd/blob/v1.4.3/cmd/ctr/commands/events/events.go
 func Events(ctx context.Context, client *containerd.Client, options types.SystemEventsOptions) error {
 	eventsClient := client.EventService()
@@ -165,22 +394,63 @@ func Events(ctx context.Context, client *containerd.Client, options types.System
 	if err != nil {
 		return err
 	}
+	now := time.Now()
+	since, err := parseEventTime(options.Since, now)
+	if err != nil {
+		return fmt.Errorf("invalid value for \"since\": %w", err)
+	}
+	until, err := parseEventTime(options.Until, now)
+	if err != nil {
+		return fmt.Errorf("invalid value for \"until\": %w", err)
+	}
+	if !since.IsZero() {
+		// The subscription above is already open, so live events are buffered
+		// while the history is being replayed.
+		history, err := historicalEvents(ctx, client)
+		if err != nil {
+			log.G(ctx).WithError(err).Warn("failed to reconstruct historical events")
+		}
+		history = eventsInWindow(history, since, until)
+		if len(history) == 0 {
+			log.G(ctx).Info("no historical events available, streaming live events only")
+		}
+		for _, eOut := range history {
+			if applyFilters(&eOut, filterMap) {
+				if err := printEvent(options.Stdout, tmpl, eOut); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	var untilCh <-chan time.Time
+	if !until.IsZero() {
+		if !until.After(now) {
+			return nil
+		}
+		timer := time.NewTimer(until.Sub(now))
+		defer timer.Stop()
+		untilCh = timer.C
+	}
 	for {
 		var e *events.Envelope
 		select {
 		case e = <-eventsCh:
 		case err := <-errCh:
 			return err
+		case <-untilCh:
+			return nil
 		}
 		if e != nil {
 			var out []byte
 			var id string
+			actor := Actor{Attributes: map[string]string{}}
 			if e.Event != nil {
 				v, err := typeurl.UnmarshalAny(e.Event)
 				if err != nil {
 					log.G(ctx).WithError(err).Warn("cannot unmarshal an event from Any")
 					continue
 				}
+				actor = newActor(v)
 				out, err = json.Marshal(v)
 				if err != nil {
 					log.G(ctx).WithError(err).Warn("cannot marshal Any into JSON")
@@ -198,27 +468,15 @@ func Events(ctx context.Context, client *containerd.Client, options types.System
 				}
 			}
 
-			eOut := EventOut{e.Timestamp, id, e.Namespace, e.Topic, TopicToStatus(e.Topic), string(out)}
+			eOut := EventOut{e.Timestamp, id, e.Namespace, e.Topic, TopicToStatus(e.Topic), string(out), actor}
+			if !inWindow(eOut.Timestamp, since, until) {
+				continue
+			}
 			match := applyFilters(&eOut, filterMap)
 			if match {
-				if tmpl != nil {
-					var b bytes.Buffer
-					if err := tmpl.Execute(&b, eOut); err != nil {
-						return err
-					}
-					if _, err := fmt.Fprintln(options.Stdout, b.String()+"\n"); err != nil {
-						return err
-					}
-				} else {
-					if _, err := fmt.Fprintln(
-						options.Stdout,
-						e.Timestamp,
-						e.Namespace,
-						e.Topic,
-						string(out),
-					); err != nil {
-						return err
-					}
+				enrichActor(namespaces.WithNamespace(ctx, e.Namespace), client, &eOut.Actor)
+				if err := printEvent(options.Stdout, tmpl, eOut); err != nil {
+					return err
 				}
 			}
 
@@ -0,0 +1,162 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package system
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	apievents "github.com/containerd/containerd/api/events"
+	"github.com/containerd/containerd/v2/core/containers"
+
+	"github.com/containerd/nerdctl/v2/pkg/labels"
+)
+
+func TestParseEventTime(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1700000000, 0)
+
+	testCases := []struct {
+		value    string
+		expected time.Time
+		err      bool
+	}{
+		{value: "", expected: time.Time{}},
+		{value: "1h", expected: now.Add(-time.Hour)},
+		{value: "1699990000", expected: time.Unix(1699990000, 0)},
+		{value: "1699990000.5", expected: time.Unix(1699990000, 500000000)},
+		{value: "2023-11-14T22:13:20Z", expected: now},
+		{value: "not-a-time", err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseEventTime(tc.value, now)
+			if tc.err {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Assert(t, got.Equal(tc.expected), "expected %v, got %v", tc.expected, got)
+		})
+	}
+}
+
+func TestEventsInWindow(t *testing.T) {
+	t.Parallel()
+	base := time.Unix(1700000000, 0)
+	at := func(d time.Duration) time.Time { return base.Add(d) }
+	evs := []EventOut{
+		{Timestamp: at(3 * time.Minute), ID: "c"},
+		{Timestamp: at(1 * time.Minute), ID: "a"},
+		{Timestamp: at(2 * time.Minute), ID: "b"},
+		{Timestamp: at(4 * time.Minute), ID: "d"},
+	}
+
+	testCases := []struct {
+		name     string
+		since    time.Time
+		until    time.Time
+		expected []string
+	}{
+		{name: "open window", expected: []string{"a", "b", "c", "d"}},
+		{name: "since only", since: at(2 * time.Minute), expected: []string{"b", "c", "d"}},
+		{name: "until only", until: at(2 * time.Minute), expected: []string{"a", "b"}},
+		{name: "since and until", since: at(2 * time.Minute), until: at(3 * time.Minute), expected: []string{"b", "c"}},
+		{name: "empty window", since: at(5 * time.Minute), expected: nil},
+		{name: "until before since", since: at(3 * time.Minute), until: at(2 * time.Minute), expected: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var ids []string
+			for _, e := range eventsInWindow(evs, tc.since, tc.until) {
+				ids = append(ids, e.ID)
+			}
+			assert.DeepEqual(t, tc.expected, ids)
+		})
+	}
+}
+
+func TestEventActor(t *testing.T) {
+	t.Parallel()
+	info := containers.Container{
+		ID:    "c1",
+		Image: "docker.io/library/alpine:latest",
+		Labels: map[string]string{
+			labels.Name:     "foo",
+			labels.StateDir: "/state",
+			"com.example":   "bar",
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		topic    string
+		event    interface{}
+		expected map[string]string
+	}{
+		{
+			name:  "start",
+			topic: "/tasks/start",
+			event: &apievents.TaskStart{ContainerID: "c1", Pid: 42},
+			expected: map[string]string{
+				"pid":         "42",
+				"image":       "docker.io/library/alpine:latest",
+				"name":        "foo",
+				"com.example": "bar",
+			},
+		},
+		{
+			name:  "die",
+			topic: "/tasks/exit",
+			event: &apievents.TaskExit{ContainerID: "c1", ID: "c1", Pid: 42, ExitStatus: 137},
+			expected: map[string]string{
+				"pid":         "42",
+				"exitCode":    "137",
+				"image":       "docker.io/library/alpine:latest",
+				"name":        "foo",
+				"com.example": "bar",
+			},
+		},
+		{
+			name:  "create",
+			topic: "/containers/create",
+			event: &apievents.ContainerCreate{ID: "c1", Image: "docker.io/library/busybox:latest"},
+			expected: map[string]string{
+				"image":       "docker.io/library/busybox:latest",
+				"name":        "foo",
+				"com.example": "bar",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			e, err := newEventOut(time.Now(), "c1", "default", tc.topic, tc.event)
+			assert.NilError(t, err)
+			addContainerAttributes(&e.Actor, info)
+			assert.Equal(t, e.Actor.ID, "c1")
+			assert.DeepEqual(t, tc.expected, e.Actor.Attributes)
+		})
+	}
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/containerd/log"
 
 	"github.com/containerd/nerdctl/v2/pkg/api/types"
+	"github.com/containerd/nerdctl/v2/pkg/defaults"
 	"github.com/containerd/nerdctl/v2/pkg/formatter"
 	"github.com/containerd/nerdctl/v2/pkg/infoutil"
 	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/dockercompat"
@@ -74,6 +75,14 @@ func Info(ctx context.Context, client *containerd.Client, options types.SystemIn
 			return err
 		}
 		infoCompat.Plugins.Log = logging.Drivers()
+		infoCompat.Runtimes = infoutil.Runtimes()
+		infoCompat.DefaultRuntime = defaults.Runtime
+		infoCompat.NerdctlRegistryConfig = &dockercompat.NerdctlRegistryConfig{
+			InsecureRegistry: options.GOptions.InsecureRegistry,
+			HostsDir:         options.GOptions.HostsDir,
+		}
+		infoCompat.Rootless = rootlessutil.IsRootless()
+		infoCompat.CNI = infoutil.CNIInfo(options.GOptions.CNIPath)
 	default:
 		return fmt.Errorf("unknown mode %q", options.Mode)
 	}
@@ -161,6 +170,8 @@ func prettyPrintInfoDockerCompat(stdout io.Writer, stderr io.Writer, info *docke
 	fmt.Fprintf(w, " Plugins:\n")
 	fmt.Fprintf(w, "  Log:     %s\n", strings.Join(info.Plugins.Log, " "))
 	fmt.Fprintf(w, "  Storage: %s\n", strings.Join(info.Plugins.Storage, " "))
+	printRuntimes(w, info)
+	printCNI(w, info.CNI)
 
 	// print Security options
 	printSecurityOptions(w, info.SecurityOptions)
@@ -173,6 +184,8 @@ func prettyPrintInfoDockerCompat(stdout io.Writer, stderr io.Writer, info *docke
 	fmt.Fprintf(w, " Total Memory:     %s\n", units.BytesSize(float64(info.MemTotal)))
 	fmt.Fprintf(w, " Name:             %s\n", info.Name)
 	fmt.Fprintf(w, " ID:               %s\n", info.ID)
+	fmt.Fprintf(w, " Rootless:         %v\n", info.Rootless)
+	printRegistryConfig(w, info.NerdctlRegistryConfig)
 
 	fmt.Fprintln(w)
 	if len(info.Warnings) > 0 {
@@ -188,6 +201,39 @@ func printF(w io.Writer, label string, dockerCompatInfo string) {
 	fmt.Fprintf(w, "%s%s\n", label, dockerCompatInfo)
 }
 
+func printRuntimes(w io.Writer, info *dockercompat.Info) {
+	if len(info.Runtimes) > 0 {
+		names := make([]string, 0, len(info.Runtimes))
+		for name := range info.Runtimes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(w, " Runtimes: %s\n", strings.Join(names, " "))
+	}
+	printF(w, " Default Runtime: ", info.DefaultRuntime)
+}
+
+func printCNI(w io.Writer, cni *dockercompat.CNIInfo) {
+	if cni == nil {
+		return
+	}
+	fmt.Fprintf(w, " CNI:\n")
+	fmt.Fprintf(w, "  Path:    %s\n", cni.Path)
+	if cni.Version != "" {
+		fmt.Fprintf(w, "  Version: %s\n", cni.Version)
+	}
+	fmt.Fprintf(w, "  Plugins: %s\n", strings.Join(cni.Plugins, " "))
+}
+
+func printRegistryConfig(w io.Writer, rc *dockercompat.NerdctlRegistryConfig) {
+	if rc == nil {
+		return
+	}
+	fmt.Fprintf(w, " Registry Config:\n")
+	fmt.Fprintf(w, "  Insecure Registry: %v\n", rc.InsecureRegistry)
+	fmt.Fprintf(w, "  Hosts Dir:         %s\n", strings.Join(rc.HostsDir, " "))
+}
+
 func printSecurityOptions(w io.Writer, securityOptions []string) {
 	if len(securityOptions) == 0 {
 		return
@@ -17,17 +17,29 @@
 package volume
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/docker/docker/pkg/stringid"
 
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
+
 	"github.com/containerd/nerdctl/v2/pkg/api/types"
 	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/native"
 	"github.com/containerd/nerdctl/v2/pkg/labels"
+	"github.com/containerd/nerdctl/v2/pkg/mountutil/volumeplugin"
+	"github.com/containerd/nerdctl/v2/pkg/mountutil/volumestore"
 	"github.com/containerd/nerdctl/v2/pkg/strutil"
 )
 
-func Create(name string, options types.VolumeCreateOptions) (*native.Volume, error) {
+func Create(ctx context.Context, name string, options types.VolumeCreateOptions) (*native.Volume, error) {
+	if options.Driver != "" && options.Driver != volumestore.LocalDriver {
+		return createWithPlugin(ctx, name, options)
+	}
+	if len(options.DriverOpts) > 0 {
+		return nil, fmt.Errorf("volume options are not supported by the %q driver: %w", volumestore.LocalDriver, errdefs.ErrInvalidArgument)
+	}
 	if name == "" {
 		name = stringid.GenerateRandomID()
 		options.Labels = append(options.Labels, labels.AnonymousVolumes+"=")
@@ -44,3 +56,26 @@ func Create(name string, options types.VolumeCreateOptions) (*native.Volume, err
 	fmt.Fprintln(options.Stdout, name)
 	return vol, nil
 }
+
+// createWithPlugin creates the volume name with the volume plugin options.Driver.
+// The volume is not recorded in the local volume store, the plugin is the source of truth.
+func createWithPlugin(ctx context.Context, name string, options types.VolumeCreateOptions) (*native.Volume, error) {
+	p, err := volumeplugin.Get(ctx, options.Driver)
+	if err != nil {
+		return nil, fmt.Errorf("unknown volume driver %q: %w", options.Driver, err)
+	}
+	if name == "" {
+		name = stringid.GenerateRandomID()
+	}
+	if len(options.Labels) > 0 {
+		log.G(ctx).Warnf("labels are not supported by volume plugins, ignoring the labels of volume %q", name)
+	}
+	if err := p.Create(ctx, name, options.DriverOpts); err != nil {
+		return nil, err
+	}
+	fmt.Fprintln(options.Stdout, name)
+	return &native.Volume{
+		Name:   name,
+		Driver: p.Name,
+	}, nil
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/containerd/errdefs"
 	"github.com/containerd/log"
 
 	"github.com/containerd/nerdctl/v2/pkg/api/types"
@@ -36,6 +37,12 @@ func Inspect(ctx context.Context, volumes []string, options types.VolumeInspectO
 	warns := []error{}
 	for _, name := range volumes {
 		var vol, err = volStore.Get(name, options.Size)
+		if errors.Is(err, errdefs.ErrNotFound) {
+			// The volume may be provided by a volume plugin
+			if p, pVol, pErr := findPluginVolume(ctx, name); pErr == nil && p != nil {
+				vol, err = pVol, nil
+			}
+		}
 		if err != nil {
 			warns = append(warns, err)
 			continue
@@ -18,9 +18,11 @@ package volume
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"text/tabwriter"
@@ -32,6 +34,7 @@ import (
 	"github.com/containerd/nerdctl/v2/pkg/api/types"
 	"github.com/containerd/nerdctl/v2/pkg/formatter"
 	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/native"
+	"github.com/containerd/nerdctl/v2/pkg/mountutil/volumestore"
 )
 
 type volumePrintable struct {
@@ -117,19 +120,32 @@ func lsPrintOutput(vols map[string]native.Volume, options types.VolumeListOption
 		}
 	}
 
-	for _, v := range vols {
+	// like Docker, the volumes are sorted by name
+	names := make([]string, 0, len(vols))
+	for name := range vols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		v := vols[name]
 		p := volumePrintable{
-			Driver:     "local",
+			Driver:     volumestore.LocalDriver,
 			Labels:     "",
 			Mountpoint: v.Mountpoint,
 			Name:       v.Name,
 			Scope:      "local",
 		}
+		if v.Driver != "" {
+			p.Driver = v.Driver
+		}
 		if v.Labels != nil {
 			p.Labels = formatter.FormatLabels(*v.Labels)
 		}
 		if options.Size {
 			p.Size = progress.Bytes(v.Size).String()
+		} else {
+			p.Size = "N/A"
 		}
 		if tmpl != nil {
 			var b bytes.Buffer
@@ -174,6 +190,16 @@ func Volumes(ns string, dataRoot string, address string, volumeSize bool, filter
 	if err != nil {
 		return nil, err
 	}
+	pVols, err := pluginVolumes(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range pVols {
+		// local volumes take precedence over the plugin volumes with the same name
+		if _, ok := vols[v.Name]; !ok {
+			vols[v.Name] = v
+		}
+	}
 
 	labelFilterFuncs, nameFilterFuncs, sizeFilterFuncs, isFilter, err := getVolumeFilterFuncs(filters)
 	if err != nil {
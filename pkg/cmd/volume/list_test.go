@@ -0,0 +1,113 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package volume
+
+import (
+	"bytes"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/nerdctl/v2/pkg/api/types"
+	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/native"
+)
+
+func fixtureVolumes() map[string]native.Volume {
+	labels := map[string]string{"app": "db"}
+	return map[string]native.Volume{
+		"data": {
+			Name:       "data",
+			Mountpoint: "/var/lib/nerdctl/1935db59/volumes/default/data/_data",
+			Labels:     &labels,
+			Size:       4096,
+		},
+		"cache": {
+			Name:       "cache",
+			Mountpoint: "/var/lib/nerdctl/1935db59/volumes/default/cache/_data",
+		},
+		"remote": {
+			Name:       "remote",
+			Driver:     "example-plugin",
+			Mountpoint: "/mnt/remote",
+		},
+	}
+}
+
+func TestLsPrintOutput(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		options  types.VolumeListOptions
+		expected string
+	}{
+		{
+			name: "table",
+			expected: `VOLUME NAME    DIRECTORY
+cache          /var/lib/nerdctl/1935db59/volumes/default/cache/_data
+data           /var/lib/nerdctl/1935db59/volumes/default/data/_data
+remote         /mnt/remote
+`,
+		},
+		{
+			name:    "quiet",
+			options: types.VolumeListOptions{Quiet: true},
+			expected: `cache
+data
+remote
+`,
+		},
+		{
+			name:    "template",
+			options: types.VolumeListOptions{Format: "{{.Name}} {{.Mountpoint}}"},
+			expected: `cache /var/lib/nerdctl/1935db59/volumes/default/cache/_data
+data /var/lib/nerdctl/1935db59/volumes/default/data/_data
+remote /mnt/remote
+`,
+		},
+		{
+			name:    "template with size",
+			options: types.VolumeListOptions{Format: "{{.Name}}\t{{.Driver}}\t{{.Scope}}\t{{.Labels}}\t{{.Size}}", Size: true},
+			expected: "cache\tlocal\tlocal\t\t0.0 B\n" +
+				"data\tlocal\tlocal\tapp=db\t4.0 KiB\n" +
+				"remote\texample-plugin\tlocal\t\t0.0 B\n",
+		},
+		{
+			name:    "json",
+			options: types.VolumeListOptions{Format: "json"},
+			expected: `{"Driver":"local","Labels":"","Mountpoint":"/var/lib/nerdctl/1935db59/volumes/default/cache/_data","Name":"cache","Scope":"local","Size":"N/A"}
+{"Driver":"local","Labels":"app=db","Mountpoint":"/var/lib/nerdctl/1935db59/volumes/default/data/_data","Name":"data","Scope":"local","Size":"N/A"}
+{"Driver":"example-plugin","Labels":"","Mountpoint":"/mnt/remote","Name":"remote","Scope":"local","Size":"N/A"}
+`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var b bytes.Buffer
+			options := tc.options
+			options.Stdout = &b
+			assert.NilError(t, lsPrintOutput(fixtureVolumes(), options))
+			assert.Equal(t, b.String(), tc.expected)
+		})
+	}
+}
+
+func TestLsPrintOutputFormatAndQuiet(t *testing.T) {
+	t.Parallel()
+	err := lsPrintOutput(fixtureVolumes(), types.VolumeListOptions{Stdout: &bytes.Buffer{}, Quiet: true, Format: "json"})
+	assert.ErrorContains(t, err, "format and quiet must not be specified together")
+}
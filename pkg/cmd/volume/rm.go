@@ -30,6 +30,7 @@ import (
 	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/dockercompat"
 	"github.com/containerd/nerdctl/v2/pkg/labels"
 	"github.com/containerd/nerdctl/v2/pkg/mountutil"
+	"github.com/containerd/nerdctl/v2/pkg/mountutil/volumeplugin"
 )
 
 func Remove(ctx context.Context, client *containerd.Client, volumes []string, options types.VolumeRemoveOptions) error {
@@ -43,6 +44,20 @@ func Remove(ctx context.Context, client *containerd.Client, volumes []string, op
 		return err
 	}
 
+	// Volumes unknown to the local store may be provided by a volume plugin
+	var localVolumes []string
+	pluginVolumes := make(map[string]*volumeplugin.Plugin)
+	for _, name := range volumes {
+		if exists, err := volStore.Exists(name); err == nil && !exists {
+			if p, _, err := findPluginVolume(ctx, name); err == nil && p != nil {
+				pluginVolumes[name] = p
+				continue
+			}
+		}
+		localVolumes = append(localVolumes, name)
+	}
+	volumes = localVolumes
+
 	// Note: to avoid racy behavior, this is called by volStore.Remove *inside a lock*
 	removableVolumes := func() (volumeNames []string, cannotRemove []error, err error) {
 		usedVolumesList, err := usedVolumes(ctx, containers)
@@ -65,6 +80,23 @@ func Remove(ctx context.Context, client *containerd.Client, volumes []string, op
 	if err != nil {
 		return err
 	}
+	if len(pluginVolumes) > 0 {
+		usedVolumesList, err := usedVolumes(ctx, containers)
+		if err != nil {
+			return err
+		}
+		for name, p := range pluginVolumes {
+			if _, ok := usedVolumesList[name]; ok {
+				cannotRemove = append(cannotRemove, fmt.Errorf("volume %q is in use (%w)", name, errdefs.ErrFailedPrecondition))
+				continue
+			}
+			if err := p.Remove(ctx, name); err != nil {
+				cannotRemove = append(cannotRemove, err)
+				continue
+			}
+			removedNames = append(removedNames, name)
+		}
+	}
 	// Otherwise, output on stdout whatever was successful
 	for _, name := range removedNames {
 		fmt.Fprintln(options.Stdout, name)
@@ -17,7 +17,13 @@
 package volume
 
 import (
+	"context"
+
+	"github.com/containerd/log"
+
 	"github.com/containerd/nerdctl/v2/pkg/clientutil"
+	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/native"
+	"github.com/containerd/nerdctl/v2/pkg/mountutil/volumeplugin"
 	"github.com/containerd/nerdctl/v2/pkg/mountutil/volumestore"
 )
 
@@ -30,3 +36,62 @@ func Store(ns string, dataRoot string, address string) (volumestore.VolumeStore,
 	}
 	return volumestore.New(dataStore, ns)
 }
+
+// Exists reports whether the volume name exists in the local volume store, or else in a volume plugin.
+func Exists(ctx context.Context, volStore volumestore.VolumeStore, name string) (bool, error) {
+	exists, err := volStore.Exists(name)
+	if err != nil || exists {
+		return exists, err
+	}
+	p, _, err := findPluginVolume(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	return p != nil, nil
+}
+
+// pluginVolumes returns the volumes of all the volume plugins.
+// Plugins that fail to list their volumes are skipped with a warning.
+func pluginVolumes(ctx context.Context) ([]native.Volume, error) {
+	plugins, err := volumeplugin.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var vols []native.Volume
+	for _, p := range plugins {
+		pVols, err := p.List(ctx)
+		if err != nil {
+			log.G(ctx).WithError(err).Warnf("failed to list the volumes of volume plugin %q", p.Name)
+			continue
+		}
+		for _, v := range pVols {
+			vols = append(vols, native.Volume{
+				Name:       v.Name,
+				Driver:     p.Name,
+				Mountpoint: v.Mountpoint,
+			})
+		}
+	}
+	return vols, nil
+}
+
+// findPluginVolume returns the volume plugin having the volume name, and the volume.
+// It returns a nil plugin if no volume plugin knows that volume.
+func findPluginVolume(ctx context.Context, name string) (*volumeplugin.Plugin, *native.Volume, error) {
+	plugins, err := volumeplugin.List(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, p := range plugins {
+		v, err := p.Get(ctx, name)
+		if err != nil {
+			continue
+		}
+		return p, &native.Volume{
+			Name:       v.Name,
+			Driver:     p.Name,
+			Mountpoint: v.Mountpoint,
+		}, nil
+	}
+	return nil, nil, nil
+}
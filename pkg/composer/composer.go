@@ -47,7 +47,7 @@ type Options struct {
 	NerdctlArgs      []string
 	NetworkInUse     func(ctx context.Context, netName string) (bool, error)
 	NetworkExists    func(string) (bool, error)
-	VolumeExists     func(string) (bool, error)
+	VolumeExists     func(ctx context.Context, volName string) (bool, error)
 	ImageExists      func(ctx context.Context, imageName string) (bool, error)
 	EnsureImage      func(ctx context.Context, imageName, pullMode, platform string, ps *serviceparser.Service, quiet bool) error
 	DebugPrintFull   bool // full debug print, may leak secret env var to logs
@@ -73,16 +73,10 @@ func New(o Options, client *containerd.Client, cfg *config.Config) (*Composer, e
 	optionsFn = append(optionsFn,
 		composecli.WithOsEnv,
 		composecli.WithWorkingDirectory(o.ProjectDirectory),
-	)
-	if o.EnvFile != "" {
-		optionsFn = append(optionsFn,
-			composecli.WithEnvFiles(o.EnvFile),
-		)
-	}
-	optionsFn = append(optionsFn,
 		composecli.WithConfigFileEnv,
 		composecli.WithDefaultConfigPath,
 		composecli.WithEnvFiles(),
+		withEnvFile(o.EnvFile),
 		composecli.WithDotEnv,
 		composecli.WithName(o.Project),
 		composecli.WithProfiles(o.Profiles),
@@ -126,6 +120,18 @@ func New(o Options, client *containerd.Client, cfg *config.Config) (*Composer, e
 	return c, nil
 }
 
+// withEnvFile adds envFile after the default .env file of the project directory,
+// so that its values take precedence over .env for interpolation.
+// Variables set in the shell environment still take precedence over both.
+func withEnvFile(envFile string) composecli.ProjectOptionsFn {
+	return func(o *composecli.ProjectOptions) error {
+		if envFile != "" {
+			o.EnvFiles = append(o.EnvFiles, envFile)
+		}
+		return nil
+	}
+}
+
 type Composer struct {
 	Options
 	project *compose.Project
@@ -0,0 +1,462 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package composer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/nerdctl/v2/pkg/composer/serviceparser"
+)
+
+const testComposeBase = `
+services:
+  web:
+    image: alpine:3.13
+    command: ["echo", "base"]
+    environment:
+      FOO: base
+      BAR: base
+    labels:
+      base: "1"
+    dns:
+      - 1.1.1.1
+    ports:
+      - "8080:80"
+    volumes:
+      - /base:/data
+      - /logs:/logs
+`
+
+const testComposeOverride = `
+services:
+  web:
+    image: alpine:3.14
+    command: ["echo", "override"]
+    environment:
+      BAR: override
+      BAZ: override
+    labels:
+      override: "1"
+    dns:
+      - 8.8.8.8
+    ports:
+      - "8443:443"
+    volumes:
+      - /override:/data
+  db:
+    image: alpine:3.14
+`
+
+// newTestComposer loads the project described by o, without a containerd client.
+func newTestComposer(o Options) (*Composer, error) {
+	o.NerdctlCmd = "nerdctl"
+	o.NetworkExists = func(string) (bool, error) { return true, nil }
+	o.VolumeExists = func(context.Context, string) (bool, error) { return true, nil }
+	o.EnsureImage = func(context.Context, string, string, string, *serviceparser.Service, bool) error {
+		return nil
+	}
+	return New(o, nil, nil)
+}
+
+func writeTestComposeFiles(t *testing.T, overrideName string) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	base := filepath.Join(dir, "docker-compose.yml")
+	override := filepath.Join(dir, overrideName)
+	assert.NilError(t, os.WriteFile(base, []byte(testComposeBase), 0o644))
+	assert.NilError(t, os.WriteFile(override, []byte(testComposeOverride), 0o644))
+	return base, override
+}
+
+func assertMergedProject(t *testing.T, c *Composer) {
+	t.Helper()
+	assert.Equal(t, len(c.project.Services), 2)
+
+	web, err := c.project.GetService("web")
+	assert.NilError(t, err)
+	// scalars and command are replaced
+	assert.Equal(t, web.Image, "alpine:3.14")
+	assert.DeepEqual(t, []string(web.Command), []string{"echo", "override"})
+	// mappings are merged, later files win
+	env := map[string]string{}
+	for k, v := range web.Environment {
+		env[k] = *v
+	}
+	assert.DeepEqual(t, env, map[string]string{"FOO": "base", "BAR": "override", "BAZ": "override"})
+	assert.Equal(t, web.Labels["base"], "1")
+	assert.Equal(t, web.Labels["override"], "1")
+	// sequences are appended
+	assert.DeepEqual(t, []string(web.DNS), []string{"1.1.1.1", "8.8.8.8"})
+	var published []string
+	for _, p := range web.Ports {
+		published = append(published, p.Published)
+	}
+	assert.DeepEqual(t, published, []string{"8080", "8443"})
+	// volumes are merged by target
+	volumes := map[string]string{}
+	for _, v := range web.Volumes {
+		volumes[v.Target] = v.Source
+	}
+	assert.DeepEqual(t, volumes, map[string]string{"/data": "/override", "/logs": "/logs"})
+}
+
+func TestNewMergesMultipleFiles(t *testing.T) {
+	base, override := writeTestComposeFiles(t, "docker-compose.prod.yml")
+	c, err := newTestComposer(Options{ConfigPaths: []string{base, override}})
+	assert.NilError(t, err)
+	assertMergedProject(t, c)
+}
+
+func TestNewMergesComposeFileEnv(t *testing.T) {
+	base, override := writeTestComposeFiles(t, "docker-compose.prod.yml")
+	t.Setenv("COMPOSE_FILE", base+string(os.PathListSeparator)+override)
+	c, err := newTestComposer(Options{})
+	assert.NilError(t, err)
+	assertMergedProject(t, c)
+}
+
+func TestNewMergesDefaultOverrideFile(t *testing.T) {
+	base, _ := writeTestComposeFiles(t, "docker-compose.override.yml")
+	t.Chdir(filepath.Dir(base))
+	c, err := newTestComposer(Options{})
+	assert.NilError(t, err)
+	assertMergedProject(t, c)
+}
+
+func TestNewProjectDirectory(t *testing.T) {
+	configDir := t.TempDir()
+	projectDir := t.TempDir()
+	composePath := filepath.Join(configDir, "compose.yaml")
+	assert.NilError(t, os.WriteFile(composePath, []byte(`
+services:
+  web:
+    build: ./app
+    env_file: ./app.env
+    volumes:
+      - ./data:/data
+`), 0o644))
+	assert.NilError(t, os.WriteFile(filepath.Join(projectDir, "app.env"), []byte("FOO=bar\n"), 0o644))
+	assert.NilError(t, os.WriteFile(filepath.Join(configDir, "app.env"), []byte("FOO=bar\n"), 0o644))
+	t.Chdir(t.TempDir())
+
+	testCases := []struct {
+		name       string
+		projectDir string
+		expected   string
+	}{
+		{name: "defaults to the compose file directory", expected: configDir},
+		{name: "explicit project directory", projectDir: projectDir, expected: projectDir},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := newTestComposer(Options{
+				ProjectDirectory: tc.projectDir,
+				ConfigPaths:      []string{composePath},
+			})
+			assert.NilError(t, err)
+			assert.Equal(t, c.project.WorkingDir, tc.expected)
+			assert.Equal(t, c.project.Name, filepath.Base(tc.expected))
+			web, err := c.project.GetService("web")
+			assert.NilError(t, err)
+			assert.Equal(t, web.Build.Context, filepath.Join(tc.expected, "app"))
+			assert.Equal(t, web.EnvFiles[0].Path, filepath.Join(tc.expected, "app.env"))
+			assert.Equal(t, web.Volumes[0].Source, filepath.Join(tc.expected, "data"))
+		})
+	}
+}
+
+func TestNewProjectName(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "myapp")
+	assert.NilError(t, os.Mkdir(dir, 0o755))
+	composePath := filepath.Join(dir, "compose.yaml")
+	assert.NilError(t, os.WriteFile(composePath, []byte(`
+services:
+  web:
+    image: alpine:3.14
+`), 0o644))
+	namedComposePath := filepath.Join(dir, "compose.named.yaml")
+	assert.NilError(t, os.WriteFile(namedComposePath, []byte(`
+name: fromfile
+services:
+  web:
+    image: alpine:3.14
+`), 0o644))
+
+	testCases := []struct {
+		name        string
+		configPath  string
+		project     string
+		projectEnv  string
+		expected    string
+		expectedErr string
+	}{
+		{name: "defaults to the project directory name", configPath: composePath, expected: "myapp"},
+		{name: "top-level name", configPath: namedComposePath, expected: "fromfile"},
+		{name: "COMPOSE_PROJECT_NAME overrides the top-level name", configPath: namedComposePath, projectEnv: "fromenv", expected: "fromenv"},
+		{name: "--project-name overrides COMPOSE_PROJECT_NAME", configPath: namedComposePath, project: "fromflag", projectEnv: "fromenv", expected: "fromflag"},
+		{name: "invalid --project-name", configPath: composePath, project: "Invalid Name", expectedErr: "invalid project name"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("COMPOSE_PROJECT_NAME", tc.projectEnv)
+			if tc.projectEnv == "" {
+				assert.NilError(t, os.Unsetenv("COMPOSE_PROJECT_NAME"))
+			}
+			c, err := newTestComposer(Options{
+				Project:     tc.project,
+				ConfigPaths: []string{tc.configPath},
+			})
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, c.project.Name, tc.expected)
+		})
+	}
+}
+
+func TestNewExtensionFieldsAndAnchors(t *testing.T) {
+	composePath := filepath.Join(t.TempDir(), "compose.yaml")
+	assert.NilError(t, os.WriteFile(composePath, []byte(`
+x-common: &common
+  image: alpine:3.14
+  environment:
+    FOO: common
+  labels:
+    team: infra
+x-command: &command ["echo", "hello"]
+services:
+  web:
+    <<: *common
+    x-owner: web-team
+    command: *command
+  db:
+    <<: *common
+    environment:
+      FOO: db
+    command: *command
+`), 0o644))
+
+	c, err := newTestComposer(Options{ConfigPaths: []string{composePath}})
+	assert.NilError(t, err)
+	assert.Equal(t, len(c.project.Services), 2)
+
+	web, err := c.project.GetService("web")
+	assert.NilError(t, err)
+	assert.Equal(t, web.Image, "alpine:3.14")
+	assert.Equal(t, *web.Environment["FOO"], "common")
+	assert.Equal(t, web.Labels["team"], "infra")
+	assert.DeepEqual(t, []string(web.Command), []string{"echo", "hello"})
+	assert.Equal(t, web.Extensions["x-owner"], "web-team")
+
+	db, err := c.project.GetService("db")
+	assert.NilError(t, err)
+	assert.Equal(t, db.Image, "alpine:3.14")
+	assert.Equal(t, *db.Environment["FOO"], "db")
+	assert.DeepEqual(t, []string(db.Command), []string{"echo", "hello"})
+
+	_, err = serviceparser.Parse(c.project, web)
+	assert.NilError(t, err)
+
+	var b strings.Builder
+	assert.NilError(t, c.Config(context.Background(), &b, ConfigOptions{}))
+	assert.Assert(t, strings.Contains(b.String(), "x-common:"), b.String())
+	assert.Assert(t, strings.Contains(b.String(), "x-owner: web-team"), b.String())
+}
+
+func TestUpVolumeDriverOpts(t *testing.T) {
+	composePath := filepath.Join(t.TempDir(), "compose.yaml")
+	assert.NilError(t, os.WriteFile(composePath, []byte(`
+name: myapp
+services:
+  web:
+    image: alpine:3.14
+    volumes:
+      - data:/data
+      - shared:/shared
+      - bind:/bind
+volumes:
+  bind:
+    driver_opts:
+      type: none
+      o: bind
+      device: /srv/bind
+  data:
+    driver: nfsplugin
+    driver_opts:
+      type: nfs
+      o: addr=10.0.0.1,rw
+      device: ":/export"
+  shared:
+    external: true
+`), 0o644))
+
+	c, err := newTestComposer(Options{ConfigPaths: []string{composePath}})
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, volumeCreateArgs(c.project.Name, "data", c.project.Volumes["data"]), []string{
+		"--label=com.docker.compose.project=myapp",
+		"--label=com.docker.compose.volume=data",
+		"--driver=nfsplugin",
+		"--opt=device=:/export",
+		"--opt=o=addr=10.0.0.1,rw",
+		"--opt=type=nfs",
+		"myapp_data",
+	})
+	// the options of the local driver are ignored, as `nerdctl volume create` rejects them
+	assert.DeepEqual(t, volumeCreateArgs(c.project.Name, "bind", c.project.Volumes["bind"]), []string{
+		"--label=com.docker.compose.project=myapp",
+		"--label=com.docker.compose.volume=bind",
+		"myapp_bind",
+	})
+
+	assert.NilError(t, c.upVolume(context.Background(), "shared"))
+	c.VolumeExists = func(context.Context, string) (bool, error) { return false, nil }
+	err = c.upVolume(context.Background(), "shared")
+	assert.ErrorContains(t, err, `external volume "shared" not found`)
+}
+
+func TestUpNetworkDriverOpts(t *testing.T) {
+	composePath := filepath.Join(t.TempDir(), "compose.yaml")
+	assert.NilError(t, os.WriteFile(composePath, []byte(`
+name: myapp
+services:
+  web:
+    image: alpine:3.14
+    networks:
+      - lan
+      - shared
+      - host
+networks:
+  lan:
+    driver: macvlan
+    driver_opts:
+      parent: eth0
+      mode: bridge
+    ipam:
+      driver: dhcp
+  shared:
+    external: true
+  host:
+    external: true
+`), 0o644))
+
+	c, err := newTestComposer(Options{ConfigPaths: []string{composePath}})
+	assert.NilError(t, err)
+
+	ctx := context.Background()
+	assert.DeepEqual(t, c.networkCreateArgs(ctx, "lan", c.project.Networks["lan"]), []string{
+		"--label=com.docker.compose.project=myapp",
+		"--label=com.docker.compose.network=lan",
+		"--driver=macvlan",
+		"--opt=mode=bridge",
+		"--opt=parent=eth0",
+		"--ipam-driver=dhcp",
+		"myapp_lan",
+	})
+
+	web, err := c.project.GetService("web")
+	assert.NilError(t, err)
+	ps, err := serviceparser.Parse(c.project, web)
+	assert.NilError(t, err)
+	assert.Assert(t, slices.Contains(ps.Containers[0].RunArgs, "--net=myapp_lan"), "%v", ps.Containers[0].RunArgs)
+
+	assert.NilError(t, c.upNetwork(ctx, "shared"))
+	c.NetworkExists = func(string) (bool, error) { return false, nil }
+	assert.ErrorContains(t, c.upNetwork(ctx, "shared"), `external network "shared" not found`)
+	assert.NilError(t, c.upNetwork(ctx, "host"))
+}
+
+func TestServicesToRestart(t *testing.T) {
+	composePath := filepath.Join(t.TempDir(), "compose.yaml")
+	assert.NilError(t, os.WriteFile(composePath, []byte(`
+services:
+  db:
+    image: alpine:3.14
+  cache:
+    image: alpine:3.14
+  api:
+    image: alpine:3.14
+    depends_on:
+      db:
+        condition: service_started
+        restart: true
+      cache:
+        condition: service_started
+  web:
+    image: alpine:3.14
+    depends_on:
+      api:
+        condition: service_started
+        restart: true
+  worker:
+    image: alpine:3.14
+    depends_on:
+      - db
+`), 0o644))
+
+	c, err := newTestComposer(Options{ConfigPaths: []string{composePath}})
+	assert.NilError(t, err)
+
+	keys := func(m map[string]bool) []string {
+		var res []string
+		for k := range m {
+			res = append(res, k)
+		}
+		slices.Sort(res)
+		return res
+	}
+
+	// db is a `restart: true` dependency of api, and api of web
+	restart, err := c.servicesToRestart([]string{"db"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, keys(restart), []string{"api", "db", "web"})
+
+	// cache is a dependency of api, without `restart: true`
+	restart, err = c.servicesToRestart([]string{"cache"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, keys(restart), []string{"cache"})
+
+	// the dependencies of the restarted services are restarted too
+	restart, err = c.servicesToRestart([]string{"worker"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, keys(restart), []string{"api", "db", "web", "worker"})
+}
+
+func TestIsReplicaName(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"proj-web-1":            true,
+		"proj-web-12":           true,
+		"proj-web-0":            false,
+		"proj-web-01":           false,
+		"proj-web-run-0123abcd": false,
+		"proj-web-":             false,
+		"proj-webapp-1":         false,
+		"proj-db-1":             false,
+		"custom":                false,
+	} {
+		assert.Equal(t, isReplicaName("proj", "web", name), expected, name)
+	}
+}
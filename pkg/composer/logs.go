@@ -23,6 +23,7 @@ import (
 	"os/exec"
 	"os/signal"
 	"strings"
+	"syscall"
 
 	"github.com/compose-spec/compose-go/v2/types"
 
@@ -44,6 +45,16 @@ type LogsOptions struct {
 	LatestRun            bool
 }
 
+// containerExitedError is returned when a container has exited, and LogsOptions.AbortOnContainerExit is set.
+type containerExitedError struct {
+	id   string
+	name string
+}
+
+func (e *containerExitedError) Error() string {
+	return fmt.Sprintf("container %q exited", e.name)
+}
+
 func (c *Composer) Logs(ctx context.Context, lo LogsOptions, services []string) error {
 	// Whether we called `compose logs`, or we are showing logs at the end of `up`, while in non detach mode, we need
 	// to release the lock. At this point, no operation will be performed that needs exclusive locking anymore, and
@@ -100,7 +111,7 @@ func (c *Composer) logs(ctx context.Context, containers []containerd.Container,
 		}
 	}
 
-	logsEOFChan := make(chan string) // value: container name
+	logsEOFChan := make(chan string) // value: container ID
 	for id, state := range containerStates {
 		// TODO: show logs without executing `nerdctl logs`
 		args := []string{"logs"}
@@ -144,16 +155,17 @@ func (c *Composer) logs(ctx context.Context, containers []containerd.Container,
 		if err := state.logCmd.Start(); err != nil {
 			return err
 		}
-		containerName := state.name
+		containerID := id
 		go func() {
 			stdoutTagger.Run()
-			logsEOFChan <- containerName
+			logsEOFChan <- containerID
 		}()
 		go stderrTagger.Run()
 	}
 
 	interruptChan := make(chan os.Signal, 1)
-	signal.Notify(interruptChan, os.Interrupt)
+	signal.Notify(interruptChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interruptChan)
 
 	logsEOFMap := make(map[string]struct{}) // key: container name
 	var containerError error
@@ -164,14 +176,15 @@ selectLoop:
 		case sig := <-interruptChan:
 			log.G(ctx).Debugf("Received signal: %s", sig)
 			break selectLoop
-		case containerName := <-logsEOFChan:
+		case containerID := <-logsEOFChan:
+			containerName := containerStates[containerID].name
 			if lo.Follow {
 				// When `nerdctl logs -f` has exited, we can assume that the container has exited
 				log.G(ctx).Infof("Container %q exited", containerName)
 				// In case a container has exited and the parameter --abort-on-container-exit,
 				// we break the loop and set an error, so we can exit the program with 1
 				if lo.AbortOnContainerExit {
-					containerError = fmt.Errorf("container %q exited", containerName)
+					containerError = &containerExitedError{id: containerID, name: containerName}
 					break selectLoop
 				}
 			} else {
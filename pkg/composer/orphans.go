@@ -19,6 +19,8 @@ package composer
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	containerd "github.com/containerd/containerd/v2/client"
 
@@ -56,6 +58,42 @@ func (c *Composer) getOrphanContainers(ctx context.Context, parsedServices []*se
 	return orphanContainers, nil
 }
 
+// getExcessReplicaContainers returns the containers of the given services that are not
+// part of their replicas anymore, i.e., the scale of the service was decreased.
+// Only the containers named like a replica (`<project>-<service>-<N>`) are considered,
+// so one-off containers created by `compose run` are left alone.
+func (c *Composer) getExcessReplicaContainers(ctx context.Context, parsedServices []*serviceparser.Service) ([]containerd.Container, error) {
+	var excessContainers []containerd.Container
+	for _, svc := range parsedServices {
+		replicaNames := make(map[string]bool, len(svc.Containers))
+		for _, container := range svc.Containers {
+			replicaNames[container.Name] = true
+		}
+		containers, err := c.Containers(ctx, svc.Unparsed.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, container := range containers {
+			containerLabels, err := container.Labels(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("error getting container labels: %w", err)
+			}
+			name := containerLabels[labels.Name]
+			if isReplicaName(c.project.Name, svc.Unparsed.Name, name) && !replicaNames[name] {
+				excessContainers = append(excessContainers, container)
+			}
+		}
+	}
+	return excessContainers, nil
+}
+
+// isReplicaName reports whether name is the default name of a replica of the service.
+func isReplicaName(projectName, serviceName, name string) bool {
+	prefix := serviceparser.DefaultContainerName(projectName, serviceName, "")
+	n, err := strconv.Atoi(strings.TrimPrefix(name, prefix))
+	return err == nil && n > 0 && name == serviceparser.DefaultContainerName(projectName, serviceName, strconv.Itoa(n))
+}
+
 func containerShortIDs(containers []containerd.Container) []string {
 	names := make([]string, 0, len(containers))
 	for _, c := range containers {
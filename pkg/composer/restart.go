@@ -36,9 +36,17 @@ type RestartOptions struct {
 
 // Restart restarts running/stopped containers in `services`. It calls
 // `nerdctl restart CONTAINER_ID` to do the actual job.
+// The services that declare `depends_on.<service>.restart: true` on a restarted service are restarted too.
 func (c *Composer) Restart(ctx context.Context, opt RestartOptions, services []string) error {
+	restart, err := c.servicesToRestart(services)
+	if err != nil {
+		return err
+	}
 	// in dependency order
-	return c.project.ForEachService(services, func(name string, svc *types.ServiceConfig) error {
+	return c.project.ForEachService(nil, func(name string, svc *types.ServiceConfig) error {
+		if !restart[name] {
+			return nil
+		}
 		containers, err := c.Containers(ctx, svc.Name)
 		if err != nil {
 			return err
@@ -48,6 +56,34 @@ func (c *Composer) Restart(ctx context.Context, opt RestartOptions, services []s
 	})
 }
 
+// servicesToRestart returns `services` with their dependencies, and the services that have to be
+// restarted along with them because of `depends_on.<service>.restart: true`.
+func (c *Composer) servicesToRestart(services []string) (map[string]bool, error) {
+	restart := make(map[string]bool)
+	var queue []string
+	if err := c.project.ForEachService(services, func(name string, svc *types.ServiceConfig) error {
+		restart[name] = true
+		queue = append(queue, name)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	for len(queue) > 0 {
+		svc, err := c.project.GetService(queue[0])
+		if err != nil {
+			return nil, err
+		}
+		queue = queue[1:]
+		for _, dependent := range c.project.GetDependentsForService(svc, func(dep types.ServiceDependency) bool { return dep.Restart }) {
+			if !restart[dependent] {
+				restart[dependent] = true
+				queue = append(queue, dependent)
+			}
+		}
+	}
+	return restart, nil
+}
+
 func (c *Composer) restartContainers(ctx context.Context, containers []containerd.Container, opt RestartOptions) error {
 	var timeoutArg string
 	if opt.Timeout != nil {
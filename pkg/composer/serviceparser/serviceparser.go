@@ -34,6 +34,7 @@ import (
 
 	"github.com/containerd/nerdctl/v2/pkg/identifiers"
 	"github.com/containerd/nerdctl/v2/pkg/reflectutil"
+	"github.com/containerd/nerdctl/v2/pkg/strutil"
 )
 
 // ComposeExtensionKey defines fields used to implement extension features.
@@ -81,6 +82,7 @@ func warnUnknownFields(svc types.ServiceConfig) {
 		"Hostname",
 		"Image",
 		"Init",
+		"Isolation",
 		"Labels",
 		"Logging",
 		"MemLimit",
@@ -124,6 +126,7 @@ func warnUnknownFields(svc types.ServiceConfig) {
 	for depName, dep := range svc.DependsOn {
 		if unknown := reflectutil.UnknownNonEmptyFields(&dep,
 			"Condition",
+			"Restart",
 		); len(unknown) > 0 {
 			log.L.Warnf("Ignoring: service %s: depends_on: %s: %+v", svc.Name, depName, unknown)
 		}
@@ -209,14 +212,10 @@ type Service struct {
 }
 
 func getReplicas(svc types.ServiceConfig) (int, error) {
-	replicas := 1
-
-	// No need to check svc.Scale, as it is automatically transformed to svc.Deploy.Replicas by compose-go
+	// compose-go only copies svc.Scale to svc.Deploy.Replicas when the deploy section is present,
+	// so GetScale is used to check both.
 	// https://github.com/compose-spec/compose-go/commit/958cb4f953330a3d1303961796d826b7f79132d7
-
-	if svc.Deploy != nil && svc.Deploy.Replicas != nil {
-		replicas = int(*svc.Deploy.Replicas) // nolint:unconvert
-	}
+	replicas := svc.GetScale()
 
 	if replicas < 0 {
 		return 0, fmt.Errorf("invalid replicas: %d", replicas)
@@ -595,10 +594,17 @@ func newContainer(project *types.Project, parsed *Service, i int) (*Container, e
 		return nil, err
 	}
 	netTypeContainer := false
+	// Like Docker Compose, the service name is an alias of every replica,
+	// so that it resolves to the IPs of all of them.
+	networkAliases := []string{svc.Name}
 	for _, net := range networks {
 		if strings.HasPrefix(net.fullName, "container:") {
 			netTypeContainer = true
 		}
+		if net.shortNetworkName == "" {
+			// network_mode (host, none, container:<name>, ...) does not support aliases
+			networkAliases = nil
+		}
 		c.RunArgs = append(c.RunArgs, "--net="+net.fullName)
 		if value, ok := svc.Networks[net.shortNetworkName]; ok {
 			if value != nil && value.Ipv4Address != "" {
@@ -607,8 +613,14 @@ func newContainer(project *types.Project, parsed *Service, i int) (*Container, e
 			if value != nil && value.MacAddress != "" {
 				c.RunArgs = append(c.RunArgs, "--mac-address="+value.MacAddress)
 			}
+			if value != nil && networkAliases != nil {
+				networkAliases = append(networkAliases, value.Aliases...)
+			}
 		}
 	}
+	for _, alias := range strutil.DedupeStrSlice(networkAliases) {
+		c.RunArgs = append(c.RunArgs, "--network-alias="+alias)
+	}
 
 	if netTypeContainer && svc.Hostname != "" {
 		return nil, fmt.Errorf("conflicting options: hostname and container network mode")
@@ -643,6 +655,10 @@ func newContainer(project *types.Project, parsed *Service, i int) (*Container, e
 		c.RunArgs = append(c.RunArgs, "--platform="+svc.Platform)
 	}
 
+	if svc.Isolation != "" {
+		c.RunArgs = append(c.RunArgs, "--isolation="+svc.Isolation)
+	}
+
 	for _, p := range svc.Ports {
 		pStr, err := servicePortConfigToFlagP(p)
 		if err != nil {
@@ -701,12 +717,23 @@ func newContainer(project *types.Project, parsed *Service, i int) (*Container, e
 		c.RunArgs = append(c.RunArgs, fmt.Sprintf("--group-add=%s", v))
 	}
 
+	var volumeDriver string
 	for _, v := range svc.Volumes {
 		vStr, mkdir, err := serviceVolumeConfigToFlagV(v, project)
 		if err != nil {
 			return nil, err
 		}
 
+		// The volumes of a volume plugin are looked up with the volume driver of the container
+		if v.Type == "volume" && v.Source != "" {
+			if driver := project.Volumes[v.Source].Driver; driver != "" && driver != "local" {
+				if volumeDriver != "" && volumeDriver != driver {
+					return nil, fmt.Errorf("service %s: volumes of different volume drivers (%q, %q) cannot be mounted together", svc.Name, volumeDriver, driver)
+				}
+				volumeDriver = driver
+			}
+		}
+
 		switch v.Type {
 		case "tmpfs":
 			c.RunArgs = append(c.RunArgs, "--tmpfs="+vStr)
@@ -716,6 +743,9 @@ func newContainer(project *types.Project, parsed *Service, i int) (*Container, e
 
 		c.Mkdir = mkdir
 	}
+	if volumeDriver != "" {
+		c.RunArgs = append(c.RunArgs, "--volume-driver="+volumeDriver)
+	}
 
 	for _, config := range svc.Configs {
 		fileRef := types.FileReferenceConfig(config)
@@ -793,9 +823,13 @@ func serviceVolumeConfigToFlagV(c types.ServiceVolumeConfig, project *types.Proj
 		"Bind",
 		"Volume",
 		"Tmpfs",
+		"Consistency",
 	); len(unknown) > 0 {
 		log.L.Warnf("Ignoring: volume: %+v", unknown)
 	}
+	if c.Consistency != "" {
+		log.L.Debugf("Ignoring: volume: consistency %q is not applicable on Linux", c.Consistency)
+	}
 	if c.Bind != nil {
 		if unknown := reflectutil.UnknownNonEmptyFields(c.Bind, "CreateHostPath", "Propagation"); len(unknown) > 0 {
 			log.L.Warnf("Ignoring: volume: Bind: %+v", unknown)
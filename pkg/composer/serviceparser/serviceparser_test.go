@@ -21,6 +21,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/compose-spec/compose-go/v2/types"
@@ -126,6 +127,8 @@ services:
     user: 1001:1001
     group_add:
       - "1001"
+    security_opt:
+      - no-new-privileges:true
 
   db:
     image: mariadb:10.5
@@ -164,6 +167,7 @@ volumes:
 	assert.Assert(t, wp1.Name == DefaultContainerName(project.Name, "wordpress", "1"))
 	assert.Assert(t, in(wp1.RunArgs, "--name="+wp1.Name))
 	assert.Assert(t, in(wp1.RunArgs, "--hostname=wordpress"))
+	assert.Assert(t, in(wp1.RunArgs, "--network-alias=wordpress"))
 	assert.Assert(t, in(wp1.RunArgs, fmt.Sprintf("--net=%s_default", project.Name)))
 	assert.Assert(t, in(wp1.RunArgs, "--restart=always"))
 	assert.Assert(t, in(wp1.RunArgs, "-e=WORDPRESS_DB_HOST=db"))
@@ -185,6 +189,7 @@ volumes:
 	assert.Assert(t, in(wp1.RunArgs, "--shm-size=1073741824"))
 	assert.Assert(t, in(wp1.RunArgs, "--user=1001:1001"))
 	assert.Assert(t, in(wp1.RunArgs, "--group-add=1001"))
+	assert.Assert(t, in(wp1.RunArgs, "--security-opt=no-new-privileges:true"))
 
 	dbSvc, err := project.GetService("db")
 	assert.NilError(t, err)
@@ -208,8 +213,6 @@ func TestParseDeprecated(t *testing.T) {
 services:
   foo:
     image: nginx:alpine
-    # scale was deprecated in favor of deploy.replicas, and is now ignored
-    scale: 2
     # cpus is deprecated in favor of deploy.resources.limits.cpu, but still valid
     cpus: 0.42
     # mem_limit is deprecated in favor of deploy.resources.limits.memory, but still valid
@@ -237,6 +240,40 @@ services:
 	}
 }
 
+func TestParseScale(t *testing.T) {
+	t.Parallel()
+	const dockerComposeYAML = `
+services:
+  foo:
+    image: nginx:alpine
+    scale: 2
+  bar:
+    image: nginx:alpine
+    scale: 3
+    deploy:
+      resources:
+        limits:
+          memory: "42m"
+`
+	comp := testutil.NewComposeDir(t, dockerComposeYAML)
+	defer comp.CleanUp()
+
+	project, err := testutil.LoadProject(comp.YAMLFullPath(), comp.ProjectName(), nil)
+	assert.NilError(t, err)
+
+	for name, expected := range map[string]int{"foo": 2, "bar": 3} {
+		svc, err := project.GetService(name)
+		assert.NilError(t, err)
+
+		ps, err := Parse(project, svc)
+		assert.NilError(t, err)
+		assert.Equal(t, len(ps.Containers), expected)
+		for i, c := range ps.Containers {
+			assert.Equal(t, c.Name, DefaultContainerName(project.Name, name, strconv.Itoa(i+1)))
+		}
+	}
+}
+
 func TestParseDeploy(t *testing.T) {
 	t.Parallel()
 	const dockerComposeYAML = `
@@ -367,6 +404,31 @@ services:
 	}
 }
 
+func TestParseIsolation(t *testing.T) {
+	t.Parallel()
+	const dockerComposeYAML = `
+services:
+  foo:
+    image: nginx:alpine
+    isolation: process
+`
+	comp := testutil.NewComposeDir(t, dockerComposeYAML)
+	defer comp.CleanUp()
+
+	project, err := testutil.LoadProject(comp.YAMLFullPath(), comp.ProjectName(), nil)
+	assert.NilError(t, err)
+
+	fooSvc, err := project.GetService("foo")
+	assert.NilError(t, err)
+
+	foo, err := Parse(project, fooSvc)
+	assert.NilError(t, err)
+
+	for _, c := range foo.Containers {
+		assert.Assert(t, in(c.RunArgs, "--isolation=process"))
+	}
+}
+
 func TestParseRelative(t *testing.T) {
 	t.Parallel()
 
@@ -418,8 +480,10 @@ services:
       source: /src/dir1
       target: /tgt/dir1
       read_only: true
+      consistency: cached
       bind:
         propagation: rshared
+    - "/src/dir2:/tgt/dir2:delegated"
 `
 	comp := testutil.NewComposeDir(t, dockerComposeYAML)
 	defer comp.CleanUp()
@@ -436,9 +500,67 @@ services:
 	t.Logf("foo: %+v", foo)
 	for _, c := range foo.Containers {
 		assert.Assert(t, in(c.RunArgs, "-v=/src/dir1:/tgt/dir1:rshared,ro"))
+		assert.Assert(t, in(c.RunArgs, "-v=/src/dir2:/tgt/dir2"))
 	}
 }
 
+func TestParseVolumeDriver(t *testing.T) {
+	t.Parallel()
+
+	const dockerComposeYAML = `
+services:
+  foo:
+    image: nginx:alpine
+    volumes:
+      - data:/data
+      - cache:/cache
+  bar:
+    image: nginx:alpine
+    volumes:
+      - cache:/cache
+  baz:
+    image: nginx:alpine
+    volumes:
+      - data:/data
+      - other:/other
+volumes:
+  data:
+    driver: nfsplugin
+  cache:
+    driver: local
+  other:
+    driver: otherplugin
+`
+	comp := testutil.NewComposeDir(t, dockerComposeYAML)
+	defer comp.CleanUp()
+
+	project, err := testutil.LoadProject(comp.YAMLFullPath(), comp.ProjectName(), nil)
+	assert.NilError(t, err)
+
+	fooSvc, err := project.GetService("foo")
+	assert.NilError(t, err)
+	foo, err := Parse(project, fooSvc)
+	assert.NilError(t, err)
+	for _, c := range foo.Containers {
+		assert.Assert(t, in(c.RunArgs, "--volume-driver=nfsplugin"))
+	}
+
+	barSvc, err := project.GetService("bar")
+	assert.NilError(t, err)
+	bar, err := Parse(project, barSvc)
+	assert.NilError(t, err)
+	for _, c := range bar.Containers {
+		for _, a := range c.RunArgs {
+			assert.Assert(t, !strings.HasPrefix(a, "--volume-driver"), a)
+		}
+	}
+
+	bazSvc, err := project.GetService("baz")
+	assert.NilError(t, err)
+	_, err = Parse(project, bazSvc)
+	assert.ErrorContains(t, err, "different volume drivers")
+}
+
 func TestTmpfsVolumeLongSyntax(t *testing.T) {
 	t.Parallel()
 
@@ -503,6 +625,7 @@ services:
 	t.Logf("foo: %+v", foo)
 	for _, c := range foo.Containers {
 		assert.Assert(t, in(c.RunArgs, "--net=host"))
+		assert.Assert(t, !in(c.RunArgs, "--network-alias=foo"))
 	}
 
 	barSvc, err := project.GetService("bar")
@@ -515,10 +638,56 @@ services:
 	for _, c := range bar.Containers {
 		assert.Assert(t, in(c.RunArgs, "--net=container:nginx"))
 		assert.Assert(t, !in(c.RunArgs, "--hostname=bar"))
+		assert.Assert(t, !in(c.RunArgs, "--network-alias=bar"))
 	}
 
 }
 
+func TestParseNetworkAliases(t *testing.T) {
+	t.Parallel()
+	const dockerComposeYAML = `
+services:
+  foo:
+    image: nginx:alpine
+    hostname: custom
+    deploy:
+      replicas: 3
+    networks:
+      front:
+        aliases:
+          - web
+          - foo
+      back: {}
+networks:
+  front: {}
+  back: {}
+`
+	comp := testutil.NewComposeDir(t, dockerComposeYAML)
+	defer comp.CleanUp()
+
+	project, err := testutil.LoadProject(comp.YAMLFullPath(), comp.ProjectName(), nil)
+	assert.NilError(t, err)
+
+	fooSvc, err := project.GetService("foo")
+	assert.NilError(t, err)
+
+	foo, err := Parse(project, fooSvc)
+	assert.NilError(t, err)
+
+	t.Logf("foo: %+v", foo)
+	assert.Equal(t, len(foo.Containers), 3)
+	for _, c := range foo.Containers {
+		assert.Assert(t, in(c.RunArgs, "--hostname=custom"))
+		var aliases []string
+		for _, a := range c.RunArgs {
+			if strings.HasPrefix(a, "--network-alias=") {
+				aliases = append(aliases, strings.TrimPrefix(a, "--network-alias="))
+			}
+		}
+		assert.DeepEqual(t, aliases, []string{"foo", "web"})
+	}
+}
+
 func TestParseConfigs(t *testing.T) {
 	t.Parallel()
 	if runtime.GOOS == "windows" {
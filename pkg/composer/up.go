@@ -43,6 +43,8 @@ type UpOptions struct {
 	NoRecreate           bool
 	Scale                map[string]int // map of service name to replicas
 	Pull                 string
+	Timeout              *uint  // timeout for stopping the containers when attached
+	ExitCodeFrom         string // service whose exit code is returned, implies AbortOnContainerExit
 }
 
 func (opts UpOptions) recreateStrategy() string {
@@ -57,6 +59,12 @@ func (opts UpOptions) recreateStrategy() string {
 }
 
 func (c *Composer) Up(ctx context.Context, uo UpOptions, services []string) error {
+	if uo.ExitCodeFrom != "" {
+		if _, err := c.ServiceNames(uo.ExitCodeFrom); err != nil {
+			return fmt.Errorf("invalid --exit-code-from service: %w", err)
+		}
+	}
+
 	for shortName := range c.project.Networks {
 		if err := c.upNetwork(ctx, shortName); err != nil {
 			return err
@@ -87,10 +95,7 @@ func (c *Composer) Up(ctx context.Context, uo UpOptions, services []string) erro
 	// use WithServices to sort the services in dependency order
 	forEachFn := func(name string, svc *types.ServiceConfig) error {
 		if replicas, ok := uo.Scale[svc.Name]; ok {
-			if svc.Deploy == nil {
-				svc.Deploy = &types.DeployConfig{}
-			}
-			svc.Deploy.Replicas = &replicas
+			svc.SetScale(replicas)
 		}
 		ps, err := serviceparser.Parse(c.project, *svc)
 		if err != nil {
@@ -120,6 +125,17 @@ func (c *Composer) Up(ctx context.Context, uo UpOptions, services []string) erro
 		}
 	}
 
+	// remove the replicas exceeding the scale of the service, e.g. after `--scale web=1` when 3 replicas are running
+	excess, err := c.getExcessReplicaContainers(ctx, parsedServices)
+	if err != nil {
+		return fmt.Errorf("error getting containers exceeding the service scale: %w", err)
+	}
+	if len(excess) > 0 {
+		if err := c.removeContainers(ctx, excess, RemoveOptions{Stop: true}); err != nil {
+			return fmt.Errorf("error removing containers exceeding the service scale: %w", err)
+		}
+	}
+
 	return c.upServices(ctx, parsedServices, uo)
 }
 
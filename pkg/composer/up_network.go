@@ -19,10 +19,15 @@ package composer
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/compose-spec/compose-go/v2/types"
 
 	"github.com/containerd/log"
 
 	"github.com/containerd/nerdctl/v2/pkg/labels"
+	"github.com/containerd/nerdctl/v2/pkg/netutil/nettype"
 	"github.com/containerd/nerdctl/v2/pkg/reflectutil"
 )
 
@@ -32,7 +37,17 @@ func (c *Composer) upNetwork(ctx context.Context, shortName string) error {
 		return fmt.Errorf("invalid network name %q", shortName)
 	}
 	if net.External {
-		// NOP
+		// "host" and "none" are not CNI networks, and always exist
+		if netType, err := nettype.Detect([]string{net.Name}); err == nil && netType != nettype.CNI {
+			return nil
+		}
+		netExists, err := c.NetworkExists(net.Name)
+		if err != nil {
+			return err
+		}
+		if !netExists {
+			return fmt.Errorf("external network %q not found", net.Name)
+		}
 		return nil
 	}
 
@@ -47,51 +62,58 @@ func (c *Composer) upNetwork(ctx context.Context, shortName string) error {
 		return err
 	} else if !netExists {
 		log.G(ctx).Infof("Creating network %s", fullName)
-		//add metadata labels to network https://github.com/compose-spec/compose-spec/blob/master/spec.md#labels-1
-		createArgs := []string{
-			fmt.Sprintf("--label=%s=%s", labels.ComposeProject, c.project.Name),
-			fmt.Sprintf("--label=%s=%s", labels.ComposeNetwork, shortName),
-		}
+		createArgs := c.networkCreateArgs(ctx, shortName, net)
 
-		if net.Driver != "" {
-			createArgs = append(createArgs, fmt.Sprintf("--driver=%s", net.Driver))
+		if c.DebugPrintFull {
+			log.G(ctx).Debugf("Creating network args: %s", createArgs)
 		}
 
-		if net.DriverOpts != nil {
-			for k, v := range net.DriverOpts {
-				createArgs = append(createArgs, fmt.Sprintf("--opt=%s=%s", k, v))
-			}
+		if err := c.runNerdctlCmd(ctx, append([]string{"network", "create"}, createArgs...)...); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		if net.Ipam.Config != nil {
-			if len(net.Ipam.Config) > 1 {
-				log.G(ctx).Warnf("Ignoring: network %s: imam.config %+v", shortName, net.Ipam.Config[1:])
-			}
-
-			ipamConfig := net.Ipam.Config[0]
-			if unknown := reflectutil.UnknownNonEmptyFields(ipamConfig, "Subnet", "Gateway", "IPRange"); len(unknown) > 0 {
-				log.G(ctx).Warnf("Ignoring: network %s: ipam.config[0]: %+v", shortName, unknown)
-			}
-			if ipamConfig.Subnet != "" {
-				createArgs = append(createArgs, fmt.Sprintf("--subnet=%s", ipamConfig.Subnet))
-			}
-			if ipamConfig.Gateway != "" {
-				createArgs = append(createArgs, fmt.Sprintf("--gateway=%s", ipamConfig.Gateway))
-			}
-			if ipamConfig.IPRange != "" {
-				createArgs = append(createArgs, fmt.Sprintf("--ip-range=%s", ipamConfig.IPRange))
-			}
-		}
+// networkCreateArgs returns the arguments of `nerdctl network create` for the network shortName.
+func (c *Composer) networkCreateArgs(ctx context.Context, shortName string, net types.NetworkConfig) []string {
+	//add metadata labels to network https://github.com/compose-spec/compose-spec/blob/master/spec.md#labels-1
+	createArgs := []string{
+		fmt.Sprintf("--label=%s=%s", labels.ComposeProject, c.project.Name),
+		fmt.Sprintf("--label=%s=%s", labels.ComposeNetwork, shortName),
+	}
 
-		createArgs = append(createArgs, fullName)
+	if net.Driver != "" {
+		createArgs = append(createArgs, fmt.Sprintf("--driver=%s", net.Driver))
+	}
 
-		if c.DebugPrintFull {
-			log.G(ctx).Debugf("Creating network args: %s", createArgs)
+	for _, k := range slices.Sorted(maps.Keys(net.DriverOpts)) {
+		createArgs = append(createArgs, fmt.Sprintf("--opt=%s=%s", k, net.DriverOpts[k]))
+	}
+
+	if net.Ipam.Driver != "" {
+		createArgs = append(createArgs, fmt.Sprintf("--ipam-driver=%s", net.Ipam.Driver))
+	}
+
+	if net.Ipam.Config != nil {
+		if len(net.Ipam.Config) > 1 {
+			log.G(ctx).Warnf("Ignoring: network %s: imam.config %+v", shortName, net.Ipam.Config[1:])
 		}
 
-		if err := c.runNerdctlCmd(ctx, append([]string{"network", "create"}, createArgs...)...); err != nil {
-			return err
+		ipamConfig := net.Ipam.Config[0]
+		if unknown := reflectutil.UnknownNonEmptyFields(ipamConfig, "Subnet", "Gateway", "IPRange"); len(unknown) > 0 {
+			log.G(ctx).Warnf("Ignoring: network %s: ipam.config[0]: %+v", shortName, unknown)
+		}
+		if ipamConfig.Subnet != "" {
+			createArgs = append(createArgs, fmt.Sprintf("--subnet=%s", ipamConfig.Subnet))
+		}
+		if ipamConfig.Gateway != "" {
+			createArgs = append(createArgs, fmt.Sprintf("--gateway=%s", ipamConfig.Gateway))
+		}
+		if ipamConfig.IPRange != "" {
+			createArgs = append(createArgs, fmt.Sprintf("--ip-range=%s", ipamConfig.IPRange))
 		}
 	}
-	return nil
+
+	return append(createArgs, net.Name)
 }
@@ -22,15 +22,21 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
+	"syscall"
 
 	"golang.org/x/sync/errgroup"
 
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/errdefs"
 	"github.com/containerd/log"
 
 	"github.com/containerd/nerdctl/v2/pkg/composer/serviceparser"
+	"github.com/containerd/nerdctl/v2/pkg/errutil"
 	"github.com/containerd/nerdctl/v2/pkg/internal/filesystem"
 	"github.com/containerd/nerdctl/v2/pkg/labels"
 )
@@ -49,26 +55,20 @@ func (c *Composer) upServices(ctx context.Context, parsedServices []*servicepars
 
 	recreate := uo.recreateStrategy()
 
-	var (
-		containers   = make(map[string]serviceparser.Container) // key: container ID
-		services     = []string{}
-		containersMu sync.Mutex
-	)
-	for _, ps := range parsedServices {
+	services := []string{}
+	// attached holds the IDs of the containers of each service, in the order of services
+	attached := make([][]string, len(parsedServices))
+	for i, ps := range parsedServices {
 		ps := ps
 		var runEG errgroup.Group
 		services = append(services, ps.Unparsed.Name)
-		for _, container := range ps.Containers {
+		attached[i] = make([]string, len(ps.Containers))
+		for j, container := range ps.Containers {
 			container := container
 			runEG.Go(func() error {
 				id, err := c.upServiceContainer(ctx, ps, container, recreate)
-				if err != nil {
-					return err
-				}
-				containersMu.Lock()
-				containers[id] = container
-				containersMu.Unlock()
-				return nil
+				attached[i][j] = id
+				return err
 			})
 		}
 		if err := runEG.Wait(); err != nil {
@@ -80,11 +80,6 @@ func (c *Composer) upServices(ctx context.Context, parsedServices []*servicepars
 		return nil
 	}
 
-	// this is used to stop containers in case --abort-on-container-exit flag is set.
-	// c.Logs returns an error, so we don't need Ctrl-c to reach the "Stopping containers (forcibly)"
-	if uo.AbortOnContainerExit {
-		defer c.stopContainersFromParsedServices(ctx, containers)
-	}
 	log.G(ctx).Info("Attaching to logs")
 	lo := LogsOptions{
 		AbortOnContainerExit: uo.AbortOnContainerExit,
@@ -93,13 +88,149 @@ func (c *Composer) upServices(ctx context.Context, parsedServices []*servicepars
 		NoLogPrefix:          uo.NoLogPrefix,
 		LatestRun:            recreate == RecreateNever,
 	}
-	if err := c.Logs(ctx, lo, services); err != nil {
+	// c.Logs returns on Ctrl-C, when all the containers have exited, or with an error when a container
+	// has exited and --abort-on-container-exit is set. In all cases, the containers are stopped.
+	logsErr := c.Logs(ctx, lo, services)
+
+	log.G(ctx).Info("Stopping containers (press Ctrl-C again to force)")
+	if err := c.stopAttachedContainers(ctx, attached, uo.Timeout); err != nil {
+		return errors.Join(logsErr, err)
+	}
+
+	var exited *containerExitedError
+	switch {
+	case uo.ExitCodeFrom != "":
+		// The exit code of the service is returned, whichever container exited first
+		i := slices.Index(services, uo.ExitCodeFrom)
+		if i < 0 {
+			return fmt.Errorf("no container was started for service %q", uo.ExitCodeFrom)
+		}
+		return c.serviceExitCodeError(ctx, uo.ExitCodeFrom, attached[i])
+	case errors.As(logsErr, &exited):
+		// The exit code of the first container to exit is returned
+		log.G(ctx).Infof("Aborting on container exit: %s", logsErr)
+		return c.containerExitCodeError(ctx, exited.id)
+	}
+	return logsErr
+}
+
+// serviceExitCodeError returns an errutil.ExitCodeError with the exit code of the container of the service
+// that exited first, among the containers ids started by `compose up` (in replica order).
+// The containers of `compose run` are not taken into account.
+func (c *Composer) serviceExitCodeError(ctx context.Context, service string, ids []string) error {
+	var first *containerd.Status
+	for _, id := range ids {
+		status, err := c.containerStatus(ctx, id)
+		if err != nil {
+			return err
+		}
+		if first == nil || status.ExitTime.Before(first.ExitTime) {
+			first = &status
+		}
+	}
+	if first == nil {
+		return fmt.Errorf("no container found for service %q", service)
+	}
+	return exitCodeError(*first)
+}
+
+// containerExitCodeError returns an errutil.ExitCodeError with the exit code of the container, or nil if it is 0.
+func (c *Composer) containerExitCodeError(ctx context.Context, id string) error {
+	status, err := c.containerStatus(ctx, id)
+	if err != nil {
 		return err
 	}
+	return exitCodeError(status)
+}
 
-	log.G(ctx).Infof("Stopping containers (forcibly)") // TODO: support gracefully stopping
-	c.stopContainersFromParsedServices(ctx, containers)
-	return nil
+func (c *Composer) containerStatus(ctx context.Context, id string) (containerd.Status, error) {
+	container, err := c.client.LoadContainer(ctx, id)
+	if err != nil {
+		return containerd.Status{}, err
+	}
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		return containerd.Status{}, err
+	}
+	return task.Status(ctx)
+}
+
+// exitCodeError returns an errutil.ExitCodeError with the exit code of status, or nil if it is 0.
+func exitCodeError(status containerd.Status) error {
+	if status.ExitStatus == 0 {
+		return nil
+	}
+	return errutil.NewExitCoderErr(int(status.ExitStatus))
+}
+
+// stopAttachedContainers stops the containers started by `compose up`, given by service in dependency order,
+// like `compose stop`: in reverse dependency order, and with the stop timeout of the containers, unless timeout is specified.
+// Other containers of the services, like the ones of `compose run`, are left running.
+// The containers are killed if another signal is received while they are being stopped.
+func (c *Composer) stopAttachedContainers(ctx context.Context, attached [][]string, timeout *uint) error {
+	var containers [][]containerd.Container
+	for _, ids := range attached {
+		var svcContainers []containerd.Container
+		for _, id := range ids {
+			container, err := c.client.LoadContainer(ctx, id)
+			if errdefs.IsNotFound(err) {
+				// already removed
+				continue
+			} else if err != nil {
+				return err
+			}
+			svcContainers = append(svcContainers, container)
+		}
+		containers = append(containers, svcContainers)
+	}
+
+	sigC := make(chan os.Signal, 1)
+	signal.Notify(sigC, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigC)
+
+	stopC := make(chan error, 1)
+	go func() {
+		// reverse dependency order
+		for _, svcContainers := range slices.Backward(containers) {
+			if err := c.stopContainers(ctx, svcContainers, StopOptions{Timeout: timeout}); err != nil {
+				stopC <- err
+				return
+			}
+		}
+		stopC <- nil
+	}()
+	select {
+	case err := <-stopC:
+		return err
+	case sig := <-sigC:
+		log.G(ctx).Infof("Received signal %s, killing containers", sig)
+		c.killRunningContainers(ctx, slices.Concat(containers...))
+		return <-stopC
+	}
+}
+
+// killRunningContainers sends SIGKILL to the containers that are still running.
+// The containers already stopped are skipped, and a failure to kill a container does not prevent killing the others.
+func (c *Composer) killRunningContainers(ctx context.Context, containers []containerd.Container) {
+	var killWG sync.WaitGroup
+	for _, container := range containers {
+		task, err := container.Task(ctx, nil)
+		if err != nil {
+			continue
+		}
+		status, err := task.Status(ctx)
+		if err != nil || status.Status != containerd.Running {
+			continue
+		}
+		killWG.Add(1)
+		go func() {
+			defer killWG.Done()
+			if err := c.runNerdctlCmd(ctx, "kill", "-s", "SIGKILL", container.ID()); err != nil {
+				log.G(ctx).Warn(err)
+			}
+		}()
+	}
+	killWG.Wait()
 }
 
 func (c *Composer) ensureServiceImage(ctx context.Context, ps *serviceparser.Service, allowBuild, forceBuild bool, bo BuildOptions, quiet bool, pullModeArg string) error {
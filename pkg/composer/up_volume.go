@@ -19,10 +19,15 @@ package composer
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/compose-spec/compose-go/v2/types"
 
 	"github.com/containerd/log"
 
 	"github.com/containerd/nerdctl/v2/pkg/labels"
+	"github.com/containerd/nerdctl/v2/pkg/mountutil/volumestore"
 	"github.com/containerd/nerdctl/v2/pkg/reflectutil"
 )
 
@@ -32,29 +37,36 @@ func (c *Composer) upVolume(ctx context.Context, shortName string) error {
 		return fmt.Errorf("invalid volume name %q", shortName)
 	}
 	if vol.External {
-		// NOP
+		volExists, err := c.VolumeExists(ctx, vol.Name)
+		if err != nil {
+			return err
+		}
+		if !volExists {
+			return fmt.Errorf("external volume %q not found", vol.Name)
+		}
 		return nil
 	}
 
-	if unknown := reflectutil.UnknownNonEmptyFields(&vol, "Name"); len(unknown) > 0 {
+	if unknown := reflectutil.UnknownNonEmptyFields(&vol, "Name", "Driver", "DriverOpts"); len(unknown) > 0 {
 		log.G(ctx).Warnf("Ignoring: volume %s: %+v", shortName, unknown)
 	}
+	if isLocalVolumeDriver(vol.Driver) && len(vol.DriverOpts) > 0 {
+		log.G(ctx).Warnf("Ignoring: volume %s: driver_opts are not supported by the %q driver", shortName, volumestore.LocalDriver)
+	}
 
 	// shortName is like "db_data", fullName is like "compose-wordpress_db_data"
 	fullName := vol.Name
 	// FIXME: this is racy. By the time we get below to creating the volume, there is no guarantee that things are still fine.
 	// Furthermore, by the time we are done creating all the volumes, they may very well have been destroyed.
 	// This cannot be fixed without getting rid of the whole "shell-out" approach entirely.
-	volExists, err := c.VolumeExists(fullName)
+	volExists, err := c.VolumeExists(ctx, fullName)
 	if err != nil {
 		return err
 	} else if !volExists {
 		log.G(ctx).Infof("Creating volume %s", fullName)
-		//add metadata labels to volume https://github.com/compose-spec/compose-spec/blob/master/spec.md#labels-2
-		createArgs := []string{
-			fmt.Sprintf("--label=%s=%s", labels.ComposeProject, c.project.Name),
-			fmt.Sprintf("--label=%s=%s", labels.ComposeVolume, shortName),
-			fullName,
+		createArgs := volumeCreateArgs(c.project.Name, shortName, vol)
+		if c.DebugPrintFull {
+			log.G(ctx).Debugf("Creating volume args: %s", createArgs)
 		}
 		if err := c.runNerdctlCmd(ctx, append([]string{"volume", "create"}, createArgs...)...); err != nil {
 			return err
@@ -62,3 +74,26 @@ func (c *Composer) upVolume(ctx context.Context, shortName string) error {
 	}
 	return nil
 }
+
+// volumeCreateArgs returns the arguments of `nerdctl volume create` for the volume shortName of the project.
+func volumeCreateArgs(projectName, shortName string, vol types.VolumeConfig) []string {
+	//add metadata labels to volume https://github.com/compose-spec/compose-spec/blob/master/spec.md#labels-2
+	createArgs := []string{
+		fmt.Sprintf("--label=%s=%s", labels.ComposeProject, projectName),
+		fmt.Sprintf("--label=%s=%s", labels.ComposeVolume, shortName),
+	}
+	if vol.Driver != "" {
+		createArgs = append(createArgs, fmt.Sprintf("--driver=%s", vol.Driver))
+	}
+	// `nerdctl volume create` rejects the options of the local driver
+	if !isLocalVolumeDriver(vol.Driver) {
+		for _, k := range slices.Sorted(maps.Keys(vol.DriverOpts)) {
+			createArgs = append(createArgs, fmt.Sprintf("--opt=%s=%s", k, vol.DriverOpts[k]))
+		}
+	}
+	return append(createArgs, vol.Name)
+}
+
+func isLocalVolumeDriver(driver string) bool {
+	return driver == "" || driver == volumestore.LocalDriver
+}
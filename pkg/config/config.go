@@ -47,6 +47,16 @@ type Config struct {
 	DNSOpts          []string `toml:"dns_opts,omitempty"`
 	DNSSearch        []string `toml:"dns_search,omitempty"`
 	DisableHCSystemd bool     `toml:"disable_hc_systemd"`
+	// GPUMode selects how `--gpus` exposes NVIDIA GPUs: "auto", "cdi", or "legacy".
+	GPUMode string `toml:"gpu_mode,omitempty"`
+	// DefaultUlimits are the ulimits applied to every container, unless overridden by `--ulimit`.
+	DefaultUlimits []string `toml:"default_ulimits,omitempty"`
+	// DefaultInitBinary is the init binary used for `--init`, unless overridden by `--init-binary`.
+	DefaultInitBinary string `toml:"default_init_binary,omitempty"`
+	// RemoteFileAuthHeader is the Authorization header sent when fetching https `--env-file` and `--label-file` from RemoteFileAuthHost.
+	RemoteFileAuthHeader string `toml:"remote_file_auth_header,omitempty"`
+	// RemoteFileAuthHost is the only host RemoteFileAuthHeader is sent to, over https.
+	RemoteFileAuthHost string `toml:"remote_file_auth_host,omitempty"`
 }
 
 // New creates a default Config object statically,
@@ -73,5 +83,7 @@ func New() *Config {
 		DNSOpts:          []string{},
 		DNSSearch:        []string{},
 		DisableHCSystemd: false,
+		GPUMode:          "auto",
+		DefaultUlimits:   []string{},
 	}
 }
@@ -195,6 +195,10 @@ func (m *noneNetworkManager) VerifyNetworkOptions(_ context.Context) error {
 		return err
 	}
 
+	if len(m.netOpts.NetworkAliases) != 0 {
+		return errors.New("conflicting options: --network-alias is not supported when using `--network=none`")
+	}
+
 	return nil
 }
 
@@ -272,8 +276,9 @@ func (m *noneNetworkManager) CleanupNetworking(ctx context.Context, container co
 // InternalNetworkingOptionLabels Returns the set of NetworkingOptions which should be set as labels on the container.
 func (m *noneNetworkManager) InternalNetworkingOptionLabels(_ context.Context) (types.NetworkOptions, error) {
 	opts := m.netOpts
-	// Cannot have a MAC address in host networking mode.
+	// Cannot have a MAC address nor link-local IPs in host networking mode.
 	opts.MACAddress = ""
+	opts.LinkLocalIPs = nil
 	return opts, nil
 }
 
@@ -372,9 +377,10 @@ func (m *containerNetworkManager) VerifyNetworkOptions(_ context.Context) error
 		"--hostname":   m.netOpts.Hostname,
 		"--domainname": m.netOpts.Domainname,
 		// NOTE: an empty slice still counts as a non-zero value so we check its length:
-		"-p/--publish": len(m.netOpts.PortMappings) != 0,
-		"--dns":        len(m.netOpts.DNSServers) != 0,
-		"--add-host":   len(m.netOpts.AddHost) != 0,
+		"-p/--publish":    len(m.netOpts.PortMappings) != 0,
+		"--dns":           len(m.netOpts.DNSServers) != 0,
+		"--add-host":      len(m.netOpts.AddHost) != 0,
+		"--network-alias": len(m.netOpts.NetworkAliases) != 0,
 	})
 
 	if len(nonZeroParams) != 0 {
@@ -462,8 +468,9 @@ func (m *containerNetworkManager) InternalNetworkingOptionLabels(ctx context.Con
 	if m.netOpts.NetworkSlice == nil || len(m.netOpts.NetworkSlice) != 1 {
 		return opts, fmt.Errorf("conflicting options: exactly one network specification is allowed when using '--network=container:<container>'")
 	}
-	// MacAddress is not allowed with container networking
+	// MacAddress and link-local IPs are not allowed with container networking
 	opts.MACAddress = ""
+	opts.LinkLocalIPs = nil
 
 	container, err := m.getNetworkingContainerForArgument(ctx, m.netOpts.NetworkSlice[0], m.client)
 	if err != nil {
@@ -539,6 +546,10 @@ func (m *hostNetworkManager) VerifyNetworkOptions(_ context.Context) error {
 		return errors.New("cannot use host networking on Windows")
 	}
 
+	if len(m.netOpts.NetworkAliases) != 0 {
+		return errors.New("conflicting options: --network-alias is not supported when using `--network=host`")
+	}
+
 	return validateUtsSettings(m.netOpts)
 }
 
@@ -616,8 +627,9 @@ func (m *hostNetworkManager) CleanupNetworking(ctx context.Context, container co
 // InternalNetworkingOptionLabels Returns the set of NetworkingOptions which should be set as labels on the container.
 func (m *hostNetworkManager) InternalNetworkingOptionLabels(_ context.Context) (types.NetworkOptions, error) {
 	opts := m.netOpts
-	// Cannot have a MAC address in host networking mode.
+	// Cannot have a MAC address nor link-local IPs in host networking mode.
 	opts.MACAddress = ""
+	opts.LinkLocalIPs = nil
 	return opts, nil
 }
 
@@ -886,6 +898,12 @@ func NetworkOptionsFromSpec(spec *specs.Spec) (types.NetworkOptions, error) {
 		opts.IPAddress = ipAddress
 	}
 
+	if linkLocalIPsJSON, ok := spec.Annotations[labels.LinkLocalIPs]; ok {
+		if err := json.Unmarshal([]byte(linkLocalIPsJSON), &opts.LinkLocalIPs); err != nil {
+			return opts, err
+		}
+	}
+
 	var networks []string
 	networksJSON := spec.Annotations[labels.Networks]
 	if err := json.Unmarshal([]byte(networksJSON), &networks); err != nil {
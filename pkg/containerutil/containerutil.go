@@ -280,6 +280,9 @@ func Start(ctx context.Context, container containerd.Container, isAttach bool, i
 		// source: https://github.com/containerd/nerdctl/blob/main/docs/command-reference.md#whale-nerdctl-start
 		attachStreamOpt = []string{"STDOUT", "STDERR"}
 	}
+	if err := MountPluginVolumes(ctx, container, lab); err != nil {
+		return err
+	}
 	task, err := taskutil.NewTask(ctx, client, container, taskutil.TaskOptions{
 		AttachStreamOpt: attachStreamOpt,
 		IsInteractive:   isInteractive,
@@ -293,6 +296,7 @@ func Start(ctx context.Context, container containerd.Container, isAttach bool, i
 		CheckpointDir:   checkpointDir,
 	})
 	if err != nil {
+		UnmountPluginVolumes(ctx, container)
 		return err
 	}
 	statusC, err := task.Wait(ctx)
@@ -300,9 +304,14 @@ func Start(ctx context.Context, container containerd.Container, isAttach bool, i
 		return err
 	}
 	if err := task.Start(ctx); err != nil {
+		UnmountPluginVolumes(ctx, container)
 		return err
 	}
 
+	// The container starts over, so its health goes back to starting and the start period applies again.
+	if err := healthcheck.ResetHealthState(ctx, container); err != nil {
+		return fmt.Errorf("failed to reset health state: %w", err)
+	}
 	// If container has health checks configured, create and start systemd timer/service files.
 	if err := healthcheck.CreateTimer(ctx, container, cfg, nerdctlCmd, nerdctlArgs); err != nil {
 		return fmt.Errorf("failed to create healthcheck timer: %w", err)
@@ -358,6 +367,12 @@ func Stop(ctx context.Context, container containerd.Container, timeout *time.Dur
 	if err := UpdateExplicitlyStoppedLabel(ctx, container, true); err != nil {
 		return err
 	}
+	// The volume plugins are told that the volumes are not used anymore once the container is stopped
+	defer func() {
+		if err == nil {
+			err = UnmountPluginVolumes(ctx, container)
+		}
+	}()
 
 	l, err := container.Labels(ctx)
 	if err != nil {
@@ -426,7 +441,8 @@ func Stop(ctx context.Context, container containerd.Container, timeout *time.Dur
 		return err
 	}
 
-	// signal will be sent once resume is finished
+	// The processes of a frozen container do not handle signals until the container is thawed,
+	// so unpause it before signaling it, and only then start counting the timeout.
 	if paused {
 		if err := task.Resume(ctx); err != nil {
 			log.G(ctx).Errorf("cannot unpause container %s: %s", container.ID(), err)
@@ -507,6 +523,9 @@ func Pause(ctx context.Context, client *containerd.Client, id string) error {
 
 	task, err := container.Task(ctx, cio.Load)
 	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return fmt.Errorf("container %s is not running", id)
+		}
 		return err
 	}
 
@@ -542,6 +561,10 @@ func Unpause(ctx context.Context, client *containerd.Client, id string, cfg *con
 		return err
 	}
 
+	if status.Status != containerd.Paused {
+		return fmt.Errorf("container %s is not paused", id)
+	}
+
 	// Recreate healthcheck related systemd timer/service files.
 	if err := healthcheck.CreateTimer(ctx, container, cfg, nerdctlCmd, nerdctlArgs); err != nil {
 		return fmt.Errorf("failed to create healthcheck timer: %w", err)
@@ -550,12 +573,7 @@ func Unpause(ctx context.Context, client *containerd.Client, id string, cfg *con
 		return fmt.Errorf("failed to start healthcheck timer: %w", err)
 	}
 
-	switch status.Status {
-	case containerd.Paused:
-		return task.Resume(ctx)
-	default:
-		return fmt.Errorf("container %s is not paused", id)
-	}
+	return task.Resume(ctx)
 }
 
 // ContainerStateDirPath returns the path to the Nerdctl-managed state directory for the container with the given ID.
@@ -572,6 +590,7 @@ type ContainerVolume struct {
 	Mode        string
 	RW          bool
 	Propagation string
+	Driver      string
 }
 
 // GetContainerVolumes is a function that returns a slice of containerVolume pointers.
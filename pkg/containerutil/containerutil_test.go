@@ -19,6 +19,8 @@ package containerutil
 import (
 	"reflect"
 	"testing"
+
+	"github.com/containerd/nerdctl/v2/pkg/labels"
 )
 
 func TestParseExtraHosts(t *testing.T) {
@@ -81,3 +83,28 @@ func TestParseExtraHosts(t *testing.T) {
 		})
 	}
 }
+
+func TestPluginVolumes(t *testing.T) {
+	lab := map[string]string{
+		labels.Mounts: `[{"Type":"bind","Source":"/mnt/foo","Destination":"/foo"},` +
+			`{"Type":"volume","Name":"local-vol","Source":"/var/lib/nerdctl/volumes/local-vol/_data","Destination":"/local","Driver":"local"},` +
+			`{"Type":"volume","Name":"plugin-vol","Source":"/mnt/plugin-vol","Destination":"/plugin","Driver":"vol"}]`,
+	}
+	vols := pluginVolumes(lab)
+	expected := []*ContainerVolume{{Type: "volume", Name: "plugin-vol", Source: "/mnt/plugin-vol", Destination: "/plugin", Driver: "vol"}}
+	if !reflect.DeepEqual(vols, expected) {
+		t.Errorf("expected %+v, got %+v", expected, vols)
+	}
+	if vols := pluginVolumes(map[string]string{}); len(vols) != 0 {
+		t.Errorf("expected no plugin volumes, got %+v", vols)
+	}
+
+	mountsJSON, err := replaceMountSources(lab[labels.Mounts], map[string]string{"/mnt/plugin-vol": "/mnt/container-id/plugin-vol"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	vols = pluginVolumes(map[string]string{labels.Mounts: mountsJSON})
+	if len(vols) != 1 || vols[0].Source != "/mnt/container-id/plugin-vol" || vols[0].Destination != "/plugin" {
+		t.Errorf("unexpected plugin volumes after replacing the sources: %+v", vols)
+	}
+}
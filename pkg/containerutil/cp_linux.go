@@ -30,6 +30,7 @@ import (
 	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/containerd/v2/core/containers"
 	"github.com/containerd/containerd/v2/core/mount"
+	"github.com/containerd/containerd/v2/pkg/oci"
 	"github.com/containerd/errdefs"
 	"github.com/containerd/log"
 
@@ -129,7 +130,7 @@ func CopyFiles(ctx context.Context, client *containerd.Client, container contain
 		}
 
 		var cleanup func() error
-		root, cleanup, err = mountSnapshotForContainer(ctx, client, conInfo, options.GOptions.Snapshotter)
+		root, cleanup, err = mountSnapshotForContainer(ctx, client, conInfo)
 		if cleanup != nil {
 			defer func() {
 				err = errors.Join(err, cleanup())
@@ -143,6 +144,13 @@ func CopyFiles(ctx context.Context, client *containerd.Client, container contain
 		log.G(ctx).Debugf("Got new root %s", root)
 	}
 
+	if options.Container2Host && options.DestPath == "-" {
+		return copyToStream(ctx, tarBinary, pid, conSpec, root, options)
+	}
+	if !options.Container2Host && options.SrcPath == "-" {
+		return copyFromStream(ctx, tarBinary, pid, conSpec, root, options)
+	}
+
 	var sourceSpec, destinationSpec *pathSpecifier
 	var sourceErr, destErr error
 	if options.Container2Host {
@@ -321,9 +329,96 @@ func CopyFiles(ctx context.Context, client *containerd.Client, container contain
 	return nil
 }
 
-func mountSnapshotForContainer(ctx context.Context, client *containerd.Client, conInfo containers.Container, snapshotter string) (string, func() error, error) {
+// copyToStream writes a tar archive of options.SrcPath to options.Stdout (`nerdctl cp CONTAINER:SRC_PATH -`).
+// As with Docker, the archive contains the source under its base name, or the content of the source
+// directory when the source ends with "/.".
+func copyToStream(ctx context.Context, tarBinary string, pid int, conSpec *oci.Spec, root string, options types.ContainerCpOptions) error {
+	sourceSpec, err := getPathSpecFromContainer(options.SrcPath, conSpec, root)
+	if err != nil {
+		if errors.Is(err, errDoesNotExist) {
+			return ErrSourceDoesNotExist
+		} else if errors.Is(err, errIsNotADir) {
+			return ErrSourceIsNotADir
+		}
+		return errors.Join(ErrFilesystem, err)
+	}
+	if !sourceSpec.exists {
+		return ErrSourceDoesNotExist
+	}
+
+	tarCDir := filepath.Dir(sourceSpec.resolvedPath)
+	tarCArg := filepath.Base(sourceSpec.resolvedPath)
+	if sourceSpec.isADir && sourceSpec.endsWithSeparatorDot {
+		tarCDir = sourceSpec.resolvedPath
+		tarCArg = "."
+	}
+	tarC := []string{tarBinary}
+	if options.FollowSymLink {
+		tarC = append(tarC, "-h")
+	}
+	tarC = append(tarC, "-c", "-f", "-", tarCArg)
+	if rootlessutil.IsRootless() {
+		tarC = append([]string{"nsenter", "-t", strconv.Itoa(pid), "-U", "--preserve-credentials", "--"}, tarC...)
+	}
+
+	tarCCmd := exec.CommandContext(ctx, tarC[0], tarC[1:]...)
+	tarCCmd.Dir = tarCDir
+	tarCCmd.Stdout = options.Stdout
+	tarCCmd.Stderr = os.Stderr
+	log.G(ctx).Debugf("executing %v in %q", tarCCmd.Args, tarCCmd.Dir)
+	if err := tarCCmd.Run(); err != nil {
+		return fmt.Errorf("failed to execute %v: %w", tarCCmd.Args, err)
+	}
+	return nil
+}
+
+// copyFromStream extracts the tar archive read from options.Stdin into the directory options.DestPath
+// (`nerdctl cp - CONTAINER:DEST_PATH`).
+func copyFromStream(ctx context.Context, tarBinary string, pid int, conSpec *oci.Spec, root string, options types.ContainerCpOptions) error {
+	destinationSpec, err := getPathSpecFromContainer(options.DestPath, conSpec, root)
+	if err != nil {
+		if errors.Is(err, errDoesNotExist) {
+			return ErrDestinationParentMustExist
+		} else if errors.Is(err, errIsNotADir) {
+			return ErrDestinationIsNotADir
+		}
+		return errors.Join(ErrFilesystem, err)
+	}
+	if !destinationSpec.exists {
+		return ErrDestinationDirMustExist
+	}
+	if !destinationSpec.isADir {
+		return ErrDestinationIsNotADir
+	}
+	if destinationSpec.readOnly {
+		return ErrTargetIsReadOnly
+	}
+
+	tarX := []string{tarBinary, "-x", "-f", "-"}
+	if rootlessutil.IsRootless() {
+		tarX = append([]string{"nsenter", "-t", strconv.Itoa(pid), "-U", "--preserve-credentials", "--"}, tarX...)
+	}
+
+	tarXCmd := exec.CommandContext(ctx, tarX[0], tarX[1:]...)
+	tarXCmd.Dir = destinationSpec.resolvedPath
+	tarXCmd.Stdin = options.Stdin
+	tarXCmd.Stdout = os.Stderr
+	var tarErr bytes.Buffer
+	tarXCmd.Stderr = &tarErr
+	log.G(ctx).Debugf("executing %v in %q", tarXCmd.Args, tarXCmd.Dir)
+	if err := tarXCmd.Run(); err != nil {
+		if strings.Contains(tarErr.String(), "Read-only file system") {
+			return ErrTargetIsReadOnly
+		}
+		return errors.Join(fmt.Errorf("failed to execute %v: %s", tarXCmd.Args, strings.TrimSpace(tarErr.String())), err)
+	}
+	return nil
+}
+
+func mountSnapshotForContainer(ctx context.Context, client *containerd.Client, conInfo containers.Container) (string, func() error, error) {
 	snapKey := conInfo.SnapshotKey
-	resp, err := client.SnapshotService(snapshotter).Mounts(ctx, snapKey)
+	// The container may have been created with a snapshotter other than the default one (`nerdctl run --snapshotter`)
+	resp, err := client.SnapshotService(conInfo.Snapshotter).Mounts(ctx, snapKey)
 	if err != nil {
 		return "", nil, err
 	}
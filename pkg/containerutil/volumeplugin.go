@@ -0,0 +1,162 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package containerutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/log"
+
+	"github.com/containerd/nerdctl/v2/pkg/labels"
+	"github.com/containerd/nerdctl/v2/pkg/mountutil/volumeplugin"
+	"github.com/containerd/nerdctl/v2/pkg/mountutil/volumestore"
+)
+
+// MountPluginVolumes mounts the volumes that volume plugins provide to the container, before its task is created.
+//
+// The volumes still mounted for a previous task (e.g. that exited on its own, or before a reboot) are unmounted first,
+// so that the plugins count a single mount per container.
+// When a plugin returns another mountpoint than the one recorded at creation, the spec of the container is updated.
+func MountPluginVolumes(ctx context.Context, container containerd.Container, lab map[string]string) error {
+	vols := pluginVolumes(lab)
+	if len(vols) == 0 {
+		return nil
+	}
+	if lab[labels.PluginVolumesMounted] == "true" {
+		unmountPluginVolumes(ctx, container.ID(), vols)
+	}
+
+	var mounted []*ContainerVolume
+	sources := make(map[string]string)
+	for _, vol := range vols {
+		p, err := volumeplugin.Get(ctx, vol.Driver)
+		if err != nil {
+			unmountPluginVolumes(ctx, container.ID(), mounted)
+			return fmt.Errorf("unknown volume driver %q of volume %q: %w", vol.Driver, vol.Name, err)
+		}
+		mountpoint, err := p.Mount(ctx, vol.Name, container.ID())
+		if err != nil {
+			unmountPluginVolumes(ctx, container.ID(), mounted)
+			return err
+		}
+		mounted = append(mounted, vol)
+		if mountpoint != vol.Source {
+			sources[vol.Source] = mountpoint
+		}
+	}
+
+	newLabels := map[string]string{labels.PluginVolumesMounted: "true"}
+	opts := []containerd.UpdateContainerOpts{}
+	if len(sources) > 0 {
+		spec, err := container.Spec(ctx)
+		if err != nil {
+			unmountPluginVolumes(ctx, container.ID(), mounted)
+			return err
+		}
+		for i, m := range spec.Mounts {
+			for oldSource, newSource := range sources {
+				if m.Source == oldSource || strings.HasPrefix(m.Source, oldSource+"/") {
+					spec.Mounts[i].Source = newSource + strings.TrimPrefix(m.Source, oldSource)
+				}
+			}
+		}
+		mountsJSON, err := replaceMountSources(lab[labels.Mounts], sources)
+		if err != nil {
+			unmountPluginVolumes(ctx, container.ID(), mounted)
+			return err
+		}
+		newLabels[labels.Mounts] = mountsJSON
+		opts = append(opts, containerd.UpdateContainerOpts(containerd.WithSpec(spec)))
+	}
+	opts = append(opts, containerd.UpdateContainerOpts(containerd.WithAdditionalContainerLabels(newLabels)))
+	if err := container.Update(ctx, opts...); err != nil {
+		unmountPluginVolumes(ctx, container.ID(), mounted)
+		return err
+	}
+	return nil
+}
+
+// UnmountPluginVolumes releases the volumes mounted by MountPluginVolumes, once the task of the container stopped.
+func UnmountPluginVolumes(ctx context.Context, container containerd.Container) error {
+	lab, err := container.Labels(ctx)
+	if err != nil {
+		return err
+	}
+	if lab[labels.PluginVolumesMounted] != "true" {
+		return nil
+	}
+	unmountPluginVolumes(ctx, container.ID(), pluginVolumes(lab))
+	return container.Update(ctx, containerd.UpdateContainerOpts(containerd.WithAdditionalContainerLabels(map[string]string{
+		labels.PluginVolumesMounted: "false",
+	})))
+}
+
+// pluginVolumes returns the volumes of the container that are provided by volume plugins.
+func pluginVolumes(lab map[string]string) []*ContainerVolume {
+	mountsJSON, ok := lab[labels.Mounts]
+	if !ok {
+		return nil
+	}
+	var mounts []*ContainerVolume
+	if err := json.Unmarshal([]byte(mountsJSON), &mounts); err != nil {
+		log.L.WithError(err).Warn("failed to unmarshal the mounts of the container")
+		return nil
+	}
+	var vols []*ContainerVolume
+	for _, m := range mounts {
+		if m.Type == "volume" && m.Driver != "" && m.Driver != volumestore.LocalDriver {
+			vols = append(vols, m)
+		}
+	}
+	return vols
+}
+
+func unmountPluginVolumes(ctx context.Context, id string, vols []*ContainerVolume) {
+	for _, vol := range vols {
+		p, err := volumeplugin.Get(ctx, vol.Driver)
+		if err == nil {
+			err = p.Unmount(ctx, vol.Name, id)
+		}
+		if err != nil {
+			log.G(ctx).WithError(err).Warnf("failed to unmount volume %q of volume plugin %q", vol.Name, vol.Driver)
+		}
+	}
+}
+
+// replaceMountSources replaces the sources of the mounts of the labels.Mounts JSON, preserving the other fields.
+func replaceMountSources(mountsJSON string, sources map[string]string) (string, error) {
+	var mounts []map[string]interface{}
+	if err := json.Unmarshal([]byte(mountsJSON), &mounts); err != nil {
+		return "", err
+	}
+	for _, m := range mounts {
+		if source, ok := m["Source"].(string); ok {
+			if newSource, ok := sources[source]; ok {
+				m["Source"] = newSource
+			}
+		}
+	}
+	b, err := json.Marshal(mounts)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -84,6 +84,9 @@ type Meta struct {
 	ExtraHosts map[string]string // host:ip
 	Name       string
 	Domainname string
+	// Aliases are the network aliases of the container, shared by all its networks.
+	// Several containers may share an alias: it then resolves to all their IPs.
+	Aliases []string `json:",omitempty"`
 }
 
 type Store interface {
@@ -17,6 +17,8 @@
 package hostsstore
 
 import (
+	"slices"
+
 	"github.com/containerd/nerdctl/v2/pkg/netutil"
 )
 
@@ -27,6 +29,9 @@ import (
 // line is line "bar.example.com bar bar.nw0 foo foo.nw0\n"
 // for  `nerdctl --name=foo --hostname=bar --domainname=example.com --network=n0`.
 //
+// line is like "bar bar.nw0 foo foo.nw0 web web.nw0\n"
+// for `nerdctl --name=foo --hostname=bar --network-alias=web --network=nw0`.
+//
 // May return an empty string slice
 func createLine(thatNetwork string, meta *Meta, myNetworks map[string]struct{}) []string {
 	line := []string{}
@@ -45,6 +50,14 @@ func createLine(thatNetwork string, meta *Meta, myNetworks map[string]struct{})
 		baseHostnames = append(baseHostnames, meta.Name)
 	}
 
+	// Network aliases may be shared by several containers: each of them gets its own
+	// line with the alias, so that the alias resolves to all their IPs.
+	for _, alias := range meta.Aliases {
+		if alias != "" && !slices.Contains(baseHostnames, alias) {
+			baseHostnames = append(baseHostnames, alias)
+		}
+	}
+
 	for _, baseHostname := range baseHostnames {
 		line = append(line, baseHostname)
 		if thatNetwork != netutil.DefaultNetworkName {
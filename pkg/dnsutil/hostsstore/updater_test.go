@@ -17,7 +17,10 @@
 package hostsstore
 
 import (
+	"fmt"
 	"net"
+	"os"
+	"slices"
 	"strings"
 	"testing"
 
@@ -31,9 +34,10 @@ func TestCreateLine(t *testing.T) {
 	type testCase struct {
 		thatIP         string
 		thatNetwork    string
-		thatHostname   string // nerdctl run --hostname
-		thatDomainname string // nerdctl run --domainname
-		thatName       string // nerdctl run --name
+		thatHostname   string   // nerdctl run --hostname
+		thatDomainname string   // nerdctl run --domainname
+		thatName       string   // nerdctl run --name
+		thatAliases    []string // nerdctl run --network-alias
 		myNetwork      string
 		expected       string
 	}
@@ -114,6 +118,23 @@ func TestCreateLine(t *testing.T) {
 			myNetwork:      netutil.DefaultNetworkName,
 			expected:       "bar.example.com.example.com bar.example.com",
 		},
+		{
+			thatIP:       "10.4.2.10",
+			thatNetwork:  "n1",
+			thatHostname: "bar",
+			thatName:     "foo",
+			thatAliases:  []string{"web", "bar", "api"}, // "bar" is already the hostname
+			myNetwork:    "n1",
+			expected:     "bar bar.n1 foo foo.n1 web web.n1 api api.n1",
+		},
+		{
+			thatIP:       "10.4.2.11",
+			thatNetwork:  netutil.DefaultNetworkName,
+			thatHostname: "bar",
+			thatAliases:  []string{"web"},
+			myNetwork:    netutil.DefaultNetworkName,
+			expected:     "bar web",
+		},
 	}
 	for _, tc := range testCases {
 		thatMeta := &Meta{
@@ -135,6 +156,7 @@ func TestCreateLine(t *testing.T) {
 			Hostname:   tc.thatHostname,
 			Domainname: tc.thatDomainname,
 			Name:       tc.thatName,
+			Aliases:    tc.thatAliases,
 		}
 
 		myNetworks := map[string]struct{}{
@@ -146,3 +168,50 @@ func TestCreateLine(t *testing.T) {
 		assert.Equal(t, tc.expected, line)
 	}
 }
+
+func TestUpdateAllHostsSharedAlias(t *testing.T) {
+	hs, err := New(t.TempDir(), "default")
+	assert.NilError(t, err)
+
+	ips := []string{"10.4.3.2", "10.4.3.3", "10.4.3.4"}
+	for i, ip := range ips {
+		id := fmt.Sprintf("web-%d", i+1)
+		_, err := hs.AllocHostsFile(id, nil)
+		assert.NilError(t, err)
+		assert.NilError(t, hs.Acquire(Meta{
+			ID: id,
+			Networks: map[string]*types100.Result{
+				"n1": {IPs: []*types100.IPConfig{{Address: net.IPNet{IP: net.ParseIP(ip), Mask: net.CIDRMask(24, 32)}}}},
+			},
+			Hostname: id,
+			Name:     id,
+			Aliases:  []string{"web"},
+		}))
+	}
+
+	resolve := func(id, name string) []string {
+		loc, err := hs.HostsPath(id)
+		assert.NilError(t, err)
+		content, err := os.ReadFile(loc)
+		assert.NilError(t, err)
+		var res []string
+		for _, line := range strings.Split(string(content), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) > 1 && slices.Contains(fields[1:], name) {
+				res = append(res, fields[0])
+			}
+		}
+		slices.Sort(res)
+		return res
+	}
+
+	for i := range ips {
+		id := fmt.Sprintf("web-%d", i+1)
+		assert.DeepEqual(t, resolve(id, "web"), ips)
+		assert.DeepEqual(t, resolve(id, "web.n1"), ips)
+	}
+
+	// The alias no longer resolves to the IP of a stopped container
+	assert.NilError(t, hs.Release("web-2"))
+	assert.DeepEqual(t, resolve("web-1", "web"), []string{"10.4.3.2", "10.4.3.4"})
+}
@@ -31,6 +31,8 @@ import (
 // FYI: https://github.com/containerd/containerd/blob/698622b89a053294593b9b5a363efff7715e9394/oci/spec_opts.go#L186-L222
 // defaults should have valid `k=v` strings.
 // overrides may have the following formats: `k=v` (override k), `k=` (emptify k), `k` (remove k).
+// The order of defaults is preserved, and the new keys of overrides are appended in their order.
+// When a key appears several times, the last value wins but the position of the first occurrence is kept.
 func ReplaceOrAppendEnvValues(defaults, overrides []string) []string {
 	cache := make(map[string]int, len(defaults))
 	results := make([]string, 0, len(defaults))
@@ -54,6 +56,7 @@ func ReplaceOrAppendEnvValues(defaults, overrides []string) []string {
 		if i, exists := cache[k]; exists {
 			results[i] = value
 		} else {
+			cache[k] = len(results)
 			results = append(results, value)
 		}
 	}
@@ -85,7 +88,7 @@ func parseEnvVars(paths []string) ([]string, error) {
 			if len(line) == 0 || strings.HasPrefix(line, "#") {
 				continue
 			}
-			vars = append(vars, line)
+			vars = append(vars, parseEnvLine(line))
 		}
 		if err = sc.Err(); err != nil {
 			return nil, err
@@ -94,6 +97,23 @@ func parseEnvVars(paths []string) ([]string, error) {
 	return vars, nil
 }
 
+// parseEnvLine parses a line of an env file.
+// An optional `export ` prefix is removed, and the value is unquoted when it is surrounded by matching
+// single or double quotes. `KEY=` sets KEY to an empty value, while a bare `KEY` inherits KEY from the host.
+func parseEnvLine(line string) string {
+	if rest, ok := strings.CutPrefix(line, "export"); ok && (strings.HasPrefix(rest, " ") || strings.HasPrefix(rest, "\t")) {
+		line = strings.TrimLeft(rest, " \t")
+	}
+	key, value, ok := strings.Cut(line, "=")
+	if !ok {
+		return line
+	}
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return key + "=" + value
+}
+
 func withOSEnv(envs []string) ([]string, error) {
 	newEnvs := make([]string, len(envs))
 
@@ -98,6 +98,13 @@ func TestReplaceOrAppendEnvValues(t *testing.T) {
 	}
 }
 
+func TestReplaceOrAppendEnvValuesOrder(t *testing.T) {
+	defaults := []string{"PATH=/usr/bin", "A=image", "B=image"}
+	overrides := []string{"C=run", "A=run", "D=run", "C=run2", "B", "D"}
+	expected := []string{"PATH=/usr/bin", "A=run", "C=run2"}
+	assert.DeepEqual(t, expected, ReplaceOrAppendEnvValues(defaults, overrides))
+}
+
 // Test TestParseEnvFileGoodFile for a env file with a few well formatted lines.
 func TestParseEnvFileGoodFile(t *testing.T) {
 	content := `foo=bar
@@ -133,6 +140,44 @@ and_underscore=working too`
 	}
 }
 
+// Test TestParseEnvFileQuotesAndExport for a env file with quoted values, empty values, bare keys and `export` prefixes.
+func TestParseEnvFileQuotesAndExport(t *testing.T) {
+	t.Setenv("NERDCTL_TEST_ENV_FILE_BARE", "from-host")
+	content := `DOUBLE="a b"
+SINGLE='c  d'
+UNMATCHED="e f'
+INNER=g "h" i
+QUOTE_ONLY="
+EMPTY=
+EMPTY_QUOTED=""
+export EXPORTED="j k"
+export	TABBED=l
+exported_key=m
+NERDCTL_TEST_ENV_FILE_BARE
+export NERDCTL_TEST_ENV_FILE_BARE_UNSET
+EQUALS="n=o"
+`
+	tmpFile := tmpFileWithContent(t, content)
+
+	variables, err := MergeEnvFileAndOSEnv([]string{tmpFile}, nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, variables, []string{
+		"DOUBLE=a b",
+		"SINGLE=c  d",
+		`UNMATCHED="e f'`,
+		`INNER=g "h" i`,
+		`QUOTE_ONLY="`,
+		"EMPTY=",
+		"EMPTY_QUOTED=",
+		"EXPORTED=j k",
+		"TABBED=l",
+		"exported_key=m",
+		"NERDCTL_TEST_ENV_FILE_BARE=from-host",
+		"NERDCTL_TEST_ENV_FILE_BARE_UNSET",
+		"EQUALS=n=o",
+	})
+}
+
 // Test TestParseEnvFileEmptyFile for an empty file.
 func TestParseEnvFileEmptyFile(t *testing.T) {
 	tmpFile := tmpFileWithContent(t, "")
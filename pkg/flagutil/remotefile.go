@@ -0,0 +1,132 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package flagutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// remoteFileTimeout is the timeout for fetching a remote `--env-file` or `--label-file`.
+	remoteFileTimeout = 30 * time.Second
+	// remoteFileMaxSize is the maximum size of a remote `--env-file` or `--label-file`.
+	remoteFileMaxSize = 1 << 20
+	// remoteFileMaxRedirects is the maximum number of redirects followed for a remote `--env-file` or `--label-file`.
+	remoteFileMaxRedirects = 10
+)
+
+// remoteFileTransport is replaced in the tests to trust the certificate of the test server.
+var remoteFileTransport = http.DefaultTransport
+
+// IsRemoteFile returns whether path is an http(s) URL.
+func IsRemoteFile(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
+
+// FetchRemoteFiles returns paths with each http(s) URL replaced by a local copy of the remote file,
+// so that they can be parsed like local `--env-file` or `--label-file` paths.
+// authHeader, when not empty, is sent as the value of the Authorization header,
+// only over https and only to authHost (a host name, or a host:port).
+// The returned cleanup function removes the local copies, and must be called even on error.
+func FetchRemoteFiles(ctx context.Context, paths []string, authHeader, authHost string) ([]string, func(), error) {
+	var tempDir string
+	cleanup := func() {
+		if tempDir != "" {
+			os.RemoveAll(tempDir)
+		}
+	}
+	res := make([]string, len(paths))
+	for i, path := range paths {
+		if !IsRemoteFile(path) {
+			res[i] = path
+			continue
+		}
+		if tempDir == "" {
+			var err error
+			// MkdirTemp creates the directory with 0700: the files may contain secrets.
+			tempDir, err = os.MkdirTemp("", "nerdctl-remote-file")
+			if err != nil {
+				return nil, cleanup, err
+			}
+		}
+		localPath := filepath.Join(tempDir, strconv.Itoa(i))
+		if err := fetchRemoteFile(ctx, path, authHeader, authHost, localPath); err != nil {
+			return nil, cleanup, fmt.Errorf("failed to fetch %s: %w", path, err)
+		}
+		res[i] = localPath
+	}
+	return res, cleanup, nil
+}
+
+func fetchRemoteFile(ctx context.Context, rawURL, authHeader, authHost, localPath string) error {
+	ctx, cancel := context.WithTimeout(ctx, remoteFileTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	if authHeader != "" && sendsAuthHeader(req.URL, authHost) {
+		req.Header.Set("Authorization", authHeader)
+	}
+	client := &http.Client{
+		Transport: remoteFileTransport,
+		// The Authorization header is kept on redirects to the same host, so it is checked again
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= remoteFileMaxRedirects {
+				return errors.New("too many redirects")
+			}
+			if !sendsAuthHeader(req.URL, authHost) {
+				req.Header.Del("Authorization")
+			}
+			return nil
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, remoteFileMaxSize+1))
+	if err != nil {
+		return err
+	}
+	if len(data) > remoteFileMaxSize {
+		return fmt.Errorf("file is larger than %d bytes", remoteFileMaxSize)
+	}
+	return os.WriteFile(localPath, data, 0o600)
+}
+
+// sendsAuthHeader returns whether the Authorization header may be sent to u.
+func sendsAuthHeader(u *url.URL, authHost string) bool {
+	if u.Scheme != "https" || authHost == "" {
+		return false
+	}
+	return u.Host == authHost || u.Hostname() == authHost
+}
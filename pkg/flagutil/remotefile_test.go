@@ -0,0 +1,131 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package flagutil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func newEnvFileMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/svc.env", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("# remote env file\nFOO=remote\nBAR=baz\n"))
+	})
+	mux.HandleFunc("/private.env", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("TOKEN=private\n"))
+	})
+	return mux
+}
+
+func newEnvFileServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(newEnvFileMux())
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newEnvFileTLSServer(t *testing.T, mux *http.ServeMux) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewTLSServer(mux)
+	t.Cleanup(srv.Close)
+	transport := remoteFileTransport
+	remoteFileTransport = srv.Client().Transport
+	t.Cleanup(func() { remoteFileTransport = transport })
+	return srv
+}
+
+func TestFetchRemoteFiles(t *testing.T) {
+	srv := newEnvFileServer(t)
+	localFile := tmpFileWithContent(t, "LOCAL=1\n")
+
+	paths, cleanup, err := FetchRemoteFiles(context.Background(), []string{localFile, srv.URL + "/svc.env"}, "", "")
+	assert.NilError(t, err)
+	assert.Equal(t, len(paths), 2)
+	assert.Equal(t, paths[0], localFile)
+
+	envs, err := MergeEnvFileAndOSEnv(paths, []string{"FOO=flag"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, envs, []string{"LOCAL=1", "FOO=remote", "BAR=baz", "FOO=flag"})
+
+	cleanup()
+	_, err = os.Stat(paths[1])
+	assert.Assert(t, os.IsNotExist(err))
+	_, err = os.Stat(localFile)
+	assert.NilError(t, err)
+}
+
+func TestFetchRemoteFilesAuthHeader(t *testing.T) {
+	srv := newEnvFileTLSServer(t, newEnvFileMux())
+	authHost := strings.TrimPrefix(srv.URL, "https://")
+
+	for _, host := range []string{authHost, "127.0.0.1"} {
+		paths, cleanup, err := FetchRemoteFiles(context.Background(), []string{srv.URL + "/private.env"}, "Bearer secret", host)
+		defer cleanup()
+		assert.NilError(t, err)
+		envs, err := MergeEnvFileAndOSEnv(paths, nil)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, envs, []string{"TOKEN=private"})
+	}
+
+	_, cleanup, err := FetchRemoteFiles(context.Background(), []string{srv.URL + "/private.env"}, "", authHost)
+	defer cleanup()
+	assert.ErrorContains(t, err, "failed to fetch "+srv.URL+"/private.env: unexpected status: 401 Unauthorized")
+}
+
+func TestFetchRemoteFilesAuthHeaderNotSent(t *testing.T) {
+	httpSrv := newEnvFileServer(t)
+	mux := newEnvFileMux()
+	mux.Handle("/redirect.env", http.RedirectHandler(httpSrv.URL+"/private.env", http.StatusFound))
+	tlsSrv := newEnvFileTLSServer(t, mux)
+
+	testCases := []struct {
+		name     string
+		url      string
+		authHost string
+	}{
+		{name: "no auth host", url: tlsSrv.URL + "/private.env"},
+		{name: "other host", url: tlsSrv.URL + "/private.env", authHost: "example.com"},
+		{name: "http", url: httpSrv.URL + "/private.env", authHost: strings.TrimPrefix(httpSrv.URL, "http://")},
+		{name: "redirect to http", url: tlsSrv.URL + "/redirect.env", authHost: "127.0.0.1"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, cleanup, err := FetchRemoteFiles(context.Background(), []string{tc.url}, "Bearer secret", tc.authHost)
+			defer cleanup()
+			assert.ErrorContains(t, err, "unexpected status: 401 Unauthorized")
+		})
+	}
+}
+
+func TestFetchRemoteFilesNotFound(t *testing.T) {
+	srv := newEnvFileServer(t)
+
+	_, cleanup, err := FetchRemoteFiles(context.Background(), []string{srv.URL + "/missing.env"}, "", "")
+	defer cleanup()
+	assert.ErrorContains(t, err, "unexpected status: 404 Not Found")
+}
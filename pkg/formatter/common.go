@@ -22,6 +22,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/docker/cli/templates"
@@ -63,11 +67,14 @@ func FormatSlice(format string, writer io.Writer, x []interface{}) error {
 		for _, f := range x {
 			var b bytes.Buffer
 			if err := tmpl.Execute(&b, f); err != nil {
-				if _, ok := err.(template.ExecError); ok {
-					// FallBack to Raw Format
-					if err = tryRawFormat(&b, f, tmpl); err != nil {
-						return err
-					}
+				var execErr template.ExecError
+				if !errors.As(err, &execErr) {
+					return err
+				}
+				// FallBack to Raw Format
+				b.Reset()
+				if err = tryRawFormat(&b, f, tmpl); err != nil {
+					return err
 				}
 			}
 			if _, err = fmt.Fprintln(writer, b.String()); err != nil {
@@ -95,12 +102,77 @@ func tryRawFormat(b *bytes.Buffer, f interface{}, tmpl *template.Template) error
 
 	tmplMissingKey := tmpl.Option("missingkey=error")
 	if rawErr := tmplMissingKey.Execute(b, raw); rawErr != nil {
-		return fmt.Errorf("template parsing error: %v", rawErr)
+		return execErrorWithContext(rawErr, raw)
 	}
 
 	return nil
 }
 
+var execErrorFieldRegexp = regexp.MustCompile(`at <([^>]+)>`)
+
+// execErrorWithContext decorates a template execution error with the field path
+// that failed to evaluate and the top-level fields available on the object.
+func execErrorWithContext(err error, raw interface{}) error {
+	var hints []string
+	if m := execErrorFieldRegexp.FindStringSubmatch(err.Error()); m != nil {
+		hints = append(hints, fmt.Sprintf("cannot evaluate %s", m[1]))
+	}
+	if obj, ok := raw.(map[string]interface{}); ok && len(obj) > 0 {
+		fields := make([]string, 0, len(obj))
+		for k := range obj {
+			fields = append(fields, k)
+		}
+		sort.Strings(fields)
+		hints = append(hints, fmt.Sprintf("available top-level fields: %s", strings.Join(fields, ", ")))
+	}
+	if len(hints) == 0 {
+		return fmt.Errorf("template parsing error: %w", err)
+	}
+	return fmt.Errorf("template parsing error: %s: %w", strings.Join(hints, "; "), err)
+}
+
+var (
+	parseErrorLineRegexp   = regexp.MustCompile(`^template: [^:]*:(\d+): (.*)$`)
+	parseErrorTokenRegexps = []*regexp.Regexp{
+		regexp.MustCompile(`bad character U\+[0-9A-F]+ '(.+)'`),
+		regexp.MustCompile(`function "([^"]+)" not defined`),
+		regexp.MustCompile(`unexpected "([^"]+)"`),
+		regexp.MustCompile(`unexpected <([^>]+)>`),
+	}
+)
+
+// parseErrorWithContext decorates a template parse error with the line and column
+// of the offending token, when the token can be located unambiguously.
+func parseErrorWithContext(err error, format string) error {
+	m := parseErrorLineRegexp.FindStringSubmatch(err.Error())
+	if m == nil {
+		return fmt.Errorf("invalid format %q: %w", format, err)
+	}
+	lineNum, convErr := strconv.Atoi(m[1])
+	lines := strings.Split(format, "\n")
+	if convErr != nil || lineNum < 1 || lineNum > len(lines) {
+		return fmt.Errorf("invalid format %q: %w", format, err)
+	}
+	line := lines[lineNum-1]
+	token := ""
+	for _, re := range parseErrorTokenRegexps {
+		if tm := re.FindStringSubmatch(m[2]); tm != nil {
+			token = tm[1]
+			break
+		}
+	}
+	if token == "" && strings.HasPrefix(m[2], "unclosed action") {
+		token = "{{"
+		if i := strings.LastIndex(line, token); i >= 0 {
+			return fmt.Errorf("invalid format %q: line %d, column %d: %w", format, lineNum, i+1, err)
+		}
+	}
+	if token != "" && strings.Count(line, token) == 1 {
+		return fmt.Errorf("invalid format %q: line %d, column %d near %q: %w", format, lineNum, strings.Index(line, token)+1, token, err)
+	}
+	return fmt.Errorf("invalid format %q: line %d: %w", format, lineNum, err)
+}
+
 // ParseTemplate wraps github.com/docker/cli/templates.Parse() to allow `json` as an alias of `{{json .}}`.
 // ParseTemplate can be removed when https://github.com/docker/cli/pull/3355 gets merged and tagged (Docker 22.XX).
 func ParseTemplate(format string) (*template.Template, error) {
@@ -110,5 +182,9 @@ func ParseTemplate(format string) (*template.Template, error) {
 	if alias, ok := aliases[format]; ok {
 		format = alias
 	}
-	return templates.Parse(format)
+	tmpl, err := templates.Parse(format)
+	if err != nil {
+		return nil, parseErrorWithContext(err, format)
+	}
+	return tmpl, nil
 }
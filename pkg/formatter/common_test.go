@@ -0,0 +1,82 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseTemplateError(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{
+			name:     "stray closing brace",
+			format:   "{{.Name}",
+			expected: `invalid format "{{.Name}": line 1, column 8 near "}": template: :1: bad character U+007D '}'`,
+		},
+		{
+			name:     "undefined function",
+			format:   "{{.ID}} {{foo .Name}}",
+			expected: `invalid format "{{.ID}} {{foo .Name}}": line 1, column 11 near "foo": template: :1: function "foo" not defined`,
+		},
+		{
+			name:     "unclosed action",
+			format:   "{{.ID}} {{.Name",
+			expected: `invalid format "{{.ID}} {{.Name": line 1, column 9: template: :1: unclosed action`,
+		},
+		{
+			name:     "error on a later line",
+			format:   "{{.ID}}\n{{if}}",
+			expected: `invalid format "{{.ID}}\n{{if}}": line 2: template: :2: missing value for if`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseTemplate(tc.format)
+			assert.Error(t, err, tc.expected)
+		})
+	}
+}
+
+func TestFormatSliceBadField(t *testing.T) {
+	type object struct {
+		ID   string
+		Name string
+	}
+	var b bytes.Buffer
+	err := FormatSlice("{{.Config.Image}}", &b, []interface{}{object{ID: "abc", Name: "foo"}})
+	assert.ErrorContains(t, err, "cannot evaluate .Config.Image")
+	assert.ErrorContains(t, err, "available top-level fields: ID, Name")
+	assert.Equal(t, b.String(), "")
+}
+
+func TestFormatSliceRawFallback(t *testing.T) {
+	type object struct {
+		ID     string
+		Labels map[string]string
+	}
+	var b bytes.Buffer
+	err := FormatSlice("{{.ID}} {{.Labels.foo}}", &b, []interface{}{object{ID: "abc", Labels: map[string]string{"foo": "bar"}}})
+	assert.NilError(t, err)
+	assert.Equal(t, b.String(), "abc bar\n")
+}
@@ -21,6 +21,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -111,61 +113,94 @@ func Ellipsis(str string, maxDisplayWidth int) string {
 	return str[:maxDisplayWidth-1] + "…"
 }
 
-func formatRange(startHost, endHost, startContainer, endContainer int32) string {
-	if startHost == endHost && startContainer == endContainer {
-		return fmt.Sprintf("%d->%d", startHost, startContainer)
-	}
-	return fmt.Sprintf("%d-%d->%d-%d", startHost, endHost, startContainer, endContainer)
-}
-
+// FormatPorts formats the port mappings like the PORTS column of `docker ps`,
+// e.g. "0.0.0.0:8080->80/tcp, [::]:8080->80/tcp".
+// Consecutive ports that are published on the same host port are grouped into ranges.
+//
+// Ported from DisplayablePorts in https://github.com/docker/cli/blob/master/cli/command/formatter/container.go
 func FormatPorts(ports []cni.PortMapping) string {
 	if len(ports) == 0 {
 		return ""
 	}
 
-	type key struct {
-		HostIP   string
-		Protocol string
-	}
-	grouped := make(map[key][]cni.PortMapping)
-
-	for _, p := range ports {
-		k := key{HostIP: p.HostIP, Protocol: p.Protocol}
-		grouped[k] = append(grouped[k], p)
+	type portGroup struct {
+		first int32
+		last  int32
 	}
-
-	var displayPorts []string
-	for k, pms := range grouped {
-		sort.Slice(pms, func(i, j int) bool {
-			return pms[i].HostPort < pms[j].HostPort
-		})
-
-		var i int
-		var ranges []string
-		for i = 0; i < len(pms); {
-			start, end := pms[i], pms[i]
-			for i+1 < len(pms) &&
-				pms[i+1].HostPort == end.HostPort+1 &&
-				pms[i+1].ContainerPort == end.ContainerPort+1 {
-				i++
-				end = pms[i]
+	groupMap := make(map[string]*portGroup)
+	var (
+		result        []string
+		hostMappings  []string
+		groupMapKeys  []string
+		sortedPorts   = slices.Clone(ports)
+		formatPortKey = func(hostIP, protocol string) string {
+			if hostIP == "" {
+				return protocol
 			}
-
-			ranges = append(
-				ranges,
-				formatRange(start.HostPort, end.HostPort, start.ContainerPort, end.ContainerPort),
-			)
-			i++
+			return hostIP + "/" + protocol
+		}
+	)
+	sort.Slice(sortedPorts, func(i, j int) bool {
+		return comparePorts(sortedPorts[i], sortedPorts[j])
+	})
+
+	for _, port := range sortedPorts {
+		current := port.ContainerPort
+		if port.HostIP != "" && port.HostPort != current {
+			hostMappings = append(hostMappings, fmt.Sprintf("%s->%d/%s",
+				net.JoinHostPort(port.HostIP, strconv.Itoa(int(port.HostPort))), port.ContainerPort, port.Protocol))
+			continue
+		}
+		portKey := formatPortKey(port.HostIP, port.Protocol)
+		group := groupMap[portKey]
+		if group == nil {
+			groupMap[portKey] = &portGroup{first: current, last: current}
+			groupMapKeys = append(groupMapKeys, portKey)
+			continue
 		}
-		displayPorts = append(
-			displayPorts,
-			fmt.Sprintf("%s:%s/%s", k.HostIP, strings.Join(ranges, ", "), k.Protocol),
-		)
+		if current == group.last+1 {
+			group.last = current
+			continue
+		}
+		result = append(result, formatPortGroup(portKey, group.first, group.last))
+		groupMap[portKey] = &portGroup{first: current, last: current}
+	}
+	for _, portKey := range groupMapKeys {
+		g := groupMap[portKey]
+		result = append(result, formatPortGroup(portKey, g.first, g.last))
 	}
+	result = append(result, hostMappings...)
+	return strings.Join(result, ", ")
+}
 
-	sort.Strings(displayPorts)
+func formatPortGroup(key string, start, last int32) string {
+	var ip, protocol string
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		ip, protocol = key[:i], key[i+1:]
+	} else {
+		protocol = key
+	}
+	group := strconv.Itoa(int(start))
+	if start != last {
+		group = fmt.Sprintf("%s-%d", group, last)
+	}
+	if ip != "" {
+		group = fmt.Sprintf("%s->%s", net.JoinHostPort(ip, group), group)
+	}
+	return fmt.Sprintf("%s/%s", group, protocol)
+}
 
-	return strings.Join(displayPorts, ", ")
+func comparePorts(i, j cni.PortMapping) bool {
+	if i.ContainerPort != j.ContainerPort {
+		return i.ContainerPort < j.ContainerPort
+	}
+	if i.HostIP != j.HostIP {
+		return i.HostIP < j.HostIP
+	}
+	if i.HostPort != j.HostPort {
+		return i.HostPort < j.HostPort
+	}
+	return i.Protocol < j.Protocol
 }
 
 func TimeSinceInHuman(since time.Time) string {
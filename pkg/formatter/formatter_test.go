@@ -126,7 +126,7 @@ func TestFormatPorts(t *testing.T) {
 					HostIP:        "127.0.0.1",
 				},
 			},
-			expected: "127.0.0.1:3000-3001->8080-8081/tcp",
+			expected: "127.0.0.1:3000->8080/tcp, 127.0.0.1:3001->8081/tcp",
 		},
 		{
 			name: "a single tcp port on anyhost",
@@ -180,7 +180,44 @@ func TestFormatPorts(t *testing.T) {
 					HostIP:        "0.0.0.0",
 				},
 			},
-			expected: "0.0.0.0:3000-3001->8080-8081/tcp, 0.0.0.0:3002-3003->8082-8083/udp",
+			expected: "0.0.0.0:3000->8080/tcp, 0.0.0.0:3001->8081/tcp, 0.0.0.0:3002->8082/udp, 0.0.0.0:3003->8083/udp",
+		},
+		{
+			name: "consecutive ports published on the same host ports are grouped",
+			input: []cni.PortMapping{
+				{HostPort: 8081, ContainerPort: 8081, Protocol: "tcp", HostIP: "0.0.0.0"},
+				{HostPort: 8080, ContainerPort: 8080, Protocol: "tcp", HostIP: "0.0.0.0"},
+				{HostPort: 8082, ContainerPort: 8082, Protocol: "tcp", HostIP: "0.0.0.0"},
+				{HostPort: 9000, ContainerPort: 9000, Protocol: "tcp", HostIP: "0.0.0.0"},
+			},
+			expected: "0.0.0.0:8080-8082->8080-8082/tcp, 0.0.0.0:9000->9000/tcp",
+		},
+		{
+			name: "IPv4 and IPv6 bindings are sorted by container port",
+			input: []cni.PortMapping{
+				{HostPort: 8443, ContainerPort: 443, Protocol: "tcp", HostIP: "::"},
+				{HostPort: 8443, ContainerPort: 443, Protocol: "tcp", HostIP: "0.0.0.0"},
+				{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", HostIP: "::"},
+				{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", HostIP: "0.0.0.0"},
+			},
+			expected: "0.0.0.0:8080->80/tcp, [::]:8080->80/tcp, 0.0.0.0:8443->443/tcp, [::]:8443->443/tcp",
+		},
+		{
+			name: "IPv6 ranges",
+			input: []cni.PortMapping{
+				{HostPort: 53, ContainerPort: 53, Protocol: "udp", HostIP: "::1"},
+				{HostPort: 54, ContainerPort: 54, Protocol: "udp", HostIP: "::1"},
+			},
+			expected: "[::1]:53-54->53-54/udp",
+		},
+		{
+			name: "ports without a host IP",
+			input: []cni.PortMapping{
+				{ContainerPort: 81, Protocol: "tcp"},
+				{ContainerPort: 80, Protocol: "tcp"},
+				{ContainerPort: 80, Protocol: "udp"},
+			},
+			expected: "80-81/tcp, 80/udp",
 		},
 	}
 
@@ -30,6 +30,7 @@ import (
 	"github.com/containerd/log"
 
 	"github.com/containerd/nerdctl/v2/pkg/idgen"
+	"github.com/containerd/nerdctl/v2/pkg/labels"
 )
 
 // ExecuteHealthCheck executes the health check command for a container
@@ -63,6 +64,37 @@ func ExecuteHealthCheck(ctx context.Context, task containerd.Task, container con
 	return nil
 }
 
+// ExecuteScheduledHealthCheck executes the health check command for a container when a probe is due.
+// It is run by the healthcheck timer, which runs at the start interval when one is configured,
+// so that the container is probed at the start interval during the start period and at the interval afterwards.
+func ExecuteScheduledHealthCheck(ctx context.Context, task containerd.Task, container containerd.Container, hc *Healthcheck) error {
+	state, err := readHealthStateFromLabels(ctx, container)
+	if err != nil {
+		return fmt.Errorf("failed to read health state from labels: %w", err)
+	}
+	startedAt, err := healthStartedAt(ctx, container, state)
+	if err != nil {
+		return err
+	}
+	if !hc.ProbeDue(state, startedAt, time.Now()) {
+		log.G(ctx).Debugf("skipping health check of container %s, no probe is due", container.ID())
+		return nil
+	}
+	return ExecuteHealthCheck(ctx, task, container, hc)
+}
+
+// healthStartedAt returns the time the start period of the container is measured from.
+func healthStartedAt(ctx context.Context, container containerd.Container, state *HealthState) (time.Time, error) {
+	if state != nil && !state.StartedAt.IsZero() {
+		return state.StartedAt, nil
+	}
+	info, err := container.Info(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get container info: %w", err)
+	}
+	return info.CreatedAt, nil
+}
+
 // probeHealthCheck executes the health check command inside the container context
 func probeHealthCheck(ctx context.Context, task containerd.Task, hc *Healthcheck, processSpec *specs.Process) (*HealthcheckResult, error) {
 	execID := "health-check-" + idgen.TruncateID(idgen.GenerateID())
@@ -134,40 +166,16 @@ func updateHealthStatus(ctx context.Context, container containerd.Container, hcC
 		}
 	}
 
-	// Get container info for start period check
-	info, err := container.Info(ctx)
+	// Get the start time for start period check
+	startedAt, err := healthStartedAt(ctx, container, currentHealth)
 	if err != nil {
-		return fmt.Errorf("failed to get container info: %w", err)
+		return err
 	}
-	containerCreated := info.CreatedAt
 
 	// Check if we're in start period workflow
-	inStartPeriodTime := hcResult.Start.Sub(containerCreated) < hcConfig.StartPeriod
-	inStartPeriodState := currentHealth.InStartPeriod
-
-	if inStartPeriodTime && inStartPeriodState {
-		// Start Period Workflow
-		if hcResult.ExitCode == 0 {
-			// First healthy result transitions us out of start period
-			currentHealth.Status = Healthy
-			currentHealth.FailingStreak = 0
-			currentHealth.InStartPeriod = false
-		}
-		// Ignore unhealthy results during start period
-	} else {
-		// Health Interval Workflow
-		if hcResult.ExitCode == 0 {
-			if currentHealth.Status != Healthy {
-				currentHealth.Status = Healthy
-				currentHealth.FailingStreak = 0
-			}
-		} else {
-			currentHealth.FailingStreak++
-			if currentHealth.FailingStreak >= hcConfig.Retries && currentHealth.Status != Unhealthy {
-				currentHealth.Status = Unhealthy
-			}
-		}
-	}
+	inStartPeriodTime := hcResult.Start.Sub(startedAt) < hcConfig.StartPeriod
+	applyHealthcheckResult(currentHealth, hcConfig, hcResult, inStartPeriodTime)
+	currentHealth.LastProbeAt = hcResult.Start
 
 	// Write updated health state back to labels
 	if err := writeHealthStateToLabels(ctx, container, currentHealth); err != nil {
@@ -181,6 +189,67 @@ func updateHealthStatus(ctx context.Context, container containerd.Container, hcC
 	return nil
 }
 
+// ResetHealthState puts the health state of a container with a health check back to starting.
+// It is called when a stopped container is started again, so that the start period applies again
+// from the new start time, and results from the previous run do not count towards the failing streak.
+func ResetHealthState(ctx context.Context, container containerd.Container) error {
+	lbs, err := container.Labels(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get container labels: %w", err)
+	}
+	hcStr, ok := lbs[labels.HealthCheck]
+	if !ok || hcStr == "" {
+		return nil
+	}
+	hc, err := HealthCheckFromJSON(hcStr)
+	if err != nil {
+		return fmt.Errorf("invalid health check configuration: %w", err)
+	}
+	if len(hc.Test) == 0 || hc.Test[0] == CmdNone {
+		return nil
+	}
+	return writeHealthStateToLabels(ctx, container, newStartingHealthState(hc, time.Now()))
+}
+
+// newStartingHealthState returns the health state of a container that has just been started.
+func newStartingHealthState(hcConfig *Healthcheck, startedAt time.Time) *HealthState {
+	return &HealthState{
+		Status:        Starting,
+		FailingStreak: 0,
+		InStartPeriod: hcConfig.StartPeriod > 0,
+		StartedAt:     startedAt,
+	}
+}
+
+// applyHealthcheckResult updates the health state with the result of a probe.
+// Exit code 0 means healthy, any other exit code (1=unhealthy, 2=reserved, or an error running the probe)
+// is a failure. Failures increment the failing streak, and the container becomes unhealthy once the streak
+// reaches the number of retries. Failures are ignored during the start period.
+func applyHealthcheckResult(state *HealthState, hcConfig *Healthcheck, hcResult *HealthcheckResult, inStartPeriodTime bool) {
+	if inStartPeriodTime && state.InStartPeriod {
+		// Start Period Workflow
+		if hcResult.ExitCode == 0 {
+			// First healthy result transitions us out of start period
+			state.Status = Healthy
+			state.FailingStreak = 0
+			state.InStartPeriod = false
+		}
+		// Ignore unhealthy results during start period
+		return
+	}
+	// Health Interval Workflow
+	if hcResult.ExitCode == 0 {
+		// Any healthy result resets the failing streak, even when the container was already healthy
+		state.Status = Healthy
+		state.FailingStreak = 0
+		return
+	}
+	state.FailingStreak++
+	if state.FailingStreak >= hcConfig.Retries && state.Status != Unhealthy {
+		state.Status = Unhealthy
+	}
+}
+
 // prepareProcessSpec prepares the process spec for health check execution
 func prepareProcessSpec(ctx context.Context, container containerd.Container, hcConfig *Healthcheck) (*specs.Process, error) {
 	hcCommand := hcConfig.Test
@@ -0,0 +1,156 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package healthcheck
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestApplyHealthcheckResult(t *testing.T) {
+	hc := &Healthcheck{Retries: 3}
+	state := &HealthState{Status: Starting}
+	apply := func(exitCode int) {
+		applyHealthcheckResult(state, hc, &HealthcheckResult{ExitCode: exitCode}, false)
+	}
+
+	apply(0)
+	assert.Equal(t, state.Status, Healthy)
+	assert.Equal(t, state.FailingStreak, 0)
+
+	// Failures below the retries keep the container healthy
+	apply(1)
+	apply(2)
+	assert.Equal(t, state.Status, Healthy)
+	assert.Equal(t, state.FailingStreak, 2)
+
+	// A healthy result resets the streak of a healthy container
+	apply(0)
+	assert.Equal(t, state.Status, Healthy)
+	assert.Equal(t, state.FailingStreak, 0)
+
+	// Exit codes 1, 2 (reserved) and probe errors are all failures
+	apply(1)
+	apply(2)
+	assert.Equal(t, state.Status, Healthy)
+	apply(-1)
+	assert.Equal(t, state.Status, Unhealthy)
+	assert.Equal(t, state.FailingStreak, 3)
+	apply(1)
+	assert.Equal(t, state.Status, Unhealthy)
+	assert.Equal(t, state.FailingStreak, 4)
+
+	apply(0)
+	assert.Equal(t, state.Status, Healthy)
+	assert.Equal(t, state.FailingStreak, 0)
+}
+
+func TestApplyHealthcheckResultStartPeriod(t *testing.T) {
+	hc := &Healthcheck{Retries: 1}
+	state := &HealthState{Status: Starting, InStartPeriod: true}
+
+	// Failures are ignored during the start period
+	applyHealthcheckResult(state, hc, &HealthcheckResult{ExitCode: 1}, true)
+	assert.Equal(t, state.Status, Starting)
+	assert.Equal(t, state.FailingStreak, 0)
+
+	// The first healthy result ends the start period
+	applyHealthcheckResult(state, hc, &HealthcheckResult{ExitCode: 0}, true)
+	assert.Equal(t, state.Status, Healthy)
+	assert.Assert(t, !state.InStartPeriod)
+
+	// Failures count again, even within the start period time
+	applyHealthcheckResult(state, hc, &HealthcheckResult{ExitCode: 1}, true)
+	assert.Equal(t, state.Status, Unhealthy)
+	assert.Equal(t, state.FailingStreak, 1)
+}
+
+func TestNewStartingHealthState(t *testing.T) {
+	hc := &Healthcheck{Retries: 1, StartPeriod: time.Minute}
+	startedAt := time.Now()
+
+	// An unhealthy container that is restarted goes back to starting
+	state := newStartingHealthState(hc, startedAt)
+	assert.Equal(t, state.Status, Starting)
+	assert.Equal(t, state.FailingStreak, 0)
+	assert.Assert(t, state.InStartPeriod)
+	assert.Equal(t, state.StartedAt, startedAt)
+
+	// The start period applies again after the restart
+	applyHealthcheckResult(state, hc, &HealthcheckResult{ExitCode: 1}, true)
+	assert.Equal(t, state.Status, Starting)
+	assert.Equal(t, state.FailingStreak, 0)
+
+	applyHealthcheckResult(state, hc, &HealthcheckResult{ExitCode: 0}, true)
+	assert.Equal(t, state.Status, Healthy)
+	assert.Assert(t, !state.InStartPeriod)
+
+	// Without a start period, failures count right away
+	state = newStartingHealthState(&Healthcheck{Retries: 1}, startedAt)
+	assert.Assert(t, !state.InStartPeriod)
+	applyHealthcheckResult(state, hc, &HealthcheckResult{ExitCode: 1}, false)
+	assert.Equal(t, state.Status, Unhealthy)
+	assert.Equal(t, state.FailingStreak, 1)
+
+	// The start time survives the label round trip
+	s, err := newStartingHealthState(hc, startedAt).ToJSONString()
+	assert.NilError(t, err)
+	decoded, err := HealthStateFromJSON(s)
+	assert.NilError(t, err)
+	assert.Assert(t, decoded.StartedAt.Equal(startedAt))
+}
+
+func TestHealthcheckStartInterval(t *testing.T) {
+	hc := &Healthcheck{Interval: 30 * time.Second, StartPeriod: time.Minute, StartInterval: 5 * time.Second}
+	startedAt := time.Now()
+
+	// The timer runs at the start interval, and at the interval without one
+	assert.Equal(t, hc.TimerInterval(), 5*time.Second)
+	assert.Equal(t, (&Healthcheck{Interval: 30 * time.Second}).TimerInterval(), 30*time.Second)
+	assert.Equal(t, (&Healthcheck{Interval: 30 * time.Second, StartInterval: 5 * time.Second}).TimerInterval(), 30*time.Second)
+
+	// The first probe is always due
+	assert.Assert(t, hc.ProbeDue(nil, startedAt, startedAt))
+	assert.Assert(t, !hc.StartIntervalEnded(nil, startedAt, startedAt))
+
+	// During the start period, probes are due at the start interval
+	state := newStartingHealthState(hc, startedAt)
+	state.LastProbeAt = startedAt.Add(10 * time.Second)
+	assert.Equal(t, hc.ProbeInterval(state, startedAt, startedAt.Add(15*time.Second)), 5*time.Second)
+	assert.Assert(t, hc.ProbeDue(state, startedAt, startedAt.Add(15*time.Second)))
+	assert.Assert(t, !hc.StartIntervalEnded(state, startedAt, startedAt.Add(15*time.Second)))
+
+	// After the start period, probes are due at the interval, and the timer is re-created at the interval
+	state.LastProbeAt = startedAt.Add(58 * time.Second)
+	assert.Equal(t, hc.ProbeInterval(state, startedAt, startedAt.Add(63*time.Second)), 30*time.Second)
+	assert.Assert(t, hc.StartIntervalEnded(state, startedAt, startedAt.Add(63*time.Second)))
+	assert.Assert(t, !hc.ProbeDue(state, startedAt, startedAt.Add(63*time.Second)))
+	assert.Assert(t, hc.ProbeDue(state, startedAt, startedAt.Add(88*time.Second)))
+
+	// Once healthy, probes are due at the interval, even within the start period
+	applyHealthcheckResult(state, hc, &HealthcheckResult{ExitCode: 0}, true)
+	state.LastProbeAt = startedAt.Add(10 * time.Second)
+	assert.Equal(t, hc.ProbeInterval(state, startedAt, startedAt.Add(15*time.Second)), 30*time.Second)
+	assert.Assert(t, !hc.ProbeDue(state, startedAt, startedAt.Add(15*time.Second)))
+	assert.Assert(t, hc.ProbeDue(state, startedAt, startedAt.Add(40*time.Second)))
+	assert.Assert(t, hc.StartIntervalEnded(state, startedAt, startedAt.Add(15*time.Second)))
+
+	// Without a start interval, the timer already runs at the interval
+	assert.Assert(t, !(&Healthcheck{Interval: 30 * time.Second}).StartIntervalEnded(state, startedAt, startedAt.Add(63*time.Second)))
+}
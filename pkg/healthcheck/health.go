@@ -56,7 +56,7 @@ const (
 type Health struct {
 	Status        HealthStatus         // Status is one of [Starting], [Healthy] or [Unhealthy].
 	FailingStreak int                  // FailingStreak is the number of consecutive failures
-	Log           []*HealthcheckResult // Log contains the last few results (oldest first)
+	Log           []*HealthcheckResult // Log contains the last MaxLogEntries results (newest first)
 }
 
 // HealthcheckResult stores information about a single run of a healthcheck probe
@@ -69,11 +69,12 @@ type HealthcheckResult struct {
 
 // Healthcheck represents the health check configuration
 type Healthcheck struct {
-	Test        []string      `json:"Test,omitempty"`        // Test is the check to perform that the container is healthy
-	Interval    time.Duration `json:"Interval,omitempty"`    // Interval is the time to wait between checks
-	Timeout     time.Duration `json:"Timeout,omitempty"`     // Timeout is the time to wait before considering the check to have hung
-	Retries     int           `json:"Retries,omitempty"`     // Retries is the number of consecutive failures needed to consider a container as unhealthy
-	StartPeriod time.Duration `json:"StartPeriod,omitempty"` // StartPeriod is the period for the container to initialize before the health check starts
+	Test          []string      `json:"Test,omitempty"`          // Test is the check to perform that the container is healthy
+	Interval      time.Duration `json:"Interval,omitempty"`      // Interval is the time to wait between checks
+	Timeout       time.Duration `json:"Timeout,omitempty"`       // Timeout is the time to wait before considering the check to have hung
+	Retries       int           `json:"Retries,omitempty"`       // Retries is the number of consecutive failures needed to consider a container as unhealthy
+	StartPeriod   time.Duration `json:"StartPeriod,omitempty"`   // StartPeriod is the period for the container to initialize before the health check starts
+	StartInterval time.Duration `json:"StartInterval,omitempty"` // StartInterval is the time to wait between checks during the start period
 }
 
 // HealthState stores the current health state of a container
@@ -81,6 +82,8 @@ type HealthState struct {
 	Status        HealthStatus // Status is one of [Starting], [Healthy] or [Unhealthy]
 	FailingStreak int          // FailingStreak is the number of consecutive failures
 	InStartPeriod bool         // InStartPeriod indicates if we're in the start period workflow
+	StartedAt     time.Time    // StartedAt is the time the container was last (re)started, the start period is measured from it
+	LastProbeAt   time.Time    // LastProbeAt is the time the last probe started
 }
 
 // ToJSONString serializes HealthState to a JSON string for label storage
@@ -137,6 +140,43 @@ func HealthcheckResultFromJSON(s string) (*HealthcheckResult, error) {
 	return &r, nil
 }
 
+// timerAccuracy is the accuracy of the healthcheck timer (AccuracySec= of the systemd timer).
+const timerAccuracy = 1 * time.Second
+
+// TimerInterval returns the time between two runs of the healthcheck timer.
+// When a start interval is configured, the timer runs at the start interval, and the runs for which
+// no probe is due are skipped (see ProbeDue), until it is re-created at the interval (see StartIntervalEnded).
+// Otherwise, it runs at the interval.
+func (hc *Healthcheck) TimerInterval() time.Duration {
+	if hc.StartInterval > 0 && hc.StartPeriod > 0 && hc.StartInterval < hc.Interval {
+		return hc.StartInterval
+	}
+	return hc.Interval
+}
+
+// StartIntervalEnded returns whether the healthcheck timer running at the start interval should be re-created
+// at the interval at now: once the start period is over or the container is healthy, no probe is due at the start interval.
+func (hc *Healthcheck) StartIntervalEnded(state *HealthState, startedAt, now time.Time) bool {
+	return hc.TimerInterval() != hc.Interval && hc.ProbeInterval(state, startedAt, now) == hc.Interval
+}
+
+// ProbeInterval returns the time between two probes at now: the start interval during the start period,
+// until the container becomes healthy, and the interval otherwise.
+func (hc *Healthcheck) ProbeInterval(state *HealthState, startedAt, now time.Time) time.Duration {
+	if hc.StartInterval > 0 && (state == nil || state.InStartPeriod) && now.Sub(startedAt) < hc.StartPeriod {
+		return hc.StartInterval
+	}
+	return hc.Interval
+}
+
+// ProbeDue returns whether a probe scheduled by the healthcheck timer should run at now.
+func (hc *Healthcheck) ProbeDue(state *HealthState, startedAt, now time.Time) bool {
+	if state == nil || state.LastProbeAt.IsZero() {
+		return true
+	}
+	return now.Sub(state.LastProbeAt) >= hc.ProbeInterval(state, startedAt, now)-timerAccuracy
+}
+
 // ApplyDefaults sets default values for unset healthcheck fields
 func (hc *Healthcheck) ApplyDefaults() {
 	if hc.Interval == 0 {
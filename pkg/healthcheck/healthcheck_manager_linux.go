@@ -35,6 +35,19 @@ import (
 	"github.com/containerd/nerdctl/v2/pkg/rootlessutil"
 )
 
+// startTimerSuffix is appended to the container ID to name the units of the healthcheck timer running at the start interval.
+// The units of a transient timer cannot be re-created while its service runs, so the timer at the interval
+// created by UpdateTimer is named after the container ID.
+const startTimerSuffix = "-start"
+
+// timerUnit returns the name of the transient timer and service units created by CreateTimer for hc.
+func timerUnit(hc *Healthcheck, containerID string) string {
+	if hc.TimerInterval() != hc.Interval {
+		return containerID + startTimerSuffix
+	}
+	return containerID
+}
+
 // CreateTimer sets up the transient systemd timer and service for healthchecks.
 func CreateTimer(ctx context.Context, container containerd.Container, cfg *config.Config, nerdctlCmd string, nerdctlArgs []string) error {
 	hc := extractHealthcheck(ctx, container)
@@ -45,8 +58,74 @@ func CreateTimer(ctx context.Context, container containerd.Container, cfg *confi
 		return nil
 	}
 
+	// The timer runs at the health-start-interval if any, and the probes that are not due yet are skipped
+	return createTimer(ctx, container.ID(), timerUnit(hc, container.ID()), hc.TimerInterval(), nerdctlCmd, nerdctlArgs)
+}
+
+// UpdateTimer re-creates the healthcheck timer running at the start interval at the interval,
+// once the start period is over or the container is healthy. It is called by the scheduled health checks.
+func UpdateTimer(ctx context.Context, container containerd.Container, cfg *config.Config, nerdctlCmd string, nerdctlArgs []string) error {
+	hc := extractHealthcheck(ctx, container)
+	if hc == nil {
+		return nil
+	}
+	if shouldSkipHealthCheckSystemd(hc, cfg) {
+		return nil
+	}
+	state, err := readHealthStateFromLabels(ctx, container)
+	if err != nil {
+		return fmt.Errorf("failed to read health state from labels: %w", err)
+	}
+	startedAt, err := healthStartedAt(ctx, container, state)
+	if err != nil {
+		return err
+	}
+	if !hc.StartIntervalEnded(state, startedAt, time.Now()) {
+		return nil
+	}
+
 	containerID := container.ID()
-	log.G(ctx).Debugf("Creating healthcheck timer unit: %s", containerID)
+	var conn *dbus.Conn
+	if rootlessutil.IsRootless() {
+		conn, err = dbus.NewUserConnectionContext(ctx)
+	} else {
+		conn, err = dbus.NewSystemConnectionContext(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("systemd DBUS connect error: %w", err)
+	}
+	defer conn.Close()
+
+	// The timer at the start interval is only replaced once
+	statuses, err := conn.ListUnitsByNamesContext(ctx, []string{containerID + ".timer"})
+	if err != nil {
+		return err
+	}
+	if len(statuses) > 0 && statuses[0].LoadState != "not-found" {
+		return nil
+	}
+
+	if err := createTimer(ctx, containerID, containerID, hc.Interval, nerdctlCmd, nerdctlArgs); err != nil {
+		return err
+	}
+	// The service is started once for the timer to elapse, the probe is skipped as it is not due yet
+	if err := restartUnit(ctx, conn, containerID+".service"); err != nil {
+		return err
+	}
+	// Stopping the timer does not stop its service, which is the caller
+	stopChan := make(chan string, 1)
+	if _, err := conn.StopUnitContext(ctx, containerID+startTimerSuffix+".timer", "fail", stopChan); err != nil {
+		return err
+	}
+	if msg := <-stopChan; msg != "done" {
+		return fmt.Errorf("unexpected systemd stop result: %s", msg)
+	}
+	return nil
+}
+
+// createTimer creates the transient systemd timer and service units named unit, running the health check every interval.
+func createTimer(ctx context.Context, containerID, unit string, interval time.Duration, nerdctlCmd string, nerdctlArgs []string) error {
+	log.G(ctx).Debugf("Creating healthcheck timer unit: %s", unit)
 
 	// Set all environment variables so that they are available for the nerdctl commands run via the systemd service file
 	cmdOpts := []string{}
@@ -62,12 +141,11 @@ func CreateTimer(ctx context.Context, container containerd.Container, cfg *confi
 		cmdOpts = append(cmdOpts, "--setenv=BUILDKIT_HOST="+buildKitHost)
 	}
 
-	// Always use health-interval for timer frequency
-	cmdOpts = append(cmdOpts, "--unit", containerID, "--on-unit-inactive="+hc.Interval.String(), "--timer-property=AccuracySec=1s")
+	cmdOpts = append(cmdOpts, "--unit", unit, "--on-unit-inactive="+interval.String(), "--timer-property=AccuracySec=1s")
 
 	cmdOpts = append(cmdOpts, nerdctlCmd)
 	cmdOpts = append(cmdOpts, nerdctlArgs...)
-	cmdOpts = append(cmdOpts, "container", "healthcheck", containerID)
+	cmdOpts = append(cmdOpts, "container", "healthcheck", "--scheduled", containerID)
 
 	log.G(ctx).Debugf("creating healthcheck timer with: systemd-run %s", strings.Join(cmdOpts, " "))
 	run := exec.Command("systemd-run", cmdOpts...)
@@ -101,8 +179,11 @@ func StartTimer(ctx context.Context, container containerd.Container, cfg *config
 	}
 	defer conn.Close()
 
+	return restartUnit(ctx, conn, timerUnit(hc, containerID)+".service")
+}
+
+func restartUnit(ctx context.Context, conn *dbus.Conn, unit string) error {
 	startChan := make(chan string)
-	unit := containerID + ".service"
 	if _, err := conn.RestartUnitContext(context.Background(), unit, "fail", startChan); err != nil {
 		return err
 	}
@@ -132,9 +213,6 @@ func ForceRemoveTransientHealthCheckFiles(ctx context.Context, containerID strin
 	timeoutCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	timer := containerID + ".timer"
-	service := containerID + ".service"
-
 	// Channel to collect any critical errors (though we'll continue cleanup regardless)
 	errChan := make(chan error, 3)
 
@@ -156,64 +234,70 @@ func ForceRemoveTransientHealthCheckFiles(ctx context.Context, containerID strin
 		}
 		defer conn.Close()
 
-		// Stop timer with timeout
-		go func() {
-			select {
-			case <-timeoutCtx.Done():
-				log.G(ctx).Warnf("timeout stopping timer %s during force cleanup", timer)
-				return
-			default:
-				tChan := make(chan string, 1)
-				if _, err := conn.StopUnitContext(timeoutCtx, timer, "ignore-dependencies", tChan); err == nil {
-					select {
-					case msg := <-tChan:
-						if msg != "done" {
-							log.G(ctx).Warnf("timer stop message during force cleanup: %s", msg)
+		// The units of the timer at the start interval exist until it is re-created at the interval (see UpdateTimer)
+		for _, unit := range loadedTimerUnits(timeoutCtx, conn, containerID, containerID+startTimerSuffix) {
+			timer := unit + ".timer"
+			service := unit + ".service"
+
+			// Stop timer with timeout
+			go func() {
+				select {
+				case <-timeoutCtx.Done():
+					log.G(ctx).Warnf("timeout stopping timer %s during force cleanup", timer)
+					return
+				default:
+					tChan := make(chan string, 1)
+					if _, err := conn.StopUnitContext(timeoutCtx, timer, "ignore-dependencies", tChan); err == nil {
+						select {
+						case msg := <-tChan:
+							if msg != "done" {
+								log.G(ctx).Warnf("timer stop message during force cleanup: %s", msg)
+							}
+						case <-timeoutCtx.Done():
+							log.G(ctx).Warnf("timeout waiting for timer stop confirmation: %s", timer)
 						}
-					case <-timeoutCtx.Done():
-						log.G(ctx).Warnf("timeout waiting for timer stop confirmation: %s", timer)
+					} else {
+						log.G(ctx).Warnf("failed to stop timer %s during force cleanup: %v", timer, err)
 					}
-				} else {
-					log.G(ctx).Warnf("failed to stop timer %s during force cleanup: %v", timer, err)
 				}
-			}
-		}()
-
-		// Stop service with timeout
-		go func() {
-			select {
-			case <-timeoutCtx.Done():
-				log.G(ctx).Warnf("timeout stopping service %s during force cleanup", service)
-				return
-			default:
-				sChan := make(chan string, 1)
-				if _, err := conn.StopUnitContext(timeoutCtx, service, "ignore-dependencies", sChan); err == nil {
-					select {
-					case msg := <-sChan:
-						if msg != "done" {
-							log.G(ctx).Warnf("service stop message during force cleanup: %s", msg)
+			}()
+
+			// Stop service with timeout
+			go func() {
+				select {
+				case <-timeoutCtx.Done():
+					log.G(ctx).Warnf("timeout stopping service %s during force cleanup", service)
+					return
+				default:
+					sChan := make(chan string, 1)
+					if _, err := conn.StopUnitContext(timeoutCtx, service, "ignore-dependencies", sChan); err == nil {
+						select {
+						case msg := <-sChan:
+							if msg != "done" {
+								log.G(ctx).Warnf("service stop message during force cleanup: %s", msg)
+							}
+						case <-timeoutCtx.Done():
+							log.G(ctx).Warnf("timeout waiting for service stop confirmation: %s", service)
 						}
-					case <-timeoutCtx.Done():
-						log.G(ctx).Warnf("timeout waiting for service stop confirmation: %s", service)
+					} else {
+						log.G(ctx).Warnf("failed to stop service %s during force cleanup: %v", service, err)
 					}
-				} else {
-					log.G(ctx).Warnf("failed to stop service %s during force cleanup: %v", service, err)
 				}
-			}
-		}()
-
-		// Reset failed units (best effort, non-blocking)
-		go func() {
-			select {
-			case <-timeoutCtx.Done():
-				log.G(ctx).Warnf("timeout resetting failed unit %s during force cleanup", service)
-				return
-			default:
-				if err := conn.ResetFailedUnitContext(timeoutCtx, service); err != nil {
-					log.G(ctx).Warnf("failed to reset failed unit %s during force cleanup: %v", service, err)
+			}()
+
+			// Reset failed units (best effort, non-blocking)
+			go func() {
+				select {
+				case <-timeoutCtx.Done():
+					log.G(ctx).Warnf("timeout resetting failed unit %s during force cleanup", service)
+					return
+				default:
+					if err := conn.ResetFailedUnitContext(timeoutCtx, service); err != nil {
+						log.G(ctx).Warnf("failed to reset failed unit %s during force cleanup: %v", service, err)
+					}
 				}
-			}
-		}()
+			}()
+		}
 
 		// Wait a short time for operations to complete, but don't block indefinitely
 		select {
@@ -240,6 +324,30 @@ func ForceRemoveTransientHealthCheckFiles(ctx context.Context, containerID strin
 	return nil
 }
 
+// loadedTimerUnits returns the names of the timer units loaded in systemd among names, with their service units.
+// All the names are returned if systemd cannot be queried.
+func loadedTimerUnits(ctx context.Context, conn *dbus.Conn, names ...string) []string {
+	var units []string
+	for _, name := range names {
+		units = append(units, name+".timer", name+".service")
+	}
+	statuses, err := conn.ListUnitsByNamesContext(ctx, units)
+	if err != nil {
+		log.G(ctx).WithError(err).Debugf("failed to list the healthcheck units %v", units)
+		return names
+	}
+	var loaded []string
+	for _, name := range names {
+		for _, st := range statuses {
+			if (st.Name == name+".timer" || st.Name == name+".service") && st.LoadState != "not-found" {
+				loaded = append(loaded, name)
+				break
+			}
+		}
+	}
+	return loaded
+}
+
 func extractHealthcheck(ctx context.Context, container containerd.Container) *Healthcheck {
 	l, err := container.Labels(ctx)
 	if err != nil {
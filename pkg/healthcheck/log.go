@@ -41,28 +41,37 @@ func writeHealthLog(ctx context.Context, container containerd.Container, result
 	if err != nil {
 		return fmt.Errorf("error fetching container state dir: %v", err)
 	}
+	return appendHealthLog(stateDir, result)
+}
 
+// appendHealthLog appends result to the log file in stateDir.
+// The log file is a ring buffer: only the last MaxLogEntries results are kept.
+func appendHealthLog(stateDir string, result *HealthcheckResult) error {
 	data, err := result.ToJSONString()
 	if err != nil {
 		return fmt.Errorf("failed to marshal health log: %w", err)
 	}
 
-	// Write the latest result to the file
 	logPath := filepath.Join(stateDir, HealthLogFilename)
 	return filesystem.WithLock(stateDir, func() error {
-		file, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY, 0o600)
-		if err != nil {
+		var lines []string
+		content, err := os.ReadFile(logPath)
+		if err != nil && !os.IsNotExist(err) {
 			return err
 		}
-		defer file.Close()
-		if _, err = file.Seek(0, io.SeekEnd); err != nil {
-			return fmt.Errorf("seek error: %w", err)
+		for _, line := range strings.Split(string(content), "\n") {
+			if line != "" {
+				lines = append(lines, line)
+			}
 		}
-		if _, err = file.Write(append([]byte(data), '\n')); err != nil {
+		lines = append(lines, data)
+		if n := len(lines); n > MaxLogEntries {
+			lines = lines[n-MaxLogEntries:]
+		}
+		if err := filesystem.WriteFileWithRename(logPath, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
 			return fmt.Errorf("failed to write health log: %w", err)
 		}
-
-		return file.Sync()
+		return nil
 	})
 }
 
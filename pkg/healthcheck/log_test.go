@@ -0,0 +1,70 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package healthcheck
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestHealthLogRingBuffer(t *testing.T) {
+	stateDir := t.TempDir()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range MaxLogEntries + 3 {
+		assert.NilError(t, appendHealthLog(stateDir, &HealthcheckResult{
+			Start:    start.Add(time.Duration(i) * time.Second),
+			End:      start.Add(time.Duration(i)*time.Second + time.Millisecond),
+			ExitCode: i % 3,
+			Output:   "probe " + strconv.Itoa(i),
+		}))
+	}
+
+	// The log file only keeps the last MaxLogEntries results
+	content, err := os.ReadFile(filepath.Join(stateDir, HealthLogFilename))
+	assert.NilError(t, err)
+	assert.Equal(t, strings.Count(string(content), "\n"), MaxLogEntries)
+
+	state, err := (&HealthState{Status: Unhealthy, FailingStreak: 2}).ToJSONString()
+	assert.NilError(t, err)
+	health, err := ReadHealthStatusForInspect(stateDir, state)
+	assert.NilError(t, err)
+	assert.Equal(t, health.Status, Unhealthy)
+	assert.Equal(t, health.FailingStreak, 2)
+	assert.Equal(t, len(health.Log), MaxLogEntries)
+	// Newest first
+	for i, entry := range health.Log {
+		n := MaxLogEntries + 2 - i
+		assert.Equal(t, entry.Output, "probe "+strconv.Itoa(n))
+		assert.Equal(t, entry.ExitCode, n%3)
+		assert.Assert(t, entry.Start.Equal(start.Add(time.Duration(n)*time.Second)))
+	}
+}
+
+func TestReadHealthStatusForInspectWithoutLog(t *testing.T) {
+	state, err := (&HealthState{Status: Starting}).ToJSONString()
+	assert.NilError(t, err)
+	health, err := ReadHealthStatusForInspect(t.TempDir(), state)
+	assert.NilError(t, err)
+	assert.Equal(t, health.Status, Starting)
+	assert.Equal(t, len(health.Log), 0)
+}
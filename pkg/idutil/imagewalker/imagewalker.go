@@ -63,6 +63,10 @@ type ImageWalker struct {
 	Client       *containerd.Client
 	OnFound      OnFound
 	OnFoundCriRm OnFoundCriRm
+	// MatchDigestReference makes Walk match a `repo@digest` request against all the images of that repository
+	// with that digest (e.g. `repo:tag`), instead of only the image named `repo@digest`.
+	// It is used by `nerdctl rmi` to detect the tags referencing the digest.
+	MatchDigestReference bool
 }
 
 // Walk walks images and calls w.OnFound .
@@ -77,16 +81,25 @@ func (w *ImageWalker) Walk(ctx context.Context, req string) (int, error) {
 		parsedReferenceStr = parsedReference.String()
 		filters = append(filters, fmt.Sprintf("name==%s", parsedReferenceStr))
 	}
-	filters = append(filters,
-		fmt.Sprintf("name==%s", req),
-		fmt.Sprintf("target.digest~=^sha256:%s.*$", regexp.QuoteMeta(req)),
-		fmt.Sprintf("target.digest~=^%s.*$", regexp.QuoteMeta(req)),
-	)
+	isDigestReference := w.MatchDigestReference && err == nil && parsedReference.Path != "" && parsedReference.Digest != ""
+	if isDigestReference {
+		// repo@digest matches the image records of that repository with that digest, and nothing else.
+		filters = append(filters, fmt.Sprintf("target.digest==%s", parsedReference.Digest))
+	} else {
+		filters = append(filters,
+			fmt.Sprintf("name==%s", req),
+			fmt.Sprintf("target.digest~=^sha256:%s.*$", regexp.QuoteMeta(req)),
+			fmt.Sprintf("target.digest~=^%s.*$", regexp.QuoteMeta(req)),
+		)
+	}
 
 	images, err := w.Client.ImageService().List(ctx, filters...)
 	if err != nil {
 		return -1, err
 	}
+	if isDigestReference {
+		images = filterDigestReference(images, parsedReference)
+	}
 
 	matchCount := len(images)
 	// to handle the `rmi -f` case where returned images are different but
@@ -117,6 +130,19 @@ func (w *ImageWalker) Walk(ctx context.Context, req string) (int, error) {
 	return matchCount, nil
 }
 
+// filterDigestReference returns the images that belong to the repository of ref and have its digest.
+func filterDigestReference(imgs []images.Image, ref *referenceutil.ImageReference) []images.Image {
+	var res []images.Image
+	for _, img := range imgs {
+		parsed, err := referenceutil.Parse(img.Name)
+		if err != nil || parsed.Name() != ref.Name() || img.Target.Digest != ref.Digest {
+			continue
+		}
+		res = append(res, img)
+	}
+	return res
+}
+
 // WalkCriRm walks images and calls w.OnFoundCriRm .
 // Only effective when in the k8s.io namespace and kube-hide-dupe is enabled.
 // The WalkCriRm deletes non-repo:tag items such as repo:digest when in the no-other-repo:tag scenario.
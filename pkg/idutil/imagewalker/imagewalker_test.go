@@ -0,0 +1,63 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package imagewalker
+
+import (
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/containerd/v2/core/images"
+
+	"github.com/containerd/nerdctl/v2/pkg/referenceutil"
+)
+
+func TestFilterDigestReference(t *testing.T) {
+	const (
+		dgst  = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+		other = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+	)
+	image := func(name, d string) images.Image {
+		return images.Image{Name: name, Target: ocispec.Descriptor{Digest: digest.Digest(d)}}
+	}
+	imgs := []images.Image{
+		image("docker.io/library/alpine:3.18", dgst),
+		image("docker.io/library/alpine:latest", dgst),
+		image("docker.io/library/alpine@"+dgst, dgst),
+		image("docker.io/library/alpine:edge", other),
+		image("docker.io/myrepo/alpine:3.18", dgst),
+		image("sha256:"+dgst[7:], dgst),
+	}
+
+	ref, err := referenceutil.Parse("alpine@" + dgst)
+	assert.NilError(t, err)
+	var names []string
+	for _, img := range filterDigestReference(imgs, ref) {
+		names = append(names, img.Name)
+	}
+	assert.DeepEqual(t, names, []string{
+		"docker.io/library/alpine:3.18",
+		"docker.io/library/alpine:latest",
+		"docker.io/library/alpine@" + dgst,
+	})
+
+	ref, err = referenceutil.Parse("myrepo/alpine@" + other)
+	assert.NilError(t, err)
+	assert.Equal(t, len(filterDigestReference(imgs, ref)), 0)
+}
@@ -23,17 +23,18 @@ import (
 	"github.com/containerd/containerd/v2/core/images"
 	"github.com/containerd/containerd/v2/core/snapshots"
 	"github.com/containerd/log"
+	"github.com/containerd/platforms"
 
 	"github.com/containerd/nerdctl/v2/pkg/imgutil"
 	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/native"
 )
 
-// Inspect inspects the image, for the platform specified in image.platform.
-func Inspect(ctx context.Context, client *containerd.Client, image images.Image, snapshotter snapshots.Snapshotter) (*native.Image, error) {
+// Inspect inspects the image, for the platform matched by platformMC.
+func Inspect(ctx context.Context, client *containerd.Client, image images.Image, platformMC platforms.MatchComparer, snapshotter snapshots.Snapshotter) (*native.Image, error) {
 
 	n := &native.Image{}
 
-	img := containerd.NewImage(client, image)
+	img := containerd.NewImageWithPlatform(client, image, platformMC)
 	idx, idxDesc, err := imgutil.ReadIndex(ctx, img)
 	if err != nil {
 		log.G(ctx).WithError(err).WithField("id", image.Name).Warnf("failed to inspect index")
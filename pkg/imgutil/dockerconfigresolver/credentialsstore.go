@@ -49,13 +49,25 @@ type CredentialsStore struct {
 	dockerConfigFile *configfile.ConfigFile
 }
 
-// Erase will remove any and all stored credentials for that registry namespace (including all legacy variants)
-// If we do not find at least ONE variant matching the namespace, this will error with ErrUnableToErase
+// Erase will remove any and all stored credentials for that registry namespace (including all legacy variants),
+// both from the config file and from the credential helper (`credsStore` / `credHelpers`) that applies, if any.
+// If no credentials are stored for any variant, this will error with ErrNotLoggedIn.
+// If we fail removing every stored variant, this will error with ErrUnableToErase
 func (cs *CredentialsStore) Erase(registryURL *RegistryURL) (map[string]error, error) {
-	// Get all associated identifiers for that registry including legacy ones and variants
-	logoutList := registryURL.AllIdentifiers()
+	// Get all associated identifiers for that registry including legacy ones and variants,
+	// and keep the ones credentials are stored for
+	var logoutList []string
+	for _, serverAddress := range registryURL.AllIdentifiers() {
+		if cs.isStored(serverAddress) {
+			logoutList = append(logoutList, serverAddress)
+		}
+	}
+	if len(logoutList) == 0 {
+		return nil, ErrNotLoggedIn
+	}
 
-	// Iterate through and delete them one by one
+	// Iterate through and delete them one by one.
+	// For credential helpers, this calls the `erase` command of the helper.
 	errs := make(map[string]error)
 	for _, serverAddress := range logoutList {
 		if err := cs.dockerConfigFile.GetCredentialsStore(serverAddress).Erase(serverAddress); err != nil {
@@ -73,6 +85,22 @@ func (cs *CredentialsStore) Erase(registryURL *RegistryURL) (map[string]error, e
 	return nil, nil
 }
 
+// isStored returns true if there is an entry for serverAddress in the config file, or if the credential helper
+// that applies returns credentials for it.
+// Failing to query the helper is reported as stored, so that erasing surfaces the error.
+func (cs *CredentialsStore) isStored(serverAddress string) bool {
+	if _, ok := cs.dockerConfigFile.AuthConfigs[serverAddress]; ok {
+		return true
+	}
+	// Note that Get does not raise an error on ENOENT, and returns empty credentials instead
+	credentials, err := cs.dockerConfigFile.GetCredentialsStore(serverAddress).Get(serverAddress)
+	return err != nil ||
+		credentials.IdentityToken != "" ||
+		credentials.Username != "" ||
+		credentials.Password != "" ||
+		credentials.RegistryToken != ""
+}
+
 // Store will save credentials for a given registry
 // On error, ErrUnableToStore
 func (cs *CredentialsStore) Store(registryURL *RegistryURL, credentials *Credentials) error {
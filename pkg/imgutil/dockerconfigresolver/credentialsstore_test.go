@@ -18,7 +18,9 @@ package dockerconfigresolver
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -400,3 +402,106 @@ func TestWorkingCredentialsStore(t *testing.T) {
 }
 
 // TODO: add more tests that write credentials (specifically to hub locations) to verify they use the canonical id properly
+
+// writeFakeCredentialHelper installs a `docker-credential-fake` helper in PATH that holds credentials for storedServer,
+// and records every `erase` call in the returned log file
+func writeFakeCredentialHelper(t *testing.T, storedServer string) string {
+	t.Helper()
+	binDir := t.TempDir()
+	logFile := filepath.Join(binDir, "erase.log")
+	script := fmt.Sprintf(`#!/bin/sh
+read -r server
+case "$1" in
+get)
+	if [ "$server" = %q ]; then
+		echo '{"ServerURL":"'"$server"'","Username":"username","Secret":"secret"}'
+		exit 0
+	fi
+	echo "credentials not found in native keychain"
+	exit 1
+	;;
+erase)
+	echo "$server" >> %q
+	;;
+esac
+`, storedServer, logFile)
+	err := filesystem.WriteFile(filepath.Join(binDir, "docker-credential-fake"), []byte(script), 0o755)
+	assert.NilError(t, err)
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return logFile
+}
+
+func TestEraseCredentials(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test requires a shell script credential helper")
+	}
+
+	readErased := func(t *testing.T, logFile string) string {
+		content, err := filesystem.ReadFile(logFile)
+		if errors.Is(err, fs.ErrNotExist) {
+			return ""
+		}
+		assert.NilError(t, err)
+		return string(content)
+	}
+
+	t.Run("credHelpers entry", func(t *testing.T) {
+		logFile := writeFakeCredentialHelper(t, "registry.example")
+		dir := writeContent(t, `{"credHelpers": {"registry.example": "fake"}}`)
+		cs, err := NewCredentialsStore(dir)
+		assert.NilError(t, err)
+		registryURL, err := Parse("registry.example")
+		assert.NilError(t, err)
+
+		_, err = cs.Erase(registryURL)
+		assert.NilError(t, err)
+		assert.Equal(t, readErased(t, logFile), "registry.example\n")
+	})
+
+	t.Run("credsStore", func(t *testing.T) {
+		logFile := writeFakeCredentialHelper(t, "https://registry.example")
+		dir := writeContent(t, `{"credsStore": "fake"}`)
+		cs, err := NewCredentialsStore(dir)
+		assert.NilError(t, err)
+		registryURL, err := Parse("registry.example")
+		assert.NilError(t, err)
+
+		_, err = cs.Erase(registryURL)
+		assert.NilError(t, err)
+		assert.Equal(t, readErased(t, logFile), "https://registry.example\n")
+	})
+
+	t.Run("unknown registry with credsStore", func(t *testing.T) {
+		logFile := writeFakeCredentialHelper(t, "registry.example")
+		dir := writeContent(t, `{"credsStore": "fake"}`)
+		cs, err := NewCredentialsStore(dir)
+		assert.NilError(t, err)
+		registryURL, err := Parse("unknown.example")
+		assert.NilError(t, err)
+
+		_, err = cs.Erase(registryURL)
+		assert.ErrorIs(t, err, ErrNotLoggedIn)
+		assert.Equal(t, readErased(t, logFile), "")
+	})
+
+	t.Run("config file entry", func(t *testing.T) {
+		dir := writeContent(t, fmt.Sprintf(`{"auths": {"registry.example:443": {"auth": %q}}}`,
+			base64.StdEncoding.EncodeToString([]byte("username:password"))))
+		cs, err := NewCredentialsStore(dir)
+		assert.NilError(t, err)
+		registryURL, err := Parse("registry.example")
+		assert.NilError(t, err)
+
+		_, err = cs.Erase(registryURL)
+		assert.NilError(t, err)
+
+		cs, err = NewCredentialsStore(dir)
+		assert.NilError(t, err)
+		af, err := cs.Retrieve(registryURL, true)
+		assert.NilError(t, err)
+		assert.Equal(t, af.Username, "")
+
+		_, err = cs.Erase(registryURL)
+		assert.ErrorIs(t, err, ErrNotLoggedIn)
+	})
+}
@@ -41,6 +41,7 @@ const (
 var (
 	ErrUnableToInstantiate = errors.New("unable to instantiate docker credentials store")
 	ErrUnableToErase       = errors.New("unable to erase credentials")
+	ErrNotLoggedIn         = errors.New("no credentials stored")
 	ErrUnableToStore       = errors.New("unable to store credentials")
 	ErrUnableToRetrieve    = errors.New("unable to retrieve credentials")
 )
@@ -19,8 +19,10 @@ package dockerconfigresolver
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 
@@ -41,6 +43,7 @@ type opts struct {
 	skipVerifyCerts bool
 	hostsDirs       []string
 	authCreds       AuthCreds
+	tlsConfig       *TLSConfig
 }
 
 // Opt for New
@@ -74,6 +77,87 @@ func WithAuthCreds(ac AuthCreds) Opt {
 	}
 }
 
+// WithTLSConfig specifies a TLS config (see NewTLSConfig) that takes precedence over
+// the TLS settings of hosts.toml.
+func WithTLSConfig(cfg *TLSConfig) Opt {
+	return func(o *opts) {
+		o.tlsConfig = cfg
+	}
+}
+
+// TLSConfig holds the TLS settings of a registry that take precedence over those of hosts.toml.
+type TLSConfig struct {
+	// Certificates are the client certificates presented to the registry.
+	Certificates []tls.Certificate
+	// InsecureSkipVerify skips verifying the registry certificate.
+	InsecureSkipVerify bool
+	// caCert is the PEM data of the CA certificates trusted on top of those of hosts.toml.
+	caCert []byte
+}
+
+// NewTLSConfig returns a TLS config that trusts the CA certificate in caCertPath, presents
+// the client certificate in certPath and keyPath, and optionally skips verifying the server certificate.
+// All paths are optional, but certPath and keyPath must be specified together.
+// NewTLSConfig returns nil when nothing is specified.
+func NewTLSConfig(caCertPath, certPath, keyPath string, skipVerify bool) (*TLSConfig, error) {
+	if caCertPath == "" && certPath == "" && keyPath == "" && !skipVerify {
+		return nil, nil
+	}
+	if (certPath == "") != (keyPath == "") {
+		return nil, errors.New("the TLS client certificate and key must be specified together")
+	}
+	cfg := &TLSConfig{
+		InsecureSkipVerify: skipVerify,
+	}
+	if caCertPath != "" {
+		data, err := os.ReadFile(caCertPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the TLS CA certificate: %w", err)
+		}
+		if !x509.NewCertPool().AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("failed to load the TLS CA certificate %q: no PEM certificate found", caCertPath)
+		}
+		cfg.caCert = data
+	}
+	if certPath != "" {
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the TLS client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// overrideTLSConfig returns a copy of base with the settings of override applied on top.
+// The CA of override is trusted in addition to the CAs of base, or to the system CAs.
+func overrideTLSConfig(base *tls.Config, override *TLSConfig) *tls.Config {
+	cfg := &tls.Config{}
+	if base != nil {
+		cfg = base.Clone()
+	}
+	if override.caCert != nil {
+		// Cloned, as containerd appends the CAs of hosts.toml to the pool of the default TLS config.
+		var pool *x509.CertPool
+		if cfg.RootCAs != nil {
+			pool = cfg.RootCAs.Clone()
+		} else if systemPool, err := x509.SystemCertPool(); err == nil {
+			pool = systemPool
+		} else {
+			pool = x509.NewCertPool()
+		}
+		pool.AppendCertsFromPEM(override.caCert)
+		cfg.RootCAs = pool
+	}
+	if len(override.Certificates) > 0 {
+		cfg.Certificates = override.Certificates
+	}
+	if override.InsecureSkipVerify {
+		cfg.InsecureSkipVerify = true
+	}
+	return cfg
+}
+
 // NewHostOptions instantiates a HostOptions struct using $DOCKER_CONFIG/config.json .
 //
 // $DOCKER_CONFIG defaults to "~/.docker".
@@ -134,7 +218,23 @@ func NewHostOptions(ctx context.Context, refHostname string, optFuncs ...Opt) (*
 			ho.DefaultScheme = "http"
 		}
 	}
-	if ho.DefaultScheme == "http" {
+	if o.tlsConfig != nil {
+		ho.DefaultTLS = overrideTLSConfig(ho.DefaultTLS, o.tlsConfig)
+		// The per-host clients are configured from hosts.toml before UpdateClient is called,
+		// so applying the config here makes it take precedence.
+		// The transport is replaced rather than updated, as it may be shared with other hosts.
+		ho.UpdateClient = func(client *http.Client) error {
+			tr, ok := client.Transport.(*http.Transport)
+			if !ok {
+				return fmt.Errorf("unexpected transport %T", client.Transport)
+			}
+			tr = tr.Clone()
+			tr.TLSClientConfig = overrideTLSConfig(tr.TLSClientConfig, o.tlsConfig)
+			client.Transport = tr
+			return nil
+		}
+	}
+	if o.plainHTTP || (ho.DefaultScheme == "http" && o.tlsConfig == nil) {
 		// https://github.com/containerd/containerd/issues/9208
 		ho.DefaultTLS = nil
 	}
@@ -0,0 +1,133 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package dockerconfigresolver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	dockerconfig "github.com/containerd/containerd/v2/core/remotes/docker/config"
+
+	"github.com/containerd/nerdctl/v2/pkg/testutil/testca"
+)
+
+func TestNewTLSConfig(t *testing.T) {
+	cfg, err := NewTLSConfig("", "", "", false)
+	assert.NilError(t, err)
+	assert.Assert(t, cfg == nil)
+
+	cfg, err = NewTLSConfig("", "", "", true)
+	assert.NilError(t, err)
+	assert.Assert(t, cfg.InsecureSkipVerify)
+
+	ca := testca.New(t)
+	cert := ca.NewCert("client")
+
+	_, err = NewTLSConfig("", cert.CertPath, "", false)
+	assert.ErrorContains(t, err, "must be specified together")
+
+	_, err = NewTLSConfig(cert.KeyPath, "", "", false)
+	assert.ErrorContains(t, err, "no PEM certificate found")
+
+	cfg, err = NewTLSConfig(ca.CertPath, cert.CertPath, cert.KeyPath, false)
+	assert.NilError(t, err)
+	assert.Assert(t, !cfg.InsecureSkipVerify)
+	assert.Equal(t, len(cfg.Certificates), 1)
+
+	// Without hosts.toml, the CA is trusted on top of the system CAs
+	tlsConfig := overrideTLSConfig(nil, cfg)
+	assert.Assert(t, tlsConfig.RootCAs.Equal(systemCertPool(t, ca.CertPath)))
+
+	// The CAs of the base config are kept, and the base pool is not modified
+	hostsCA := testca.New(t)
+	base := &tls.Config{RootCAs: certPool(t, hostsCA.CertPath)}
+	tlsConfig = overrideTLSConfig(base, cfg)
+	assert.Assert(t, tlsConfig.RootCAs.Equal(certPool(t, hostsCA.CertPath, ca.CertPath)))
+	assert.Assert(t, base.RootCAs.Equal(certPool(t, hostsCA.CertPath)))
+}
+
+func TestNewHostOptionsTLSConfigOnTopOfHostsToml(t *testing.T) {
+	const host = "registry.example.com"
+	hostsCA := testca.New(t)
+	hostsCert := hostsCA.NewCert("hosts-client")
+
+	// hosts.toml configures its own CA, client certificate and verification
+	hostsDir := t.TempDir()
+	assert.NilError(t, os.MkdirAll(filepath.Join(hostsDir, host), 0o755))
+	hostsToml := fmt.Sprintf(`server = "https://%s"
+
+[host."https://%s"]
+  ca = %q
+  client = [[%q, %q]]
+  skip_verify = false
+`, host, host, hostsCA.CertPath, hostsCert.CertPath, hostsCert.KeyPath)
+	assert.NilError(t, os.WriteFile(filepath.Join(hostsDir, host, "hosts.toml"), []byte(hostsToml), 0o644))
+
+	ca := testca.New(t)
+	cert := ca.NewCert("client")
+	tlsConfig, err := NewTLSConfig(ca.CertPath, cert.CertPath, cert.KeyPath, true)
+	assert.NilError(t, err)
+
+	noCreds := func(string) (string, string, error) {
+		return "", "", nil
+	}
+	ho, err := NewHostOptions(context.Background(), host, WithHostsDirs([]string{hostsDir}), WithAuthCreds(noCreds), WithTLSConfig(tlsConfig))
+	assert.NilError(t, err)
+
+	regHosts, err := dockerconfig.ConfigureHosts(context.Background(), *ho)(host)
+	assert.NilError(t, err)
+	assert.Assert(t, len(regHosts) > 0)
+	for i, rh := range regHosts {
+		tr, ok := rh.Client.Transport.(*http.Transport)
+		assert.Assert(t, ok, "unexpected transport %T", rh.Client.Transport)
+		assert.Assert(t, tr.TLSClientConfig.InsecureSkipVerify)
+		// containerd trusts the CA of the host section on top of the system CAs, the server only trusts the system CAs
+		want := systemCertPool(t, ca.CertPath)
+		if i < len(regHosts)-1 {
+			want = systemCertPool(t, hostsCA.CertPath, ca.CertPath)
+		}
+		assert.Assert(t, tr.TLSClientConfig.RootCAs.Equal(want))
+		assert.DeepEqual(t, tr.TLSClientConfig.Certificates, tlsConfig.Certificates)
+	}
+}
+
+func certPool(t *testing.T, paths ...string) *x509.CertPool {
+	return appendCerts(t, x509.NewCertPool(), paths...)
+}
+
+func systemCertPool(t *testing.T, paths ...string) *x509.CertPool {
+	pool, err := x509.SystemCertPool()
+	assert.NilError(t, err)
+	return appendCerts(t, pool, paths...)
+}
+
+func appendCerts(t *testing.T, pool *x509.CertPool, paths ...string) *x509.CertPool {
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		assert.NilError(t, err)
+		assert.Assert(t, pool.AppendCertsFromPEM(data))
+	}
+	return pool
+}
@@ -139,6 +139,13 @@ func EnsureImage(ctx context.Context, client *containerd.Client, rawRef string,
 	useTransferAPI := containerdutil.SupportsFullTransferService(ctx, client)
 	if !useTransferAPI {
 		log.G(ctx).Debug("Detected containerd < 2.0, using legacy pull method")
+	} else if options.AllowNondistributableArtifacts {
+		// Blobs are fetched by the daemon with the transfer service, so the fetcher cannot be customized
+		log.G(ctx).Debug("Using legacy pull method for fetching non-distributable blobs from the registry")
+		useTransferAPI = false
+	} else if options.IncludeChunks {
+		log.G(ctx).Debug("Using legacy pull method for fetching the missing chunks of zstd:chunked layers")
+		useTransferAPI = false
 	}
 
 	if useTransferAPI {
@@ -205,6 +212,19 @@ func ResolveDigest(ctx context.Context, rawRef string, insecure bool, hostsDirs
 	return desc.Digest.String(), nil
 }
 
+// unpackEnabled returns whether the pulled image has to be unpacked into the snapshotter.
+// The image is unpacked if given 1 platform, unless specified otherwise by options.Unpack.
+// With options.Unpack set to false, only the content is fetched into the content store, and no snapshot is created.
+func unpackEnabled(options types.ImagePullOptions) (bool, error) {
+	if options.Unpack == nil {
+		return len(options.OCISpecPlatform) == 1, nil
+	}
+	if *options.Unpack && len(options.OCISpecPlatform) != 1 {
+		return false, fmt.Errorf("unpacking requires a single platform to be specified (e.g., --platform=amd64)")
+	}
+	return *options.Unpack, nil
+}
+
 // PullImage pulls an image using the specified resolver.
 func PullImage(ctx context.Context, client *containerd.Client, resolver remotes.Resolver, ref string, options types.ImagePullOptions) (*EnsuredImage, error) {
 	ctx, done, err := client.WithLease(ctx)
@@ -213,6 +233,13 @@ func PullImage(ctx context.Context, client *containerd.Client, resolver remotes.
 	}
 	defer done(ctx)
 
+	if options.AllowNondistributableArtifacts {
+		resolver = pull.NewNonDistributableResolver(resolver)
+	}
+	if options.IncludeChunks {
+		resolver = pull.NewChunkDedupResolver(resolver, client.ContentStore())
+	}
+
 	var containerdImage containerd.Image
 	config := &pull.Config{
 		Resolver:   resolver,
@@ -226,13 +253,9 @@ func PullImage(ctx context.Context, client *containerd.Client, resolver remotes.
 		}
 	}
 
-	// unpack(B) if given 1 platform unless specified by `unpack`
-	unpackB := len(options.OCISpecPlatform) == 1
-	if options.Unpack != nil {
-		unpackB = *options.Unpack
-		if unpackB && len(options.OCISpecPlatform) != 1 {
-			return nil, fmt.Errorf("unpacking requires a single platform to be specified (e.g., --platform=amd64)")
-		}
+	unpackB, err := unpackEnabled(options)
+	if err != nil {
+		return nil, err
 	}
 
 	snOpt := getSnapshotterOpts(options.GOptions.Snapshotter)
@@ -19,7 +19,10 @@ package imgutil
 import (
 	"testing"
 
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"gotest.tools/v3/assert"
+
+	"github.com/containerd/nerdctl/v2/pkg/api/types"
 )
 
 func TestParseRepoTag(t *testing.T) {
@@ -57,3 +60,35 @@ func TestParseRepoTag(t *testing.T) {
 		assert.Equal(t, tc.tag, tag)
 	}
 }
+
+func TestUnpackEnabled(t *testing.T) {
+	yes, no := true, false
+	single := []ocispec.Platform{{OS: "linux", Architecture: "amd64"}}
+	multi := []ocispec.Platform{{OS: "linux", Architecture: "amd64"}, {OS: "linux", Architecture: "arm64"}}
+	testCases := []struct {
+		name      string
+		platforms []ocispec.Platform
+		unpack    *bool
+		expected  bool
+		err       string
+	}{
+		{name: "auto, single platform", platforms: single, expected: true},
+		{name: "auto, all platforms", expected: false},
+		{name: "auto, multiple platforms", platforms: multi, expected: false},
+		{name: "false, single platform", platforms: single, unpack: &no, expected: false},
+		{name: "false, all platforms", unpack: &no, expected: false},
+		{name: "true, single platform", platforms: single, unpack: &yes, expected: true},
+		{name: "true, multiple platforms", platforms: multi, unpack: &yes, err: "unpacking requires a single platform"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			unpack, err := unpackEnabled(types.ImagePullOptions{OCISpecPlatform: tc.platforms, Unpack: tc.unpack})
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, unpack, tc.expected)
+		})
+	}
+}
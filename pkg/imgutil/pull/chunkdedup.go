@@ -0,0 +1,345 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package pull
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/containerd/containerd/v2/core/content"
+	"github.com/containerd/containerd/v2/core/remotes"
+	"github.com/containerd/log"
+	"github.com/containerd/stargz-snapshotter/estargz"
+	"github.com/containerd/stargz-snapshotter/estargz/zstdchunked"
+)
+
+// NewChunkDedupResolver wraps resolver so that zstd:chunked layers are fetched chunk by chunk:
+// the chunks already present in the zstd:chunked layers of the content store are copied locally,
+// and only the missing ones are fetched from the registry, with range requests.
+//
+// The layer is fetched as a whole when none of its chunks are found locally,
+// or when the assembled layer does not match the digest of its descriptor.
+func NewChunkDedupResolver(resolver remotes.Resolver, cs content.Store) remotes.Resolver {
+	return &chunkDedupResolver{Resolver: resolver, index: &chunkIndex{cs: cs}}
+}
+
+type chunkDedupResolver struct {
+	remotes.Resolver
+	index *chunkIndex
+}
+
+func (r *chunkDedupResolver) Fetcher(ctx context.Context, ref string) (remotes.Fetcher, error) {
+	fetcher, err := r.Resolver.Fetcher(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return &chunkDedupFetcher{Fetcher: fetcher, index: r.index}, nil
+}
+
+type chunkDedupFetcher struct {
+	remotes.Fetcher
+	index *chunkIndex
+}
+
+func (f *chunkDedupFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	if !isZstdChunked(desc) {
+		return f.Fetcher.Fetch(ctx, desc)
+	}
+	rc, err := f.fetchChunks(ctx, desc)
+	if err != nil {
+		log.G(ctx).WithError(err).Debugf("fetching zstd:chunked layer %s as a whole", desc.Digest)
+		return f.Fetcher.Fetch(ctx, desc)
+	}
+	return rc, nil
+}
+
+func isZstdChunked(desc ocispec.Descriptor) bool {
+	return desc.MediaType == ocispec.MediaTypeImageLayerZstd &&
+		desc.Annotations[zstdchunked.ManifestChecksumAnnotation] != "" &&
+		desc.Size > zstdchunked.FooterSize
+}
+
+func (f *chunkDedupFetcher) fetchChunks(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	remote := &remoteReaderAt{ctx: ctx, fetcher: f.Fetcher, desc: desc}
+	toc, payloadSize, trailer, err := readZstdChunkedTOC(remote, desc.Size)
+	if err != nil {
+		return nil, err
+	}
+	segments := tocSegments(toc, payloadSize)
+	sources := f.index.lookup(ctx, segments)
+	if len(sources) == 0 {
+		return nil, errors.New("no chunk found in the local layers")
+	}
+
+	tmp, err := os.CreateTemp("", "nerdctl-zstdchunked-")
+	if err != nil {
+		return nil, err
+	}
+	rc := &tempFileReadCloser{File: tmp}
+	if err := assembleLayer(ctx, f.index.cs, remote, segments, sources, trailer, tmp, desc.Digest); err != nil {
+		rc.Close()
+		return nil, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		rc.Close()
+		return nil, err
+	}
+	log.G(ctx).Debugf("reused %d/%d chunks of zstd:chunked layer %s from the local layers", len(sources), len(segments), desc.Digest)
+	return rc, nil
+}
+
+// assembleLayer writes the payload of the layer to w segment by segment, copying the segments found in sources
+// from the local layers and fetching the consecutive missing segments in a single range request.
+func assembleLayer(ctx context.Context, provider content.Provider, remote io.ReaderAt, segments []tocSegment,
+	sources map[int]chunkSource, trailer []byte, w io.Writer, expected digest.Digest) error {
+	digester := expected.Algorithm().Digester()
+	mw := io.MultiWriter(w, digester.Hash())
+
+	localBlobs := make(map[digest.Digest]content.ReaderAt)
+	defer func() {
+		for _, ra := range localBlobs {
+			ra.Close()
+		}
+	}()
+	for i := 0; i < len(segments); {
+		src, ok := sources[i]
+		if !ok {
+			// fetch all the consecutive missing segments at once
+			start, end := segments[i].offset, segments[i].offset+segments[i].size
+			for i++; i < len(segments); i++ {
+				if _, ok := sources[i]; ok {
+					break
+				}
+				end = segments[i].offset + segments[i].size
+			}
+			if _, err := io.Copy(mw, io.NewSectionReader(remote, start, end-start)); err != nil {
+				return err
+			}
+			continue
+		}
+		ra, ok := localBlobs[src.blob.Digest]
+		if !ok {
+			var err error
+			ra, err = provider.ReaderAt(ctx, src.blob)
+			if err != nil {
+				return err
+			}
+			localBlobs[src.blob.Digest] = ra
+		}
+		if _, err := io.Copy(mw, io.NewSectionReader(ra, src.offset, segments[i].size)); err != nil {
+			return err
+		}
+		i++
+	}
+	if _, err := mw.Write(trailer); err != nil {
+		return err
+	}
+	if dgst := digester.Digest(); dgst != expected {
+		return fmt.Errorf("assembled layer has an unexpected digest %s", dgst)
+	}
+	return nil
+}
+
+// readZstdChunkedTOC reads the TOC of the zstd:chunked blob of the given size,
+// and returns it with the size of the payload and the trailer (TOC and footer) following the payload.
+func readZstdChunkedTOC(ra io.ReaderAt, size int64) (*estargz.JTOC, int64, []byte, error) {
+	decompressor := new(zstdchunked.Decompressor)
+	footer := make([]byte, decompressor.FooterSize())
+	if _, err := ra.ReadAt(footer, size-int64(len(footer))); err != nil {
+		return nil, 0, nil, err
+	}
+	payloadSize, tocOffset, tocSize, err := decompressor.ParseFooter(footer)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	if payloadSize < 0 || tocOffset < payloadSize || tocSize < 0 || tocOffset+tocSize > size-int64(len(footer)) {
+		return nil, 0, nil, errors.New("invalid zstd:chunked footer")
+	}
+	trailer := make([]byte, size-payloadSize)
+	if _, err := ra.ReadAt(trailer, payloadSize); err != nil && !errors.Is(err, io.EOF) {
+		return nil, 0, nil, err
+	}
+	toc, _, err := decompressor.ParseTOC(bytes.NewReader(trailer[tocOffset-payloadSize : tocOffset-payloadSize+tocSize]))
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	return toc, payloadSize, trailer, nil
+}
+
+// tocSegment is a range of the payload of a zstd:chunked blob, starting at the beginning of a chunk
+// (or of the payload), and ending at the beginning of the next chunk (or at the end of the payload).
+type tocSegment struct {
+	offset int64
+	size   int64
+	// key identifies the content of the segment: its size, the tar headers and the chunks it contains.
+	// Two segments with the same key are expected to have the same compressed content.
+	key string
+}
+
+// tocSegments splits the payload of a zstd:chunked blob of the given TOC into segments.
+//
+// Every chunk starts a new compressed stream at its offset, while the tar header of an entry
+// is written in the stream preceding its first chunk.
+func tocSegments(toc *estargz.JTOC, payloadSize int64) []tocSegment {
+	var (
+		segments []tocSegment
+		start    int64
+	)
+	h := sha256.New()
+	closeSegment := func(end int64) {
+		segments = append(segments, tocSegment{
+			offset: start,
+			size:   end - start,
+			key:    fmt.Sprintf("%d:%x", end-start, h.Sum(nil)),
+		})
+		start = end
+		h.Reset()
+	}
+	for _, e := range toc.Entries {
+		if e.Type != "chunk" {
+			fmt.Fprintf(h, "%s\x00%s\x00%d\x00%s\x00%s\x00%o\x00%d\x00%d\x00%s\x00%s\x00%d\x00%d\x00%s\x00",
+				e.Type, e.Name, e.Size, e.ModTime3339, e.LinkName, e.Mode, e.UID, e.GID, e.Uname, e.Gname, e.DevMajor, e.DevMinor, e.Digest)
+			xattrs := make([]string, 0, len(e.Xattrs))
+			for k := range e.Xattrs {
+				xattrs = append(xattrs, k)
+			}
+			slices.Sort(xattrs)
+			for _, k := range xattrs {
+				fmt.Fprintf(h, "%s=%x\x00", k, e.Xattrs[k])
+			}
+		}
+		if e.Offset > start && e.Offset < payloadSize {
+			closeSegment(e.Offset)
+		}
+		if e.ChunkDigest != "" {
+			fmt.Fprintf(h, "chunk\x00%s\x00%d\x00%d\x00", e.ChunkDigest, e.ChunkSize, e.InnerOffset)
+		}
+	}
+	closeSegment(payloadSize)
+	return segments
+}
+
+// chunkSource is the location of a segment in a local blob.
+type chunkSource struct {
+	blob   ocispec.Descriptor
+	offset int64
+}
+
+// chunkIndex indexes the segments of the zstd:chunked blobs of the content store by their key.
+// The index is loaded on the first lookup.
+type chunkIndex struct {
+	cs       content.Store
+	once     sync.Once
+	segments map[string]chunkSource
+}
+
+// lookup returns the local sources of the given segments, by index of the segment.
+func (idx *chunkIndex) lookup(ctx context.Context, segments []tocSegment) map[int]chunkSource {
+	idx.once.Do(func() {
+		idx.segments = loadChunkIndex(ctx, idx.cs)
+	})
+	sources := make(map[int]chunkSource)
+	for i, s := range segments {
+		if src, ok := idx.segments[s.key]; ok {
+			sources[i] = src
+		}
+	}
+	return sources
+}
+
+func loadChunkIndex(ctx context.Context, cs content.Store) map[string]chunkSource {
+	segments := make(map[string]chunkSource)
+	err := cs.Walk(ctx, func(info content.Info) error {
+		if info.Size <= zstdchunked.FooterSize {
+			return nil
+		}
+		blob := ocispec.Descriptor{Digest: info.Digest, Size: info.Size}
+		ra, err := cs.ReaderAt(ctx, blob)
+		if err != nil {
+			return nil
+		}
+		defer ra.Close()
+		toc, payloadSize, _, err := readZstdChunkedTOC(ra, info.Size)
+		if err != nil {
+			// not a zstd:chunked blob
+			return nil
+		}
+		for _, s := range tocSegments(toc, payloadSize) {
+			if _, ok := segments[s.key]; !ok {
+				segments[s.key] = chunkSource{blob: blob, offset: s.offset}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.G(ctx).WithError(err).Warn("failed to index the zstd:chunked layers of the content store")
+	}
+	return segments
+}
+
+// remoteReaderAt reads ranges of a blob from the registry, with a range request per ReadAt.
+type remoteReaderAt struct {
+	ctx     context.Context
+	fetcher remotes.Fetcher
+	desc    ocispec.Descriptor
+}
+
+func (r *remoteReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off >= r.desc.Size {
+		return 0, io.EOF
+	}
+	rc, err := r.fetcher.Fetch(r.ctx, r.desc)
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+	seeker, ok := rc.(io.Seeker)
+	if !ok {
+		return 0, errors.New("the fetcher does not support range requests")
+	}
+	if _, err := seeker.Seek(off, io.SeekStart); err != nil {
+		return 0, err
+	}
+	n, err := io.ReadFull(rc, p)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// tempFileReadCloser removes the file when closed.
+type tempFileReadCloser struct {
+	*os.File
+}
+
+func (f *tempFileReadCloser) Close() error {
+	err := f.File.Close()
+	if rmErr := os.Remove(f.Name()); rmErr != nil && err == nil {
+		err = rmErr
+	}
+	return err
+}
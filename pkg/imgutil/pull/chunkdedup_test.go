@@ -0,0 +1,206 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package pull
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/containerd/v2/core/content"
+	"github.com/containerd/containerd/v2/core/remotes/docker"
+	"github.com/containerd/containerd/v2/plugins/content/local"
+	"github.com/containerd/stargz-snapshotter/estargz"
+	"github.com/containerd/stargz-snapshotter/estargz/zstdchunked"
+)
+
+type zstdChunkedCompression struct {
+	*zstdchunked.Decompressor
+	*zstdchunked.Compressor
+}
+
+// buildZstdChunked returns a zstd:chunked layer of the given files and its descriptor.
+func buildZstdChunked(t *testing.T, files map[string][]byte, names ...string) ([]byte, ocispec.Descriptor) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range names {
+		assert.NilError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Typeflag: tar.TypeReg,
+			Mode:     0644,
+			Size:     int64(len(files[name])),
+			ModTime:  time.Unix(1700000000, 0),
+		}))
+		_, err := tw.Write(files[name])
+		assert.NilError(t, err)
+	}
+	assert.NilError(t, tw.Close())
+
+	metadata := make(map[string]string)
+	blob, err := estargz.Build(io.NewSectionReader(bytes.NewReader(buf.Bytes()), 0, int64(buf.Len())),
+		estargz.WithChunkSize(64<<10),
+		estargz.WithCompression(&zstdChunkedCompression{
+			new(zstdchunked.Decompressor),
+			&zstdchunked.Compressor{CompressionLevel: zstd.SpeedDefault, Metadata: metadata},
+		}))
+	assert.NilError(t, err)
+	defer blob.Close()
+	b, err := io.ReadAll(blob)
+	assert.NilError(t, err)
+	return b, ocispec.Descriptor{
+		MediaType:   ocispec.MediaTypeImageLayerZstd,
+		Digest:      digest.FromBytes(b),
+		Size:        int64(len(b)),
+		Annotations: metadata,
+	}
+}
+
+// countingTransport counts the bytes of the response bodies.
+type countingTransport struct {
+	n atomic.Int64
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &countingReadCloser{ReadCloser: resp.Body, n: &c.n}
+	return resp, nil
+}
+
+type countingReadCloser struct {
+	io.ReadCloser
+	n *atomic.Int64
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+func TestChunkDedupFetcher(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	files := make(map[string][]byte)
+	for name, size := range map[string]int{"base/a": 512 << 10, "base/b": 256 << 10, "new/c": 256 << 10} {
+		files[name] = make([]byte, size)
+		rnd.Read(files[name])
+	}
+	baseBlob, baseDesc := buildZstdChunked(t, files, "base/a", "base/b")
+	newBlob, newDesc := buildZstdChunked(t, files, "base/a", "base/b", "new/c")
+
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, newDesc.Digest.String()) {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(newBlob))
+	}))
+	defer registry.Close()
+
+	fetch := func(t *testing.T, localBlobs ...[]byte) (fetchedBytes int64, fetched []byte) {
+		ctx := context.Background()
+		cs, err := local.NewStore(t.TempDir())
+		assert.NilError(t, err)
+		for _, b := range localBlobs {
+			assert.NilError(t, content.WriteBlob(ctx, cs, "local", bytes.NewReader(b), ocispec.Descriptor{Digest: digest.FromBytes(b), Size: int64(len(b))}))
+		}
+
+		transport := &countingTransport{}
+		resolver := NewChunkDedupResolver(docker.NewResolver(docker.ResolverOptions{
+			Hosts: docker.ConfigureDefaultRegistries(
+				docker.WithPlainHTTP(docker.MatchAllHosts),
+				docker.WithClient(&http.Client{Transport: transport}),
+			),
+		}), cs)
+		ref := strings.TrimPrefix(registry.URL, "http://") + "/test/image:latest"
+		fetcher, err := resolver.Fetcher(ctx, ref)
+		assert.NilError(t, err)
+		rc, err := fetcher.Fetch(ctx, newDesc)
+		assert.NilError(t, err)
+		defer rc.Close()
+		b, err := io.ReadAll(rc)
+		assert.NilError(t, err)
+		return transport.n.Load(), b
+	}
+
+	t.Run("only the missing chunks are fetched when a base layer is present", func(t *testing.T) {
+		fetchedBytes, fetched := fetch(t, baseBlob)
+		assert.Assert(t, bytes.Equal(fetched, newBlob))
+		assert.Assert(t, fetchedBytes < newDesc.Size/2, "fetched %d bytes of %d", fetchedBytes, newDesc.Size)
+	})
+
+	t.Run("the layer is fetched as a whole without a base layer", func(t *testing.T) {
+		fetchedBytes, fetched := fetch(t)
+		assert.Assert(t, bytes.Equal(fetched, newBlob))
+		assert.Assert(t, fetchedBytes >= newDesc.Size, "fetched %d bytes of %d", fetchedBytes, newDesc.Size)
+	})
+
+	t.Run("other local blobs are ignored", func(t *testing.T) {
+		_, fetched := fetch(t, []byte("not a zstd:chunked blob"), files["base/a"])
+		assert.Assert(t, bytes.Equal(fetched, newBlob))
+	})
+
+	t.Run("layers without a TOC annotation are fetched as usual", func(t *testing.T) {
+		assert.Assert(t, isZstdChunked(newDesc))
+		assert.Assert(t, isZstdChunked(baseDesc))
+		desc := newDesc
+		desc.Annotations = nil
+		assert.Assert(t, !isZstdChunked(desc))
+		desc = newDesc
+		desc.MediaType = ocispec.MediaTypeImageLayerGzip
+		assert.Assert(t, !isZstdChunked(desc))
+	})
+}
+
+func TestTOCSegments(t *testing.T) {
+	toc := &estargz.JTOC{Entries: []*estargz.TOCEntry{
+		{Name: "dir/", Type: "dir"},
+		{Name: "dir/a", Type: "reg", Size: 20, Offset: 100, ChunkSize: 10, ChunkDigest: "sha256:a1"},
+		{Type: "chunk", Offset: 150, ChunkOffset: 10, ChunkSize: 10, ChunkDigest: "sha256:a2"},
+		{Name: "dir/b", Type: "reg", Size: 5, Offset: 200, ChunkSize: 5, ChunkDigest: "sha256:b"},
+		{Name: "dir/c", Type: "reg", Size: 5, Offset: 200, InnerOffset: 5, ChunkSize: 5, ChunkDigest: "sha256:c"},
+	}}
+	segments := tocSegments(toc, 300)
+	assert.Equal(t, len(segments), 4)
+	for i, expected := range [][2]int64{{0, 100}, {100, 50}, {150, 50}, {200, 100}} {
+		assert.Equal(t, segments[i].offset, expected[0])
+		assert.Equal(t, segments[i].size, expected[1])
+	}
+
+	// the key of a segment depends on the header of the next entry
+	toc.Entries[3].Name = "dir/renamed"
+	renamed := tocSegments(toc, 300)
+	assert.Equal(t, renamed[0].key, segments[0].key)
+	assert.Equal(t, renamed[1].key, segments[1].key)
+	assert.Assert(t, renamed[2].key != segments[2].key)
+	assert.Equal(t, renamed[3].key, segments[3].key)
+}
@@ -0,0 +1,85 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package pull
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/containerd/containerd/v2/core/images"
+	"github.com/containerd/containerd/v2/core/remotes"
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
+)
+
+// NewNonDistributableResolver wraps resolver so that non-distributable (foreign) blobs are fetched from the registry
+// first, and only from the `urls` of their descriptor when the registry does not serve them.
+//
+// By default, containerd fetches foreign blobs from their `urls`, which fails for registries (mirrors) that re-host them,
+// e.g. after `nerdctl push --allow-nondistributable-artifacts`.
+func NewNonDistributableResolver(resolver remotes.Resolver) remotes.Resolver {
+	return &nonDistributableResolver{Resolver: resolver}
+}
+
+type nonDistributableResolver struct {
+	remotes.Resolver
+}
+
+func (r *nonDistributableResolver) Fetcher(ctx context.Context, ref string) (remotes.Fetcher, error) {
+	fetcher, err := r.Resolver.Fetcher(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return &nonDistributableFetcher{Fetcher: fetcher}, nil
+}
+
+type nonDistributableFetcher struct {
+	remotes.Fetcher
+}
+
+func (f *nonDistributableFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	if !images.IsNonDistributable(desc.MediaType) || len(desc.URLs) == 0 {
+		return f.Fetcher.Fetch(ctx, desc)
+	}
+
+	registryDesc := desc
+	registryDesc.URLs = nil
+	rc, err := f.Fetcher.Fetch(ctx, registryDesc)
+	if err == nil {
+		// The docker fetcher only sends the request on the first read, so peek to know whether the registry serves the blob
+		br := bufio.NewReader(rc)
+		if _, err = br.Peek(1); err == nil || errors.Is(err, io.EOF) {
+			return &readCloser{Reader: br, Closer: rc}, nil
+		}
+		rc.Close()
+	}
+	if !errdefs.IsNotFound(err) {
+		return nil, err
+	}
+
+	log.G(ctx).WithError(err).Debugf("non-distributable blob %s is not served by the registry, fetching from %v", desc.Digest, desc.URLs)
+	return f.Fetcher.Fetch(ctx, desc)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,96 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package pull
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/containerd/v2/core/images"
+	"github.com/containerd/containerd/v2/core/remotes/docker"
+)
+
+func TestNonDistributableFetcher(t *testing.T) {
+	const content = "foreign layer"
+	dgst := digest.FromString(content)
+
+	serveBlob := func(hits *atomic.Int32, found bool) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits.Add(1)
+			if !found || !strings.HasSuffix(r.URL.Path, dgst.String()) {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = io.WriteString(w, content)
+		}))
+	}
+
+	fetch := func(t *testing.T, registryHasBlob bool, mediaType string) (registryHits, foreignHits int32, fetched string) {
+		var rHits, fHits atomic.Int32
+		registry := serveBlob(&rHits, registryHasBlob)
+		defer registry.Close()
+		foreign := serveBlob(&fHits, true)
+		defer foreign.Close()
+
+		resolver := NewNonDistributableResolver(docker.NewResolver(docker.ResolverOptions{
+			Hosts: docker.ConfigureDefaultRegistries(docker.WithPlainHTTP(docker.MatchAllHosts)),
+		}))
+		ref := strings.TrimPrefix(registry.URL, "http://") + "/test/image:latest"
+		fetcher, err := resolver.Fetcher(context.Background(), ref)
+		assert.NilError(t, err)
+
+		rc, err := fetcher.Fetch(context.Background(), ocispec.Descriptor{
+			MediaType: mediaType,
+			Digest:    dgst,
+			Size:      int64(len(content)),
+			URLs:      []string{foreign.URL + "/layers/" + dgst.String()},
+		})
+		assert.NilError(t, err)
+		defer rc.Close()
+		b, err := io.ReadAll(rc)
+		assert.NilError(t, err)
+		return rHits.Load(), fHits.Load(), string(b)
+	}
+
+	t.Run("registry re-hosting the foreign layer is preferred", func(t *testing.T) {
+		registryHits, foreignHits, fetched := fetch(t, true, images.MediaTypeDockerSchema2LayerForeignGzip)
+		assert.Equal(t, fetched, content)
+		assert.Assert(t, registryHits > 0)
+		assert.Equal(t, foreignHits, int32(0))
+	})
+
+	t.Run("falls back to the urls when the registry returns 404", func(t *testing.T) {
+		registryHits, foreignHits, fetched := fetch(t, false, ocispec.MediaTypeImageLayerNonDistributableGzip) //nolint:staticcheck // deprecated
+		assert.Equal(t, fetched, content)
+		assert.Assert(t, registryHits > 0)
+		assert.Assert(t, foreignHits > 0)
+	})
+
+	t.Run("distributable layers are fetched as usual", func(t *testing.T) {
+		_, _, fetched := fetch(t, true, ocispec.MediaTypeImageLayerGzip)
+		assert.Equal(t, fetched, content)
+	})
+}
@@ -19,7 +19,6 @@ package imgutil
 import (
 	"context"
 	"errors"
-	"fmt"
 	"io"
 	"net/http"
 	"os"
@@ -45,15 +44,12 @@ func prepareImageStore(ctx context.Context, parsedReference *referenceutil.Image
 		storeOpts = append(storeOpts, transferimage.WithPlatforms(options.OCISpecPlatform...))
 	}
 
-	unpackEnabled := len(options.OCISpecPlatform) == 1
-	if options.Unpack != nil {
-		unpackEnabled = *options.Unpack
-		if unpackEnabled && len(options.OCISpecPlatform) != 1 {
-			return nil, fmt.Errorf("unpacking requires a single platform to be specified (e.g., --platform=amd64)")
-		}
+	unpack, err := unpackEnabled(options)
+	if err != nil {
+		return nil, err
 	}
 
-	if unpackEnabled {
+	if unpack {
 		platform := options.OCISpecPlatform[0]
 		snapshotter := options.GOptions.Snapshotter
 		storeOpts = append(storeOpts, transferimage.WithUnpack(platform, snapshotter))
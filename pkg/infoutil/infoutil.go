@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
@@ -38,6 +39,9 @@ import (
 	"github.com/containerd/nerdctl/v2/pkg/version"
 )
 
+// UnknownVersion is the version reported for the components that could not be detected.
+const UnknownVersion = "unknown"
+
 func NativeDaemonInfo(ctx context.Context, client *containerd.Client) (*native.DaemonInfo, error) {
 	introService := client.IntrospectionService()
 	plugins, err := introService.Plugins(ctx)
@@ -149,19 +153,19 @@ func buildctlVersion() dockercompat.ComponentVersion {
 	buildctlBinary, err := buildkitutil.BuildctlBinary()
 	if err != nil {
 		log.L.WithError(err).Warnf("unable to determine buildctl version")
-		return dockercompat.ComponentVersion{Name: "buildctl"}
+		return unknownComponentVersion("buildctl")
 	}
 
 	stdout, err := exec.Command(buildctlBinary, "--version").Output()
 	if err != nil {
 		log.L.WithError(err).Warnf("unable to determine buildctl version")
-		return dockercompat.ComponentVersion{Name: "buildctl"}
+		return unknownComponentVersion("buildctl")
 	}
 
 	v, err := parseBuildctlVersion(stdout)
 	if err != nil {
 		log.L.Warn(err)
-		return dockercompat.ComponentVersion{Name: "buildctl"}
+		return unknownComponentVersion("buildctl")
 	}
 	return *v
 }
@@ -194,16 +198,22 @@ func runcVersion() dockercompat.ComponentVersion {
 	stdout, err := exec.Command("runc", "--version").Output()
 	if err != nil {
 		log.L.WithError(err).Warnf("unable to determine runc version")
-		return dockercompat.ComponentVersion{Name: "runc"}
+		return unknownComponentVersion("runc")
 	}
 	v, err := parseRuncVersion(stdout)
 	if err != nil {
 		log.L.Warn(err)
-		return dockercompat.ComponentVersion{Name: "runc"}
+		return unknownComponentVersion("runc")
 	}
 	return *v
 }
 
+// unknownComponentVersion is returned for the components that are not installed, or
+// whose version cannot be parsed, so that `nerdctl version` still succeeds.
+func unknownComponentVersion(name string) dockercompat.ComponentVersion {
+	return dockercompat.ComponentVersion{Name: name, Version: UnknownVersion}
+}
+
 func parseRuncVersion(runcVersionStdout []byte) (*dockercompat.ComponentVersion, error) {
 	var versionList = strings.Split(strings.TrimSpace(string(runcVersionStdout)), "\n")
 	firstLine := strings.Fields(versionList[0])
@@ -232,6 +242,102 @@ func parseRuncVersion(runcVersionStdout []byte) (*dockercompat.ComponentVersion,
 	}, nil
 }
 
+// Runtimes returns the containerd runtime shims found in $PATH, keyed by the runtime name
+// (e.g., "io.containerd.runc.v2" for "containerd-shim-runc-v2").
+func Runtimes() map[string]dockercompat.Runtime {
+	return runtimesFromPath(os.Getenv("PATH"))
+}
+
+func runtimesFromPath(pathEnv string) map[string]dockercompat.Runtime {
+	runtimes := make(map[string]dockercompat.Runtime)
+	for _, dir := range filepath.SplitList(pathEnv) {
+		if dir == "" {
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(dir, "containerd-shim-*"))
+		if err != nil {
+			continue
+		}
+		for _, m := range matches {
+			name := shimRuntimeName(filepath.Base(m))
+			if name == "" {
+				continue
+			}
+			// Like exec.LookPath, the first match in $PATH wins
+			if _, ok := runtimes[name]; ok {
+				continue
+			}
+			if st, err := os.Stat(m); err != nil || st.IsDir() {
+				continue
+			}
+			runtimes[name] = dockercompat.Runtime{Path: m}
+		}
+	}
+	return runtimes
+}
+
+// shimRuntimeName converts a shim binary name such as "containerd-shim-runc-v2" to
+// the runtime name "io.containerd.runc.v2". An empty string is returned for non-shim binaries.
+func shimRuntimeName(binary string) string {
+	s := strings.TrimSuffix(binary, ".exe")
+	if !strings.HasPrefix(s, "containerd-shim-") {
+		return ""
+	}
+	s = strings.TrimPrefix(s, "containerd-shim-")
+	i := strings.LastIndex(s, "-")
+	if i <= 0 || i == len(s)-1 {
+		return ""
+	}
+	return "io.containerd." + s[:i] + "." + s[i+1:]
+}
+
+// CNIInfo returns the CNI plugins installed in cniPath, along with their version.
+func CNIInfo(cniPath string) *dockercompat.CNIInfo {
+	info := &dockercompat.CNIInfo{Path: cniPath}
+	entries, err := os.ReadDir(cniPath)
+	if err != nil {
+		log.L.WithError(err).Debugf("unable to read CNI path %q", cniPath)
+		return info
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		info.Plugins = append(info.Plugins, strings.TrimSuffix(e.Name(), ".exe"))
+	}
+	// All the plugins of github.com/containernetworking/plugins share the same version,
+	// so it is enough to ask one of them.
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), ".exe")
+		if name != "bridge" && name != "loopback" && name != "nat" {
+			continue
+		}
+		cmd := exec.Command(filepath.Join(cniPath, e.Name()))
+		// Without CNI_COMMAND, the plugin just prints its "about" string
+		cmd.Env = []string{}
+		out, _ := cmd.CombinedOutput()
+		v, err := parseCNIPluginVersion(out)
+		if err != nil {
+			log.L.WithError(err).Debug("unable to determine CNI plugins version")
+			continue
+		}
+		info.Version = v
+		break
+	}
+	return info
+}
+
+func parseCNIPluginVersion(cniPluginStderr []byte) (string, error) {
+	// e.g., "CNI bridge plugin v1.4.0"
+	for _, line := range strings.Split(string(cniPluginStderr), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 4 && fields[0] == "CNI" && fields[2] == "plugin" {
+			return fields[3], nil
+		}
+	}
+	return "", fmt.Errorf("unable to determine CNI plugin version, got %q", string(cniPluginStderr))
+}
+
 // getMobySysInfo returns the moby system info for the given cgroup manager
 func getMobySysInfo(cgroupManager string) *sysinfo.SysInfo {
 	var info dockercompat.Info
@@ -272,6 +378,16 @@ func BlockIOWriteIOpsDevice(cgroupManager string) bool {
 	return getMobySysInfo(cgroupManager).BlkioWriteIOpsDevice
 }
 
+// SwapLimit returns whether swap limit (swap accounting of the memory cgroup) is supported or not
+func SwapLimit(cgroupManager string) bool {
+	return getMobySysInfo(cgroupManager).SwapLimit
+}
+
+// MemorySwappiness returns whether memory swappiness is supported or not
+func MemorySwappiness(cgroupManager string) bool {
+	return getMobySysInfo(cgroupManager).MemorySwappiness
+}
+
 // CPURealtime returns whether CPU realtime period is supported or not
 func CPURealtime(cgroupManager string) bool {
 	return getMobySysInfo(cgroupManager).CPURealtime
@@ -17,6 +17,8 @@
 package infoutil
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"gotest.tools/v3/assert"
@@ -83,3 +85,65 @@ libseccomp: 2.5.1`: {
 		}
 	}
 }
+
+func TestShimRuntimeName(t *testing.T) {
+	testCases := map[string]string{
+		"containerd-shim-runc-v2":       "io.containerd.runc.v2",
+		"containerd-shim-runsc-v1":      "io.containerd.runsc.v1",
+		"containerd-shim-runhcs-v1.exe": "io.containerd.runhcs.v1",
+		"containerd-shim-spin-wasm-v2":  "io.containerd.spin-wasm.v2",
+		"containerd-shim":               "",
+		"containerd-shim-runc":          "",
+		"containerd-shim-runc-":         "",
+		"runc":                          "",
+	}
+
+	for binary, expected := range testCases {
+		assert.Equal(t, expected, shimRuntimeName(binary), binary)
+	}
+}
+
+func TestRuntimesFromPath(t *testing.T) {
+	dir1, dir2 := t.TempDir(), t.TempDir()
+	for _, f := range []string{
+		filepath.Join(dir1, "containerd-shim-runc-v2"),
+		filepath.Join(dir1, "containerd"),
+		filepath.Join(dir2, "containerd-shim-runc-v2"),
+		filepath.Join(dir2, "containerd-shim-runsc-v1"),
+	} {
+		assert.NilError(t, os.WriteFile(f, nil, 0o755))
+	}
+	assert.NilError(t, os.Mkdir(filepath.Join(dir2, "containerd-shim-dir-v1"), 0o755))
+
+	got := runtimesFromPath(dir1 + string(filepath.ListSeparator) + dir2)
+	assert.DeepEqual(t, map[string]dockercompat.Runtime{
+		"io.containerd.runc.v2":  {Path: filepath.Join(dir1, "containerd-shim-runc-v2")},
+		"io.containerd.runsc.v1": {Path: filepath.Join(dir2, "containerd-shim-runsc-v1")},
+	}, got)
+}
+
+func TestParseCNIPluginVersion(t *testing.T) {
+	testCases := map[string]string{
+		"CNI bridge plugin v1.4.0\nCNI protocol versions supported: 0.1.0, 0.2.0, 0.3.0, 0.3.1, 0.4.0, 1.0.0\n": "v1.4.0",
+		"CNI loopback plugin v1.1.1": "v1.1.1",
+		"unknown":                    "",
+	}
+
+	for s, expected := range testCases {
+		got, err := parseCNIPluginVersion([]byte(s))
+		if expected != "" {
+			assert.NilError(t, err)
+			assert.Equal(t, expected, got)
+		} else {
+			assert.Assert(t, err != nil)
+		}
+	}
+}
+
+func TestComponentVersionUnknown(t *testing.T) {
+	// Neither runc nor buildctl can be found in an empty $PATH
+	t.Setenv("PATH", t.TempDir())
+
+	assert.DeepEqual(t, dockercompat.ComponentVersion{Name: "runc", Version: UnknownVersion}, runcVersion())
+	assert.DeepEqual(t, dockercompat.ComponentVersion{Name: "buildctl", Version: UnknownVersion}, buildctlVersion())
+}
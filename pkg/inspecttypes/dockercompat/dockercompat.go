@@ -31,12 +31,15 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/docker/go-connections/nat"
 	"github.com/docker/go-units"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/opencontainers/runtime-spec/specs-go"
 
 	containerd "github.com/containerd/containerd/v2/client"
@@ -69,7 +72,7 @@ type Image struct {
 
 	// TODO: OsVersion     string `json:",omitempty"`
 
-	Size        int64 // Size is the unpacked size of the image
+	Size        int64 // Size is the compressed size of the unique layers of the image
 	VirtualSize int64 `json:"VirtualSize,omitempty"` // Deprecated
 
 	// TODO: GraphDriver	GraphDriverData
@@ -139,13 +142,13 @@ type Container struct {
 // From https://github.com/moby/moby/blob/8dbd90ec00daa26dc45d7da2431c965dec99e8b4/api/types/container/host_config.go#L391
 // HostConfig the non-portable Config structure of a container.
 type HostConfig struct {
-	// Binds           []string      // List of volume bindings for this container
+	Binds           []string        // List of volume bindings for this container
 	ContainerIDFile string          // File (path) where the containerId is written
 	LogConfig       loggerLogConfig // Configuration of the logs for this container
-	// NetworkMode     NetworkMode   // Network mode to use for the container
-	PortBindings nat.PortMap // Port mapping between the exposed port (container) and the host
-	// RestartPolicy   RestartPolicy // Restart policy to be used for the container
-	// AutoRemove      bool          // Automatically remove container when it exits
+	NetworkMode     string          // Network mode to use for the container
+	PortBindings    nat.PortMap     // Port mapping between the exposed port (container) and the host
+	RestartPolicy   RestartPolicy   // Restart policy to be used for the container
+	AutoRemove      bool            // Automatically remove container when it exits
 	// VolumeDriver    string        // Name of the volume driver used to mount volumes
 	// VolumesFrom     []string      // List of volumes to take from other container
 	// CapAdd          strslice.StrSlice // List of kernel capabilities to add to the container
@@ -185,6 +188,13 @@ type HostConfig struct {
 	BlkioSettings
 }
 
+// RestartPolicy represents the restart policies of the container.
+// From https://github.com/moby/moby/blob/v20.10.1/api/types/container/host_config.go#L272-L276
+type RestartPolicy struct {
+	Name              string
+	MaximumRetryCount int
+}
+
 // From https://github.com/moby/moby/blob/v20.10.1/api/types/types.go#L416-L427
 // MountPoint represents a mount point configuration inside the container.
 // This is used for reporting the mountpoints in use by a container.
@@ -309,12 +319,18 @@ type NetworkEndpointSettings struct {
 	// TODO DriverOpts          map[string]string
 }
 
+// formatTimestamp formats t like Docker: in UTC, with nanoseconds.
+// The zero time is formatted as "0001-01-01T00:00:00Z".
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
 // ContainerFromNative instantiates a Docker-compatible Container from containerd-native Container.
 func ContainerFromNative(n *native.Container) (*Container, error) {
 	var hostname string
 	c := &Container{
 		ID:      n.ID,
-		Created: n.CreatedAt.Format(time.RFC3339Nano),
+		Created: formatTimestamp(n.CreatedAt),
 		Image:   n.Image,
 		Name:    n.Labels[labels.Name],
 		Driver:  n.Snapshotter,
@@ -322,9 +338,9 @@ func ContainerFromNative(n *native.Container) (*Container, error) {
 		Platform: runtime.GOOS, // for Docker compatibility, this Platform string does NOT contain arch like "/amd64"
 	}
 	c.HostConfig = new(HostConfig)
-	if n.Labels[restart.StatusLabel] == string(containerd.Running) {
-		c.RestartCount, _ = strconv.Atoi(n.Labels[restart.CountLabel])
-	}
+	// The restart count is incremented by the restart monitor on every policy-driven restart.
+	// It is kept when the container is stopped, or restarted manually.
+	c.RestartCount, _ = strconv.Atoi(n.Labels[restart.CountLabel])
 	containerAnnotations := make(map[string]string)
 	if sp, ok := n.Spec.(*specs.Spec); ok {
 		containerAnnotations = sp.Annotations
@@ -369,12 +385,15 @@ func ContainerFromNative(n *native.Container) (*Container, error) {
 	}
 
 	c.HostConfig.Tmpfs = make(map[string]string)
+	// Only the mounts specified by the user are reported as Binds, not the mounts of the spec
+	c.HostConfig.Binds = []string{}
 	if nerdctlMounts := n.Labels[labels.Mounts]; nerdctlMounts != "" {
 		mounts, err := parseMounts(nerdctlMounts)
 		if err != nil {
 			return nil, err
 		}
 		c.Mounts = mounts
+		c.HostConfig.Binds = bindsFromMounts(mounts, n.Labels[labels.AnonymousVolumes])
 		for _, mount := range mounts {
 			if mount.Type == "tmpfs" {
 				c.HostConfig.Tmpfs[mount.Destination] = mount.Mode
@@ -382,6 +401,10 @@ func ContainerFromNative(n *native.Container) (*Container, error) {
 		}
 	}
 
+	c.HostConfig.NetworkMode = networkModeFromNative(n.Labels)
+	c.HostConfig.RestartPolicy = restartPolicyFromNative(n.Labels)
+	c.HostConfig.AutoRemove, _ = strconv.ParseBool(n.Labels[labels.ContainerAutoRemove])
+
 	if nedctlExtraHosts := n.Labels[labels.ExtraHosts]; nedctlExtraHosts != "" {
 		c.HostConfig.ExtraHosts = parseExtraHosts(nedctlExtraHosts)
 	}
@@ -440,10 +463,13 @@ func ContainerFromNative(n *native.Container) (*Container, error) {
 	cs := new(ContainerState)
 	cs.Restarting = n.Labels[restart.StatusLabel] == string(containerd.Running)
 	cs.Error = n.Labels[labels.Error]
+	// Like Docker, the timestamps of a container that has not been started (or has not exited) are zero-valued.
+	var startedAt, finishedAt time.Time
 	if n.Process != nil {
 		cs.Status = statusFromNative(n.Process.Status, n.Labels)
-		cs.Running = n.Process.Status.Status == containerd.Running
 		cs.Paused = n.Process.Status.Status == containerd.Paused
+		// Like Docker, a paused container is still running
+		cs.Running = n.Process.Status.Status == containerd.Running || cs.Paused
 		cs.Pid = n.Process.Pid
 		cs.ExitCode = int(n.Process.Status.ExitStatus)
 		if containerAnnotations[labels.StateDir] != "" {
@@ -451,12 +477,12 @@ func ContainerFromNative(n *native.Container) (*Container, error) {
 				log.L.WithError(err).Errorf("failed retrieving state")
 			} else if err = lf.Load(); err != nil {
 				log.L.WithError(err).Errorf("failed retrieving StartedAt from state")
-			} else if !time.Time.IsZero(lf.StartedAt) {
-				cs.StartedAt = lf.StartedAt.UTC().Format(time.RFC3339Nano)
+			} else {
+				startedAt = lf.StartedAt
 			}
 		}
-		if !n.Process.Status.ExitTime.IsZero() {
-			cs.FinishedAt = n.Process.Status.ExitTime.Format(time.RFC3339Nano)
+		if !cs.Running {
+			finishedAt = n.Process.Status.ExitTime
 		}
 		nSettings, err := networkSettingsFromNative(n.Process.NetNS, n.Spec.(*specs.Spec))
 		if err != nil {
@@ -465,14 +491,19 @@ func ContainerFromNative(n *native.Container) (*Container, error) {
 		c.NetworkSettings = nSettings
 		c.HostConfig.PortBindings = *nSettings.Ports
 	} else {
-		// n.process is not set if the container is not started, making the networkSetting null
+		// n.process is not set if the container is not started (i.e., created without a task)
+		cs.Status = "created"
+		// making the networkSetting null
 		// we should send an empty object even in this case inorder for it to be compatible with docker inspect response
 		nSettings, err := networkSettingsFromNative(nil, n.Spec.(*specs.Spec))
 		if err != nil {
 			return nil, err
 		}
 		c.NetworkSettings = nSettings
+		c.HostConfig.PortBindings = *nSettings.Ports
 	}
+	cs.StartedAt = formatTimestamp(startedAt)
+	cs.FinishedAt = formatTimestamp(finishedAt)
 
 	cpuSetting, err := cpuSettingsFromNative(n.Spec.(*specs.Spec))
 	if err != nil {
@@ -607,12 +638,29 @@ func ImageFromNative(nativeImage *native.Image) (*Image, error) {
 		Architecture: imgOCI.Architecture,
 		Variant:      imgOCI.Platform.Variant,
 		Os:           imgOCI.OS,
-		Size:         nativeImage.Size,
-		VirtualSize:  nativeImage.Size,
 		RepoTags:     []string{fmt.Sprintf("%s:%s", repository, tag)},
 		RepoDigests:  []string{fmt.Sprintf("%s@%s", repository, nativeImage.Image.Target.Digest.String())},
 	}
 
+	// The platform of the image config is incomplete for some images (e.g., the variant is often missing),
+	// so complete it with the platform of the manifest in the index.
+	if nativeImage.ManifestDesc != nil && nativeImage.ManifestDesc.Platform != nil {
+		if image.Os == "" {
+			image.Os = nativeImage.ManifestDesc.Platform.OS
+		}
+		if image.Architecture == "" {
+			image.Architecture = nativeImage.ManifestDesc.Platform.Architecture
+		}
+		if image.Variant == "" {
+			image.Variant = nativeImage.ManifestDesc.Platform.Variant
+		}
+	}
+	// Like Docker with the containerd image store, the size is the total size of the (unique) layers.
+	if nativeImage.Manifest != nil {
+		image.Size = layersSize(nativeImage.Manifest.Layers)
+		image.VirtualSize = image.Size
+	}
+
 	if len(imgOCI.History) > 0 {
 		image.Comment = imgOCI.History[len(imgOCI.History)-1].Comment
 		if !imgOCI.History[len(imgOCI.History)-1].Created.IsZero() {
@@ -827,6 +875,69 @@ func convertToNatPort(portMappings []cni.PortMapping) (*nat.PortMap, error) {
 	return &portMap, nil
 }
 
+// bindsFromMounts returns the bind mounts and the named volumes in the `SOURCE:DEST[:MODE]` form,
+// like the `Binds` of `docker inspect`. Tmpfs mounts and anonymous volumes are not included.
+func bindsFromMounts(mounts []MountPoint, anonVolumesJSON string) []string {
+	var anonVolumes []string
+	if anonVolumesJSON != "" {
+		if err := json.Unmarshal([]byte(anonVolumesJSON), &anonVolumes); err != nil {
+			log.L.WithError(err).Debug("failed to parse the anonymous volumes label")
+		}
+	}
+	binds := []string{}
+	for _, m := range mounts {
+		var src string
+		switch m.Type {
+		case "bind":
+			src = m.Source
+		case "volume":
+			if slices.Contains(anonVolumes, m.Name) {
+				continue
+			}
+			src = m.Name
+		default:
+			continue
+		}
+		bind := src + ":" + m.Destination
+		if m.Mode != "" {
+			bind += ":" + m.Mode
+		}
+		binds = append(binds, bind)
+	}
+	return binds
+}
+
+// networkModeFromNative returns the first network of the container, e.g., "bridge", "host", "none",
+// "container:<ID>", or the name of a user-defined network.
+func networkModeFromNative(lbls map[string]string) string {
+	var networks []string
+	if networksJSON := lbls[labels.Networks]; networksJSON != "" {
+		if err := json.Unmarshal([]byte(networksJSON), &networks); err != nil {
+			log.L.WithError(err).Debug("failed to parse the networks label")
+		}
+	}
+	if len(networks) == 0 {
+		return ""
+	}
+	return networks[0]
+}
+
+func restartPolicyFromNative(lbls map[string]string) RestartPolicy {
+	policyLabel, ok := lbls[restart.PolicyLabel]
+	if !ok {
+		return RestartPolicy{Name: "no"}
+	}
+	policy, err := restart.NewPolicy(policyLabel)
+	if err != nil {
+		log.L.WithError(err).Debugf("failed to parse the restart policy %q", policyLabel)
+		return RestartPolicy{Name: "no"}
+	}
+	return RestartPolicy{
+		Name:              policy.Name(),
+		MaximumRetryCount: policy.MaximumRetryCount(),
+	}
+}
+
 func parseExtraHosts(extraHostsJSON string) []string {
 	var extraHosts []string
 	if err := json.Unmarshal([]byte(extraHostsJSON), &extraHosts); err != nil {
@@ -987,6 +1098,20 @@ func NetworkFromNative(n *native.Network) (*Network, error) {
 	return &res, nil
 }
 
+// layersSize returns the total size of the layers, counting the layers present several times only once.
+func layersSize(layers []ocispec.Descriptor) int64 {
+	var size int64
+	seen := make(map[digest.Digest]struct{}, len(layers))
+	for _, l := range layers {
+		if _, ok := seen[l.Digest]; ok {
+			continue
+		}
+		seen[l.Digest] = struct{}{}
+		size += l.Size
+	}
+	return size
+}
+
 func parseMounts(nerdctlMounts string) ([]MountPoint, error) {
 	var mounts []MountPoint
 	err := json.Unmarshal([]byte(nerdctlMounts), &mounts)
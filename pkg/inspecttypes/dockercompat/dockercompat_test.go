@@ -33,12 +33,14 @@ import (
 	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/containerd/v2/core/containers"
 	"github.com/containerd/containerd/v2/core/images"
+	"github.com/containerd/containerd/v2/core/runtime/restart"
 	"github.com/containerd/go-cni"
 
 	"github.com/containerd/nerdctl/v2/pkg/healthcheck"
 	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/native"
 	"github.com/containerd/nerdctl/v2/pkg/internal/filesystem"
 	"github.com/containerd/nerdctl/v2/pkg/labels"
+	"github.com/containerd/nerdctl/v2/pkg/ocihook/state"
 )
 
 func TestContainerFromNative(t *testing.T) {
@@ -96,11 +98,14 @@ func TestContainerFromNative(t *testing.T) {
 					Status:     "running",
 					Running:    true,
 					Pid:        10000,
-					FinishedAt: "",
+					StartedAt:  "0001-01-01T00:00:00Z",
+					FinishedAt: "0001-01-01T00:00:00Z",
 				},
 				HostConfig: &HostConfig{
-					PortBindings: nat.PortMap{},
-					GroupAdd:     []string{},
+					Binds:         []string{"/mnt/foo:/mnt/foo:rshared,rw"},
+					RestartPolicy: RestartPolicy{Name: "no"},
+					PortBindings:  nat.PortMap{},
+					GroupAdd:      []string{},
 					LogConfig: loggerLogConfig{
 						Driver: "json-file",
 						Opts:   map[string]string{},
@@ -192,11 +197,14 @@ func TestContainerFromNative(t *testing.T) {
 					Status:     "running",
 					Running:    true,
 					Pid:        10000,
-					FinishedAt: "",
+					StartedAt:  "0001-01-01T00:00:00Z",
+					FinishedAt: "0001-01-01T00:00:00Z",
 				},
 				HostConfig: &HostConfig{
-					PortBindings: nat.PortMap{},
-					GroupAdd:     []string{},
+					Binds:         []string{},
+					RestartPolicy: RestartPolicy{Name: "no"},
+					PortBindings:  nat.PortMap{},
+					GroupAdd:      []string{},
 					LogConfig: loggerLogConfig{
 						Driver: "json-file",
 						Opts:   map[string]string{},
@@ -283,11 +291,14 @@ func TestContainerFromNative(t *testing.T) {
 					Status:     "running",
 					Running:    true,
 					Pid:        10000,
-					FinishedAt: "",
+					StartedAt:  "0001-01-01T00:00:00Z",
+					FinishedAt: "0001-01-01T00:00:00Z",
 				},
 				HostConfig: &HostConfig{
-					PortBindings: nat.PortMap{},
-					GroupAdd:     []string{},
+					Binds:         []string{},
+					RestartPolicy: RestartPolicy{Name: "no"},
+					PortBindings:  nat.PortMap{},
+					GroupAdd:      []string{},
 					LogConfig: loggerLogConfig{
 						Driver: "json-file",
 						Opts:   map[string]string{},
@@ -339,9 +350,12 @@ func TestContainerFromNative(t *testing.T) {
 					Status:     "running",
 					Running:    true,
 					Pid:        0,
-					FinishedAt: "",
+					StartedAt:  "0001-01-01T00:00:00Z",
+					FinishedAt: "0001-01-01T00:00:00Z",
 				},
 				HostConfig: &HostConfig{
+					Binds:         []string{},
+					RestartPolicy: RestartPolicy{Name: "no"},
 					LogConfig:     loggerLogConfig{Driver: "json-file", Opts: map[string]string{}},
 					PortBindings:  nat.PortMap{},
 					GroupAdd:      []string{},
@@ -361,6 +375,38 @@ func TestContainerFromNative(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "created container without task",
+			n: &native.Container{
+				Container: containers.Container{},
+				Spec:      &specs.Spec{},
+			},
+			expected: &Container{
+				Created:  "0001-01-01T00:00:00Z",
+				Platform: runtime.GOOS,
+				Mounts:   []MountPoint{},
+				State: &ContainerState{
+					Status:     "created",
+					StartedAt:  "0001-01-01T00:00:00Z",
+					FinishedAt: "0001-01-01T00:00:00Z",
+				},
+				HostConfig: &HostConfig{
+					Binds:         []string{},
+					RestartPolicy: RestartPolicy{Name: "no"},
+					LogConfig:     loggerLogConfig{Driver: "json-file", Opts: map[string]string{}},
+					PortBindings:  nat.PortMap{},
+					GroupAdd:      []string{},
+					Tmpfs:         map[string]string{},
+					UTSMode:       "host",
+					BlkioSettings: getDefaultBlkioSettings(),
+				},
+				NetworkSettings: &NetworkSettings{
+					Ports:    &nat.PortMap{},
+					Networks: map[string]*NetworkEndpointSettings{},
+				},
+				Config: &Config{},
+			},
+		},
 	}
 
 	for _, tc := range testcase {
@@ -371,6 +417,138 @@ func TestContainerFromNative(t *testing.T) {
 	}
 }
 
+func TestContainerFromNativeRestartCount(t *testing.T) {
+	testcase := []struct {
+		name     string
+		labels   map[string]string
+		expected int
+	}{
+		{
+			name:     "no restart policy",
+			labels:   map[string]string{},
+			expected: 0,
+		},
+		{
+			name: "restarted by policy",
+			labels: map[string]string{
+				restart.StatusLabel: string(containerd.Running),
+				restart.CountLabel:  "3",
+			},
+			expected: 3,
+		},
+		{
+			name: "stopped after restarts by policy",
+			labels: map[string]string{
+				restart.StatusLabel: string(containerd.Stopped),
+				restart.CountLabel:  "3",
+			},
+			expected: 3,
+		},
+	}
+
+	for _, tc := range testcase {
+		t.Run(tc.name, func(tt *testing.T) {
+			n := &native.Container{
+				Container: containers.Container{Labels: tc.labels},
+				Spec:      &specs.Spec{},
+			}
+			d, err := ContainerFromNative(n)
+			assert.NilError(tt, err)
+			assert.Equal(tt, d.RestartCount, tc.expected)
+		})
+	}
+}
+
+func TestContainerFromNativeHostConfig(t *testing.T) {
+	memoryLimit := int64(64 * 1024 * 1024)
+	n := &native.Container{
+		Container: containers.Container{
+			Labels: map[string]string{
+				labels.Mounts: `[{"Type":"bind","Source":"/mnt/foo","Destination":"/foo","Mode":"ro","RW":false},` +
+					`{"Type":"volume","Name":"myvol","Source":"/var/lib/nerdctl/volumes/myvol/_data","Destination":"/data","RW":true},` +
+					`{"Type":"volume","Name":"0123abcd","Source":"/var/lib/nerdctl/volumes/0123abcd/_data","Destination":"/anon","RW":true},` +
+					`{"Type":"tmpfs","Destination":"/tmp","Mode":"size=64m","RW":true}]`,
+				labels.AnonymousVolumes:    `["0123abcd"]`,
+				labels.Networks:            `["mynet"]`,
+				labels.ContainerAutoRemove: "true",
+				restart.PolicyLabel:        "on-failure:3",
+			},
+		},
+		Spec: &specs.Spec{
+			Linux: &specs.Linux{
+				Resources: &specs.LinuxResources{
+					Memory: &specs.LinuxMemory{Limit: &memoryLimit},
+				},
+			},
+		},
+	}
+	d, err := ContainerFromNative(n)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, d.HostConfig.Binds, []string{"/mnt/foo:/foo:ro", "myvol:/data"})
+	assert.Equal(t, d.HostConfig.NetworkMode, "mynet")
+	assert.Equal(t, d.HostConfig.AutoRemove, true)
+	assert.Equal(t, d.HostConfig.RestartPolicy, RestartPolicy{Name: "on-failure", MaximumRetryCount: 3})
+	assert.Equal(t, d.HostConfig.Memory, memoryLimit)
+	assert.DeepEqual(t, d.HostConfig.Tmpfs, map[string]string{"/tmp": "size=64m"})
+}
+
+func TestContainerFromNativeTimestamps(t *testing.T) {
+	loc := time.FixedZone("UTC+9", 9*60*60)
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 123456789, loc)
+	startedAt := time.Date(2024, 1, 2, 3, 4, 6, 1, loc)
+	exitTime := time.Date(2024, 1, 2, 3, 4, 7, 0, loc)
+
+	stateDir := t.TempDir()
+	lf, err := state.New(stateDir)
+	assert.NilError(t, err)
+	assert.NilError(t, lf.Transform(func(lf *state.Store) error {
+		lf.StartedAt = startedAt
+		return nil
+	}))
+
+	testcase := []struct {
+		name       string
+		process    *native.Process
+		startedAt  string
+		finishedAt string
+	}{
+		{
+			name:       "created",
+			startedAt:  "0001-01-01T00:00:00Z",
+			finishedAt: "0001-01-01T00:00:00Z",
+		},
+		{
+			name:       "running",
+			process:    &native.Process{Status: containerd.Status{Status: containerd.Running}},
+			startedAt:  "2024-01-01T18:04:06.000000001Z",
+			finishedAt: "0001-01-01T00:00:00Z",
+		},
+		{
+			name:       "exited",
+			process:    &native.Process{Status: containerd.Status{Status: containerd.Stopped, ExitTime: exitTime}},
+			startedAt:  "2024-01-01T18:04:06.000000001Z",
+			finishedAt: "2024-01-01T18:04:07Z",
+		},
+	}
+
+	for _, tc := range testcase {
+		t.Run(tc.name, func(tt *testing.T) {
+			n := &native.Container{
+				Container: containers.Container{
+					CreatedAt: createdAt,
+				},
+				Spec:    &specs.Spec{Annotations: map[string]string{labels.StateDir: stateDir}},
+				Process: tc.process,
+			}
+			d, err := ContainerFromNative(n)
+			assert.NilError(tt, err)
+			assert.Equal(tt, d.Created, "2024-01-01T18:04:05.123456789Z")
+			assert.Equal(tt, d.State.StartedAt, tc.startedAt)
+			assert.Equal(tt, d.State.FinishedAt, tc.finishedAt)
+		})
+	}
+}
+
 func TestNetworkSettingsFromNative(t *testing.T) {
 	tempStateDir, err := os.MkdirTemp(t.TempDir(), "rw")
 	if err != nil {
@@ -671,4 +849,62 @@ func TestImageFromNative(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("parses the platform and the size of the layers", func(t *testing.T) {
+		testcases := []struct {
+			name                 string
+			config               ocispec.Platform
+			manifestPlatform     *ocispec.Platform
+			expectedOs           string
+			expectedArchitecture string
+			expectedVariant      string
+		}{
+			{
+				name:                 "host platform",
+				config:               ocispec.Platform{OS: "linux", Architecture: "amd64"},
+				manifestPlatform:     &ocispec.Platform{OS: "linux", Architecture: "amd64"},
+				expectedOs:           "linux",
+				expectedArchitecture: "amd64",
+			},
+			{
+				name:                 "variant from the config",
+				config:               ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"},
+				expectedOs:           "linux",
+				expectedArchitecture: "arm",
+				expectedVariant:      "v7",
+			},
+			{
+				name:                 "variant missing from the config",
+				config:               ocispec.Platform{OS: "linux", Architecture: "arm64"},
+				manifestPlatform:     &ocispec.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"},
+				expectedOs:           "linux",
+				expectedArchitecture: "arm64",
+				expectedVariant:      "v8",
+			},
+		}
+
+		for _, tc := range testcases {
+			t.Run(tc.name, func(t *testing.T) {
+				img := native.Image{
+					ManifestDesc: &ocispec.Descriptor{Platform: tc.manifestPlatform},
+					Manifest: &ocispec.Manifest{
+						Layers: []ocispec.Descriptor{
+							{Digest: "sha256:layer1", Size: 100},
+							{Digest: "sha256:layer2", Size: 20},
+							{Digest: "sha256:layer1", Size: 100},
+						},
+					},
+					ImageConfig: ocispec.Image{Platform: tc.config},
+					Size:        4096,
+				}
+
+				out, err := ImageFromNative(&img)
+				assert.NilError(t, err)
+				assert.Equal(t, out.Os, tc.expectedOs)
+				assert.Equal(t, out.Architecture, tc.expectedArchitecture)
+				assert.Equal(t, out.Variant, tc.expectedVariant)
+				assert.Equal(t, out.Size, int64(120))
+			})
+		}
+	})
 }
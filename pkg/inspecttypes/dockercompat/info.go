@@ -59,10 +59,34 @@ type Info struct {
 	Name            string
 	ServerVersion   string
 	SecurityOptions []string
+	Runtimes        map[string]Runtime `json:",omitempty"`
+	DefaultRuntime  string
+	// NerdctlRegistryConfig is not Docker's RegistryConfig (IndexConfigs, InsecureRegistryCIDRs, Mirrors)
+	NerdctlRegistryConfig *NerdctlRegistryConfig `json:",omitempty"` // nerdctl extension
+	Rootless              bool                   // nerdctl extension
+	CNI                   *CNIInfo               `json:",omitempty"` // nerdctl extension
 
 	Warnings []string
 }
 
+// Runtime describes an OCI runtime (containerd shim) available on the host.
+type Runtime struct {
+	Path string `json:"path,omitempty"`
+}
+
+// NerdctlRegistryConfig describes the registry settings of nerdctl (not of containerd).
+type NerdctlRegistryConfig struct {
+	InsecureRegistry bool
+	HostsDir         []string
+}
+
+// CNIInfo describes the CNI plugins used by nerdctl.
+type CNIInfo struct {
+	Path    string
+	Version string   `json:",omitempty"`
+	Plugins []string `json:",omitempty"`
+}
+
 type PluginsInfo struct {
 	Log     []string
 	Storage []string // nerdctl extension
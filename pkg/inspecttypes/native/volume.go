@@ -19,6 +19,7 @@ package native
 // Volume is also compatible with Docker
 type Volume struct {
 	Name       string             `json:"Name"`
+	Driver     string             `json:"Driver,omitempty"` // only set for the volumes of volume plugins
 	Mountpoint string             `json:"Mountpoint"`
 	Labels     *map[string]string `json:"Labels,omitempty"`
 	Size       int64              `json:"Size,omitempty"`
@@ -32,6 +32,7 @@ import (
 	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/containerd/v2/core/containers"
 	"github.com/containerd/containerd/v2/pkg/oci"
+	"github.com/containerd/log"
 
 	"github.com/containerd/nerdctl/v2/pkg/idutil/containerwalker"
 	"github.com/containerd/nerdctl/v2/pkg/labels"
@@ -143,11 +144,14 @@ func GenerateIPCOpts(ctx context.Context, ipc IPC, client *containerd.Client) ([
 		if len(ipc.ShmSize) > 0 {
 			shmBytes, err := units.RAMInBytes(ipc.ShmSize)
 			if err != nil {
-				return nil, err
+				return nil, fmt.Errorf("failed to parse shm-size %q: %w", ipc.ShmSize, err)
 			}
 			opts = append(opts, oci.WithDevShmSize(shmBytes/1024))
 		}
 	case Host:
+		if len(ipc.ShmSize) > 0 {
+			log.G(ctx).Warnf("ignoring shm-size %q, as the host /dev/shm is used with ipc mode %q", ipc.ShmSize, ipc.Mode)
+		}
 		opts = append(opts, withBindMountHostIPC)
 		if runtime.GOOS != "windows" {
 			opts = append(opts, oci.WithHostNamespace(specs.IPCNamespace))
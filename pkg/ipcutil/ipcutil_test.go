@@ -0,0 +1,101 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ipcutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/containerd/v2/pkg/oci"
+)
+
+func newShmSpec() *oci.Spec {
+	return &oci.Spec{
+		Mounts: []specs.Mount{
+			{
+				Destination: "/dev/shm",
+				Type:        "tmpfs",
+				Source:      "shm",
+				Options:     []string{"nosuid", "noexec", "nodev", "mode=1777", "size=65536k"},
+			},
+		},
+	}
+}
+
+func shmOptions(t *testing.T, s *oci.Spec) []string {
+	for _, m := range s.Mounts {
+		if m.Destination == "/dev/shm" {
+			return m.Options
+		}
+	}
+	t.Fatal("no /dev/shm mount found")
+	return nil
+}
+
+func TestGenerateIPCOptsShmSize(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		ipc      IPC
+		expected []string
+	}{
+		{
+			name:     "private default",
+			ipc:      IPC{Mode: Private},
+			expected: []string{"nosuid", "noexec", "nodev", "mode=1777", "size=65536k"},
+		},
+		{
+			name:     "private 256m",
+			ipc:      IPC{Mode: Private, ShmSize: "256m"},
+			expected: []string{"nosuid", "noexec", "nodev", "mode=1777", "size=262144k"},
+		},
+		{
+			name:     "private 1g",
+			ipc:      IPC{Mode: Private, ShmSize: "1g"},
+			expected: []string{"nosuid", "noexec", "nodev", "mode=1777", "size=1048576k"},
+		},
+		{
+			name:     "host ignores shm-size",
+			ipc:      IPC{Mode: Host, ShmSize: "256m"},
+			expected: []string{"rbind", "nosuid", "noexec", "nodev"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			opts, err := GenerateIPCOpts(ctx, tc.ipc, nil)
+			assert.NilError(t, err)
+			s := newShmSpec()
+			s.Linux = &specs.Linux{}
+			for _, opt := range opts {
+				assert.NilError(t, opt(ctx, nil, nil, s))
+			}
+			assert.DeepEqual(t, shmOptions(t, s), tc.expected)
+		})
+	}
+}
+
+func TestGenerateIPCOptsInvalidShmSize(t *testing.T) {
+	t.Parallel()
+	_, err := GenerateIPCOpts(context.Background(), IPC{Mode: Private, ShmSize: "foo"}, nil)
+	assert.ErrorContains(t, err, "failed to parse shm-size")
+}
@@ -50,6 +50,10 @@ const (
 	// Domainname
 	Domainname = Prefix + "domainname"
 
+	// NetworkAliases is a JSON-marshalled string of []string, e.g. []string{"web"}.
+	// The aliases are added to the hosts file of the other containers on the same networks.
+	NetworkAliases = Prefix + "network-aliases"
+
 	// ExtraHosts are HostIPs to appended to /etc/hosts
 	ExtraHosts = Prefix + "extraHosts"
 
@@ -70,6 +74,9 @@ const (
 	// IP6Address is the static IP6 address of the container assigned by the user
 	IP6Address = Prefix + "ip6"
 
+	// LinkLocalIPs is a JSON-marshalled string of []string, the link-local addresses of the container assigned by the user
+	LinkLocalIPs = Prefix + "link-local-ips"
+
 	// LogURI is the log URI
 	LogURI = Prefix + "log-uri"
 
@@ -86,6 +93,9 @@ const (
 	// Mounts is the mount points for the container.
 	Mounts = Prefix + "mounts"
 
+	// PluginVolumesMounted is "true" while the volume plugin volumes of the container are mounted for its task.
+	PluginVolumesMounted = Prefix + "plugin-volumes-mounted"
+
 	// StopTimeout is seconds to wait for stop a container.
 	StopTimeout = Prefix + "stop-timeout"
 
@@ -19,6 +19,7 @@ package logging
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -194,44 +195,128 @@ func FetchLogs(stdout, stderr io.Writer, journalctlArgs []string, stopChannel ch
 }
 
 // Formats command line arguments for `journalctl` with the provided log viewing options and
-// exec's and redirects `journalctl`s outputs to the provided io.Writers.
+// exec's `journalctl`, decoding its JSON output back into the container's stdout and stderr streams.
 func viewLogsJournald(lvopts LogViewOptions, stdout, stderr io.Writer, stopChannel chan os.Signal) error {
 	if !checkExecutableAvailableInPath("journalctl") {
 		return fmt.Errorf("`journalctl` executable could not be found in PATH, cannot use Journald to view logs")
 	}
-	shortID := lvopts.ContainerID[:12]
-	var journalctlArgs = []string{fmt.Sprintf("SYSLOG_IDENTIFIER=%s", shortID), "--output=cat"}
+	journalctlArgs, err := journalctlArgs(lvopts, time.Now())
+	if err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	decodeErr := make(chan error, 1)
+	go func() {
+		err := decodeJournalEntries(pr, stdout, stderr, lvopts.Timestamps)
+		// Unblock journalctl if decoding stopped early.
+		pr.CloseWithError(err)
+		decodeErr <- err
+	}()
+	err = FetchLogs(pw, stderr, journalctlArgs, stopChannel)
+	pw.Close()
+	if dErr := <-decodeErr; err == nil {
+		err = dErr
+	}
+	return err
+}
+
+// journalctlArgs returns the `journalctl` arguments to read back the logs of a container.
+// Entries are matched on CONTAINER_ID_FULL rather than SYSLOG_IDENTIFIER, which can be
+// overridden with `--log-opt tag`.
+func journalctlArgs(lvopts LogViewOptions, now time.Time) ([]string, error) {
+	args := []string{fmt.Sprintf("CONTAINER_ID_FULL=%s", lvopts.ContainerID), "--output=json", "--all"}
 	if lvopts.Follow {
-		journalctlArgs = append(journalctlArgs, "-f")
+		args = append(args, "-f")
+	}
+	if lvopts.Tail > 0 {
+		args = append(args, "-n", strconv.FormatUint(uint64(lvopts.Tail), 10))
 	}
 	if lvopts.Since != "" {
 		// using GetTimestamp from moby to keep time format consistency
-		ts, err := timetypes.GetTimestamp(lvopts.Since, time.Now())
+		ts, err := timetypes.GetTimestamp(lvopts.Since, now)
 		if err != nil {
-			return fmt.Errorf("invalid value for \"since\": %w", err)
+			return nil, fmt.Errorf("invalid value for \"since\": %w", err)
 		}
 		date, err := prepareJournalCtlDate(ts)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		journalctlArgs = append(journalctlArgs, "--since", date)
-	}
-	if lvopts.Timestamps {
-		log.L.Warnf("unsupported Timestamps option for journald driver")
+		args = append(args, "--since", date)
 	}
 	if lvopts.Until != "" {
 		// using GetTimestamp from moby to keep time format consistency
-		ts, err := timetypes.GetTimestamp(lvopts.Until, time.Now())
+		ts, err := timetypes.GetTimestamp(lvopts.Until, now)
 		if err != nil {
-			return fmt.Errorf("invalid value for \"until\": %w", err)
+			return nil, fmt.Errorf("invalid value for \"until\": %w", err)
 		}
 		date, err := prepareJournalCtlDate(ts)
 		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--until", date)
+	}
+	return args, nil
+}
+
+// journalEntry is the subset of the fields of a `journalctl --output=json` entry used to
+// reconstruct the container's log stream.
+type journalEntry struct {
+	// MESSAGE is a JSON string, or an array of bytes when it is not valid UTF-8.
+	Message           json.RawMessage `json:"MESSAGE"`
+	Priority          string          `json:"PRIORITY"`
+	RealtimeTimestamp string          `json:"__REALTIME_TIMESTAMP"`
+}
+
+// decodeJournalEntries reads the `journalctl --output=json` entries from r, and writes their
+// messages to stderr for entries logged with the error priority, and to stdout otherwise.
+func decodeJournalEntries(r io.Reader, stdout, stderr io.Writer, timestamps bool) error {
+	dec := json.NewDecoder(r)
+	for {
+		var e journalEntry
+		if err := dec.Decode(&e); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to decode journal entry: %w", err)
+		}
+		var msg []byte
+		var str string
+		if err := json.Unmarshal(e.Message, &str); err == nil {
+			msg = []byte(str)
+		} else {
+			var raw []int
+			if err := json.Unmarshal(e.Message, &raw); err != nil {
+				return fmt.Errorf("failed to decode journal message %q: %w", e.Message, err)
+			}
+			msg = make([]byte, len(raw))
+			for i, b := range raw {
+				msg[i] = byte(b)
+			}
+		}
+
+		var output []byte
+		if timestamps {
+			usec, err := strconv.ParseInt(e.RealtimeTimestamp, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid journal timestamp %q: %w", e.RealtimeTimestamp, err)
+			}
+			output = append(output, time.UnixMicro(usec).UTC().Format(time.RFC3339Nano)...)
+			output = append(output, ' ')
+		}
+		output = append(output, msg...)
+		if !bytes.HasSuffix(msg, []byte("\n")) {
+			output = append(output, '\n')
+		}
+
+		writeTo := stdout
+		if e.Priority == strconv.Itoa(int(journal.PriErr)) {
+			writeTo = stderr
+		}
+		if _, err := writeTo.Write(output); err != nil {
 			return err
 		}
-		journalctlArgs = append(journalctlArgs, "--until", date)
 	}
-	return FetchLogs(stdout, stderr, journalctlArgs, stopChannel)
 }
 
 func prepareJournalCtlDate(t string) (string, error) {
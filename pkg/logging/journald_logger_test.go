@@ -0,0 +1,74 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestJournalctlArgs(t *testing.T) {
+	const id = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	now := time.Unix(1700000000, 0)
+
+	args, err := journalctlArgs(LogViewOptions{ContainerID: id}, now)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, args, []string{"CONTAINER_ID_FULL=" + id, "--output=json", "--all"})
+
+	args, err = journalctlArgs(LogViewOptions{
+		ContainerID: id,
+		Follow:      true,
+		Tail:        5,
+		Since:       "1699999000",
+		Until:       "1699999900",
+	}, now)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, args, []string{
+		"CONTAINER_ID_FULL=" + id, "--output=json", "--all",
+		"-f",
+		"-n", "5",
+		"--since", time.Unix(1699999000, 0).Format("2006-01-02 15:04:05"),
+		"--until", time.Unix(1699999900, 0).Format("2006-01-02 15:04:05"),
+	})
+
+	_, err = journalctlArgs(LogViewOptions{ContainerID: id, Since: "invalid"}, now)
+	assert.ErrorContains(t, err, `invalid value for "since"`)
+}
+
+func TestDecodeJournalEntries(t *testing.T) {
+	const entries = `{"MESSAGE":"foo","PRIORITY":"6","__REALTIME_TIMESTAMP":"1700000000000001"}
+{"MESSAGE":"+ echo bar\n","PRIORITY":"3","__REALTIME_TIMESTAMP":"1700000000000002"}
+{"MESSAGE":[98,97,114,255],"PRIORITY":"6","__REALTIME_TIMESTAMP":"1700000000000003"}
+`
+	var stdout, stderr bytes.Buffer
+	assert.NilError(t, decodeJournalEntries(strings.NewReader(entries), &stdout, &stderr, false))
+	assert.Equal(t, stdout.String(), "foo\nbar\xff\n")
+	assert.Equal(t, stderr.String(), "+ echo bar\n")
+
+	stdout.Reset()
+	stderr.Reset()
+	assert.NilError(t, decodeJournalEntries(strings.NewReader(entries), &stdout, &stderr, true))
+	assert.Equal(t, stdout.String(), "2023-11-14T22:13:20.000001Z foo\n2023-11-14T22:13:20.000003Z bar\xff\n")
+	assert.Equal(t, stderr.String(), "2023-11-14T22:13:20.000002Z + echo bar\n")
+
+	err := decodeJournalEntries(strings.NewReader(`{"MESSAGE":{}}`), &stdout, &stderr, false)
+	assert.ErrorContains(t, err, "failed to decode journal message")
+}
@@ -48,6 +48,7 @@ type Processed struct {
 	Mount           specs.Mount
 	Name            string // name
 	AnonymousVolume string // anonymous volume name
+	Driver          string // volume plugin providing the volume, empty for local volumes
 	Mode            string
 	Opts            []oci.SpecOpts
 }
@@ -57,6 +58,7 @@ type volumeSpec struct {
 	Name            string
 	Source          string
 	AnonymousVolume string
+	Driver          string
 }
 
 func ProcessFlagV(s string, volStore volumestore.VolumeStore, createDir bool) (*Processed, error) {
@@ -90,6 +92,7 @@ func ProcessFlagV(s string, volStore volumestore.VolumeStore, createDir bool) (*
 		res = &Processed{
 			Type:            volSpec.Type,
 			AnonymousVolume: volSpec.AnonymousVolume,
+			Driver:          volSpec.Driver,
 		}
 	case 2, 3:
 		// Vaildate destination
@@ -111,6 +114,7 @@ func ProcessFlagV(s string, volStore volumestore.VolumeStore, createDir bool) (*
 			Type:            volSpec.Type,
 			Name:            volSpec.Name,
 			AnonymousVolume: volSpec.AnonymousVolume,
+			Driver:          volSpec.Driver,
 		}
 
 		// Parse volume options
@@ -196,6 +200,7 @@ func handleAnonymousVolumes(s string, volStore volumestore.VolumeStore) (volumeS
 
 	res.Type = Volume
 	res.Source = anonVol.Mountpoint
+	res.Driver = anonVol.Driver
 	return res, nil
 }
 
@@ -211,6 +216,7 @@ func handleNamedVolumes(source string, volStore volumestore.VolumeStore) (volume
 	// src is now an absolute path
 	res.Type = Volume
 	res.Source = vol.Mountpoint
+	res.Driver = vol.Driver
 
 	return res, nil
 }
@@ -18,6 +18,7 @@ package mountutil
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
@@ -32,6 +33,7 @@ import (
 
 	"github.com/containerd/containerd/v2/core/containers"
 	"github.com/containerd/containerd/v2/core/mount"
+	"github.com/containerd/containerd/v2/pkg/kernelversion"
 	"github.com/containerd/containerd/v2/pkg/oci"
 	"github.com/containerd/log"
 
@@ -55,6 +57,18 @@ const (
 	DefaultPropagationMode = "rprivate"
 )
 
+// recursiveReadonlySupported reports whether the kernel supports recursive read-only
+// mounts (mount_setattr(2) with AT_RECURSIVE, Linux >= 5.12).
+// It is a variable so that tests can override it.
+var recursiveReadonlySupported = func() bool {
+	ok, err := kernelversion.GreaterEqualThan(kernelversion.KernelVersion{Kernel: 5, Major: 12})
+	if err != nil {
+		log.L.WithError(err).Debug("failed to detect the kernel version")
+		return false
+	}
+	return ok
+}
+
 // UnprivilegedMountFlags is from https://github.com/moby/moby/blob/v20.10.5/daemon/oci_linux.go#L420-L450
 //
 // Get the set of mount flags that are set on the mount that contains the given
@@ -121,6 +135,8 @@ func parseVolumeOptionsWithMountInfo(vType, src, optsRaw string, getMountInfoFun
 		case "bind", "rbind":
 			// bind means not recursively bind-mounted, rbind is the opposite
 			bindOpts = append(bindOpts, opt)
+		case "consistent", "cached", "delegated":
+			log.L.Debugf("Ignoring volume option %q: not applicable on Linux", opt)
 		case "":
 			// NOP
 		default:
@@ -266,17 +282,33 @@ func ensureMountOptionalValue(mi mount.Info, vals ...string) error {
 	return nil
 }
 
+// defaultTmpfsOptions are applied to every tmpfs mount, like Docker.
+// User-specified options take precedence over them.
+var defaultTmpfsOptions = []string{"rw", "noexec", "nosuid", "nodev"}
+
 func ProcessFlagTmpfs(s string) (*Processed, error) {
 	split := strings.SplitN(s, ":", 2)
-	dst := split[0]
-	options := []string{"noexec", "nosuid", "nodev"}
-	if len(split) == 2 {
-		raw := append(options, strings.Split(split[1], ",")...)
-		var err error
-		options, err = mobymount.MergeTmpfsOptions(raw)
-		if err != nil {
-			return nil, err
-		}
+	var opts []string
+	if len(split) == 2 && split[1] != "" {
+		opts = strings.Split(split[1], ",")
+	}
+	return processTmpfs(split[0], opts)
+}
+
+// processTmpfs builds the tmpfs mount for both `--tmpfs` and `--mount type=tmpfs`,
+// so that equivalent inputs produce identical mounts.
+func processTmpfs(dst string, opts []string) (*Processed, error) {
+	if !filepath.IsAbs(dst) {
+		return nil, fmt.Errorf("invalid tmpfs destination %q: must be an absolute path", dst)
+	}
+	raw := append(append([]string{}, defaultTmpfsOptions...), opts...)
+	options, err := mobymount.MergeTmpfsOptions(raw)
+	if err != nil {
+		return nil, err
+	}
+	options, err = normalizeTmpfsOptions(options)
+	if err != nil {
+		return nil, err
 	}
 	res := &Processed{
 		Mount: specs.Mount{
@@ -291,6 +323,78 @@ func ProcessFlagTmpfs(s string) (*Processed, error) {
 	return res, nil
 }
 
+// normalizeTmpfsOptions checks the values of the size= and mode= tmpfs options,
+// and rewrites them in the form understood by the kernel, e.g., "size=64MB" into "size=64m".
+func normalizeTmpfsOptions(options []string) ([]string, error) {
+	res := make([]string, 0, len(options))
+	for _, opt := range options {
+		k, v, ok := strings.Cut(opt, "=")
+		if !ok {
+			res = append(res, opt)
+			continue
+		}
+		switch k {
+		case "size":
+			if pct, isPct := strings.CutSuffix(v, "%"); isPct {
+				if n, err := strconv.Atoi(pct); err != nil || n <= 0 || n > 100 {
+					return nil, fmt.Errorf("invalid tmpfs size %q", v)
+				}
+				break
+			}
+			n, err := units.RAMInBytes(v)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid tmpfs size %q", v)
+			}
+			opt = getTmpfsSize(n)
+		case "mode":
+			n, err := strconv.ParseUint(v, 8, 32)
+			if err != nil || n > 07777 {
+				return nil, fmt.Errorf("invalid tmpfs mode %q", v)
+			}
+			opt = fmt.Sprintf("mode=%o", n)
+		}
+		res = append(res, opt)
+	}
+	return res, nil
+}
+
+// isConsistencyValue reports whether v is a valid value for the consistency mount option.
+func isConsistencyValue(v string) bool {
+	switch v {
+	case "default", "consistent", "cached", "delegated":
+		return true
+	}
+	return false
+}
+
+// expandMountSource expands a leading `~` to the home directory of the invoking user,
+// and `$VAR` or `${VAR}` to the value of the environment variable VAR.
+// Unset variables are an error rather than an empty string, and `$$` stands for a literal `$`.
+func expandMountSource(src string) (string, error) {
+	if src == "~" || strings.HasPrefix(src, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to expand %q in mount source: %w", "~", err)
+		}
+		src = home + src[1:]
+	}
+	var unset []string
+	src = os.Expand(src, func(name string) string {
+		if name == "$" {
+			return "$"
+		}
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			unset = append(unset, name)
+		}
+		return v
+	})
+	if len(unset) > 0 {
+		return "", fmt.Errorf("environment variable %q used in mount source is not set", unset[0])
+	}
+	return src, nil
+}
+
 func ProcessFlagMount(s string, volStore volumestore.VolumeStore) (*Processed, error) {
 	fields := strings.Split(s, ",")
 	var (
@@ -299,15 +403,17 @@ func ProcessFlagMount(s string, volStore volumestore.VolumeStore) (*Processed, e
 		dst              string
 		bindPropagation  string
 		bindNonRecursive bool
+		bindRecursive    string
+		bindExpandSource bool
 		rwOption         string
 		tmpfsSize        int64
-		tmpfsMode        os.FileMode
+		tmpfsMode        *os.FileMode
+		volumeLabels     []string
 		err              error
 	)
 
 	// set default values
 	mountType = Volume
-	tmpfsMode = os.FileMode(01777)
 
 	// three types of mount(and examples):
 	// --mount type=bind,source="$(pwd)"/target,target=/app2,readonly,bind-propagation=shared
@@ -328,6 +434,9 @@ func ProcessFlagMount(s string, volStore volumestore.VolumeStore) (*Processed, e
 			case "bind-nonrecursive":
 				bindNonRecursive = true
 				continue
+			case "bind-expand-source":
+				bindExpandSource = true
+				continue
 			}
 		}
 
@@ -368,6 +477,18 @@ func ProcessFlagMount(s string, volStore volumestore.VolumeStore) (*Processed, e
 			if err != nil {
 				return nil, fmt.Errorf("invalid value for %s: %s", key, value)
 			}
+		case "bind-expand-source":
+			bindExpandSource, err = strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %s: %s", key, value)
+			}
+		case "bind-recursive":
+			switch value {
+			case "enabled", "disabled", "writable", "readonly":
+				bindRecursive = value
+			default:
+				return nil, fmt.Errorf("invalid value for %s: %s (must be enabled, disabled, writable or readonly)", key, value)
+			}
 		case "tmpfs-size":
 			tmpfsSize, err = units.RAMInBytes(value)
 			if err != nil {
@@ -378,14 +499,68 @@ func ProcessFlagMount(s string, volStore volumestore.VolumeStore) (*Processed, e
 			if err != nil {
 				return nil, fmt.Errorf("invalid value for %s: %s", key, value)
 			}
-			tmpfsMode = os.FileMode(ui64)
+			mode := os.FileMode(ui64)
+			tmpfsMode = &mode
+		case "volume-label":
+			volumeLabels = append(volumeLabels, value)
+		case "consistency":
+			// Docker Desktop for Mac uses consistency to tune file sharing; it has no effect on Linux.
+			if !isConsistencyValue(value) {
+				return nil, fmt.Errorf("invalid value for %s: %s", key, value)
+			}
+			log.L.Debugf("Ignoring mount option %q: not applicable on Linux", field)
 		default:
 			return nil, fmt.Errorf("unexpected key '%s' in '%s'", key, field)
 		}
 	}
 
+	if bindExpandSource {
+		if mountType != Bind {
+			return nil, fmt.Errorf("bind-expand-source is only supported for bind mounts, got %q", s)
+		}
+		if src, err = expandMountSource(src); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(volumeLabels) > 0 {
+		if mountType != Volume || !isNamedVolume(src) {
+			return nil, fmt.Errorf("volume-label is only supported for named volumes, got %q", s)
+		}
+		// Missing named volumes are created on first use, with the requested labels.
+		// Existing volumes are reused as-is.
+		exists, err := volStore.Exists(src)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			if _, err := volStore.CreateWithoutLock(src, volumeLabels); err != nil {
+				return nil, fmt.Errorf("failed to create volume %q: %w", src, err)
+			}
+		}
+	}
+
+	if bindRecursive != "" {
+		if mountType != Bind {
+			return nil, fmt.Errorf("bind-recursive is only supported for bind mounts, got %q", s)
+		}
+		if bindNonRecursive {
+			return nil, fmt.Errorf("bind-recursive and bind-nonrecursive cannot be used together, got %q", s)
+		}
+	}
+	if mountType == Bind {
+		if bindRecursive == "disabled" {
+			bindNonRecursive = true
+		}
+		if !bindNonRecursive {
+			if rwOption, bindPropagation, err = resolveBindRecursive(bindRecursive, rwOption, bindPropagation); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// compose new fileds and join into a string
-	// to call legacy ProcessFlagTmpfs or ProcessFlagV function
+	// to call legacy ProcessFlagV function
 	fields = []string{}
 	options := []string{}
 	if rwOption != "" {
@@ -397,13 +572,13 @@ func ProcessFlagMount(s string, volStore volumestore.VolumeStore) (*Processed, e
 
 	switch mountType {
 	case Tmpfs:
-		fields = []string{dst}
-		if tmpfsMode != 0 {
-			options = append(options, fmt.Sprintf("mode=%o", tmpfsMode))
+		if tmpfsMode != nil {
+			options = append(options, fmt.Sprintf("mode=%o", *tmpfsMode))
 		}
 		if tmpfsSize > 0 {
 			options = append(options, getTmpfsSize(tmpfsSize))
 		}
+		return processTmpfs(dst, options)
 	case Volume, Bind:
 		fields = []string{src, dst}
 		if bindPropagation != "" {
@@ -427,8 +602,6 @@ func ProcessFlagMount(s string, volStore volumestore.VolumeStore) (*Processed, e
 	log.L.Debugf("Call legacy %s process, spec: %s ", mountType, fieldsStr)
 
 	switch mountType {
-	case Tmpfs:
-		return ProcessFlagTmpfs(fieldsStr)
 	case Volume, Bind:
 		// createDir=false for --mount option to disallow creating directories on host if not found
 		return ProcessFlagV(fieldsStr, volStore, false)
@@ -436,6 +609,42 @@ func ProcessFlagMount(s string, volStore volumestore.VolumeStore) (*Processed, e
 	return nil, fmt.Errorf("invalid mount type '%s' must be a volume/bind/tmpfs", mountType)
 }
 
+// resolveBindRecursive returns the read/write and propagation options of a recursive
+// bind mount for the given bind-recursive mode, following Docker:
+//   - enabled (default): a read-only mount is made recursively read-only when the kernel
+//     supports it, and falls back to a non-recursive read-only mount otherwise.
+//   - writable: submounts of a read-only mount are left writable.
+//   - readonly: a read-only mount is made recursively read-only, or fails.
+//
+// Recursive read-only mounts require the "rprivate" propagation.
+func resolveBindRecursive(bindRecursive, rwOption, bindPropagation string) (string, string, error) {
+	readonly := rwOption == "readonly" || rwOption == "ro" || rwOption == "rro"
+	rprivate := bindPropagation == "" || bindPropagation == "rprivate"
+	switch bindRecursive {
+	case "readonly":
+		if !readonly {
+			return "", "", errors.New("bind-recursive=readonly requires the mount to be readonly")
+		}
+		if !rprivate {
+			return "", "", fmt.Errorf("bind-recursive=readonly requires bind-propagation=rprivate, got %q", bindPropagation)
+		}
+		if !recursiveReadonlySupported() {
+			return "", "", errors.New("bind-recursive=readonly requires kernel >= 5.12")
+		}
+		return "rro", "rprivate", nil
+	case "", "enabled":
+		if !readonly || rwOption == "rro" || !rprivate {
+			return rwOption, bindPropagation, nil
+		}
+		if !recursiveReadonlySupported() {
+			log.L.Debug("Recursive read-only mounts are not supported by the kernel, falling back to a non-recursive read-only mount")
+			return rwOption, bindPropagation, nil
+		}
+		return "rro", "rprivate", nil
+	}
+	return rwOption, bindPropagation, nil
+}
+
 // copy from https://github.com/moby/moby/blob/085c6a98d54720e70b28354ccec6da9b1b9e7fcf/volume/mounts/linux_parser.go#L375
 func getTmpfsSize(size int64) string {
 	// calculate suffix here, making this linux specific, but that is
@@ -18,6 +18,8 @@ package mountutil
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -27,6 +29,8 @@ import (
 
 	"github.com/containerd/containerd/v2/core/mount"
 	"github.com/containerd/containerd/v2/pkg/oci"
+
+	"github.com/containerd/nerdctl/v2/pkg/mountutil/volumestore"
 )
 
 // TestParseVolumeOptions tests volume options are parsed as expected.
@@ -50,6 +54,14 @@ func TestParseVolumeOptions(t *testing.T) {
 			wants:   []string{"ro"},
 		},
 
+		{
+			name:    "consistency option is ignored",
+			vType:   "bind",
+			src:     "dummy",
+			optsRaw: "ro,cached",
+			wants:   []string{"ro", "rprivate"},
+		},
+
 		// tests for rw/ro flags
 		{
 			name:    "read write",
@@ -198,13 +210,89 @@ func TestParseVolumeOptions(t *testing.T) {
 
 func TestProcessTmpfs(t *testing.T) {
 	testCases := map[string][]string{
-		"/tmp":               {"noexec", "nosuid", "nodev"},
-		"/tmp:size=64m,exec": {"nosuid", "nodev", "size=64m", "exec"},
+		"/tmp":                          {"rw", "noexec", "nosuid", "nodev"},
+		"/tmp:":                         {"rw", "noexec", "nosuid", "nodev"},
+		"/tmp:size=64m,exec":            {"rw", "nosuid", "nodev", "size=64m", "exec"},
+		"/run:rw,size=64m,mode=1777":    {"noexec", "nosuid", "nodev", "rw", "size=64m", "mode=1777"},
+		"/run:ro,size=50%":              {"noexec", "nosuid", "nodev", "ro", "size=50%"},
+		"/run:size=1m,size=2m,suid":     {"rw", "noexec", "nodev", "size=2m", "suid"},
+		"/run:defaults,noexec,mode=700": {"rw", "nosuid", "nodev", "noexec", "mode=700"},
 	}
 	for k, expected := range testCases {
 		x, err := ProcessFlagTmpfs(k)
 		assert.NilError(t, err)
 		assert.DeepEqual(t, expected, x.Mount.Options)
+		assert.Equal(t, strings.Join(expected, ","), x.Mode)
+	}
+}
+
+func TestProcessTmpfsInvalid(t *testing.T) {
+	testCases := map[string]string{
+		"tmp":              "must be an absolute path",
+		":size=1m":         "must be an absolute path",
+		"/tmp:size=abc":    "invalid tmpfs size",
+		"/tmp:size=0":      "invalid tmpfs size",
+		"/tmp:size=150%":   "invalid tmpfs size",
+		"/tmp:mode=999":    "invalid tmpfs mode",
+		"/tmp:mode=17777":  "invalid tmpfs mode",
+		"/tmp:unknown=opt": "invalid tmpfs option",
+		"/tmp:notanoption": "invalid tmpfs option",
+	}
+	for k, expected := range testCases {
+		_, err := ProcessFlagTmpfs(k)
+		assert.ErrorContains(t, err, expected, k)
+	}
+}
+
+func TestProcessTmpfsMountParity(t *testing.T) {
+	testCases := []struct {
+		tmpfs    string
+		mount    string
+		expected []string
+	}{
+		{
+			tmpfs:    "/tmp",
+			mount:    "type=tmpfs,target=/tmp",
+			expected: []string{"rw", "noexec", "nosuid", "nodev"},
+		},
+		{
+			tmpfs:    "/tmp:ro",
+			mount:    "type=tmpfs,target=/tmp,readonly",
+			expected: []string{"noexec", "nosuid", "nodev", "ro"},
+		},
+		{
+			tmpfs:    "/tmp:size=64m",
+			mount:    "type=tmpfs,target=/tmp,tmpfs-size=64m",
+			expected: []string{"rw", "noexec", "nosuid", "nodev", "size=64m"},
+		},
+		{
+			tmpfs:    "/tmp:size=64MB",
+			mount:    "type=tmpfs,target=/tmp,tmpfs-size=64MB",
+			expected: []string{"rw", "noexec", "nosuid", "nodev", "size=64m"},
+		},
+		{
+			tmpfs:    "/tmp:mode=0770",
+			mount:    "type=tmpfs,target=/tmp,tmpfs-mode=0770",
+			expected: []string{"rw", "noexec", "nosuid", "nodev", "mode=770"},
+		},
+		{
+			tmpfs:    "/tmp:mode=0",
+			mount:    "type=tmpfs,target=/tmp,tmpfs-mode=0",
+			expected: []string{"rw", "noexec", "nosuid", "nodev", "mode=0"},
+		},
+		{
+			tmpfs:    "/tmp:ro,mode=1770,size=1k",
+			mount:    "type=tmpfs,target=/tmp,ro,tmpfs-mode=1770,tmpfs-size=1024",
+			expected: []string{"noexec", "nosuid", "nodev", "ro", "mode=1770", "size=1k"},
+		},
+	}
+	for _, tc := range testCases {
+		fromTmpfs, err := ProcessFlagTmpfs(tc.tmpfs)
+		assert.NilError(t, err, tc.tmpfs)
+		fromMount, err := ProcessFlagMount(tc.mount, nil)
+		assert.NilError(t, err, tc.mount)
+		assert.DeepEqual(t, tc.expected, fromTmpfs.Mount.Options)
+		assert.DeepEqual(t, fromTmpfs, fromMount)
 	}
 }
 
@@ -348,3 +436,147 @@ func TestProcessFlagVAnonymousVolumes(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessFlagMountConsistency(t *testing.T) {
+	src := t.TempDir()
+	for _, consistency := range []string{"default", "consistent", "cached", "delegated"} {
+		x, err := ProcessFlagMount("type=bind,source="+src+",target=/mnt/foo,consistency="+consistency, mockVolumeStore)
+		assert.NilError(t, err)
+		assert.Equal(t, x.Type, Bind)
+		assert.Equal(t, x.Mount.Source, src)
+		assert.Equal(t, x.Mount.Destination, "/mnt/foo")
+		assert.DeepEqual(t, x.Mount.Options, []string{"rbind", "rprivate"})
+	}
+
+	_, err := ProcessFlagMount("type=bind,source="+src+",target=/mnt/foo,consistency=bogus", mockVolumeStore)
+	assert.ErrorContains(t, err, "invalid value for consistency")
+}
+
+func TestProcessFlagMountBindRecursive(t *testing.T) {
+	src := t.TempDir()
+	orig := recursiveReadonlySupported
+	t.Cleanup(func() {
+		recursiveReadonlySupported = orig
+	})
+
+	tests := []struct {
+		mount     string
+		supported bool
+		wants     []string
+		err       string
+	}{
+		{mount: "rw", supported: true, wants: []string{"rbind", "rprivate"}},
+		{mount: "ro", supported: true, wants: []string{"rbind", "ro", "rro", "rprivate"}},
+		{mount: "readonly", supported: true, wants: []string{"rbind", "ro", "rro", "rprivate"}},
+		{mount: "readonly=true", supported: true, wants: []string{"rbind", "ro", "rro", "rprivate"}},
+		{mount: "ro", supported: false, wants: []string{"rbind", "ro", "rprivate"}},
+		{mount: "ro,bind-propagation=private", supported: true, wants: []string{"rbind", "ro", "private"}},
+		{mount: "ro,bind-nonrecursive", supported: true, wants: []string{"bind", "ro", "rprivate"}},
+		{mount: "ro,bind-recursive=enabled", supported: false, wants: []string{"rbind", "ro", "rprivate"}},
+		{mount: "ro,bind-recursive=writable", supported: true, wants: []string{"rbind", "ro", "rprivate"}},
+		{mount: "ro,bind-recursive=disabled", supported: true, wants: []string{"bind", "ro", "rprivate"}},
+		{mount: "ro,bind-recursive=readonly", supported: true, wants: []string{"rbind", "ro", "rro", "rprivate"}},
+		{mount: "ro,bind-recursive=readonly", supported: false, err: "bind-recursive=readonly requires kernel >= 5.12"},
+		{mount: "bind-recursive=readonly", supported: true, err: "bind-recursive=readonly requires the mount to be readonly"},
+		{mount: "ro,bind-recursive=readonly,bind-propagation=rshared", supported: true, err: "bind-recursive=readonly requires bind-propagation=rprivate"},
+		{mount: "ro,bind-recursive=readonly,bind-nonrecursive", supported: true, err: "bind-recursive and bind-nonrecursive cannot be used together"},
+		{mount: "bind-recursive=bogus", supported: true, err: "invalid value for bind-recursive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mount, func(t *testing.T) {
+			recursiveReadonlySupported = func() bool {
+				return tt.supported
+			}
+			x, err := ProcessFlagMount("type=bind,source="+src+",target=/mnt/foo,"+tt.mount, mockVolumeStore)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, x.Mount.Options, tt.wants)
+		})
+	}
+
+	_, err := ProcessFlagMount("type=volume,source=foo,target=/mnt/foo,ro,bind-recursive=readonly", mockVolumeStore)
+	assert.ErrorContains(t, err, "bind-recursive is only supported for bind mounts")
+}
+
+func TestProcessFlagMountExpandSource(t *testing.T) {
+	home := t.TempDir()
+	assert.NilError(t, os.Mkdir(filepath.Join(home, "data"), 0o755))
+	t.Setenv("HOME", home)
+	t.Setenv("NERDCTL_TEST_DATA", "data")
+
+	for src, expected := range map[string]string{
+		"~":                    home,
+		"~/data":               filepath.Join(home, "data"),
+		"$HOME/data":           filepath.Join(home, "data"),
+		"${HOME}/data":         filepath.Join(home, "data"),
+		"~/$NERDCTL_TEST_DATA": filepath.Join(home, "data"),
+	} {
+		x, err := ProcessFlagMount("type=bind,source="+src+",target=/mnt/foo,bind-expand-source", mockVolumeStore)
+		assert.NilError(t, err, src)
+		assert.Equal(t, x.Type, Bind)
+		assert.Equal(t, x.Mount.Source, expected, src)
+	}
+
+	// `$$` is a literal `$`
+	literal := filepath.Join(home, "$data")
+	assert.NilError(t, os.Mkdir(literal, 0o755))
+	x, err := ProcessFlagMount("type=bind,source=~/$$data,target=/mnt/foo,bind-expand-source=true", mockVolumeStore)
+	assert.NilError(t, err)
+	assert.Equal(t, x.Mount.Source, literal)
+
+	// `~` is only expanded at the start of the source
+	x, err = ProcessFlagMount("type=bind,source="+home+"/~,target=/mnt/foo,bind-expand-source", mockVolumeStore)
+	assert.NilError(t, err)
+	assert.Equal(t, x.Mount.Source, filepath.Join(home, "~"))
+
+	// without bind-expand-source, the source is taken literally
+	x, err = ProcessFlagMount("type=bind,source="+literal+",target=/mnt/foo", mockVolumeStore)
+	assert.NilError(t, err)
+	assert.Equal(t, x.Mount.Source, literal)
+	x, err = ProcessFlagMount("type=bind,source="+literal+",target=/mnt/foo,bind-expand-source=false", mockVolumeStore)
+	assert.NilError(t, err)
+	assert.Equal(t, x.Mount.Source, literal)
+
+	_, err = ProcessFlagMount("type=bind,source=$NERDCTL_TEST_UNSET/data,target=/mnt/foo,bind-expand-source", mockVolumeStore)
+	assert.ErrorContains(t, err, `environment variable "NERDCTL_TEST_UNSET" used in mount source is not set`)
+
+	_, err = ProcessFlagMount("type=volume,source=$NERDCTL_TEST_DATA,target=/mnt/foo,bind-expand-source", mockVolumeStore)
+	assert.ErrorContains(t, err, "bind-expand-source is only supported for bind mounts")
+}
+
+func TestProcessFlagMountVolumeLabels(t *testing.T) {
+	volStore, err := volumestore.New(t.TempDir(), "test")
+	assert.NilError(t, err)
+	processFlagMount := func(s string) (*Processed, error) {
+		assert.NilError(t, volStore.Lock())
+		defer volStore.Release()
+		return ProcessFlagMount(s, volStore)
+	}
+
+	x, err := processFlagMount("type=volume,source=labeled,target=/mnt/foo,volume-label=foo=bar,volume-label=baz=qux")
+	assert.NilError(t, err)
+	assert.Equal(t, x.Type, Volume)
+	assert.Equal(t, x.Name, "labeled")
+	assert.Equal(t, x.AnonymousVolume, "")
+
+	vol, err := volStore.Get("labeled", false)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, *vol.Labels, map[string]string{"foo": "bar", "baz": "qux"})
+	assert.Equal(t, x.Mount.Source, vol.Mountpoint)
+
+	// An existing volume is reused without touching its labels
+	_, err = processFlagMount("type=volume,source=labeled,target=/mnt/foo,volume-label=foo=other")
+	assert.NilError(t, err)
+	vol, err = volStore.Get("labeled", false)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, *vol.Labels, map[string]string{"foo": "bar", "baz": "qux"})
+
+	_, err = processFlagMount("type=volume,target=/mnt/foo,volume-label=foo=bar")
+	assert.ErrorContains(t, err, "volume-label is only supported for named volumes")
+	_, err = processFlagMount("type=tmpfs,target=/mnt/foo,volume-label=foo=bar")
+	assert.ErrorContains(t, err, "volume-label is only supported for named volumes")
+}
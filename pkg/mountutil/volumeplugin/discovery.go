@@ -0,0 +1,174 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package volumeplugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
+)
+
+var (
+	// socketDirs are the directories where plugins listen on `<name>.sock` or `<name>/<name>.sock`
+	socketDirs = []string{"/run/docker/plugins"}
+	// specDirs are the directories where plugins are described by `<name>.spec` or `<name>.json`
+	specDirs = []string{"/etc/docker/plugins", "/usr/lib/docker/plugins"}
+)
+
+// Get finds the plugin called name and activates it.
+// It returns an error wrapping errdefs.ErrNotFound when there is no such plugin.
+func Get(ctx context.Context, name string) (*Plugin, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return nil, fmt.Errorf("invalid volume plugin name %q: %w", name, errdefs.ErrInvalidArgument)
+	}
+	addr, err := lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	p, err := New(name, addr)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Activate(ctx); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// List activates and returns all the volume plugins that can be discovered.
+// Plugins that cannot be activated are skipped with a warning.
+func List(ctx context.Context) ([]*Plugin, error) {
+	names, err := discover()
+	if err != nil {
+		return nil, err
+	}
+	var plugins []*Plugin
+	for _, name := range names {
+		p, err := Get(ctx, name)
+		if err != nil {
+			if !errors.Is(err, ErrNotVolumePlugin) {
+				log.G(ctx).WithError(err).Warnf("failed to activate plugin %q", name)
+			}
+			continue
+		}
+		plugins = append(plugins, p)
+	}
+	return plugins, nil
+}
+
+// lookup returns the address of the plugin called name
+func lookup(name string) (string, error) {
+	for _, dir := range socketDirs {
+		for _, socket := range []string{
+			filepath.Join(dir, name+".sock"),
+			filepath.Join(dir, name, name+".sock"),
+		} {
+			if st, err := os.Stat(socket); err == nil && st.Mode()&fs.ModeSocket != 0 {
+				return "unix://" + socket, nil
+			}
+		}
+	}
+	for _, dir := range specDirs {
+		for _, ext := range []string{".spec", ".json"} {
+			spec := filepath.Join(dir, name+ext)
+			b, err := os.ReadFile(spec)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return "", err
+			}
+			return parseSpec(spec, b)
+		}
+	}
+	return "", fmt.Errorf("volume plugin %q: %w", name, errdefs.ErrNotFound)
+}
+
+// parseSpec parses either a `.spec` file containing only the URL of a plugin,
+// or a `.json` file containing an object with an `Addr` field.
+func parseSpec(path string, b []byte) (string, error) {
+	var addr string
+	if filepath.Ext(path) == ".json" {
+		var spec struct {
+			Addr string
+		}
+		if err := json.Unmarshal(b, &spec); err != nil {
+			return "", fmt.Errorf("failed to parse plugin spec %q: %w", path, err)
+		}
+		addr = spec.Addr
+	} else {
+		addr = strings.TrimSpace(string(b))
+	}
+	if addr == "" {
+		return "", fmt.Errorf("plugin spec %q has no address", path)
+	}
+	return addr, nil
+}
+
+// discover returns the names of all the plugins found in socketDirs and specDirs
+func discover() ([]string, error) {
+	var names []string
+	seen := make(map[string]struct{})
+	add := func(name string) {
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	for _, dir := range socketDirs {
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			switch {
+			case e.IsDir():
+				if st, err := os.Stat(filepath.Join(dir, e.Name(), e.Name()+".sock")); err == nil && st.Mode()&fs.ModeSocket != 0 {
+					add(e.Name())
+				}
+			case e.Type()&fs.ModeSocket != 0 && strings.HasSuffix(e.Name(), ".sock"):
+				add(strings.TrimSuffix(e.Name(), ".sock"))
+			}
+		}
+	}
+	for _, dir := range specDirs {
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if ext := filepath.Ext(e.Name()); !e.IsDir() && (ext == ".spec" || ext == ".json") {
+				add(strings.TrimSuffix(e.Name(), ext))
+			}
+		}
+	}
+	return names, nil
+}
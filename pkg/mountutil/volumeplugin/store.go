@@ -0,0 +1,110 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package volumeplugin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/log"
+
+	"github.com/containerd/nerdctl/v2/pkg/inspecttypes/native"
+	"github.com/containerd/nerdctl/v2/pkg/mountutil/volumestore"
+)
+
+// volumeStore wraps the local volume store, to create and mount the volumes of a container with a volume plugin.
+type volumeStore struct {
+	volumestore.VolumeStore
+	ctx context.Context
+	// plugin is the driver of the new volumes
+	plugin *Plugin
+	// mountID identifies the caller of Mount, typically the container ID
+	mountID string
+	// mounted records the plugin volumes mounted through this store
+	mounted []*native.Volume
+}
+
+// NewVolumeStore returns a volume store creating the new volumes with the volume driver named driver,
+// and mounting them for mountID (the container ID) to know their mountpoint.
+//
+// Volumes that already exist in the local store are always returned as is.
+// With the local driver, local is returned and no volume plugin is contacted.
+func NewVolumeStore(ctx context.Context, local volumestore.VolumeStore, driver, mountID string) (volumestore.VolumeStore, error) {
+	if driver == "" || driver == volumestore.LocalDriver {
+		return local, nil
+	}
+	p, err := Get(ctx, driver)
+	if err != nil {
+		return nil, fmt.Errorf("unknown volume driver %q: %w", driver, err)
+	}
+	return &volumeStore{
+		VolumeStore: local,
+		ctx:         ctx,
+		plugin:      p,
+		mountID:     mountID,
+	}, nil
+}
+
+// CreateWithoutLock returns the local volume name if it exists, or creates and mounts it with the volume plugin.
+func (vs *volumeStore) CreateWithoutLock(name string, labels []string) (*native.Volume, error) {
+	exists, err := vs.VolumeStore.Exists(name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return vs.VolumeStore.CreateWithoutLock(name, labels)
+	}
+	// A volume is only mounted once per container (e.g. `--mount type=volume` looks the volume up twice)
+	for _, vol := range vs.mounted {
+		if vol.Name == name {
+			return vol, nil
+		}
+	}
+	p := vs.plugin
+	if err := p.Create(vs.ctx, name, nil); err != nil {
+		return nil, err
+	}
+	if len(labels) > 0 {
+		log.G(vs.ctx).Warnf("labels are not supported by volume plugins, ignoring the labels of volume %q", name)
+	}
+	mountpoint, err := p.Mount(vs.ctx, name, vs.mountID)
+	if err != nil {
+		return nil, err
+	}
+	vol := &native.Volume{
+		Name:       name,
+		Driver:     p.Name,
+		Mountpoint: mountpoint,
+	}
+	vs.mounted = append(vs.mounted, vol)
+	return vol, nil
+}
+
+// Unmount releases the plugin volumes mounted through the volume store returned by NewVolumeStore.
+// It is meant to be called once the container is created: the volumes are mounted again when the container starts.
+func Unmount(ctx context.Context, store volumestore.VolumeStore) {
+	vs, ok := store.(*volumeStore)
+	if !ok {
+		return
+	}
+	for _, vol := range vs.mounted {
+		if err := vs.plugin.Unmount(ctx, vol.Name, vs.mountID); err != nil {
+			log.G(ctx).WithError(err).Warnf("failed to unmount volume %q of volume plugin %q", vol.Name, vol.Driver)
+		}
+	}
+	vs.mounted = nil
+}
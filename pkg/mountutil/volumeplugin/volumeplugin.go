@@ -0,0 +1,215 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package volumeplugin implements a client for the Docker volume plugin API.
+// See https://docs.docker.com/engine/extend/plugins_volume/
+//
+// Plugins are discovered like Docker legacy plugins: either as a unix socket in /run/docker/plugins,
+// or as a spec file (`<name>.spec` containing the URL of the plugin, or `<name>.json`) in
+// /etc/docker/plugins or /usr/lib/docker/plugins.
+package volumeplugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+const (
+	// volumeDriverInterface is the name of the interface implemented by volume plugins
+	volumeDriverInterface = "VolumeDriver"
+	// mediaType is the media type of the plugin API messages
+	mediaType = "application/vnd.docker.plugins.v1.2+json"
+
+	// Timeouts from https://github.com/moby/moby/blob/v28.5.2/volume/drivers/proxy.go
+	longTimeout  = 2 * time.Minute
+	shortTimeout = 1 * time.Minute
+)
+
+// ErrNotVolumePlugin is returned when a plugin does not implement the volume plugin API
+var ErrNotVolumePlugin = errors.New("plugin does not implement the volume plugin API")
+
+// Volume is a volume as returned by a plugin
+type Volume struct {
+	Name       string
+	Mountpoint string
+	Status     map[string]interface{} `json:",omitempty"`
+}
+
+// Plugin is a client for a volume plugin
+type Plugin struct {
+	// Name is the name of the plugin, used as the volume driver name
+	Name string
+	// Addr is the address of the plugin, e.g. "unix:///run/docker/plugins/foo.sock" or "tcp://localhost:8080"
+	Addr string
+
+	baseURL string
+	client  *http.Client
+}
+
+// New returns a client for the plugin called name, listening on addr.
+// It does not check whether the plugin is reachable, see Activate.
+func New(name, addr string) (*Plugin, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %q for plugin %q: %w", addr, name, err)
+	}
+	p := &Plugin{
+		Name: name,
+		Addr: addr,
+	}
+	transport := &http.Transport{}
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socket)
+		}
+		// The host is ignored when dialing the unix socket
+		p.baseURL = "http://plugin"
+	case "tcp", "http":
+		p.baseURL = "http://" + u.Host
+	default:
+		return nil, fmt.Errorf("unsupported address %q for plugin %q: only unix, tcp and http are supported", addr, name)
+	}
+	p.client = &http.Client{Transport: transport}
+	return p, nil
+}
+
+// Activate performs the handshake with the plugin, and checks that it implements the volume plugin API.
+func (p *Plugin) Activate(ctx context.Context) error {
+	var resp struct {
+		Implements []string
+	}
+	if err := p.call(ctx, "/Plugin.Activate", nil, &resp, shortTimeout); err != nil {
+		return err
+	}
+	if !slices.Contains(resp.Implements, volumeDriverInterface) {
+		return fmt.Errorf("plugin %q implements %v: %w", p.Name, resp.Implements, ErrNotVolumePlugin)
+	}
+	return nil
+}
+
+// Create creates the volume name, with the driver specific options opts.
+func (p *Plugin) Create(ctx context.Context, name string, opts map[string]string) error {
+	req := struct {
+		Name string
+		Opts map[string]string
+	}{Name: name, Opts: opts}
+	return p.call(ctx, "/VolumeDriver.Create", req, nil, longTimeout)
+}
+
+// Remove removes the volume name.
+func (p *Plugin) Remove(ctx context.Context, name string) error {
+	req := struct{ Name string }{Name: name}
+	return p.call(ctx, "/VolumeDriver.Remove", req, nil, longTimeout)
+}
+
+// Mount mounts the volume name for the caller id (e.g. a container ID), and returns its path on the host.
+func (p *Plugin) Mount(ctx context.Context, name, id string) (string, error) {
+	req := struct{ Name, ID string }{Name: name, ID: id}
+	var resp struct{ Mountpoint string }
+	if err := p.call(ctx, "/VolumeDriver.Mount", req, &resp, longTimeout); err != nil {
+		return "", err
+	}
+	if resp.Mountpoint == "" {
+		return "", fmt.Errorf("volume plugin %q returned an empty mountpoint for volume %q", p.Name, name)
+	}
+	return resp.Mountpoint, nil
+}
+
+// Unmount releases the mount of the volume name by the caller id.
+func (p *Plugin) Unmount(ctx context.Context, name, id string) error {
+	req := struct{ Name, ID string }{Name: name, ID: id}
+	return p.call(ctx, "/VolumeDriver.Unmount", req, nil, longTimeout)
+}
+
+// Get returns the volume name.
+func (p *Plugin) Get(ctx context.Context, name string) (*Volume, error) {
+	req := struct{ Name string }{Name: name}
+	var resp struct{ Volume *Volume }
+	if err := p.call(ctx, "/VolumeDriver.Get", req, &resp, shortTimeout); err != nil {
+		return nil, err
+	}
+	if resp.Volume == nil {
+		return nil, fmt.Errorf("volume plugin %q returned no volume for %q", p.Name, name)
+	}
+	return resp.Volume, nil
+}
+
+// List returns all the volumes of the plugin.
+func (p *Plugin) List(ctx context.Context) ([]*Volume, error) {
+	var resp struct{ Volumes []*Volume }
+	if err := p.call(ctx, "/VolumeDriver.List", struct{}{}, &resp, shortTimeout); err != nil {
+		return nil, err
+	}
+	return resp.Volumes, nil
+}
+
+// call posts req to the endpoint of the plugin, and decodes the response into resp.
+// Errors reported by the plugin in the `Err` field of the response are returned as errors.
+func (p *Plugin) call(ctx context.Context, endpoint string, req, resp interface{}, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var body bytes.Buffer
+	if req != nil {
+		if err := json.NewEncoder(&body).Encode(req); err != nil {
+			return err
+		}
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+endpoint, &body)
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Accept", mediaType)
+	httpReq.Header.Set("Content-Type", mediaType)
+
+	httpResp, err := p.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to call %s on volume plugin %q: %w", endpoint, p.Name, err)
+	}
+	defer httpResp.Body.Close()
+
+	b, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read the response of %s from volume plugin %q: %w", endpoint, p.Name, err)
+	}
+	var pluginErr struct{ Err string }
+	// The body may not be JSON when the plugin fails
+	_ = json.Unmarshal(b, &pluginErr)
+	if pluginErr.Err != "" {
+		return fmt.Errorf("volume plugin %q: %s: %s", p.Name, endpoint, pluginErr.Err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("volume plugin %q: %s: unexpected status %s: %s", p.Name, endpoint, httpResp.Status, bytes.TrimSpace(b))
+	}
+	if resp != nil {
+		if err := json.Unmarshal(b, resp); err != nil {
+			return fmt.Errorf("failed to decode the response of %s from volume plugin %q: %w", endpoint, p.Name, err)
+		}
+	}
+	return nil
+}
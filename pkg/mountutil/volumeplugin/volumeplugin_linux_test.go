@@ -0,0 +1,284 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package volumeplugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/errdefs"
+
+	"github.com/containerd/nerdctl/v2/pkg/mountutil/volumestore"
+)
+
+// mockPlugin implements the volume plugin API in memory
+type mockPlugin struct {
+	implements []string
+	mu         sync.Mutex
+	volumes    map[string]map[string]string
+	mounts     map[string][]string
+	calls      int
+}
+
+func (m *mockPlugin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	var req struct {
+		Name string
+		ID   string
+		Opts map[string]string
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	reply := func(v interface{}) {
+		w.Header().Set("Content-Type", mediaType)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	_, exists := m.volumes[req.Name]
+	switch r.URL.Path {
+	case "/Plugin.Activate":
+		reply(map[string]interface{}{"Implements": m.implements})
+	case "/VolumeDriver.Create":
+		m.volumes[req.Name] = req.Opts
+		reply(map[string]string{})
+	case "/VolumeDriver.Remove":
+		if !exists {
+			reply(map[string]string{"Err": "no such volume"})
+			return
+		}
+		if len(m.mounts[req.Name]) > 0 {
+			reply(map[string]string{"Err": "volume is mounted"})
+			return
+		}
+		delete(m.volumes, req.Name)
+		reply(map[string]string{})
+	case "/VolumeDriver.Mount":
+		if !exists {
+			reply(map[string]string{"Err": "no such volume"})
+			return
+		}
+		m.mounts[req.Name] = append(m.mounts[req.Name], req.ID)
+		reply(map[string]string{"Mountpoint": "/mnt/" + req.Name})
+	case "/VolumeDriver.Unmount":
+		var ids []string
+		for _, id := range m.mounts[req.Name] {
+			if id != req.ID {
+				ids = append(ids, id)
+			}
+		}
+		m.mounts[req.Name] = ids
+		reply(map[string]string{})
+	case "/VolumeDriver.Get":
+		if !exists {
+			reply(map[string]string{"Err": "no such volume"})
+			return
+		}
+		reply(map[string]interface{}{"Volume": Volume{Name: req.Name, Mountpoint: "/mnt/" + req.Name}})
+	case "/VolumeDriver.List":
+		var vols []Volume
+		for name := range m.volumes {
+			vols = append(vols, Volume{Name: name})
+		}
+		reply(map[string]interface{}{"Volumes": vols})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// startMockPlugin serves a mock volume plugin on dir/name.sock
+func startMockPlugin(t *testing.T, dir, name string, implements ...string) *mockPlugin {
+	t.Helper()
+	m := &mockPlugin{
+		implements: implements,
+		volumes:    make(map[string]map[string]string),
+		mounts:     make(map[string][]string),
+	}
+	l, err := net.Listen("unix", filepath.Join(dir, name+".sock"))
+	assert.NilError(t, err)
+	srv := &http.Server{Handler: m}
+	go srv.Serve(l)
+	t.Cleanup(func() { srv.Close() })
+	return m
+}
+
+// withPluginDirs points the plugin discovery to temporary directories
+func withPluginDirs(t *testing.T) (socketDir, specDir string) {
+	t.Helper()
+	socketDir, specDir = t.TempDir(), t.TempDir()
+	oldSocketDirs, oldSpecDirs := socketDirs, specDirs
+	socketDirs, specDirs = []string{socketDir}, []string{specDir}
+	t.Cleanup(func() {
+		socketDirs, specDirs = oldSocketDirs, oldSpecDirs
+	})
+	return socketDir, specDir
+}
+
+func TestLookup(t *testing.T) {
+	socketDir, specDir := withPluginDirs(t)
+	startMockPlugin(t, socketDir, "flat", volumeDriverInterface)
+	assert.NilError(t, os.Mkdir(filepath.Join(socketDir, "nested"), 0o755))
+	startMockPlugin(t, filepath.Join(socketDir, "nested"), "nested", volumeDriverInterface)
+	assert.NilError(t, os.WriteFile(filepath.Join(specDir, "spec.spec"), []byte("tcp://localhost:8080\n"), 0o644))
+	assert.NilError(t, os.WriteFile(filepath.Join(specDir, "json.json"), []byte(`{"Name":"json","Addr":"unix:///run/json.sock"}`), 0o644))
+	assert.NilError(t, os.WriteFile(filepath.Join(specDir, "empty.spec"), nil, 0o644))
+
+	addr, err := lookup("flat")
+	assert.NilError(t, err)
+	assert.Equal(t, addr, "unix://"+filepath.Join(socketDir, "flat.sock"))
+
+	addr, err = lookup("nested")
+	assert.NilError(t, err)
+	assert.Equal(t, addr, "unix://"+filepath.Join(socketDir, "nested", "nested.sock"))
+
+	addr, err = lookup("spec")
+	assert.NilError(t, err)
+	assert.Equal(t, addr, "tcp://localhost:8080")
+
+	addr, err = lookup("json")
+	assert.NilError(t, err)
+	assert.Equal(t, addr, "unix:///run/json.sock")
+
+	_, err = lookup("empty")
+	assert.ErrorContains(t, err, "has no address")
+
+	_, err = lookup("missing")
+	assert.Assert(t, errdefs.IsNotFound(err))
+
+	names, err := discover()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, names, []string{"flat", "nested", "empty", "json", "spec"})
+}
+
+func TestGet(t *testing.T) {
+	socketDir, _ := withPluginDirs(t)
+	startMockPlugin(t, socketDir, "vol", volumeDriverInterface)
+	startMockPlugin(t, socketDir, "net", "NetworkDriver")
+	ctx := context.Background()
+
+	p, err := Get(ctx, "vol")
+	assert.NilError(t, err)
+	assert.Equal(t, p.Name, "vol")
+
+	_, err = Get(ctx, "net")
+	assert.Assert(t, errors.Is(err, ErrNotVolumePlugin))
+
+	_, err = Get(ctx, "missing")
+	assert.Assert(t, errdefs.IsNotFound(err))
+
+	_, err = Get(ctx, "../vol")
+	assert.Assert(t, errdefs.IsInvalidArgument(err))
+
+	plugins, err := List(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, len(plugins), 1)
+	assert.Equal(t, plugins[0].Name, "vol")
+}
+
+func TestPluginLifecycle(t *testing.T) {
+	socketDir, _ := withPluginDirs(t)
+	m := startMockPlugin(t, socketDir, "vol", volumeDriverInterface)
+	ctx := context.Background()
+
+	p, err := Get(ctx, "vol")
+	assert.NilError(t, err)
+
+	assert.NilError(t, p.Create(ctx, "foo", map[string]string{"size": "1G"}))
+	assert.DeepEqual(t, m.volumes["foo"], map[string]string{"size": "1G"})
+
+	vol, err := p.Get(ctx, "foo")
+	assert.NilError(t, err)
+	assert.Equal(t, vol.Mountpoint, "/mnt/foo")
+
+	_, err = p.Get(ctx, "bar")
+	assert.ErrorContains(t, err, "no such volume")
+
+	vols, err := p.List(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, len(vols), 1)
+	assert.Equal(t, vols[0].Name, "foo")
+
+	mountpoint, err := p.Mount(ctx, "foo", "container-id")
+	assert.NilError(t, err)
+	assert.Equal(t, mountpoint, "/mnt/foo")
+	assert.DeepEqual(t, m.mounts["foo"], []string{"container-id"})
+
+	assert.ErrorContains(t, p.Remove(ctx, "foo"), "volume is mounted")
+	assert.NilError(t, p.Unmount(ctx, "foo", "container-id"))
+	assert.NilError(t, p.Remove(ctx, "foo"))
+	assert.Equal(t, len(m.volumes), 0)
+}
+
+func TestVolumeStore(t *testing.T) {
+	socketDir, _ := withPluginDirs(t)
+	m := startMockPlugin(t, socketDir, "vol", volumeDriverInterface)
+	ctx := context.Background()
+
+	local, err := volumestore.New(t.TempDir(), "test")
+	assert.NilError(t, err)
+	assert.NilError(t, local.Lock())
+	defer local.Release()
+	_, err = local.CreateWithoutLock("local-vol", nil)
+	assert.NilError(t, err)
+
+	_, err = NewVolumeStore(ctx, local, "missing", "container-id")
+	assert.ErrorContains(t, err, `unknown volume driver "missing"`)
+
+	vs, err := NewVolumeStore(ctx, local, "vol", "container-id")
+	assert.NilError(t, err)
+
+	// Existing local volumes are used as is
+	vol, err := vs.CreateWithoutLock("local-vol", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, vol.Driver, "")
+	assert.Equal(t, len(m.volumes), 0)
+
+	// New volumes are created and mounted by the plugin, once
+	for range 2 {
+		vol, err = vs.CreateWithoutLock("plugin-vol", nil)
+		assert.NilError(t, err)
+		assert.Equal(t, vol.Driver, "vol")
+		assert.Equal(t, vol.Mountpoint, "/mnt/plugin-vol")
+	}
+	assert.DeepEqual(t, m.mounts["plugin-vol"], []string{"container-id"})
+	exists, err := local.Exists("plugin-vol")
+	assert.NilError(t, err)
+	assert.Assert(t, !exists)
+
+	// Unmount releases the plugin volumes mounted through the store
+	Unmount(ctx, vs)
+	assert.Equal(t, len(m.mounts["plugin-vol"]), 0)
+
+	// With the local driver, the local store is used and no plugin is contacted
+	calls := m.calls
+	localStore, err := NewVolumeStore(ctx, local, volumestore.LocalDriver, "other-id")
+	assert.NilError(t, err)
+	assert.Equal(t, localStore, local)
+	vol, err = localStore.CreateWithoutLock("new-local-vol", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, vol.Driver, "")
+	Unmount(ctx, localStore)
+	assert.Equal(t, m.calls, calls)
+}
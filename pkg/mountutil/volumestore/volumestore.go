@@ -33,6 +33,10 @@ import (
 	"github.com/containerd/nerdctl/v2/pkg/strutil"
 )
 
+// LocalDriver is the name of the volume driver implemented by the volume store.
+// Volume plugins are not supported, so this is the only available driver.
+const LocalDriver = "local"
+
 const (
 	volumeDirBasename  = "volumes"
 	dataDirName        = "_data"
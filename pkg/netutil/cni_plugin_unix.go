@@ -18,7 +18,11 @@
 
 package netutil
 
-import "github.com/containerd/nerdctl/v2/pkg/rootlessutil"
+import (
+	"encoding/json"
+
+	"github.com/containerd/nerdctl/v2/pkg/rootlessutil"
+)
 
 // bridgeConfig describes the bridge plugin
 type bridgeConfig struct {
@@ -175,3 +179,50 @@ func newDHCPIPAMConfig() *dhcpIPAMConfig {
 		Type: "dhcp",
 	}
 }
+
+// customPluginConfig describes a CNI plugin appended to the chain with the "com.nerdctl.plugins" network option
+type customPluginConfig map[string]interface{}
+
+// newCustomPlugin returns the default config of the CNI plugin pluginType
+func newCustomPlugin(pluginType string) (customPluginConfig, error) {
+	var plugin CNIPlugin
+	switch pluginType {
+	case "bandwidth":
+		return customPluginConfig{
+			"type": pluginType,
+			"capabilities": map[string]interface{}{
+				"bandwidth": true,
+			},
+		}, nil
+	case "portmap":
+		plugin = newPortMapPlugin()
+	case "firewall":
+		plugin = newFirewallPlugin("same-bridge")
+	case "tuning":
+		plugin = newTuningPlugin()
+	default:
+		return customPluginConfig{"type": pluginType}, nil
+	}
+	return toCustomPlugin(plugin)
+}
+
+// toCustomPlugin converts plugin to a customPluginConfig, so that its parameters can be modified
+func toCustomPlugin(plugin CNIPlugin) (customPluginConfig, error) {
+	if c, ok := plugin.(customPluginConfig); ok {
+		return c, nil
+	}
+	b, err := json.Marshal(plugin)
+	if err != nil {
+		return nil, err
+	}
+	var c customPluginConfig
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c customPluginConfig) GetPluginType() string {
+	pluginType, _ := c["type"].(string)
+	return pluginType
+}
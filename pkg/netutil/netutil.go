@@ -28,6 +28,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/containernetworking/cni/libcni"
 
@@ -293,6 +294,58 @@ type cniNetworkConfig struct {
 	Plugins    []CNIPlugin       `json:"plugins"`
 }
 
+// ParseNetworkFlagValues parses the values of `--network`, which may use the extended syntax
+// `name=<NETWORK>[,mac-address=<MAC>]`.
+// As `--network` is a string slice flag, the fields of the extended syntax are received as separate values,
+// so a `name=` value starts a network, and the following `<key>=<value>` values apply to that network.
+// It returns the networks, and the MAC addresses requested per network.
+func ParseNetworkFlagValues(values []string) ([]string, map[string]string, error) {
+	var (
+		networks     []string
+		macAddresses = make(map[string]string)
+		current      string
+	)
+	for _, v := range values {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok {
+			networks = append(networks, v)
+			current = ""
+			continue
+		}
+		switch key {
+		case "name":
+			if value == "" {
+				return nil, nil, fmt.Errorf("invalid network %q: the network name must not be empty: %w", v, errdefs.ErrInvalidArgument)
+			}
+			networks = append(networks, value)
+			current = value
+		case "mac-address", "mac_address":
+			if current == "" {
+				return nil, nil, fmt.Errorf("invalid network option %q: must follow name=<NETWORK>: %w", v, errdefs.ErrInvalidArgument)
+			}
+			if _, err := net.ParseMAC(value); err != nil {
+				return nil, nil, fmt.Errorf("invalid MAC address %q for network %q: %w", value, current, err)
+			}
+			macAddresses[current] = value
+		default:
+			return nil, nil, fmt.Errorf("unsupported network option %q (only name and mac-address are supported): %w", key, errdefs.ErrInvalidArgument)
+		}
+	}
+	return networks, macAddresses, nil
+}
+
+// ValidateLinkLocalIP checks that ip is an IPv4 (169.254.0.0/16) or IPv6 (fe80::/10) link-local unicast address.
+func ValidateLinkLocalIP(ip string) error {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return fmt.Errorf("invalid link-local IP address %q: %w", ip, errdefs.ErrInvalidArgument)
+	}
+	if !parsed.IsLinkLocalUnicast() {
+		return fmt.Errorf("%q is not a link-local IP address (expected 169.254.0.0/16 or fe80::/10): %w", ip, errdefs.ErrInvalidArgument)
+	}
+	return nil
+}
+
 func (e *CNIEnv) CreateNetwork(opts types.NetworkCreateOptions) (*NetworkConfig, error) { //nolint:revive
 	var netConf *NetworkConfig
 
@@ -366,3 +366,36 @@ func TestNetworkWithDefaultNameAlreadyExists(t *testing.T) {
 	assert.Assert(t, len(defaultNamedNetworksFileDefinitions) == 1)
 	assert.Assert(t, defaultNamedNetworksFileDefinitions[0] == testConfFile)
 }
+
+func TestValidateLinkLocalIP(t *testing.T) {
+	for _, ip := range []string{"169.254.10.1", "169.254.0.1", "fe80::1", "fe80::abcd:1"} {
+		assert.NilError(t, ValidateLinkLocalIP(ip), ip)
+	}
+	for _, ip := range []string{"10.0.0.1", "127.0.0.1", "2001:db8::1", "ff02::1", "169.254.10.1/16", "foo", ""} {
+		assert.Assert(t, ValidateLinkLocalIP(ip) != nil, ip)
+	}
+}
+
+func TestParseNetworkFlagValues(t *testing.T) {
+	networks, macs, err := ParseNetworkFlagValues([]string{"bridge"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, networks, []string{"bridge"})
+	assert.Equal(t, len(macs), 0)
+
+	networks, macs, err = ParseNetworkFlagValues([]string{"name=foo", "mac-address=02:42:ac:11:00:02", "bar", "name=baz"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, networks, []string{"foo", "bar", "baz"})
+	assert.DeepEqual(t, macs, map[string]string{"foo": "02:42:ac:11:00:02"})
+
+	_, _, err = ParseNetworkFlagValues([]string{"bar", "mac-address=02:42:ac:11:00:02"})
+	assert.ErrorContains(t, err, "must follow name=<NETWORK>")
+
+	_, _, err = ParseNetworkFlagValues([]string{"name=foo", "mac-address=invalid"})
+	assert.ErrorContains(t, err, "invalid MAC address")
+
+	_, _, err = ParseNetworkFlagValues([]string{"name=foo", "driver-opt=foo=bar"})
+	assert.ErrorContains(t, err, "unsupported network option")
+
+	_, _, err = ParseNetworkFlagValues([]string{"name="})
+	assert.ErrorContains(t, err, "must not be empty")
+}
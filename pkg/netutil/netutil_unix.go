@@ -90,11 +90,19 @@ func (n *NetworkConfig) clean() error {
 	return nil
 }
 
+// CustomPluginsOption is the network option appending CNI plugins to the chain, e.g. "bandwidth,firewall".
+// The parameters of these plugins are set with "<plugin>.<key>=<value>" options.
+const CustomPluginsOption = "com.nerdctl.plugins"
+
 func (e *CNIEnv) generateCNIPlugins(driver string, name string, ipam map[string]interface{}, opts map[string]string, ipv6 bool, internal bool) ([]CNIPlugin, error) {
 	var (
 		plugins []CNIPlugin
 		err     error
 	)
+	opts, customPlugins, customParams, err := splitCustomPluginOptions(opts)
+	if err != nil {
+		return nil, err
+	}
 	switch driver {
 	case "bridge":
 		mtu := 0
@@ -212,6 +220,93 @@ func (e *CNIEnv) generateCNIPlugins(driver string, name string, ipam map[string]
 	default:
 		return nil, fmt.Errorf("unsupported cni driver %q", driver)
 	}
+	return e.appendCustomPlugins(plugins, customPlugins, customParams)
+}
+
+// splitCustomPluginOptions extracts the CustomPluginsOption option and the "<plugin>.<key>=<value>" options
+// of the plugins it lists from opts, and returns the remaining driver options.
+func splitCustomPluginOptions(opts map[string]string) (map[string]string, []string, map[string]map[string]string, error) {
+	list, ok := opts[CustomPluginsOption]
+	if !ok {
+		return opts, nil, nil, nil
+	}
+	var customPlugins []string
+	params := make(map[string]map[string]string)
+	for _, plugin := range strings.Split(list, ",") {
+		plugin = strings.TrimSpace(plugin)
+		if plugin == "" {
+			continue
+		}
+		if strings.ContainsAny(plugin, `/\`) || plugin == "." || plugin == ".." {
+			return nil, nil, nil, fmt.Errorf("invalid CNI plugin name %q in %q", plugin, CustomPluginsOption)
+		}
+		if _, ok := params[plugin]; !ok {
+			customPlugins = append(customPlugins, plugin)
+			params[plugin] = make(map[string]string)
+		}
+	}
+	if len(customPlugins) == 0 {
+		return nil, nil, nil, fmt.Errorf("network option %q must list at least one CNI plugin", CustomPluginsOption)
+	}
+	remaining := make(map[string]string, len(opts))
+	for opt, v := range opts {
+		if opt == CustomPluginsOption {
+			continue
+		}
+		if plugin, key, ok := strings.Cut(opt, "."); ok && key != "" {
+			if p, ok := params[plugin]; ok {
+				p[key] = v
+				continue
+			}
+		}
+		remaining[opt] = v
+	}
+	return remaining, customPlugins, params, nil
+}
+
+// appendCustomPlugins appends the CNI plugins customPlugins to the chain with their default config,
+// and sets their parameters. Plugins that are already in the chain are modified in place.
+// Parameter values are parsed as JSON when possible (numbers, booleans, objects), and used as strings otherwise.
+func (e *CNIEnv) appendCustomPlugins(plugins []CNIPlugin, customPlugins []string, params map[string]map[string]string) ([]CNIPlugin, error) {
+	for _, pluginType := range customPlugins {
+		if _, err := exec.LookPath(filepath.Join(e.Path, pluginType)); err != nil {
+			return nil, fmt.Errorf("needs CNI plugin %q to be installed in CNI_PATH (%q): %w", pluginType, e.Path, err)
+		}
+		idx := -1
+		for i, p := range plugins {
+			if p.GetPluginType() == pluginType {
+				idx = i
+				break
+			}
+		}
+		var (
+			plugin customPluginConfig
+			err    error
+		)
+		if idx >= 0 {
+			plugin, err = toCustomPlugin(plugins[idx])
+		} else {
+			plugin, err = newCustomPlugin(pluginType)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for key, v := range params[pluginType] {
+			if key == "type" {
+				return nil, fmt.Errorf("the type of CNI plugin %q cannot be changed", pluginType)
+			}
+			var value interface{}
+			if err := json.Unmarshal([]byte(v), &value); err != nil {
+				value = v
+			}
+			plugin[key] = value
+		}
+		if idx >= 0 {
+			plugins[idx] = plugin
+		} else {
+			plugins = append(plugins, plugin)
+		}
+	}
 	return plugins, nil
 }
 
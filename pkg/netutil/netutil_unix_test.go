@@ -19,6 +19,9 @@
 package netutil
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/Masterminds/semver/v3"
@@ -69,3 +72,79 @@ func TestGuessFirewallPluginVersion(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateCNIPluginsCustomPlugins(t *testing.T) {
+	cniPath := t.TempDir()
+	for _, plugin := range []string{"bridge", "portmap", "tuning", "bandwidth", "example"} {
+		assert.NilError(t, os.WriteFile(filepath.Join(cniPath, plugin), []byte("#!/bin/sh\n"), 0o755))
+	}
+	assert.NilError(t, os.WriteFile(filepath.Join(cniPath, "firewall"), []byte("#!/bin/sh\necho 'CNI firewall plugin v1.7.1' >&2\n"), 0o755))
+	e := &CNIEnv{Path: cniPath}
+
+	generate := func(opts map[string]string) ([]map[string]interface{}, error) {
+		plugins, err := e.generateCNIPlugins("bridge", "test-custom-plugins", nil, opts, false, false)
+		if err != nil {
+			return nil, err
+		}
+		b, err := json.Marshal(plugins)
+		assert.NilError(t, err)
+		var res []map[string]interface{}
+		assert.NilError(t, json.Unmarshal(b, &res))
+		return res, nil
+	}
+	pluginTypes := func(plugins []map[string]interface{}) []string {
+		var types []string
+		for _, p := range plugins {
+			types = append(types, p["type"].(string))
+		}
+		return types
+	}
+
+	plugins, err := generate(map[string]string{
+		CustomPluginsOption:      "bandwidth,example, firewall",
+		"bandwidth.ingressRate":  "1000",
+		"bandwidth.ingressBurst": "2000",
+		"example.mode":           "strict",
+		"example.nested":         `{"foo":["bar"]}`,
+		"firewall.backend":       "iptables",
+		"mtu":                    "1400",
+	})
+	assert.NilError(t, err)
+	// The firewall plugin is already in the chain of bridge networks, so it is not appended again
+	assert.DeepEqual(t, pluginTypes(plugins), []string{"bridge", "portmap", "firewall", "tuning", "bandwidth", "example"})
+	assert.Equal(t, plugins[0]["mtu"], float64(1400))
+	assert.Equal(t, plugins[2]["backend"], "iptables")
+	assert.Equal(t, plugins[2]["ingressPolicy"], "same-bridge")
+	assert.DeepEqual(t, plugins[4], map[string]interface{}{
+		"type":         "bandwidth",
+		"capabilities": map[string]interface{}{"bandwidth": true},
+		"ingressRate":  float64(1000),
+		"ingressBurst": float64(2000),
+	})
+	assert.DeepEqual(t, plugins[5], map[string]interface{}{
+		"type":   "example",
+		"mode":   "strict",
+		"nested": map[string]interface{}{"foo": []interface{}{"bar"}},
+	})
+
+	// Without the option, the chain is unchanged
+	plugins, err = generate(nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, pluginTypes(plugins), []string{"bridge", "portmap", "firewall", "tuning"})
+
+	_, err = generate(map[string]string{CustomPluginsOption: "not-installed"})
+	assert.ErrorContains(t, err, `needs CNI plugin "not-installed" to be installed`)
+
+	_, err = generate(map[string]string{CustomPluginsOption: "../bandwidth"})
+	assert.ErrorContains(t, err, "invalid CNI plugin name")
+
+	_, err = generate(map[string]string{CustomPluginsOption: ","})
+	assert.ErrorContains(t, err, "must list at least one CNI plugin")
+
+	_, err = generate(map[string]string{CustomPluginsOption: "bandwidth", "bandwidth.type": "example"})
+	assert.ErrorContains(t, err, "cannot be changed")
+
+	// Parameters of plugins that are not listed are rejected by the driver
+	_, err = generate(map[string]string{CustomPluginsOption: "bandwidth", "example.mode": "strict"})
+	assert.ErrorContains(t, err, `unsupported "bridge" network option "example.mode"`)
+}
@@ -145,6 +145,12 @@ func newHandlerOpts(state *specs.State, dataStore, cniPath, cniNetconfPath, brid
 	}
 	o.extraHosts = extraHosts
 
+	if networkAliasesJSON := state.Annotations[labels.NetworkAliases]; networkAliasesJSON != "" {
+		if err := json.Unmarshal([]byte(networkAliasesJSON), &o.networkAliases); err != nil {
+			return nil, err
+		}
+	}
+
 	hs, err := loadSpec(o.state.Bundle)
 	if err != nil {
 		return nil, err
@@ -228,6 +234,12 @@ func newHandlerOpts(state *specs.State, dataStore, cniPath, cniNetconfPath, brid
 		o.containerIP6 = ip6Address
 	}
 
+	if linkLocalIPsJSON, ok := o.state.Annotations[labels.LinkLocalIPs]; ok {
+		if err := json.Unmarshal([]byte(linkLocalIPsJSON), &o.linkLocalIPs); err != nil {
+			return nil, err
+		}
+	}
+
 	if rootlessutil.IsRootlessChild() {
 		o.rootlessKitClient, err = rootlessutil.NewRootlessKitClient()
 		if err != nil {
@@ -262,9 +274,11 @@ type handlerOpts struct {
 	rootlessKitClient rlkclient.Client
 	bypassClient      b4nndclient.Client
 	extraHosts        map[string]string // host:ip
+	networkAliases    []string
 	containerIP       string
 	containerMAC      string
 	containerIP6      string
+	linkLocalIPs      []string
 }
 
 // hookSpec is from https://github.com/containerd/containerd/blob/v1.4.3/cmd/containerd/command/oci-hook.go#L59-L64
@@ -421,6 +435,16 @@ func getIP6AddressOpts(opts *handlerOpts) ([]cni.NamespaceOpts, error) {
 	return nil, nil
 }
 
+// sandboxInterface returns the name of the container interface of the CNI result res
+func sandboxInterface(res *types100.Result) string {
+	for _, iface := range res.Interfaces {
+		if iface.Sandbox != "" {
+			return iface.Name
+		}
+	}
+	return ""
+}
+
 func reserveSocket(protocol, hostAddr string) (*os.File, error) {
 	type filer interface {
 		File() (*os.File, error)
@@ -548,6 +572,7 @@ func applyNetworkSettings(opts *handlerOpts) (err error) {
 		Domainname: opts.state.Annotations[labels.Domainname],
 		ExtraHosts: opts.extraHosts,
 		Name:       opts.state.Annotations[labels.Name],
+		Aliases:    opts.networkAliases,
 	}
 
 	// When containerd gets bounced, containers that were previously running and that are restarted will go again
@@ -579,6 +604,19 @@ func applyNetworkSettings(opts *handlerOpts) (err error) {
 		hsMeta.Networks[cniName] = cniResRaw[i]
 	}
 
+	// The link-local addresses are not managed by the IPAM of the CNI plugins:
+	// they are added to the interface of the first network with netlink, once the CNI plugins have set it up.
+	// They go away with the network namespace, so nothing needs to be done on removal.
+	if len(opts.linkLocalIPs) > 0 && len(cniResRaw) > 0 {
+		ifName := sandboxInterface(cniResRaw[0])
+		if ifName == "" {
+			return errors.New("failed to add the link-local addresses: the CNI result has no container interface")
+		}
+		if err := addLinkLocalIPs(nsPath, ifName, opts.linkLocalIPs); err != nil {
+			return fmt.Errorf("failed to add the link-local addresses %v to %q: %w", opts.linkLocalIPs, ifName, err)
+		}
+	}
+
 	b4nnEnabled, b4nnBindEnabled, err := bypass4netnsutil.IsBypass4netnsEnabled(opts.state.Annotations)
 	if err != nil {
 		return err
@@ -17,6 +17,12 @@
 package ocihook
 
 import (
+	"fmt"
+	"net"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+
 	"github.com/containerd/containerd/v2/contrib/apparmor"
 	"github.com/containerd/log"
 
@@ -37,3 +43,30 @@ func loadAppArmor() {
 		// but the profile was not actually loaded, runc will fail.
 	}
 }
+
+// addLinkLocalIPs adds the link-local addresses ips to the interface ifName of the network namespace nsPath.
+// IPv4 addresses are added with the /16 prefix of 169.254.0.0/16, and IPv6 addresses with the /64 prefix of fe80::/64.
+func addLinkLocalIPs(nsPath, ifName string, ips []string) error {
+	return ns.WithNetNSPath(nsPath, func(_ ns.NetNS) error {
+		link, err := netlink.LinkByName(ifName)
+		if err != nil {
+			return err
+		}
+		for _, s := range ips {
+			ip := net.ParseIP(s)
+			if ip == nil {
+				return fmt.Errorf("invalid link-local IP address %q", s)
+			}
+			mask := net.CIDRMask(64, 128)
+			if ip.To4() != nil {
+				ip = ip.To4()
+				mask = net.CIDRMask(16, 32)
+			}
+			addr := &netlink.Addr{IPNet: &net.IPNet{IP: ip, Mask: mask}}
+			if err := netlink.AddrReplace(link, addr); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -18,6 +18,12 @@
 
 package ocihook
 
+import "errors"
+
 func loadAppArmor() {
 	//noop
 }
+
+func addLinkLocalIPs(_, _ string, _ []string) error {
+	return errors.New("link-local addresses are only supported on Linux")
+}
@@ -17,13 +17,21 @@
 package platformutil
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
 
 	"github.com/containerd/platforms"
 )
 
+// ErrMissingEmulation is returned by CheckEmulation when a platform cannot be executed on the host.
+var ErrMissingEmulation = errors.New("missing emulation")
+
+// binfmtMiscDir is a variable so that it can be replaced in tests.
+var binfmtMiscDir = "/proc/sys/fs/binfmt_misc"
+
 func qemuArchFromOCIArch(ociArch string) (string, error) {
 	switch ociArch {
 	case "amd64":
@@ -59,13 +67,13 @@ func canExecProbably(s string) (bool, error) {
 			return false, err
 		}
 		candidates := []string{
-			"/proc/sys/fs/binfmt_misc/qemu-" + qemuArch,
-			"/proc/sys/fs/binfmt_misc/buildkit-qemu-" + qemuArch,
+			filepath.Join(binfmtMiscDir, "qemu-"+qemuArch),
+			filepath.Join(binfmtMiscDir, "buildkit-qemu-"+qemuArch),
 		}
 		// Rosetta 2 for Linux on ARM Mac
 		// https://developer.apple.com/documentation/virtualization/running_intel_binaries_in_linux_vms_with_rosetta
 		if runtime.GOARCH == "arm64" && p.Architecture == "amd64" {
-			candidates = append(candidates, "/proc/sys/fs/binfmt_misc/rosetta")
+			candidates = append(candidates, filepath.Join(binfmtMiscDir, "rosetta"))
 		}
 		for _, cand := range candidates {
 			if _, err := os.Stat(cand); err == nil {
@@ -88,3 +96,24 @@ func CanExecProbably(ss ...string) (bool, error) {
 	}
 	return true, nil
 }
+
+// CheckEmulation returns ErrMissingEmulation when one of the platforms ss differs from the host platform
+// and no binfmt_misc handler is registered for it.
+// The check is only performed on Linux, where emulation relies on binfmt_misc.
+func CheckEmulation(ss ...string) error {
+	if runtime.GOOS != "linux" {
+		return nil
+	}
+	for _, s := range ss {
+		ok, err := canExecProbably(s)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%w: platform %q differs from the host platform %q and no binfmt_misc handler is registered for it "+
+				"(hint: install qemu-user-static, e.g., `nerdctl run --privileged --rm tonistiigi/binfmt:master --install all`, "+
+				"or specify --allow-missing-emulation to skip this check)", ErrMissingEmulation, s, platforms.DefaultString())
+		}
+	}
+	return nil
+}
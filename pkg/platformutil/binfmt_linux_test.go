@@ -0,0 +1,97 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package platformutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/platforms"
+)
+
+// foreignArch returns an architecture that the host cannot execute natively.
+func foreignArch() string {
+	if runtime.GOARCH == "s390x" {
+		return "riscv64"
+	}
+	return "s390x"
+}
+
+func withBinfmtMiscDir(t *testing.T, handlers ...string) {
+	dir := t.TempDir()
+	for _, h := range handlers {
+		assert.NilError(t, os.WriteFile(filepath.Join(dir, h), []byte("enabled\n"), 0o644))
+	}
+	orig := binfmtMiscDir
+	binfmtMiscDir = dir
+	t.Cleanup(func() { binfmtMiscDir = orig })
+}
+
+func TestCanExecProbably(t *testing.T) {
+	arch := foreignArch()
+	foreign := "linux/" + arch
+
+	testCases := []struct {
+		name     string
+		platform string
+		handlers []string
+		expected bool
+		err      bool
+	}{
+		{name: "empty", platform: "", expected: true},
+		{name: "host", platform: platforms.DefaultString(), expected: true},
+		{name: "not registered", platform: foreign, expected: false},
+		{name: "qemu", platform: foreign, handlers: []string{"qemu-" + arch}, expected: true},
+		{name: "buildkit qemu", platform: foreign, handlers: []string{"buildkit-qemu-" + arch}, expected: true},
+		{name: "other arch registered", platform: foreign, handlers: []string{"qemu-mips64el"}, expected: false},
+		{name: "unknown arch", platform: "linux/unknown", err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			withBinfmtMiscDir(t, tc.handlers...)
+			ok, err := CanExecProbably(tc.platform)
+			if tc.err {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, tc.expected, ok)
+		})
+	}
+}
+
+func TestCheckEmulation(t *testing.T) {
+	arch := foreignArch()
+	foreign := "linux/" + arch
+
+	withBinfmtMiscDir(t)
+	assert.NilError(t, CheckEmulation())
+	assert.NilError(t, CheckEmulation(platforms.DefaultString()))
+	err := CheckEmulation(platforms.DefaultString(), foreign)
+	assert.Assert(t, errors.Is(err, ErrMissingEmulation))
+	assert.ErrorContains(t, err, "qemu-user-static")
+	assert.ErrorContains(t, err, "--allow-missing-emulation")
+
+	withBinfmtMiscDir(t, "qemu-"+arch)
+	assert.NilError(t, CheckEmulation(platforms.DefaultString(), foreign))
+}
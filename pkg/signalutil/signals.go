@@ -62,3 +62,36 @@ func StopCatch(sigc chan os.Signal) {
 	signal.Stop(sigc)
 	close(sigc)
 }
+
+// StopOnTerminationSignals calls stop when the current process receives SIGINT, SIGTERM or SIGHUP,
+// so that the caller can proceed with the cleanup of the task once it has exited.
+// stop is expected to stop the task gracefully, and to escalate to SIGKILL after the stop timeout.
+// Any subsequent termination signal kills the task with SIGKILL right away.
+// It is meant to be used when the signals are not forwarded to the task (e.g., `run --rm --sig-proxy=false`).
+func StopOnTerminationSignals(ctx context.Context, task killer, stop func(context.Context) error) chan os.Signal {
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	go func() {
+		stopping := false
+		for s := range sigc {
+			if !stopping {
+				stopping = true
+				log.G(ctx).Debugf("received signal %s, stopping the task", s)
+				go func() {
+					if err := stop(ctx); err != nil && !errdefs.IsNotFound(err) {
+						log.G(ctx).WithError(err).Errorf("failed to stop the task on signal %s", s)
+					}
+				}()
+				continue
+			}
+			log.G(ctx).Debugf("received signal %s while stopping, killing the task", s)
+			if err := task.Kill(ctx, syscall.SIGKILL); err != nil {
+				if errdefs.IsNotFound(err) {
+					return
+				}
+				log.G(ctx).WithError(err).Errorf("failed to kill the task on signal %s", s)
+			}
+		}
+	}()
+	return sigc
+}
@@ -19,6 +19,7 @@ package snapshotterutil
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -27,13 +28,20 @@ import (
 	"strings"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 
 	"github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/core/content"
+	"github.com/containerd/containerd/v2/core/images"
 	"github.com/containerd/log"
 
 	"github.com/containerd/nerdctl/v2/pkg/api/types"
 )
 
+// SociIndexDigestAnnotation is the annotation of an image manifest that contains the digest of its SOCI index (SOCI Index V2).
+const SociIndexDigestAnnotation = "com.amazon.soci.index-digest"
+
 // setupSociCommand creates and sets up a SOCI command with common configuration
 func setupSociCommand(gOpts types.GlobalCommandOptions) (*exec.Cmd, error) {
 	sociExecutable, err := exec.LookPath("soci")
@@ -154,10 +162,71 @@ func ConvertSociIndexV2(ctx context.Context, client *client.Client, srcRef strin
 		return "", fmt.Errorf("failed to get converted image: %w", err)
 	}
 
+	sociIndexes, err := SociIndexDigests(ctx, client.ContentStore(), img.Target())
+	if err != nil {
+		return "", fmt.Errorf("failed to read the SOCI indexes of the converted image: %w", err)
+	}
+	if len(sociIndexes) == 0 {
+		log.L.Warnf("No SOCI index was generated for %s, probably because no layer is larger than --soci-min-layer-size", destRef)
+	}
+	for manifest, index := range sociIndexes {
+		log.L.Debugf("SOCI index %s was generated for manifest %s", index, manifest)
+	}
+
 	// Return the full reference with digest
 	return fmt.Sprintf("%s@%s", destRef, img.Target().Digest), nil
 }
 
+// SociIndexDigests returns the digests of the SOCI indexes of an image converted to SOCI Index V2,
+// keyed by the digest of the image manifest they belong to.
+// With SOCI Index V2, the image manifest carries the digest of its SOCI index in an annotation,
+// so the image remains usable by snapshotters that don't know about SOCI.
+func SociIndexDigests(ctx context.Context, provider content.Provider, target ocispec.Descriptor) (map[digest.Digest]digest.Digest, error) {
+	res := make(map[digest.Digest]digest.Digest)
+	handler := images.HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		switch desc.MediaType {
+		case ocispec.MediaTypeImageIndex, images.MediaTypeDockerSchema2ManifestList:
+			children, err := images.Children(ctx, provider, desc)
+			if err != nil {
+				return nil, err
+			}
+			// Only the manifests available locally were converted
+			var manifests []ocispec.Descriptor
+			for _, child := range children {
+				if _, err := content.ReadBlob(ctx, provider, child); err == nil {
+					manifests = append(manifests, child)
+				}
+			}
+			return manifests, nil
+		case ocispec.MediaTypeImageManifest, images.MediaTypeDockerSchema2Manifest:
+			b, err := content.ReadBlob(ctx, provider, desc)
+			if err != nil {
+				return nil, err
+			}
+			var manifest ocispec.Manifest
+			if err := json.Unmarshal(b, &manifest); err != nil {
+				return nil, err
+			}
+			index := manifest.Annotations[SociIndexDigestAnnotation]
+			if index == "" {
+				index = desc.Annotations[SociIndexDigestAnnotation]
+			}
+			if index != "" {
+				dgst, err := digest.Parse(index)
+				if err != nil {
+					return nil, fmt.Errorf("invalid SOCI index digest %q in manifest %s: %w", index, desc.Digest, err)
+				}
+				res[desc.Digest] = dgst
+			}
+		}
+		return nil, nil
+	})
+	if err := images.Walk(ctx, handler, target); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // CreateSociIndexV1 creates a SOCI index(`rawRef`)
 func CreateSociIndexV1(rawRef string, gOpts types.GlobalCommandOptions, allPlatform bool, platforms []string, sOpts types.SociOptions) error {
 	sociCmd, err := setupSociCommand(gOpts)
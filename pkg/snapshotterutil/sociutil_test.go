@@ -0,0 +1,66 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package snapshotterutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"gotest.tools/v3/assert"
+
+	"github.com/containerd/containerd/v2/core/content"
+	"github.com/containerd/containerd/v2/plugins/content/local"
+)
+
+func TestSociIndexDigests(t *testing.T) {
+	ctx := context.Background()
+	cs, err := local.NewStore(t.TempDir())
+	assert.NilError(t, err)
+
+	write := func(mediaType string, v any) ocispec.Descriptor {
+		b, err := json.Marshal(v)
+		assert.NilError(t, err)
+		desc := ocispec.Descriptor{MediaType: mediaType, Digest: digest.FromBytes(b), Size: int64(len(b))}
+		assert.NilError(t, content.WriteBlob(ctx, cs, desc.Digest.String(), bytes.NewReader(b), desc))
+		return desc
+	}
+	sociIndex := digest.FromString("soci index")
+	converted := write(ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		MediaType:   ocispec.MediaTypeImageManifest,
+		Annotations: map[string]string{SociIndexDigestAnnotation: sociIndex.String()},
+	})
+	// All the layers of this manifest were below --soci-min-layer-size
+	notConverted := write(ocispec.MediaTypeImageManifest, ocispec.Manifest{MediaType: ocispec.MediaTypeImageManifest})
+	// Manifests of other platforms are not available locally
+	missing := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("missing"), Size: 7}
+	index := write(ocispec.MediaTypeImageIndex, ocispec.Index{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{converted, notConverted, missing},
+	})
+
+	res, err := SociIndexDigests(ctx, cs, index)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, res, map[digest.Digest]digest.Digest{converted.Digest: sociIndex})
+
+	res, err = SociIndexDigests(ctx, cs, notConverted)
+	assert.NilError(t, err)
+	assert.Equal(t, len(res), 0)
+}
@@ -18,13 +18,13 @@ package statsutil
 
 import (
 	"bufio"
+	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/vishvananda/netlink"
-
 	v1 "github.com/containerd/cgroups/v3/cgroup1/stats"
 	v2 "github.com/containerd/cgroups/v3/cgroup2/stats"
 )
@@ -38,22 +38,21 @@ func calculateMemPercent(limit float64, usedNo float64) float64 {
 	return 0
 }
 
-func SetCgroupStatsFields(previousStats *ContainerStats, data *v1.Metrics, links []netlink.Link, systemInfo SystemInfo) (StatsEntry, error) {
+func SetCgroupStatsFields(previousStats *ContainerStats, data *v1.Metrics, netStats NetworkStats, systemInfo SystemInfo) (StatsEntry, error) {
 	cpuPercent := calculateCgroupCPUPercent(previousStats, data, systemInfo)
 	blkRead, blkWrite := calculateCgroupBlockIO(data)
 	mem := calculateCgroupMemUsage(data)
 	memLimit := getCgroupMemLimit(float64(data.Memory.Usage.Limit))
 	memPercent := calculateMemPercent(memLimit, mem)
 	pidsStatsCurrent := data.Pids.Current
-	netRx, netTx := calculateCgroupNetwork(links)
 
 	return StatsEntry{
 		CPUPercentage:    cpuPercent,
 		Memory:           mem,
 		MemoryPercentage: memPercent,
 		MemoryLimit:      memLimit,
-		NetworkRx:        netRx,
-		NetworkTx:        netTx,
+		NetworkRx:        float64(netStats.RxBytes),
+		NetworkTx:        float64(netStats.TxBytes),
 		BlockRead:        float64(blkRead),
 		BlockWrite:       float64(blkWrite),
 		PidsCurrent:      pidsStatsCurrent,
@@ -61,22 +60,21 @@ func SetCgroupStatsFields(previousStats *ContainerStats, data *v1.Metrics, links
 
 }
 
-func SetCgroup2StatsFields(previousStats *ContainerStats, metrics *v2.Metrics, links []netlink.Link) (StatsEntry, error) {
+func SetCgroup2StatsFields(previousStats *ContainerStats, metrics *v2.Metrics, netStats NetworkStats) (StatsEntry, error) {
 	cpuPercent := calculateCgroup2CPUPercent(previousStats, metrics)
 	blkRead, blkWrite := calculateCgroup2IO(metrics)
 	mem := calculateCgroup2MemUsage(metrics)
 	memLimit := getCgroupMemLimit(float64(metrics.Memory.UsageLimit))
 	memPercent := calculateMemPercent(memLimit, mem)
 	pidsStatsCurrent := metrics.Pids.Current
-	netRx, netTx := calculateCgroupNetwork(links)
 
 	return StatsEntry{
 		CPUPercentage:    cpuPercent,
 		Memory:           mem,
 		MemoryPercentage: memPercent,
 		MemoryLimit:      memLimit,
-		NetworkRx:        netRx,
-		NetworkTx:        netTx,
+		NetworkRx:        float64(netStats.RxBytes),
+		NetworkTx:        float64(netStats.TxBytes),
 		BlockRead:        float64(blkRead),
 		BlockWrite:       float64(blkWrite),
 		PidsCurrent:      pidsStatsCurrent,
@@ -200,15 +198,54 @@ func calculateCgroup2IO(metrics *v2.Metrics) (uint64, uint64) {
 	return ioRead, ioWrite
 }
 
-func calculateCgroupNetwork(links []netlink.Link) (float64, float64) {
-	var rx, tx float64
+// NetworkStats holds the counters of the network interfaces of a container, except the loopback.
+type NetworkStats struct {
+	RxBytes uint64
+	TxBytes uint64
+}
+
+// ReadNetworkStats reads the counters of the interfaces of the network namespace of pid.
+func ReadNetworkStats(pid int) (NetworkStats, error) {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/net/dev", pid))
+	if err != nil {
+		return NetworkStats{}, err
+	}
+	defer f.Close()
+	return parseNetDev(f)
+}
 
-	for _, l := range links {
-		stats := l.Attrs().Statistics
-		if stats != nil {
-			rx += float64(stats.RxBytes)
-			tx += float64(stats.TxBytes)
+// parseNetDev sums the received and transmitted bytes of the interfaces listed in the /proc/net/dev format:
+//
+//	Inter-|   Receive                                                |  Transmit
+//	 face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+//	  eth0:    1296      16    0    0    0     0          0         0      936      12    0    0    0     0       0          0
+func parseNetDev(r io.Reader) (NetworkStats, error) {
+	var res NetworkStats
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		name, counters, ok := strings.Cut(sc.Text(), ":")
+		if !ok {
+			// header lines
+			continue
+		}
+		name = strings.TrimSpace(name)
+		if name == "lo" {
+			continue
+		}
+		fields := strings.Fields(counters)
+		if len(fields) < 16 {
+			return NetworkStats{}, fmt.Errorf("invalid number of fields for interface %q: %d", name, len(fields))
+		}
+		rx, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			return NetworkStats{}, fmt.Errorf("invalid received bytes for interface %q: %w", name, err)
+		}
+		tx, err := strconv.ParseUint(fields[8], 10, 64)
+		if err != nil {
+			return NetworkStats{}, fmt.Errorf("invalid transmitted bytes for interface %q: %w", name, err)
 		}
+		res.RxBytes += rx
+		res.TxBytes += tx
 	}
-	return rx, tx
+	return res, sc.Err()
 }
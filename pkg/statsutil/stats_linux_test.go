@@ -0,0 +1,109 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package statsutil
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	v1 "github.com/containerd/cgroups/v3/cgroup1/stats"
+	v2 "github.com/containerd/cgroups/v3/cgroup2/stats"
+)
+
+func TestParseNetDev(t *testing.T) {
+	const netDev = `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo:   12345      10    0    0    0     0          0         0    12345      10    0    0    0     0       0          0
+  eth0:    1296      16    0    0    0     0          0         0      936      12    0    0    0     0       0          0
+  eth1:     704       8    0    0    0     0          0         0      100       2    0    0    0     0       0          0
+`
+	stats, err := parseNetDev(strings.NewReader(netDev))
+	assert.NilError(t, err)
+	assert.Equal(t, stats, NetworkStats{RxBytes: 2000, TxBytes: 1036})
+
+	stats, err = parseNetDev(strings.NewReader(""))
+	assert.NilError(t, err)
+	assert.Equal(t, stats, NetworkStats{})
+
+	_, err = parseNetDev(strings.NewReader("  eth0: 1296 16 0\n"))
+	assert.ErrorContains(t, err, "invalid number of fields")
+
+	_, err = parseNetDev(strings.NewReader("  eth0: x 16 0 0 0 0 0 0 936 12 0 0 0 0 0 0\n"))
+	assert.ErrorContains(t, err, "invalid received bytes")
+}
+
+func TestCalculateCgroupBlockIO(t *testing.T) {
+	metrics := &v1.Metrics{
+		Blkio: &v1.BlkIOStat{
+			IoServiceBytesRecursive: []*v1.BlkIOEntry{
+				{Op: "Read", Major: 8, Minor: 0, Value: 4096},
+				{Op: "Write", Major: 8, Minor: 0, Value: 1024},
+				{Op: "Sync", Major: 8, Minor: 0, Value: 5120},
+				{Op: "Total", Major: 8, Minor: 0, Value: 5120},
+				{Op: "read", Major: 8, Minor: 16, Value: 100},
+				{Op: "write", Major: 8, Minor: 16, Value: 200},
+				{Op: "", Major: 8, Minor: 16, Value: 300},
+			},
+		},
+	}
+	read, write := calculateCgroupBlockIO(metrics)
+	assert.Equal(t, read, uint64(4196))
+	assert.Equal(t, write, uint64(1224))
+}
+
+func TestCalculateCgroup2IO(t *testing.T) {
+	metrics := &v2.Metrics{
+		Io: &v2.IOStat{
+			Usage: []*v2.IOEntry{
+				{Major: 8, Minor: 0, Rbytes: 4096, Wbytes: 1024, Rios: 2, Wios: 1},
+				{Major: 8, Minor: 16, Rbytes: 100, Rios: 1},
+				{Major: 253, Minor: 0},
+			},
+		},
+	}
+	read, write := calculateCgroup2IO(metrics)
+	assert.Equal(t, read, uint64(4196))
+	assert.Equal(t, write, uint64(1024))
+}
+
+func TestSetCgroup2StatsFields(t *testing.T) {
+	metrics := &v2.Metrics{
+		CPU:    &v2.CPUStat{},
+		Pids:   &v2.PidsStat{Current: 3},
+		Memory: &v2.MemoryStat{Usage: 2048, UsageLimit: 4096},
+		Io: &v2.IOStat{
+			Usage: []*v2.IOEntry{{Major: 8, Minor: 0, Rbytes: 4096, Wbytes: 1024, Rios: 2, Wios: 1}},
+		},
+	}
+	entry, err := SetCgroup2StatsFields(&ContainerStats{}, metrics, NetworkStats{RxBytes: 1296, TxBytes: 936})
+	assert.NilError(t, err)
+	assert.Equal(t, entry.NetworkRx, float64(1296))
+	assert.Equal(t, entry.NetworkTx, float64(936))
+	assert.Equal(t, entry.BlockRead, float64(4096))
+	assert.Equal(t, entry.BlockWrite, float64(1024))
+	assert.Equal(t, entry.PidsCurrent, uint64(3))
+	assert.Equal(t, entry.NetIO(), "1.3kB / 936B")
+	assert.Equal(t, entry.BlockIO(), "4.1kB / 1.02kB")
+}
+
+func TestReadNetworkStats(t *testing.T) {
+	_, err := ReadNetworkStats(os.Getpid())
+	assert.NilError(t, err)
+}